@@ -4,16 +4,25 @@ import (
 	"context"
 	"log/slog"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+	// Embedded so ?tz= works on hosts without a time zone database.
+	_ "time/tzdata"
 
 	"github.com/DarcoProgramador/shortener-go-backend/internal/controller"
 	"github.com/DarcoProgramador/shortener-go-backend/internal/database"
-	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/generator"
 	"github.com/DarcoProgramador/shortener-go-backend/internal/handlers"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/recorder"
 	"github.com/DarcoProgramador/shortener-go-backend/internal/routes"
 )
 
 func main() {
-	ctx := context.Background()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 
 	dbSql, err := database.InitDB(ctx, "./urls.db")
@@ -23,10 +32,83 @@ func main() {
 		return
 	}
 
-	queries := db.New(dbSql)
+	queries := database.NewStore(dbSql)
+
+	codeGenerator, err := generator.New(os.Getenv("SHORTENER_CODE_GENERATOR"), queries.GetLastURLID)
+	if err != nil {
+		logger.Error("cannot init code generator", slog.Any("msg", err))
+		os.Exit(1)
+		return
+	}
+
+	var flushInterval time.Duration
+	if value := os.Getenv("SHORTENER_FLUSH_INTERVAL"); value != "" {
+		flushInterval, err = time.ParseDuration(value)
+		if err != nil {
+			logger.Error("cannot parse SHORTENER_FLUSH_INTERVAL", slog.Any("msg", err))
+			os.Exit(1)
+			return
+		}
+	}
+
+	visits := recorder.NewBatcher(queries, logger, flushInterval, recorder.DefaultBatchSize)
+
+	var idempotencyWindow time.Duration
+	if value := os.Getenv("SHORTENER_IDEMPOTENCY_WINDOW"); value != "" {
+		idempotencyWindow, err = time.ParseDuration(value)
+		if err != nil {
+			logger.Error("cannot parse SHORTENER_IDEMPOTENCY_WINDOW", slog.Any("msg", err))
+			os.Exit(1)
+			return
+		}
+	}
+
+	var dedupe bool
+	if value := os.Getenv("SHORTENER_DEDUPE"); value != "" {
+		dedupe, err = strconv.ParseBool(value)
+		if err != nil {
+			logger.Error("cannot parse SHORTENER_DEDUPE", slog.Any("msg", err))
+			os.Exit(1)
+			return
+		}
+	}
+
+	var stripTracking bool
+	if value := os.Getenv("SHORTENER_STRIP_TRACKING"); value != "" {
+		stripTracking, err = strconv.ParseBool(value)
+		if err != nil {
+			logger.Error("cannot parse SHORTENER_STRIP_TRACKING", slog.Any("msg", err))
+			os.Exit(1)
+			return
+		}
+	}
+
+	ctrll := controller.NewController(queries, codeGenerator, visits, controller.Options{
+		IdempotencyWindow: idempotencyWindow,
+		Dedupe:            dedupe,
+		StripTracking:     stripTracking,
+	})
+
+	// Links stored before URLs were normalized are normalized before any
+	// request is served, so dedupe and search find them too.
+	normalized, err := ctrll.NormalizeLinks(ctx)
+	if err != nil {
+		logger.Error("cannot normalize link URLs", slog.Any("msg", err))
+		os.Exit(1)
+		return
+	}
+	if normalized > 0 {
+		logger.Info("normalized link URLs", slog.Int("links", normalized))
+	}
 
-	ctrll := controller.NewController(queries)
 	hdlr := handlers.NewHandlers(ctrll, logger)
 
 	routes.StartServer(ctx, hdlr, logger)
+
+	// The server has stopped taking requests, so nothing is recorded after this.
+	closeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := visits.Close(closeCtx); err != nil {
+		logger.Error("cannot write pending visits", slog.Any("msg", err))
+	}
 }
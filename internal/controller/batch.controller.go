@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	"github.com/DarcoProgramador/shortener-go-backend/utils"
+)
+
+const (
+	maxBatchSize = 1000
+	// batchChunkSize is how many links are inserted per transaction, so a
+	// large batch does not hold SQLite's write lock for too long.
+	batchChunkSize = 100
+
+	batchCreated  = "created"
+	batchRenamed  = "renamed"
+	batchReused   = "reused"
+	batchInvalid  = "invalid"
+	batchConflict = "conflict"
+	batchFailed   = "error"
+)
+
+// batchLink is a validated link and its tags waiting to be inserted with the
+// rest of its batch. index is its position in the results and dedupe whether
+// an existing link to its URL is returned instead. Imported links also carry
+// the stats to restore and, when their short code could not be kept, why.
+type batchLink struct {
+	index   int
+	params  db.CreateURLParams
+	tags    []string
+	dedupe  bool
+	stats   *db.RestoreURLStatsByIDParams
+	renamed string
+}
+
+// insertBatch inserts links in transactions of batchChunkSize and records the
+// outcome of each one in results.
+func (c *Controller) insertBatch(ctx context.Context, links []batchLink, results []models.BatchResult) {
+	for start := 0; start < len(links); start += batchChunkSize {
+		chunk := links[start:min(start+batchChunkSize, len(links))]
+
+		err := c.queries.ExecTx(ctx, func(q db.Querier) error {
+			for _, link := range chunk {
+				result := &results[link.index]
+
+				// The lookup runs in the transaction, so a link repeated
+				// in the batch reuses the one created before it.
+				if link.dedupe {
+					existing, err := existingLink(ctx, q, link.params.Url)
+					if err != nil {
+						return err
+					}
+					if existing != nil {
+						result.Status = batchReused
+						result.Link = existing
+						continue
+					}
+				}
+
+				data, err := c.insertLink(ctx, q, link.params, link.tags)
+				switch {
+				case errors.Is(err, ErrAliasTaken):
+					result.Status = batchConflict
+					result.Message = err.Error()
+					continue
+				case errors.Is(err, ErrCodeExhausted):
+					result.Status = batchFailed
+					result.Message = err.Error()
+					continue
+				case err != nil:
+					return err
+				}
+
+				if link.stats != nil {
+					stats := *link.stats
+					stats.ID = data.ID
+					if err := q.RestoreURLStatsByID(ctx, stats); err != nil {
+						return err
+					}
+
+					if stats.CreatedAt.Valid {
+						data.Createdat = stats.CreatedAt
+					}
+					data.Updatedat = stats.UpdatedAt
+				}
+
+				result.Status = batchCreated
+				if link.renamed != "" {
+					result.Status = batchRenamed
+					result.Message = link.renamed
+				}
+				result.Link = createdLinkResponse(data, link.tags)
+			}
+			return nil
+		})
+
+		// The chunk was rolled back, so none of its links exist, including
+		// those reported as created before the failure.
+		if err != nil {
+			for _, link := range chunk {
+				results[link.index] = models.BatchResult{Index: link.index, Status: batchFailed, Message: err.Error()}
+			}
+		}
+	}
+}
+
+func batchResponse(results []models.BatchResult) *models.BatchResponse {
+	response := &models.BatchResponse{Results: results}
+	for _, result := range results {
+		switch result.Status {
+		case batchCreated, batchRenamed:
+			response.Created++
+		case batchReused:
+			response.Reused++
+		default:
+			response.Failed++
+		}
+	}
+
+	return response
+}
+
+func (c *Controller) CreateShortLinks(ctx context.Context, requests []models.ShortLinkRequest) (*models.BatchResponse, error) {
+	if len(requests) == 0 || len(requests) > maxBatchSize {
+		return nil, utils.ErrInvalidBatchSize
+	}
+
+	results := make([]models.BatchResult, len(requests))
+	links := make([]batchLink, 0, len(requests))
+
+	// Requests are validated, their passwords hashed and their campaigns
+	// looked up before any transaction is opened.
+	for i, request := range requests {
+		results[i].Index = i
+
+		params, tags, err := c.newLinkParams(request)
+		if err != nil {
+			results[i].Status = batchInvalid
+			results[i].Message = err.Error()
+			continue
+		}
+
+		params.Campaignid, params.Url, err = campaignLink(ctx, c.queries, request.CampaignId, params.Url)
+		switch {
+		case errors.Is(err, ErrCampaignNotFound), errors.Is(err, utils.ErrInvalidCampaignID):
+			results[i].Status = batchInvalid
+			results[i].Message = err.Error()
+			continue
+		case err != nil:
+			results[i].Status = batchFailed
+			results[i].Message = err.Error()
+			continue
+		}
+
+		dedupe := c.options.Dedupe
+		if request.Dedupe != nil {
+			dedupe = *request.Dedupe
+		}
+		links = append(links, batchLink{index: i, params: params, tags: tags, dedupe: dedupe && plainLink(params, tags)})
+	}
+
+	c.insertBatch(ctx, links, results)
+
+	return batchResponse(results), nil
+}
@@ -0,0 +1,284 @@
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/generator"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	recorderMock "github.com/DarcoProgramador/shortener-go-backend/mocks/recorder_mock"
+	storeMock "github.com/DarcoProgramador/shortener-go-backend/mocks/store_mock"
+	"github.com/DarcoProgramador/shortener-go-backend/utils"
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// createdURL makes CreateURL return the row it was asked to insert.
+func createdURL(ctx context.Context, arg db.CreateURLParams) (db.CreateURLRow, error) {
+	return db.CreateURLRow{
+		ID:             1,
+		Url:            arg.Url,
+		Shortcode:      arg.Shortcode,
+		Createdat:      sql.NullTime{Time: time.Now(), Valid: true},
+		Redirectstatus: arg.Redirectstatus,
+		Campaignid:     arg.Campaignid,
+		Title:          arg.Title,
+		Description:    arg.Description,
+		Notes:          arg.Notes,
+	}, nil
+}
+
+func TestController_CreateShortLinks(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		requests []models.ShortLinkRequest
+	}
+	tests := []struct {
+		name             string
+		args             args
+		mockExpectations func(t *testing.T) *storeMock.MockStore
+		want             []string
+		wantErr          bool
+		errIs            error
+	}{
+		{
+			name: "CreateShortLinks with per item results",
+			args: args{
+				ctx: context.TODO(),
+				requests: []models.ShortLinkRequest{
+					{Url: "https://www.google.com"},
+					{Url: "not a url"},
+					{Url: "https://www.google.com", Alias: "taken"},
+					{Url: "https://www.google.com", Alias: "spring-sale"},
+					{Url: "https://www.google.com", MaxClicks: -1},
+					{Url: "https://www.google.com", CampaignId: intPtr(9)},
+				},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				runInTx(q)
+				q.EXPECT().GetCampaignByID(mock.Anything, int64(9)).Return(db.Campaign{}, sql.ErrNoRows).Once()
+				q.EXPECT().GetLastURLID(mock.Anything).Return(0, nil).Once()
+				q.EXPECT().CreateURL(mock.Anything, mock.MatchedBy(func(arg db.CreateURLParams) bool {
+					return arg.Shortcode == "taken"
+				})).Return(db.CreateURLRow{}, sqlite3.Error{
+					Code:         sqlite3.ErrConstraint,
+					ExtendedCode: sqlite3.ErrConstraintUnique,
+				}).Once()
+				q.EXPECT().CreateURL(mock.Anything, mock.Anything).RunAndReturn(createdURL).Times(2)
+				return q
+			},
+			want:    []string{batchCreated, batchInvalid, batchConflict, batchCreated, batchInvalid, batchInvalid},
+			wantErr: false,
+		},
+		{
+			name: "CreateShortLinks in several transactions",
+			args: args{
+				ctx: context.TODO(),
+				requests: func() []models.ShortLinkRequest {
+					requests := make([]models.ShortLinkRequest, batchChunkSize+2)
+					for i := range requests {
+						requests[i].Url = "https://www.google.com"
+					}
+					// Invalid requests do not take a place in a transaction.
+					requests[0].Url = "not a url"
+					return requests
+				}(),
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().ExecTx(mock.Anything, mock.Anything).RunAndReturn(
+					func(ctx context.Context, fn func(db.Querier) error) error {
+						return fn(q)
+					},
+				).Times(2)
+				q.EXPECT().GetLastURLID(mock.Anything).Return(0, nil).Times(batchChunkSize + 1)
+				q.EXPECT().CreateURL(mock.Anything, mock.Anything).RunAndReturn(createdURL).Times(batchChunkSize + 1)
+				return q
+			},
+			want: func() []string {
+				want := make([]string, batchChunkSize+2)
+				for i := range want {
+					want[i] = batchCreated
+				}
+				want[0] = batchInvalid
+				return want
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "CreateShortLinks with failed transaction",
+			args: args{
+				ctx: context.TODO(),
+				requests: []models.ShortLinkRequest{
+					{Url: "https://www.google.com", Alias: "first"},
+					{Url: "not a url"},
+					{Url: "https://www.google.com", Alias: "second"},
+				},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				runInTx(q)
+				// The second insert fails, which rolls back the first one.
+				q.EXPECT().CreateURL(mock.Anything, mock.MatchedBy(func(arg db.CreateURLParams) bool {
+					return arg.Shortcode == "first"
+				})).RunAndReturn(createdURL).Once()
+				q.EXPECT().CreateURL(mock.Anything, mock.Anything).Return(db.CreateURLRow{}, assert.AnError).Once()
+				return q
+			},
+			want:    []string{batchFailed, batchInvalid, batchFailed},
+			wantErr: false,
+		},
+		{
+			name: "CreateShortLinks empty batch",
+			args: args{
+				ctx:      context.TODO(),
+				requests: []models.ShortLinkRequest{},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				return storeMock.NewMockStore(t)
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrInvalidBatchSize,
+		},
+		{
+			name: "CreateShortLinks batch too large",
+			args: args{
+				ctx:      context.TODO(),
+				requests: make([]models.ShortLinkRequest, maxBatchSize+1),
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				return storeMock.NewMockStore(t)
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrInvalidBatchSize,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.mockExpectations(t)
+			r := recorderMock.NewMockRecorder(t)
+
+			c := NewController(q, generator.NewRandom(), r, Options{})
+
+			got, err := c.CreateShortLinks(tt.args.ctx, tt.args.requests)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs, "El error no es el esperado")
+			}
+
+			if err != nil {
+				assert.Nil(t, got, "El valor de got debe ser nulo cuando se espera un error")
+				return
+			}
+
+			statuses := make([]string, len(got.Results))
+			created := 0
+			for i, result := range got.Results {
+				assert.Equal(t, i, result.Index, "Los resultados deben seguir el orden del lote")
+				statuses[i] = result.Status
+
+				if result.Status == batchCreated {
+					created++
+					assert.NotNil(t, result.Link, "Un link creado debe incluir sus datos")
+				} else {
+					assert.Nil(t, result.Link, "Un link no creado no debe incluir datos")
+					assert.NotEmpty(t, result.Message, "Un link no creado debe explicar el motivo")
+				}
+			}
+
+			assert.Equal(t, tt.want, statuses, "Los estados no coinciden")
+			assert.Equal(t, created, got.Created, "Los valores de los campos Created no coinciden")
+			assert.Equal(t, len(statuses)-created, got.Failed, "Los valores de los campos Failed no coinciden")
+		})
+	}
+}
+
+func TestController_CreateShortLinksDedupe(t *testing.T) {
+	canonical := "http://www.google.com/"
+
+	t.Run("CreateShortLinks reuses existing link", func(t *testing.T) {
+		q := storeMock.NewMockStore(t)
+		runInTx(q)
+		q.EXPECT().GetPlainURLByURL(mock.Anything, canonical).Return("abc123", nil).Once()
+		q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(db.GetURLByShortCodeRow{
+			ID:        7,
+			Url:       canonical,
+			Shortcode: "abc123",
+			Createdat: sql.NullTime{Time: time.Now(), Valid: true},
+		}, nil).Once()
+		q.EXPECT().ListTagsByURLID(mock.Anything, int64(7)).Return(nil, nil).Once()
+		// Dedupe desactivado o un link con alias siempre se crean.
+		q.EXPECT().GetLastURLID(mock.Anything).Return(0, nil).Once()
+		q.EXPECT().CreateURL(mock.Anything, mock.Anything).RunAndReturn(createdURL).Times(2)
+
+		c := NewController(q, generator.NewRandom(), recorderMock.NewMockRecorder(t), Options{})
+
+		got, err := c.CreateShortLinks(context.TODO(), []models.ShortLinkRequest{
+			{Url: "http://www.google.com", Dedupe: boolPtr(true)},
+			{Url: "http://www.google.com"},
+			{Url: "http://www.google.com", Alias: "spring-sale", Dedupe: boolPtr(true)},
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, batchReused, got.Results[0].Status, "El link debe reutilizarse")
+		assert.Equal(t, "abc123", got.Results[0].Link.ShortCode, "El link reutilizado no es el esperado")
+		assert.True(t, got.Results[0].Link.Reused, "El link debe marcarse como reutilizado")
+		assert.Equal(t, batchCreated, got.Results[1].Status, "Sin dedupe el link debe crearse")
+		assert.Equal(t, batchCreated, got.Results[2].Status, "Un link con alias debe crearse")
+		assert.Equal(t, 2, got.Created, "Los valores de los campos Created no coinciden")
+		assert.Equal(t, 1, got.Reused, "Los valores de los campos Reused no coinciden")
+		assert.Equal(t, 0, got.Failed, "Los valores de los campos Failed no coinciden")
+	})
+
+	t.Run("CreateShortLinks reuses link created earlier in the batch", func(t *testing.T) {
+		var created db.CreateURLRow
+		q := storeMock.NewMockStore(t)
+		runInTx(q)
+		q.EXPECT().GetPlainURLByURL(mock.Anything, canonical).Return("", sql.ErrNoRows).Once()
+		q.EXPECT().GetLastURLID(mock.Anything).Return(0, nil).Once()
+		q.EXPECT().CreateURL(mock.Anything, mock.Anything).RunAndReturn(
+			func(ctx context.Context, arg db.CreateURLParams) (db.CreateURLRow, error) {
+				created, _ = createdURL(ctx, arg)
+				return created, nil
+			},
+		).Once()
+		// The second lookup runs in the same transaction and finds the first link.
+		q.EXPECT().GetPlainURLByURL(mock.Anything, canonical).RunAndReturn(
+			func(ctx context.Context, url string) (string, error) {
+				return created.Shortcode, nil
+			},
+		).Once()
+		q.EXPECT().GetURLByShortCode(mock.Anything, mock.Anything).RunAndReturn(
+			func(ctx context.Context, shortCode string) (db.GetURLByShortCodeRow, error) {
+				return db.GetURLByShortCodeRow{
+					ID:        created.ID,
+					Url:       created.Url,
+					Shortcode: shortCode,
+					Createdat: created.Createdat,
+				}, nil
+			},
+		).Once()
+		q.EXPECT().ListTagsByURLID(mock.Anything, int64(1)).Return(nil, nil).Once()
+
+		c := NewController(q, generator.NewRandom(), recorderMock.NewMockRecorder(t), Options{Dedupe: true})
+
+		got, err := c.CreateShortLinks(context.TODO(), []models.ShortLinkRequest{
+			{Url: "http://www.google.com"},
+			{Url: "HTTP://www.google.com:80/"},
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, []string{batchCreated, batchReused}, []string{got.Results[0].Status, got.Results[1].Status}, "Los estados no coinciden")
+		assert.Equal(t, got.Results[0].Link.ShortCode, got.Results[1].Link.ShortCode, "El duplicado debe reutilizar el link del lote")
+		assert.Equal(t, 1, got.Created, "Los valores de los campos Created no coinciden")
+		assert.Equal(t, 1, got.Reused, "Los valores de los campos Reused no coinciden")
+	})
+}
@@ -0,0 +1,213 @@
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/visitor"
+	"github.com/DarcoProgramador/shortener-go-backend/utils"
+)
+
+// utmValues returns the default UTM parameters of a campaign as a query.
+func utmValues(campaign db.Campaign) url.Values {
+	return url.Values{
+		"utm_source":   {campaign.Utmsource.String},
+		"utm_medium":   {campaign.Utmmedium.String},
+		"utm_campaign": {campaign.Utmcampaign.String},
+		"utm_term":     {campaign.Utmterm.String},
+		"utm_content":  {campaign.Utmcontent.String},
+	}
+}
+
+// campaignLink returns the campaign a link joins and its URL with the default
+// UTM parameters of the campaign it does not set itself. A nil or zero id
+// leaves the link out of any campaign.
+func campaignLink(ctx context.Context, q db.Querier, campaignID *int, link string) (sql.NullInt64, string, error) {
+	if campaignID == nil || *campaignID == 0 {
+		return sql.NullInt64{}, link, nil
+	}
+
+	if *campaignID < 0 {
+		return sql.NullInt64{}, "", utils.ErrInvalidCampaignID
+	}
+
+	campaign, err := q.GetCampaignByID(ctx, int64(*campaignID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return sql.NullInt64{}, "", ErrUnknownCampaign
+	}
+	if err != nil {
+		return sql.NullInt64{}, "", err
+	}
+
+	return sql.NullInt64{
+		Int64: campaign.ID,
+		Valid: true,
+	}, utils.AddQueryDefaults(link, utmValues(campaign)), nil
+}
+
+func campaignResponse(campaign db.Campaign) *models.CampaignResponse {
+	return &models.CampaignResponse{
+		Id:       int(campaign.ID),
+		Name:     campaign.Name,
+		Owner:    campaign.Owner.String,
+		StartsAt: timePtr(campaign.Startsat),
+		EndsAt:   timePtr(campaign.Endsat),
+		UTM: models.UTMParameters{
+			Source:   campaign.Utmsource.String,
+			Medium:   campaign.Utmmedium.String,
+			Campaign: campaign.Utmcampaign.String,
+			Term:     campaign.Utmterm.String,
+			Content:  campaign.Utmcontent.String,
+		},
+		CreatedAt: timePtr(campaign.Createdat),
+		UpdatedAt: timePtr(campaign.Updatedat),
+	}
+}
+
+// campaignParams validates a campaign and builds the row to store.
+func campaignParams(request models.CampaignRequest) (db.CreateCampaignParams, error) {
+	if err := utils.ValidateCampaign(request.Name, request.StartsAt, request.EndsAt); err != nil {
+		return db.CreateCampaignParams{}, err
+	}
+
+	return db.CreateCampaignParams{
+		Name:        strings.TrimSpace(request.Name),
+		Owner:       nullString(strings.TrimSpace(request.Owner)),
+		Startsat:    nullTime(request.StartsAt),
+		Endsat:      nullTime(request.EndsAt),
+		Utmsource:   nullString(request.UTM.Source),
+		Utmmedium:   nullString(request.UTM.Medium),
+		Utmcampaign: nullString(request.UTM.Campaign),
+		Utmterm:     nullString(request.UTM.Term),
+		Utmcontent:  nullString(request.UTM.Content),
+	}, nil
+}
+
+func (c *Controller) CreateCampaign(ctx context.Context, request models.CampaignRequest) (*models.CampaignResponse, error) {
+	params, err := campaignParams(request)
+	if err != nil {
+		return nil, err
+	}
+
+	campaign, err := c.queries.CreateCampaign(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return campaignResponse(campaign), nil
+}
+
+func (c *Controller) ListCampaigns(ctx context.Context) (*models.ListCampaignsResponse, error) {
+	campaigns, err := c.queries.ListCampaigns(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.ListCampaignsResponse{
+		Campaigns: make([]models.CampaignResponse, len(campaigns)),
+	}
+	for i, campaign := range campaigns {
+		response.Campaigns[i] = *campaignResponse(campaign)
+	}
+
+	return response, nil
+}
+
+func (c *Controller) GetCampaign(ctx context.Context, id int64) (*models.CampaignResponse, error) {
+	campaign, err := c.queries.GetCampaignByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCampaignNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return campaignResponse(campaign), nil
+}
+
+func (c *Controller) UpdateCampaign(ctx context.Context, request models.CampaignRequest, id int64) (*models.CampaignResponse, error) {
+	params, err := campaignParams(request)
+	if err != nil {
+		return nil, err
+	}
+
+	campaign, err := c.queries.UpdateCampaignByID(ctx, db.UpdateCampaignByIDParams{
+		Name:        params.Name,
+		Owner:       params.Owner,
+		Startsat:    params.Startsat,
+		Endsat:      params.Endsat,
+		Utmsource:   params.Utmsource,
+		Utmmedium:   params.Utmmedium,
+		Utmcampaign: params.Utmcampaign,
+		Utmterm:     params.Utmterm,
+		Utmcontent:  params.Utmcontent,
+		Updatedat: sql.NullTime{
+			Time:  time.Now().UTC(),
+			Valid: true,
+		},
+		ID: id,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCampaignNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return campaignResponse(campaign), nil
+}
+
+func (c *Controller) DeleteCampaign(ctx context.Context, id int64) error {
+	deleted, err := c.queries.DeleteCampaignByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return ErrCampaignNotFound
+	}
+
+	return nil
+}
+
+func (c *Controller) GetCampaignStats(ctx context.Context, id int64) (*models.CampaignStatsResponse, error) {
+	campaign, err := c.queries.GetCampaignByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCampaignNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	campaignID := sql.NullInt64{Int64: campaign.ID, Valid: true}
+
+	data, err := c.queries.GetCampaignStats(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	sketch, err := c.queries.ListVisitorSketchByCampaignID(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	ranks := make(map[int64]int64, len(sketch))
+	for _, register := range sketch {
+		ranks[register.Register] = register.Rank
+	}
+
+	return &models.CampaignStatsResponse{
+		CampaignId:     int(campaign.ID),
+		Name:           campaign.Name,
+		Links:          uint(data.Links),
+		AccessCount:    uint(data.Accesscount),
+		BotCount:       uint(data.Botcount),
+		UniqueVisitors: visitor.Estimate(ranks),
+	}, nil
+}
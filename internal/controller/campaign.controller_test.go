@@ -0,0 +1,502 @@
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/generator"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	recorderMock "github.com/DarcoProgramador/shortener-go-backend/mocks/recorder_mock"
+	storeMock "github.com/DarcoProgramador/shortener-go-backend/mocks/store_mock"
+	"github.com/DarcoProgramador/shortener-go-backend/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestController_CreateCampaign(t *testing.T) {
+	startsAt := mustParseTime(t, "2025-03-01T00:00:00Z")
+	endsAt := mustParseTime(t, "2025-04-01T00:00:00Z")
+
+	type args struct {
+		ctx     context.Context
+		request models.CampaignRequest
+	}
+	tests := []struct {
+		name             string
+		args             args
+		mockExpectations func(t *testing.T) *storeMock.MockStore
+		want             *models.CampaignResponse
+		wantErr          bool
+		errIs            error
+	}{
+		{
+			name: "CreateCampaign_OK",
+			args: args{
+				ctx: context.TODO(),
+				request: models.CampaignRequest{
+					Name:     " Spring sale ",
+					Owner:    "marketing",
+					StartsAt: &startsAt,
+					EndsAt:   &endsAt,
+					UTM:      models.UTMParameters{Source: "newsletter", Medium: "email"},
+				},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().CreateCampaign(mock.Anything, db.CreateCampaignParams{
+					Name:      "Spring sale",
+					Owner:     sql.NullString{String: "marketing", Valid: true},
+					Startsat:  sql.NullTime{Time: startsAt, Valid: true},
+					Endsat:    sql.NullTime{Time: endsAt, Valid: true},
+					Utmsource: sql.NullString{String: "newsletter", Valid: true},
+					Utmmedium: sql.NullString{String: "email", Valid: true},
+				}).RunAndReturn(func(ctx context.Context, arg db.CreateCampaignParams) (db.Campaign, error) {
+					return db.Campaign{
+						ID:        1,
+						Name:      arg.Name,
+						Owner:     arg.Owner,
+						Startsat:  arg.Startsat,
+						Endsat:    arg.Endsat,
+						Utmsource: arg.Utmsource,
+						Utmmedium: arg.Utmmedium,
+						Createdat: sql.NullTime{Time: startsAt, Valid: true},
+					}, nil
+				})
+				return q
+			},
+			want: &models.CampaignResponse{
+				Id:        1,
+				Name:      "Spring sale",
+				Owner:     "marketing",
+				StartsAt:  &startsAt,
+				EndsAt:    &endsAt,
+				UTM:       models.UTMParameters{Source: "newsletter", Medium: "email"},
+				CreatedAt: &startsAt,
+			},
+			wantErr: false,
+		},
+		{
+			name: "CreateCampaign without name",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.CampaignRequest{Name: "  "},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				return storeMock.NewMockStore(t)
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrInvalidCampaignName,
+		},
+		{
+			name: "CreateCampaign with invalid range",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.CampaignRequest{Name: "Spring sale", StartsAt: &endsAt, EndsAt: &startsAt},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				return storeMock.NewMockStore(t)
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrInvalidCampaignRange,
+		},
+		{
+			name: "CreateCampaign with error",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.CampaignRequest{Name: "Spring sale"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().CreateCampaign(mock.Anything, mock.Anything).Return(db.Campaign{}, assert.AnError)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.mockExpectations(t)
+			r := recorderMock.NewMockRecorder(t)
+
+			c := NewController(q, generator.NewRandom(), r, Options{})
+
+			got, err := c.CreateCampaign(tt.args.ctx, tt.args.request)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs, "El error no es el esperado")
+			}
+
+			assert.Equal(t, tt.want, got, "La campaña no coincide")
+		})
+	}
+}
+
+func TestController_ListCampaigns(t *testing.T) {
+	tests := []struct {
+		name             string
+		mockExpectations func(t *testing.T) *storeMock.MockStore
+		want             *models.ListCampaignsResponse
+		wantErr          bool
+	}{
+		{
+			name: "ListCampaigns_OK",
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().ListCampaigns(mock.Anything).Return([]db.Campaign{springCampaign}, nil)
+				return q
+			},
+			want: &models.ListCampaignsResponse{
+				Campaigns: []models.CampaignResponse{
+					{Id: 3, Name: "Spring sale", UTM: models.UTMParameters{Source: "newsletter", Medium: "email"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ListCampaigns without campaigns",
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().ListCampaigns(mock.Anything).Return([]db.Campaign{}, nil)
+				return q
+			},
+			want: &models.ListCampaignsResponse{
+				Campaigns: []models.CampaignResponse{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ListCampaigns with error",
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().ListCampaigns(mock.Anything).Return(nil, assert.AnError)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.mockExpectations(t)
+			r := recorderMock.NewMockRecorder(t)
+
+			c := NewController(q, generator.NewRandom(), r, Options{})
+
+			got, err := c.ListCampaigns(context.TODO())
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.want, got, "Las campañas no coinciden")
+		})
+	}
+}
+
+func TestController_GetCampaign(t *testing.T) {
+	tests := []struct {
+		name             string
+		id               int64
+		mockExpectations func(t *testing.T) *storeMock.MockStore
+		want             *models.CampaignResponse
+		wantErr          bool
+		errIs            error
+	}{
+		{
+			name: "GetCampaign_OK",
+			id:   3,
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetCampaignByID(mock.Anything, int64(3)).Return(springCampaign, nil)
+				return q
+			},
+			want: &models.CampaignResponse{
+				Id:   3,
+				Name: "Spring sale",
+				UTM:  models.UTMParameters{Source: "newsletter", Medium: "email"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GetCampaign not found",
+			id:   9,
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetCampaignByID(mock.Anything, int64(9)).Return(db.Campaign{}, sql.ErrNoRows)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   ErrCampaignNotFound,
+		},
+		{
+			name: "GetCampaign with error",
+			id:   3,
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetCampaignByID(mock.Anything, int64(3)).Return(db.Campaign{}, assert.AnError)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.mockExpectations(t)
+			r := recorderMock.NewMockRecorder(t)
+
+			c := NewController(q, generator.NewRandom(), r, Options{})
+
+			got, err := c.GetCampaign(context.TODO(), tt.id)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs, "El error no es el esperado")
+			}
+
+			assert.Equal(t, tt.want, got, "La campaña no coincide")
+		})
+	}
+}
+
+func TestController_UpdateCampaign(t *testing.T) {
+	tests := []struct {
+		name             string
+		request          models.CampaignRequest
+		id               int64
+		mockExpectations func(t *testing.T) *storeMock.MockStore
+		want             *models.CampaignResponse
+		wantErr          bool
+		errIs            error
+	}{
+		{
+			name:    "UpdateCampaign_OK",
+			request: models.CampaignRequest{Name: "Summer sale", UTM: models.UTMParameters{Campaign: "summer"}},
+			id:      3,
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().UpdateCampaignByID(mock.Anything, mock.MatchedBy(func(arg db.UpdateCampaignByIDParams) bool {
+					return arg.ID == 3 && arg.Name == "Summer sale" && arg.Updatedat.Valid && !arg.Utmsource.Valid
+				})).RunAndReturn(func(ctx context.Context, arg db.UpdateCampaignByIDParams) (db.Campaign, error) {
+					return db.Campaign{
+						ID:          arg.ID,
+						Name:        arg.Name,
+						Utmcampaign: arg.Utmcampaign,
+					}, nil
+				})
+				return q
+			},
+			want: &models.CampaignResponse{
+				Id:   3,
+				Name: "Summer sale",
+				UTM:  models.UTMParameters{Campaign: "summer"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "UpdateCampaign not found",
+			request: models.CampaignRequest{Name: "Summer sale"},
+			id:      9,
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().UpdateCampaignByID(mock.Anything, mock.Anything).Return(db.Campaign{}, sql.ErrNoRows)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   ErrCampaignNotFound,
+		},
+		{
+			name:    "UpdateCampaign with too long name",
+			request: models.CampaignRequest{Name: string(make([]byte, utils.MaxCampaignNameLength+1))},
+			id:      3,
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				return storeMock.NewMockStore(t)
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrInvalidCampaignName,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.mockExpectations(t)
+			r := recorderMock.NewMockRecorder(t)
+
+			c := NewController(q, generator.NewRandom(), r, Options{})
+
+			got, err := c.UpdateCampaign(context.TODO(), tt.request, tt.id)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs, "El error no es el esperado")
+			}
+
+			assert.Equal(t, tt.want, got, "La campaña no coincide")
+		})
+	}
+}
+
+func TestController_DeleteCampaign(t *testing.T) {
+	tests := []struct {
+		name             string
+		id               int64
+		mockExpectations func(t *testing.T) *storeMock.MockStore
+		wantErr          bool
+		errIs            error
+	}{
+		{
+			name: "DeleteCampaign_OK",
+			id:   3,
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().DeleteCampaignByID(mock.Anything, int64(3)).Return(1, nil)
+				return q
+			},
+			wantErr: false,
+		},
+		{
+			name: "DeleteCampaign not found",
+			id:   9,
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().DeleteCampaignByID(mock.Anything, int64(9)).Return(0, nil)
+				return q
+			},
+			wantErr: true,
+			errIs:   ErrCampaignNotFound,
+		},
+		{
+			name: "DeleteCampaign with error",
+			id:   3,
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().DeleteCampaignByID(mock.Anything, int64(3)).Return(0, assert.AnError)
+				return q
+			},
+			wantErr: true,
+			errIs:   assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.mockExpectations(t)
+			r := recorderMock.NewMockRecorder(t)
+
+			c := NewController(q, generator.NewRandom(), r, Options{})
+
+			err := c.DeleteCampaign(context.TODO(), tt.id)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs, "El error no es el esperado")
+			}
+		})
+	}
+}
+
+func TestController_GetCampaignStats(t *testing.T) {
+	campaignID := sql.NullInt64{Int64: 3, Valid: true}
+
+	tests := []struct {
+		name             string
+		id               int64
+		mockExpectations func(t *testing.T) *storeMock.MockStore
+		want             *models.CampaignStatsResponse
+		wantErr          bool
+		errIs            error
+	}{
+		{
+			name: "GetCampaignStats_OK",
+			id:   3,
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetCampaignByID(mock.Anything, int64(3)).Return(springCampaign, nil)
+				q.EXPECT().GetCampaignStats(mock.Anything, campaignID).Return(db.GetCampaignStatsRow{
+					Links:       2,
+					Accesscount: 15,
+					Botcount:    3,
+				}, nil)
+				q.EXPECT().ListVisitorSketchByCampaignID(mock.Anything, campaignID).Return([]db.ListVisitorSketchByCampaignIDRow{
+					{Register: 12, Rank: 1},
+					{Register: 345, Rank: 3},
+					{Register: 6789, Rank: 2},
+				}, nil)
+				return q
+			},
+			want: &models.CampaignStatsResponse{
+				CampaignId:     3,
+				Name:           "Spring sale",
+				Links:          2,
+				AccessCount:    15,
+				BotCount:       3,
+				UniqueVisitors: 3,
+			},
+			wantErr: false,
+		},
+		{
+			name: "GetCampaignStats without links",
+			id:   3,
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetCampaignByID(mock.Anything, int64(3)).Return(springCampaign, nil)
+				q.EXPECT().GetCampaignStats(mock.Anything, campaignID).Return(db.GetCampaignStatsRow{}, nil)
+				q.EXPECT().ListVisitorSketchByCampaignID(mock.Anything, campaignID).Return(nil, nil)
+				return q
+			},
+			want: &models.CampaignStatsResponse{
+				CampaignId: 3,
+				Name:       "Spring sale",
+			},
+			wantErr: false,
+		},
+		{
+			name: "GetCampaignStats not found",
+			id:   9,
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetCampaignByID(mock.Anything, int64(9)).Return(db.Campaign{}, sql.ErrNoRows)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   ErrCampaignNotFound,
+		},
+		{
+			name: "GetCampaignStats with error counting visitors",
+			id:   3,
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetCampaignByID(mock.Anything, int64(3)).Return(springCampaign, nil)
+				q.EXPECT().GetCampaignStats(mock.Anything, campaignID).Return(db.GetCampaignStatsRow{Links: 1}, nil)
+				q.EXPECT().ListVisitorSketchByCampaignID(mock.Anything, campaignID).Return(nil, assert.AnError)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.mockExpectations(t)
+			r := recorderMock.NewMockRecorder(t)
+
+			c := NewController(q, generator.NewRandom(), r, Options{})
+
+			got, err := c.GetCampaignStats(context.TODO(), tt.id)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs, "El error no es el esperado")
+			}
+
+			assert.Equal(t, tt.want, got, "Las estadísticas no coinciden")
+		})
+	}
+}
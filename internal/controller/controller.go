@@ -2,45 +2,249 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
 
-	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/database"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/generator"
 	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/recorder"
+)
+
+var (
+	ErrLinkNotFound  = errors.New("short link not found")
+	ErrLinkExpired   = errors.New("short link has expired")
+	ErrLinkNotActive = errors.New("short link is not active yet")
+	ErrLinkExhausted = errors.New("short link has reached its click limit")
+	ErrAliasTaken    = errors.New("alias is already in use")
+	ErrCodeExhausted = errors.New("could not generate a unique short code")
+	ErrTagNotFound   = errors.New("tag not found")
+
+	ErrCampaignNotFound = errors.New("campaign not found")
+	// ErrUnknownCampaign is returned when a link is added to a campaign
+	// that does not exist. It is also an ErrCampaignNotFound.
+	ErrUnknownCampaign = fmt.Errorf("%w: a link can only join an existing campaign", ErrCampaignNotFound)
+
+	ErrPasswordRequired = errors.New("short link is password protected")
+	ErrWrongPassword    = errors.New("wrong password")
+
+	// ErrVersionMismatch is returned when a link is changed from a version
+	// other than its current one.
+	ErrVersionMismatch = errors.New("short link has changed since the given version")
+
+	// ErrIdempotencyKeyReused is returned when an idempotency key comes back
+	// with a request other than the one it was first sent with.
+	ErrIdempotencyKeyReused = errors.New("Idempotency-Key was already used with a different request")
 )
 
 type ControllerInterface interface {
 	// CreateShortLink creates a short link from a URL
+	// The short code is the requested alias, or a random one when empty.
 	// It returns the short link details.
-	// If the URL is invalid, it returns an error.
-	// CreateShortLink(ctx, url) (*models.ShortLinkResponse, error)
-	CreateShortLink(context.Context, string) (*models.ShortLinkResponse, error)
-	// GetOriginalLink returns the original URL of a short link by its short code
+	// Tags are stored in lower case and without duplicates.
+	// A link added to a campaign gets the campaign's default UTM parameters that its URL does not set.
+	// If the URL, the redirect status, the alias, the activation window, the click limit, the password or a tag is invalid, it returns an error.
+	// If the campaign does not exist, it returns ErrUnknownCampaign.
+	// If the alias is already in use, it returns ErrAliasTaken.
+	// With dedupe, from the request or the server default, a link without alias, password, activation
+	// window, click limit, campaign, metadata or tags and with the default redirect status reuses the
+	// oldest such link to the same canonical URL; the link is returned as it is, marked as reused.
+	// CreateShortLink(ctx, request) (*models.ShortLinkResponse, error)
+	CreateShortLink(context.Context, models.ShortLinkRequest) (*models.ShortLinkResponse, error)
+	// CreateShortLinkIdempotent creates a short link as CreateShortLink does, once per idempotency key
+	// The key is stored with a hash of the request and the created link for the idempotency window,
+	// so a retry with the same key and request returns the same link and true instead of creating another.
+	// Failed requests do not store the key, so they can be retried with it.
+	// If the key is invalid, it returns utils.ErrInvalidIdempotencyKey.
+	// If the key was used with a different request, it returns ErrIdempotencyKeyReused.
+	// CreateShortLinkIdempotent(ctx, key, request) (*models.ShortLinkResponse, bool, error)
+	CreateShortLinkIdempotent(context.Context, string, models.ShortLinkRequest) (*models.ShortLinkResponse, bool, error)
+	// CreateShortLinks creates a short link for each request of a batch
+	// It returns one result per request, in order: a request that is invalid or whose
+	// alias is already in use is reported in its result instead of failing the batch.
+	// Links are inserted in transactions of up to 100; when one fails, its links are
+	// not created and are reported as errors. Requests deduplicated like in
+	// CreateShortLink reuse an existing link, including one created earlier in the batch.
+	// If the batch is empty or has more than 1000 requests, it returns an error.
+	// CreateShortLinks(ctx, requests) (*models.BatchResponse, error)
+	CreateShortLinks(context.Context, []models.ShortLinkRequest) (*models.BatchResponse, error)
+	// GetLink returns the details of a short link by its short code
+	// It has no side effects: no visit is counted and the activation window and click
+	// limit are not checked, so it also works for links visitors cannot open.
+	// The URL of a password protected link is only included when password matches.
+	// If the short code does not exist, it returns ErrLinkNotFound.
+	// If the password is given but wrong, it returns ErrWrongPassword.
+	// GetLink(ctx, shortCode, password) (*models.ShortLinkResponse, error)
+	GetLink(context.Context, string, string) (*models.ShortLinkResponse, error)
+	// ResolveLink returns the original URL of a short link by its short code and counts the visit
 	// It returns the original URL and the short link details.
-	// If the short code does not exist, it returns an error.
-	// GetOriginalLink(ctx, shortCode) (*models.ShortLinkResponse, error)
-	GetOriginalLink(context.Context, string) (*models.ShortLinkResponse, error)
-	// UpdateLink updates the URL of a short link by its short code
-	// It returns the updated short link.
-	// If the short code does not exist, it returns an error.
-	// If the URL is invalid, it returns an error.
-	// UpdateLink(ctx, url, shortCode) (*models.ShortLinkResponse, error)
-	UpdateLink(context.Context, string, string) (*models.ShortLinkResponse, error)
+	// If the short code does not exist, it returns ErrLinkNotFound.
+	// Outside of its activation window it returns ErrLinkNotActive or ErrLinkExpired
+	// and the visit is not counted.
+	// A password protected link returns ErrPasswordRequired or ErrWrongPassword
+	// until the visit carries the right password.
+	// Visits are counted in the background by the recorder, except on links with
+	// a click limit: there the limit is checked and the visit counted in one
+	// atomic step, and once the limit is reached it returns ErrLinkExhausted.
+	// Every counted visit is recorded as a click with its referrer, user agent,
+	// anonymised IP and accept-language, and its visitor is added to the
+	// unique visitor counts.
+	// Visits from bots, link previews and prefetches are only added to the bot
+	// count: they are not recorded and do not use up the click limit.
+	// ResolveLink(ctx, shortCode, visit) (*models.ShortLinkResponse, error)
+	ResolveLink(context.Context, string, models.VisitRequest) (*models.ShortLinkResponse, error)
+	// ListLinks returns a page of short links sorted by creation time, access count or last update
+	// Links can be filtered by destination domain, creation range, tag, campaign and a substring of the URL.
+	// A page starts after request.Cursor, the next cursor of the previous page; the last page has none.
+	// If the sort, order, limit, cursor, time zone or dates are invalid, it returns an error.
+	// ListLinks(ctx, request) (*models.ListLinksResponse, error)
+	ListLinks(context.Context, models.ListLinksRequest) (*models.ListLinksResponse, error)
+	// ExportLinks writes every short link with its stats to w as CSV or NDJSON
+	// Links are read page by page in id order and written as they are read, so an
+	// export streams however many links there are.
+	// Protected links are exported without their URL, and password hashes are never exported.
+	// If the format is not csv or ndjson, it returns utils.ErrInvalidFormat before writing anything.
+	// ExportLinks(ctx, format, w) error
+	ExportLinks(context.Context, string, io.Writer) error
+	// ImportLinks creates the short links of a CSV or NDJSON file, such as an export
+	// A CSV file is read by its header, which may also use the columns of Bitly and
+	// YOURLS exports; it must have a url column.
+	// Short codes are kept when they are valid aliases, and replaced by a generated
+	// one otherwise; a short code already in use is reported as a conflict. Creation
+	// times, campaigns and access and bot counts are restored. Protected links cannot
+	// be imported, as their URL and password are not exported.
+	// It returns one result per link of the file, in order. Links are inserted in
+	// transactions of up to 100 while the file is read; if reading it fails halfway,
+	// the links read before are imported and their results are returned with the error.
+	// If the format or the CSV header is invalid, it returns an error.
+	// ImportLinks(ctx, format, body) (*models.BatchResponse, error)
+	ImportLinks(context.Context, string, io.Reader) (*models.BatchResponse, error)
+	// NormalizeLinks stores the URL of every short link normalized, as new links store it
+	// Links stored before URLs were normalized are otherwise missed by dedupe and by a search for
+	// their URL. Links are read page by page in id order, and their version changes only when
+	// their URL does; a URL that is no longer valid is kept as it is.
+	// It returns how many links were changed.
+	// NormalizeLinks(ctx) (int, error)
+	NormalizeLinks(context.Context) (int, error)
+	// UpdateLink updates the URL, redirect status, activation window, click limit, password, tags and campaign of a short link by its short code
+	// It returns the updated short link. Tags and the campaign are replaced only when the request has them;
+	// a link in a campaign, whether moved to it or left in it, gets its default UTM parameters on its URL as on creation.
+	// If the short code does not exist, it returns ErrLinkNotFound.
+	// If the URL, the redirect status, the activation window, the click limit, the password or a tag is invalid, it returns an error.
+	// If the campaign does not exist, it returns ErrUnknownCampaign.
+	// A non zero version must be the current version of the link, or it returns ErrVersionMismatch.
+	// UpdateLink(ctx, request, shortCode, version) (*models.ShortLinkResponse, error)
+	UpdateLink(context.Context, models.ShortLinkRequest, string, int64) (*models.ShortLinkResponse, error)
+	// PatchLink applies a JSON Merge Patch to a short link by its short code
+	// Members of the patch replace the fields of the link and null resets them to their
+	// default, as if a PUT omitted them; the password, tags and campaign are removed by null.
+	// Fields missing from the patch are kept. It then updates the link as UpdateLink does.
+	// If the short code does not exist, it returns ErrLinkNotFound.
+	// If the patch is not a JSON object of link fields, or has a member a PUT cannot change,
+	// it returns utils.ErrInvalidPatch.
+	// A non zero version must be the current version of the link, or it returns ErrVersionMismatch.
+	// PatchLink(ctx, patch, shortCode, version) (*models.ShortLinkResponse, error)
+	PatchLink(context.Context, []byte, string, int64) (*models.ShortLinkResponse, error)
 	// DeleteShortLink deletes a short link by its short code
-	// It returns an error if the short code does not exist.
-	// DeleteShortLink(ctx, shortCode) error
-	DeleteShortLink(context.Context, string) error
+	// If the short code does not exist, it returns ErrLinkNotFound.
+	// A non zero version must be the current version of the link, or it returns ErrVersionMismatch.
+	// DeleteShortLink(ctx, shortCode, version) error
+	DeleteShortLink(context.Context, string, int64) error
 	// GetStatShortLink returns the statistics of a short link by its short code
-	// It returns the statistics of the short link.
-	// If the short code does not exist, it returns an error.
-	// GetStatShortLink(ctx, shortCode) (*models.StatShortLinkResponse, error)
-	GetStatShortLink(context.Context, string) (*models.StatShortLinkResponse, error)
+	// It returns the statistics of the short link: the human access count, the bot
+	// count, the estimated lifetime unique visitors and the unique visitors of the
+	// current UTC day.
+	// The URL of a password protected link is left out unless password is its
+	// password; a wrong password returns ErrWrongPassword.
+	// If the short code does not exist, it returns ErrLinkNotFound.
+	// GetStatShortLink(ctx, shortCode, password) (*models.StatShortLinkResponse, error)
+	GetStatShortLink(context.Context, string, string) (*models.StatShortLinkResponse, error)
+	// GetTimeSeries returns the clicks and unique visitors of a short link counted per hour, day or week
+	// Buckets follow the calendar of the requested time zone and empty buckets are included.
+	// If the interval, the time zone or the dates are invalid, it returns an error.
+	// If the short code does not exist, it returns ErrLinkNotFound.
+	// GetTimeSeries(ctx, shortCode, request) (*models.TimeSeriesResponse, error)
+	GetTimeSeries(context.Context, string, models.TimeSeriesRequest) (*models.TimeSeriesResponse, error)
+	// GetBreakdown returns the top referrer domains, browsers, operating systems and device classes of a short link
+	// Each list is sorted by clicks and holds at most request.Limit entries (10 by default, up to 100).
+	// If the limit or the dates are invalid, it returns an error.
+	// If the short code does not exist, it returns ErrLinkNotFound.
+	// GetBreakdown(ctx, shortCode, request) (*models.BreakdownResponse, error)
+	GetBreakdown(context.Context, string, models.BreakdownRequest) (*models.BreakdownResponse, error)
+	// ListTags returns every tag in use with its number of links and their summed access and bot counts
+	// Tags are sorted by name.
+	// ListTags(ctx) (*models.ListTagsResponse, error)
+	ListTags(context.Context) (*models.ListTagsResponse, error)
+	// GetTagStats returns the statistics of the links of a tag added up
+	// Unique visitors are estimated from the visitor sketches of those links, so a
+	// visitor of two of them counts twice.
+	// If no link has the tag, it returns ErrTagNotFound.
+	// GetTagStats(ctx, tag) (*models.TagStatsResponse, error)
+	GetTagStats(context.Context, string) (*models.TagStatsResponse, error)
+	// CreateCampaign creates a campaign with a name, an owner, a date range and default UTM parameters
+	// It returns the campaign details.
+	// If the name or the date range is invalid, it returns an error.
+	// CreateCampaign(ctx, request) (*models.CampaignResponse, error)
+	CreateCampaign(context.Context, models.CampaignRequest) (*models.CampaignResponse, error)
+	// ListCampaigns returns every campaign sorted by id
+	// ListCampaigns(ctx) (*models.ListCampaignsResponse, error)
+	ListCampaigns(context.Context) (*models.ListCampaignsResponse, error)
+	// GetCampaign returns the details of a campaign by its id
+	// If the campaign does not exist, it returns ErrCampaignNotFound.
+	// GetCampaign(ctx, id) (*models.CampaignResponse, error)
+	GetCampaign(context.Context, int64) (*models.CampaignResponse, error)
+	// UpdateCampaign replaces the name, owner, date range and default UTM parameters of a campaign by its id
+	// It returns the updated campaign. The URLs of links already in the campaign are not changed.
+	// If the name or the date range is invalid, it returns an error.
+	// If the campaign does not exist, it returns ErrCampaignNotFound.
+	// UpdateCampaign(ctx, request, id) (*models.CampaignResponse, error)
+	UpdateCampaign(context.Context, models.CampaignRequest, int64) (*models.CampaignResponse, error)
+	// DeleteCampaign deletes a campaign by its id
+	// Its links are kept and no longer belong to a campaign.
+	// If the campaign does not exist, it returns ErrCampaignNotFound.
+	// DeleteCampaign(ctx, id) error
+	DeleteCampaign(context.Context, int64) error
+	// GetCampaignStats returns the statistics of the links of a campaign added up
+	// Unique visitors are estimated from the visitor sketches of those links, so a
+	// visitor of two of them counts twice.
+	// If the campaign does not exist, it returns ErrCampaignNotFound.
+	// GetCampaignStats(ctx, id) (*models.CampaignStatsResponse, error)
+	GetCampaignStats(context.Context, int64) (*models.CampaignStatsResponse, error)
+}
+
+// Options are the server wide settings of a Controller.
+type Options struct {
+	// IdempotencyWindow is how long idempotency keys are remembered. A non
+	// positive value falls back to DefaultIdempotencyWindow.
+	IdempotencyWindow time.Duration
+	// Dedupe makes creations return an existing link to the same URL when
+	// the request does not choose.
+	Dedupe bool
+	// StripTracking removes click and recipient identifiers such as fbclid
+	// or gclid from the URLs of new and updated links.
+	StripTracking bool
 }
 
 type Controller struct {
-	queries db.Querier
+	queries   database.Store
+	generator generator.CodeGenerator
+	recorder  recorder.Recorder
+	salts     *saltCache
+	options   Options
 }
 
-func NewController(queries db.Querier) ControllerInterface {
+func NewController(queries database.Store, generator generator.CodeGenerator, recorder recorder.Recorder, options Options) ControllerInterface {
+	if options.IdempotencyWindow <= 0 {
+		options.IdempotencyWindow = DefaultIdempotencyWindow
+	}
+
 	return &Controller{
-		queries: queries,
+		queries:   queries,
+		generator: generator,
+		recorder:  recorder,
+		salts:     &saltCache{},
+		options:   options,
 	}
 }
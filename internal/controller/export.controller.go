@@ -0,0 +1,223 @@
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/visitor"
+	"github.com/DarcoProgramador/shortener-go-backend/utils"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+
+	// exportPageSize is how many links are read per query, so an export
+	// never holds every link in memory.
+	exportPageSize = 500
+)
+
+// csvColumns is the header of a CSV export, in the order of its columns.
+var csvColumns = []string{
+	"id",
+	"shortCode",
+	"url",
+	"title",
+	"description",
+	"notes",
+	"redirectStatus",
+	"createdAt",
+	"updatedAt",
+	"notBefore",
+	"expiresAt",
+	"maxClicks",
+	"protected",
+	"campaignId",
+	"tags",
+	"accessCount",
+	"botCount",
+	"uniqueVisitors",
+}
+
+// utcTime returns a stored time in UTC, so every time of an export is
+// written the same way whatever format SQLite kept it in.
+func utcTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	utc := t.Time.UTC()
+	return &utc
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+func csvRecord(link models.ExportedLink) []string {
+	return []string{
+		strconv.Itoa(link.Id),
+		link.ShortCode,
+		link.Url,
+		link.Title,
+		link.Description,
+		link.Notes,
+		strconv.Itoa(link.RedirectStatus),
+		formatTime(link.CreatedAt),
+		formatTime(link.UpdatedAt),
+		formatTime(link.NotBefore),
+		formatTime(link.ExpiresAt),
+		strconv.Itoa(link.MaxClicks),
+		strconv.FormatBool(link.Protected),
+		strconv.Itoa(link.CampaignId),
+		strings.Join(link.Tags, ","),
+		strconv.FormatUint(uint64(link.AccessCount), 10),
+		strconv.FormatUint(uint64(link.BotCount), 10),
+		strconv.FormatUint(uint64(link.UniqueVisitors), 10),
+	}
+}
+
+// exportedLink returns a link as it is exported. Exports need no password,
+// so protected links are exported without their URL or password hash.
+func exportedLink(link db.Url, tags []string, uniqueVisitors uint) models.ExportedLink {
+	destination := link.Url
+	if link.Passwordhash.Valid {
+		destination = ""
+	}
+
+	return models.ExportedLink{
+		Id:             int(link.ID),
+		ShortCode:      link.Shortcode,
+		Url:            destination,
+		Title:          link.Title.String,
+		Description:    link.Description.String,
+		Notes:          link.Notes.String,
+		RedirectStatus: int(link.Redirectstatus),
+		CreatedAt:      utcTime(link.Createdat),
+		UpdatedAt:      utcTime(link.Updatedat),
+		NotBefore:      utcTime(link.Notbefore),
+		ExpiresAt:      utcTime(link.Expiresat),
+		MaxClicks:      int(link.Maxclicks.Int64),
+		Protected:      link.Passwordhash.Valid,
+		CampaignId:     int(link.Campaignid.Int64),
+		Tags:           tags,
+		AccessCount:    uint(link.Accesscount.Int64),
+		BotCount:       uint(link.Botcount),
+		UniqueVisitors: uniqueVisitors,
+	}
+}
+
+// pageVisitors returns the estimated lifetime unique visitors of the links
+// with an id between firstID and lastID, reading their sketches in one query.
+func (c *Controller) pageVisitors(ctx context.Context, firstID, lastID int64) (map[int64]uint, error) {
+	rows, err := c.queries.ListVisitorSketchesByURLIDRange(ctx, db.ListVisitorSketchesByURLIDRangeParams{
+		FirstID: firstID,
+		LastID:  lastID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sketches := make(map[int64]map[int64]int64)
+	for _, row := range rows {
+		if sketches[row.Urlid] == nil {
+			sketches[row.Urlid] = make(map[int64]int64)
+		}
+		sketches[row.Urlid][row.Register] = row.Rank
+	}
+
+	visitors := make(map[int64]uint, len(sketches))
+	for urlID, ranks := range sketches {
+		visitors[urlID] = visitor.Estimate(ranks)
+	}
+
+	return visitors, nil
+}
+
+func (c *Controller) ExportLinks(ctx context.Context, format string, w io.Writer) error {
+	var write func(models.ExportedLink) error
+	var flush func() error
+
+	switch format {
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(csvColumns); err != nil {
+			return err
+		}
+
+		write = func(link models.ExportedLink) error {
+			return writer.Write(csvRecord(link))
+		}
+		flush = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+	case FormatNDJSON:
+		encoder := json.NewEncoder(w)
+
+		write = func(link models.ExportedLink) error {
+			return encoder.Encode(link)
+		}
+		flush = func() error { return nil }
+	default:
+		return utils.ErrInvalidFormat
+	}
+
+	var afterID int64
+	for {
+		links, err := c.queries.ListURLsAfterID(ctx, db.ListURLsAfterIDParams{
+			AfterID:  afterID,
+			RowLimit: exportPageSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(links) == 0 {
+			return flush()
+		}
+
+		visitors, err := c.pageVisitors(ctx, links[0].ID, links[len(links)-1].ID)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]int64, len(links))
+		for i, link := range links {
+			ids[i] = link.ID
+		}
+
+		tags, err := c.tagsByURLID(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		for _, link := range links {
+			if err := write(exportedLink(link, tags[link.ID], visitors[link.ID])); err != nil {
+				return err
+			}
+		}
+
+		// Each page is handed to w as soon as it is written.
+		if err := flush(); err != nil {
+			return err
+		}
+
+		if len(links) < exportPageSize {
+			return nil
+		}
+		afterID = links[len(links)-1].ID
+	}
+}
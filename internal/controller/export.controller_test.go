@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"testing"
+
+	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/generator"
+	recorderMock "github.com/DarcoProgramador/shortener-go-backend/mocks/recorder_mock"
+	storeMock "github.com/DarcoProgramador/shortener-go-backend/mocks/store_mock"
+	"github.com/DarcoProgramador/shortener-go-backend/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// exportURLs returns two links, the first in a campaign and the second
+// titled, protected, tagged and with stats, and the sketch of the first one.
+func exportURLs(t *testing.T, q *storeMock.MockStore) {
+	first := listedURL(t, 1, "abc123", "2025-03-01T10:00:00Z", 3)
+	first.Redirectstatus = 302
+	first.Campaignid = sql.NullInt64{Int64: 3, Valid: true}
+
+	second := listedURL(t, 2, "spring-sale", "2025-03-02T10:00:00+01:00", 42)
+	second.Redirectstatus = 301
+	second.Title = sql.NullString{String: "Spring sale, 2025", Valid: true}
+	second.Maxclicks = sql.NullInt64{Int64: 100, Valid: true}
+	second.Expiresat = sql.NullTime{Time: mustParseTime(t, "2025-04-01T00:00:00Z"), Valid: true}
+	second.Passwordhash = sql.NullString{String: "$2a$10$hash", Valid: true}
+	second.Botcount = 5
+
+	q.EXPECT().ListURLsAfterID(mock.Anything, db.ListURLsAfterIDParams{AfterID: 0, RowLimit: exportPageSize}).Return([]db.Url{first, second}, nil)
+	q.EXPECT().ListVisitorSketchesByURLIDRange(mock.Anything, db.ListVisitorSketchesByURLIDRangeParams{FirstID: 1, LastID: 2}).Return([]db.ListVisitorSketchesByURLIDRangeRow{
+		{Urlid: 1, Register: 3, Rank: 1},
+	}, nil)
+	q.EXPECT().ListTagsByURLIDs(mock.Anything, "[1,2]").Return([]db.ListTagsByURLIDsRow{
+		{Urlid: 2, Name: "promo"},
+		{Urlid: 2, Name: "spring"},
+	}, nil)
+}
+
+func TestController_ExportLinks(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		format string
+	}
+	tests := []struct {
+		name             string
+		args             args
+		mockExpectations func(t *testing.T) *storeMock.MockStore
+		want             string
+		wantErr          bool
+		errIs            error
+	}{
+		{
+			name: "ExportLinks CSV",
+			args: args{
+				ctx:    context.TODO(),
+				format: FormatCSV,
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				exportURLs(t, q)
+				return q
+			},
+			want: "id,shortCode,url,title,description,notes,redirectStatus,createdAt,updatedAt,notBefore,expiresAt,maxClicks,protected,campaignId,tags,accessCount,botCount,uniqueVisitors\n" +
+				"1,abc123,https://www.google.com/abc123,,,,302,2025-03-01T10:00:00Z,,,,0,false,3,,3,0,1\n" +
+				"2,spring-sale,,\"Spring sale, 2025\",,,301,2025-03-02T09:00:00Z,,,2025-04-01T00:00:00Z,100,true,0,\"promo,spring\",42,5,0\n",
+			wantErr: false,
+		},
+		{
+			name: "ExportLinks NDJSON",
+			args: args{
+				ctx:    context.TODO(),
+				format: FormatNDJSON,
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				exportURLs(t, q)
+				return q
+			},
+			want: `{"id":1,"shortCode":"abc123","url":"https://www.google.com/abc123","redirectStatus":302,"createdAt":"2025-03-01T10:00:00Z","campaignId":3,"accessCount":3,"botCount":0,"uniqueVisitors":1}` + "\n" +
+				`{"id":2,"shortCode":"spring-sale","title":"Spring sale, 2025","redirectStatus":301,"createdAt":"2025-03-02T09:00:00Z","expiresAt":"2025-04-01T00:00:00Z","maxClicks":100,"protected":true,"tags":["promo","spring"],"accessCount":42,"botCount":5,"uniqueVisitors":0}` + "\n",
+			wantErr: false,
+		},
+		{
+			name: "ExportLinks without links",
+			args: args{
+				ctx:    context.TODO(),
+				format: FormatCSV,
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().ListURLsAfterID(mock.Anything, mock.Anything).Return([]db.Url{}, nil)
+				return q
+			},
+			want:    "id,shortCode,url,title,description,notes,redirectStatus,createdAt,updatedAt,notBefore,expiresAt,maxClicks,protected,campaignId,tags,accessCount,botCount,uniqueVisitors\n",
+			wantErr: false,
+		},
+		{
+			name: "ExportLinks invalid format",
+			args: args{
+				ctx:    context.TODO(),
+				format: "xml",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				return storeMock.NewMockStore(t)
+			},
+			want:    "",
+			wantErr: true,
+			errIs:   utils.ErrInvalidFormat,
+		},
+		{
+			name: "ExportLinks with error",
+			args: args{
+				ctx:    context.TODO(),
+				format: FormatCSV,
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().ListURLsAfterID(mock.Anything, mock.Anything).Return(nil, assert.AnError)
+				return q
+			},
+			want:    "",
+			wantErr: true,
+			errIs:   assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.mockExpectations(t)
+			r := recorderMock.NewMockRecorder(t)
+
+			c := NewController(q, generator.NewRandom(), r, Options{})
+
+			var got bytes.Buffer
+			err := c.ExportLinks(tt.args.ctx, tt.args.format, &got)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs, "El error no es el esperado")
+			}
+
+			assert.Equal(t, tt.want, got.String(), "El archivo exportado no coincide")
+		})
+	}
+}
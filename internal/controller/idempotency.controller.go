@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/DarcoProgramador/shortener-go-backend/internal/database"
+	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	"github.com/DarcoProgramador/shortener-go-backend/utils"
+)
+
+// DefaultIdempotencyWindow is how long an idempotency key is remembered.
+const DefaultIdempotencyWindow = 24 * time.Hour
+
+// errKeyStored reports that a concurrent request stored the same
+// idempotency key first.
+var errKeyStored = errors.New("idempotency key already stored")
+
+// requestHash identifies a creation request by its decoded fields, so a
+// retry that only formats the JSON differently is still the same request.
+// The stored hash would be a fast unsalted hash of the password, so only
+// whether the link is protected is part of it; the password is stored as a
+// bcrypt hash next to it and compared by samePassword.
+func requestHash(request models.ShortLinkRequest) (string, error) {
+	protected := request.Password != nil && *request.Password != ""
+	request.Password = nil
+
+	body, err := json.Marshal(struct {
+		models.ShortLinkRequest
+		Protected bool `json:"protected"`
+	}{request, protected})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func (c *Controller) CreateShortLinkIdempotent(ctx context.Context, key string, request models.ShortLinkRequest) (*models.ShortLinkResponse, bool, error) {
+	if err := utils.ValidateIdempotencyKey(key); err != nil {
+		return nil, false, err
+	}
+
+	hash, err := requestHash(request)
+	if err != nil {
+		return nil, false, err
+	}
+
+	now := time.Now().UTC()
+
+	// Expired keys are deleted first, so a key older than the window
+	// creates a new link.
+	if err := c.queries.DeleteIdempotencyKeysBefore(ctx, now.Add(-c.options.IdempotencyWindow)); err != nil {
+		return nil, false, err
+	}
+
+	response, err := c.storedResponse(ctx, key, hash, request.Password)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return response, err == nil, err
+	}
+
+	response, err = c.createShortLink(ctx, request, func(q db.Querier, response *models.ShortLinkResponse) error {
+		body, err := json.Marshal(response)
+		if err != nil {
+			return err
+		}
+
+		// The link was created, so the password is already valid.
+		var password sql.NullString
+		if request.Password != nil {
+			if password, err = passwordHash(*request.Password); err != nil {
+				return err
+			}
+		}
+
+		err = q.CreateIdempotencyKey(ctx, db.CreateIdempotencyKeyParams{
+			Idempotencykey: key,
+			Requesthash:    hash,
+			Response:       body,
+			Createdat:      now,
+			Passwordhash:   password,
+		})
+		if database.IsUniqueViolation(err) {
+			return errKeyStored
+		}
+
+		return err
+	})
+
+	// A retry that raced the original request loses its own link and gets
+	// the one the original created.
+	if errors.Is(err, errKeyStored) {
+		response, err = c.storedResponse(ctx, key, hash, request.Password)
+		return response, err == nil, err
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return response, false, nil
+}
+
+// storedResponse returns the link created for an idempotency key, or
+// sql.ErrNoRows when the key is not stored.
+func (c *Controller) storedResponse(ctx context.Context, key, hash string, password *string) (*models.ShortLinkResponse, error) {
+	stored, err := c.queries.GetIdempotencyKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if stored.Requesthash != hash || !samePassword(stored.Passwordhash, password) {
+		return nil, ErrIdempotencyKeyReused
+	}
+
+	var response models.ShortLinkResponse
+	if err := json.Unmarshal(stored.Response, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// samePassword reports whether a retry has the password of the request that
+// stored its key. A protected key stored without a password hash cannot be
+// compared, so it never matches.
+func samePassword(hash sql.NullString, password *string) bool {
+	if password == nil || *password == "" {
+		return !hash.Valid
+	}
+
+	return hash.Valid && utils.CheckPassword(hash.String, *password)
+}
@@ -0,0 +1,339 @@
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/generator"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	recorderMock "github.com/DarcoProgramador/shortener-go-backend/mocks/recorder_mock"
+	storeMock "github.com/DarcoProgramador/shortener-go-backend/mocks/store_mock"
+	"github.com/DarcoProgramador/shortener-go-backend/utils"
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestController_CreateShortLinkIdempotent(t *testing.T) {
+	request := models.ShortLinkRequest{Url: "http://www.google.com", Alias: "spring-sale"}
+	hash, _ := requestHash(request)
+	protectedRequest := request
+	protectedRequest.Password = stringPtr(linkPassword)
+	protectedHash, _ := requestHash(protectedRequest)
+	stored, _ := json.Marshal(models.ShortLinkResponse{
+		Id:        7,
+		Url:       "http://www.google.com",
+		ShortCode: "spring-sale",
+		Version:   1,
+	})
+
+	type args struct {
+		ctx     context.Context
+		key     string
+		request models.ShortLinkRequest
+	}
+	tests := []struct {
+		name             string
+		args             args
+		mockExpectations func(t *testing.T) *storeMock.MockStore
+		want             *models.ShortLinkResponse
+		wantReplayed     bool
+		wantErr          bool
+		errIs            error
+	}{
+		{
+			name: "CreateShortLinkIdempotent new key",
+			args: args{
+				ctx:     context.TODO(),
+				key:     "job-42",
+				request: request,
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().DeleteIdempotencyKeysBefore(mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+					return time.Since(before) > 59*time.Minute && time.Since(before) < 61*time.Minute
+				})).Return(nil)
+				q.EXPECT().GetIdempotencyKey(mock.Anything, "job-42").Return(db.GetIdempotencyKeyRow{}, sql.ErrNoRows)
+				runInTx(q)
+				q.EXPECT().CreateURL(mock.Anything, mock.Anything).RunAndReturn(createdURL)
+				q.EXPECT().CreateIdempotencyKey(mock.Anything, mock.MatchedBy(func(arg db.CreateIdempotencyKeyParams) bool {
+					var response models.ShortLinkResponse
+					return arg.Idempotencykey == "job-42" &&
+						arg.Requesthash == hash &&
+						json.Unmarshal(arg.Response, &response) == nil &&
+						response.ShortCode == "spring-sale"
+				})).Return(nil)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:        1,
+				Url:       "http://www.google.com/",
+				ShortCode: "spring-sale",
+			},
+			wantReplayed: false,
+			wantErr:      false,
+		},
+		{
+			name: "CreateShortLinkIdempotent retry",
+			args: args{
+				ctx:     context.TODO(),
+				key:     "job-42",
+				request: request,
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().DeleteIdempotencyKeysBefore(mock.Anything, mock.Anything).Return(nil)
+				q.EXPECT().GetIdempotencyKey(mock.Anything, "job-42").Return(db.GetIdempotencyKeyRow{
+					Requesthash: hash,
+					Response:    stored,
+				}, nil)
+				// No se espera ninguna llamada a CreateURL
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:        7,
+				Url:       "http://www.google.com",
+				ShortCode: "spring-sale",
+				Version:   1,
+			},
+			wantReplayed: true,
+			wantErr:      false,
+		},
+		{
+			name: "CreateShortLinkIdempotent key reused",
+			args: args{
+				ctx:     context.TODO(),
+				key:     "job-42",
+				request: models.ShortLinkRequest{Url: "http://www.bing.com"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().DeleteIdempotencyKeysBefore(mock.Anything, mock.Anything).Return(nil)
+				q.EXPECT().GetIdempotencyKey(mock.Anything, "job-42").Return(db.GetIdempotencyKeyRow{
+					Requesthash: hash,
+					Response:    stored,
+				}, nil)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   ErrIdempotencyKeyReused,
+		},
+		{
+			name: "CreateShortLinkIdempotent new key with password",
+			args: args{
+				ctx:     context.TODO(),
+				key:     "job-42",
+				request: protectedRequest,
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().DeleteIdempotencyKeysBefore(mock.Anything, mock.Anything).Return(nil)
+				q.EXPECT().GetIdempotencyKey(mock.Anything, "job-42").Return(db.GetIdempotencyKeyRow{}, sql.ErrNoRows)
+				runInTx(q)
+				q.EXPECT().CreateURL(mock.Anything, mock.Anything).RunAndReturn(createdURL)
+				q.EXPECT().CreateIdempotencyKey(mock.Anything, mock.MatchedBy(func(arg db.CreateIdempotencyKeyParams) bool {
+					return arg.Requesthash == protectedHash &&
+						arg.Passwordhash.Valid &&
+						!strings.Contains(arg.Passwordhash.String, linkPassword) &&
+						utils.CheckPassword(arg.Passwordhash.String, linkPassword)
+				})).Return(nil)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:        1,
+				Url:       "http://www.google.com/",
+				ShortCode: "spring-sale",
+			},
+			wantReplayed: false,
+			wantErr:      false,
+		},
+		{
+			name: "CreateShortLinkIdempotent retry with password",
+			args: args{
+				ctx:     context.TODO(),
+				key:     "job-42",
+				request: protectedRequest,
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().DeleteIdempotencyKeysBefore(mock.Anything, mock.Anything).Return(nil)
+				q.EXPECT().GetIdempotencyKey(mock.Anything, "job-42").Return(db.GetIdempotencyKeyRow{
+					Requesthash:  protectedHash,
+					Response:     stored,
+					Passwordhash: sql.NullString{String: linkPasswordHash, Valid: true},
+				}, nil)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:        7,
+				Url:       "http://www.google.com",
+				ShortCode: "spring-sale",
+				Version:   1,
+			},
+			wantReplayed: true,
+			wantErr:      false,
+		},
+		{
+			name: "CreateShortLinkIdempotent retry with another password",
+			args: args{
+				ctx:     context.TODO(),
+				key:     "job-42",
+				request: models.ShortLinkRequest{Url: "http://www.google.com", Alias: "spring-sale", Password: stringPtr("another password")},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().DeleteIdempotencyKeysBefore(mock.Anything, mock.Anything).Return(nil)
+				q.EXPECT().GetIdempotencyKey(mock.Anything, "job-42").Return(db.GetIdempotencyKeyRow{
+					Requesthash:  protectedHash,
+					Response:     stored,
+					Passwordhash: sql.NullString{String: linkPasswordHash, Valid: true},
+				}, nil)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   ErrIdempotencyKeyReused,
+		},
+		{
+			name: "CreateShortLinkIdempotent protected key without password hash",
+			args: args{
+				ctx:     context.TODO(),
+				key:     "job-42",
+				request: protectedRequest,
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().DeleteIdempotencyKeysBefore(mock.Anything, mock.Anything).Return(nil)
+				q.EXPECT().GetIdempotencyKey(mock.Anything, "job-42").Return(db.GetIdempotencyKeyRow{
+					Requesthash: protectedHash,
+					Response:    stored,
+				}, nil)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   ErrIdempotencyKeyReused,
+		},
+		{
+			name: "CreateShortLinkIdempotent concurrent retry",
+			args: args{
+				ctx:     context.TODO(),
+				key:     "job-42",
+				request: request,
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().DeleteIdempotencyKeysBefore(mock.Anything, mock.Anything).Return(nil)
+				q.EXPECT().GetIdempotencyKey(mock.Anything, "job-42").Return(db.GetIdempotencyKeyRow{}, sql.ErrNoRows).Once()
+				runInTx(q)
+				q.EXPECT().CreateURL(mock.Anything, mock.Anything).RunAndReturn(createdURL)
+				q.EXPECT().CreateIdempotencyKey(mock.Anything, mock.Anything).Return(sqlite3.Error{
+					Code:         sqlite3.ErrConstraint,
+					ExtendedCode: sqlite3.ErrConstraintPrimaryKey,
+				})
+				q.EXPECT().GetIdempotencyKey(mock.Anything, "job-42").Return(db.GetIdempotencyKeyRow{
+					Requesthash: hash,
+					Response:    stored,
+				}, nil).Once()
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:        7,
+				Url:       "http://www.google.com",
+				ShortCode: "spring-sale",
+				Version:   1,
+			},
+			wantReplayed: true,
+			wantErr:      false,
+		},
+		{
+			name: "CreateShortLinkIdempotent invalid link",
+			args: args{
+				ctx:     context.TODO(),
+				key:     "job-42",
+				request: models.ShortLinkRequest{Url: "asdasd"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().DeleteIdempotencyKeysBefore(mock.Anything, mock.Anything).Return(nil)
+				q.EXPECT().GetIdempotencyKey(mock.Anything, "job-42").Return(db.GetIdempotencyKeyRow{}, sql.ErrNoRows)
+				// No se espera ninguna llamada a CreateIdempotencyKey
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrInvalidURL,
+		},
+		{
+			name: "CreateShortLinkIdempotent invalid key",
+			args: args{
+				ctx:     context.TODO(),
+				key:     "job\n42",
+				request: request,
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrInvalidIdempotencyKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.mockExpectations(t)
+			r := recorderMock.NewMockRecorder(t)
+
+			c := NewController(q, generator.NewRandom(), r, Options{IdempotencyWindow: time.Hour})
+
+			got, replayed, err := c.CreateShortLinkIdempotent(tt.args.ctx, tt.args.key, tt.args.request)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs, "El error no es el esperado")
+			}
+
+			if err != nil {
+				assert.Nil(t, got, "El valor de got debe ser nulo cuando se espera un error")
+				return
+			}
+
+			assert.Equal(t, tt.wantReplayed, replayed, "Los valores de replayed no coinciden")
+			assert.Equal(t, tt.want.Id, got.Id, "Los valores de los campos Id no coinciden")
+			assert.Equal(t, tt.want.Url, got.Url, "Los valores de los campos Url no coinciden")
+			assert.Equal(t, tt.want.ShortCode, got.ShortCode, "Los valores de los campos ShortCode no coinciden")
+			assert.Equal(t, tt.want.Version, got.Version, "Los valores de los campos Version no coinciden")
+		})
+	}
+}
+
+func TestRequestHash(t *testing.T) {
+	request := models.ShortLinkRequest{Url: "http://www.google.com"}
+	hash, err := requestHash(request)
+	assert.NoError(t, err)
+
+	protected := request
+	protected.Password = stringPtr(linkPassword)
+	protectedHash, err := requestHash(protected)
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, protectedHash, "Un link con contraseña no es la misma petición que uno sin ella")
+
+	other := request
+	other.Password = stringPtr("another password")
+	otherHash, err := requestHash(other)
+	assert.NoError(t, err)
+	assert.Equal(t, protectedHash, otherHash, "El hash no debe depender de la contraseña")
+
+	empty := request
+	empty.Password = stringPtr("")
+	emptyHash, err := requestHash(empty)
+	assert.NoError(t, err)
+	assert.Equal(t, hash, emptyHash, "Una contraseña vacía no protege el link")
+}
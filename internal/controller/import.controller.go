@@ -0,0 +1,340 @@
+package controller
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	"github.com/DarcoProgramador/shortener-go-backend/utils"
+)
+
+// importColumns maps the CSV headers an import understands, in lower case
+// and without spaces, dashes or underscores, to the field they fill. Besides
+// the columns of an export it knows those of Bitly (long_url, link, title,
+// created_at) and YOURLS (keyword, url, title, timestamp, clicks) exports.
+// Other columns are ignored.
+var importColumns = map[string]string{
+	"shortcode":      "shortCode",
+	"keyword":        "shortCode",
+	"bitlink":        "shortCode",
+	"link":           "shortCode",
+	"shorturl":       "shortCode",
+	"url":            "url",
+	"longurl":        "url",
+	"title":          "title",
+	"description":    "description",
+	"notes":          "notes",
+	"redirectstatus": "redirectStatus",
+	"createdat":      "createdAt",
+	"created":        "createdAt",
+	"datecreated":    "createdAt",
+	"timestamp":      "createdAt",
+	"updatedat":      "updatedAt",
+	"notbefore":      "notBefore",
+	"expiresat":      "expiresAt",
+	"maxclicks":      "maxClicks",
+	"protected":      "protected",
+	"campaignid":     "campaignId",
+	"tags":           "tags",
+	"accesscount":    "accessCount",
+	"clicks":         "accessCount",
+	"totalclicks":    "accessCount",
+	"botcount":       "botCount",
+}
+
+// errProtectedImport reports a protected link in an import file. Exports
+// leave out the URL and password of protected links, and importing one
+// without them would lose its protection.
+var errProtectedImport = errors.New("protected links cannot be imported")
+
+var headerCleaner = strings.NewReplacer(" ", "", "_", "", "-", "", "\ufeff", "")
+
+// importTimeLayouts are the timestamps found in exports. Those without a
+// zone, like YOURLS', are read as UTC.
+var importTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	time.DateTime,
+	time.DateOnly,
+}
+
+func parseImportTime(value string) (*time.Time, error) {
+	for _, layout := range importTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			t = t.UTC()
+			return &t, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid time %q", value)
+}
+
+// importShortCode returns the short code of a value that may also be a full
+// short link, like Bitly's bit.ly/3abcXYZ.
+func importShortCode(value string) string {
+	value = strings.TrimSuffix(strings.TrimSpace(value), "/")
+	return value[strings.LastIndex(value, "/")+1:]
+}
+
+func setImportField(link *models.ExportedLink, field, value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+
+	var err error
+	switch field {
+	case "shortCode":
+		link.ShortCode = value
+	case "url":
+		link.Url = value
+	case "title":
+		link.Title = value
+	case "description":
+		link.Description = value
+	case "notes":
+		link.Notes = value
+	case "protected":
+		link.Protected, err = strconv.ParseBool(value)
+	case "tags":
+		// Tags are written comma separated in a single column.
+		link.Tags = strings.Split(value, ",")
+	case "createdAt":
+		link.CreatedAt, err = parseImportTime(value)
+	case "updatedAt":
+		link.UpdatedAt, err = parseImportTime(value)
+	case "notBefore":
+		link.NotBefore, err = parseImportTime(value)
+	case "expiresAt":
+		link.ExpiresAt, err = parseImportTime(value)
+	case "redirectStatus":
+		link.RedirectStatus, err = strconv.Atoi(value)
+	case "maxClicks":
+		link.MaxClicks, err = strconv.Atoi(value)
+	case "campaignId":
+		link.CampaignId, err = strconv.Atoi(value)
+	case "accessCount", "botCount":
+		var count uint64
+		count, err = strconv.ParseUint(value, 10, 64)
+		if field == "accessCount" {
+			link.AccessCount = uint(count)
+		} else {
+			link.BotCount = uint(count)
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid %s %q", field, value)
+	}
+	return nil
+}
+
+// importRow is a link read from an import file. err tells why the link
+// could not be read; it only fails that link.
+type importRow struct {
+	link models.ExportedLink
+	err  error
+}
+
+// importReader returns the next link of an import file, or io.EOF at the
+// end of it. Any other error stops the import.
+type importReader func() (importRow, error)
+
+func csvImportReader(body io.Reader) (importReader, error) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, utils.ErrInvalidImportHeader
+	}
+
+	fields := make([]string, len(header))
+	hasURL := false
+	for i, name := range header {
+		fields[i] = importColumns[strings.ToLower(headerCleaner.Replace(name))]
+		hasURL = hasURL || fields[i] == "url"
+	}
+	if !hasURL {
+		return nil, utils.ErrInvalidImportHeader
+	}
+
+	return func() (importRow, error) {
+		record, err := reader.Read()
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return importRow{err: parseErr}, nil
+		}
+		if err != nil {
+			return importRow{}, err
+		}
+
+		var row importRow
+		for i, value := range record {
+			if i >= len(fields) || fields[i] == "" {
+				continue
+			}
+
+			if err := setImportField(&row.link, fields[i], value); err != nil {
+				row.err = err
+				break
+			}
+		}
+		return row, nil
+	}, nil
+}
+
+func ndjsonImportReader(body io.Reader) importReader {
+	reader := bufio.NewReader(body)
+
+	return func() (importRow, error) {
+		for {
+			line, err := reader.ReadBytes('\n')
+			if err != nil && !errors.Is(err, io.EOF) {
+				return importRow{}, err
+			}
+
+			line = bytes.TrimSpace(line)
+			if len(line) == 0 {
+				if err != nil {
+					return importRow{}, err
+				}
+				continue
+			}
+
+			var row importRow
+			if jsonErr := json.Unmarshal(line, &row.link); jsonErr != nil {
+				row.err = errors.New("invalid JSON")
+			}
+			return row, nil
+		}
+	}
+}
+
+// newImportLink validates an imported link. Its short code is kept when it
+// is a valid alias; otherwise a new one is generated and renamed says why.
+func (c *Controller) newImportLink(index int, link models.ExportedLink) (batchLink, error) {
+	if link.Protected {
+		return batchLink{}, errProtectedImport
+	}
+
+	code := importShortCode(link.ShortCode)
+
+	var renamed string
+	if code != "" {
+		if err := utils.ValidateAlias(code); err != nil {
+			renamed = fmt.Sprintf("short code %q was not kept: %v", code, err)
+			code = ""
+		}
+	}
+
+	params, tags, err := c.newLinkParams(models.ShortLinkRequest{
+		Url:            link.Url,
+		Alias:          code,
+		RedirectStatus: link.RedirectStatus,
+		ExpiresAt:      link.ExpiresAt,
+		NotBefore:      link.NotBefore,
+		MaxClicks:      link.MaxClicks,
+		Title:          link.Title,
+		Description:    link.Description,
+		Notes:          link.Notes,
+		Tags:           link.Tags,
+	})
+	if err != nil {
+		return batchLink{}, err
+	}
+
+	return batchLink{
+		index:  index,
+		params: params,
+		tags:   tags,
+		stats: &db.RestoreURLStatsByIDParams{
+			CreatedAt:   nullTime(link.CreatedAt),
+			UpdatedAt:   nullTime(link.UpdatedAt),
+			AccessCount: int64(link.AccessCount),
+			BotCount:    int64(link.BotCount),
+		},
+		renamed: renamed,
+	}, nil
+}
+
+func (c *Controller) ImportLinks(ctx context.Context, format string, body io.Reader) (*models.BatchResponse, error) {
+	var next importReader
+	switch format {
+	case FormatCSV:
+		var err error
+		if next, err = csvImportReader(body); err != nil {
+			return nil, err
+		}
+	case FormatNDJSON:
+		next = ndjsonImportReader(body)
+	default:
+		return nil, utils.ErrInvalidFormat
+	}
+
+	results := []models.BatchResult{}
+	links := make([]batchLink, 0, batchChunkSize)
+
+	// Links are inserted while the file is read, so a large import is never
+	// held in memory.
+	for {
+		row, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			// The links read so far are imported, so the caller can tell
+			// which ones exist.
+			c.insertBatch(ctx, links, results)
+			return batchResponse(results), err
+		}
+
+		index := len(results)
+		results = append(results, models.BatchResult{Index: index})
+
+		var link batchLink
+		if row.err == nil {
+			link, row.err = c.newImportLink(index, row.link)
+		}
+		if row.err != nil {
+			results[index].Status = batchInvalid
+			results[index].Message = row.err.Error()
+			continue
+		}
+
+		campaignID := row.link.CampaignId
+		link.params.Campaignid, link.params.Url, err = campaignLink(ctx, c.queries, &campaignID, link.params.Url)
+		switch {
+		case errors.Is(err, ErrCampaignNotFound), errors.Is(err, utils.ErrInvalidCampaignID):
+			results[index].Status = batchInvalid
+			results[index].Message = err.Error()
+			continue
+		case err != nil:
+			results[index].Status = batchFailed
+			results[index].Message = err.Error()
+			continue
+		}
+		links = append(links, link)
+
+		if len(links) == batchChunkSize {
+			c.insertBatch(ctx, links, results)
+			links = links[:0]
+		}
+	}
+
+	c.insertBatch(ctx, links, results)
+
+	return batchResponse(results), nil
+}
@@ -0,0 +1,286 @@
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/generator"
+	recorderMock "github.com/DarcoProgramador/shortener-go-backend/mocks/recorder_mock"
+	storeMock "github.com/DarcoProgramador/shortener-go-backend/mocks/store_mock"
+	"github.com/DarcoProgramador/shortener-go-backend/utils"
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// withShortCode matches the insert of the link with a short code.
+func withShortCode(code string) interface{} {
+	return mock.MatchedBy(func(arg db.CreateURLParams) bool {
+		return arg.Shortcode == code
+	})
+}
+
+func TestController_ImportLinks(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		format  string
+		body    string
+		readErr error
+	}
+	tests := []struct {
+		name             string
+		args             args
+		mockExpectations func(t *testing.T) *storeMock.MockStore
+		want             []string
+		wantErr          bool
+		errIs            error
+	}{
+		{
+			name: "ImportLinks export file",
+			args: args{
+				ctx:    context.TODO(),
+				format: FormatCSV,
+				body: "id,shortCode,url,title,description,notes,redirectStatus,createdAt,updatedAt,notBefore,expiresAt,maxClicks,protected,campaignId,tags,accessCount,botCount,uniqueVisitors\n" +
+					"7,spring-sale,https://www.google.com/?utm_source=newsletter&utm_medium=email,\"Spring sale, 2025\",,Q3 webinar,301,2025-03-02T09:00:00Z,,,2025-04-01T00:00:00Z,100,false,3,\"spring,promo\",42,5,12\n" +
+					"8,private,,,,,302,2025-03-03T09:00:00Z,,,,0,true,0,,1,0,1\n",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				runInTx(q)
+				q.EXPECT().GetCampaignByID(mock.Anything, int64(3)).Return(springCampaign, nil)
+				q.EXPECT().CreateURL(mock.Anything, db.CreateURLParams{
+					Url:            "https://www.google.com/?utm_source=newsletter&utm_medium=email",
+					Shortcode:      "spring-sale",
+					Redirectstatus: 301,
+					Expiresat:      sql.NullTime{Time: mustParseTime(t, "2025-04-01T00:00:00Z"), Valid: true},
+					Maxclicks:      sql.NullInt64{Int64: 100, Valid: true},
+					Domain:         sql.NullString{String: "google.com", Valid: true},
+					Campaignid:     sql.NullInt64{Int64: 3, Valid: true},
+					Title:          sql.NullString{String: "Spring sale, 2025", Valid: true},
+					Notes:          sql.NullString{String: "Q3 webinar", Valid: true},
+				}).RunAndReturn(createdURL).Once()
+				expectTags(q, "promo", "spring")
+				q.EXPECT().RestoreURLStatsByID(mock.Anything, db.RestoreURLStatsByIDParams{
+					CreatedAt:   sql.NullTime{Time: mustParseTime(t, "2025-03-02T09:00:00Z"), Valid: true},
+					AccessCount: 42,
+					BotCount:    5,
+					ID:          1,
+				}).Return(nil).Once()
+				return q
+			},
+			// Protected links are exported without their URL.
+			want:    []string{batchCreated, batchInvalid},
+			wantErr: false,
+		},
+		{
+			name: "ImportLinks with unknown campaign",
+			args: args{
+				ctx:    context.TODO(),
+				format: FormatNDJSON,
+				body:   `{"shortCode":"sale","url":"https://www.google.com","campaignId":9}`,
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetCampaignByID(mock.Anything, int64(9)).Return(db.Campaign{}, sql.ErrNoRows)
+				return q
+			},
+			want:    []string{batchInvalid},
+			wantErr: false,
+		},
+		{
+			name: "ImportLinks Bitly export",
+			args: args{
+				ctx:    context.TODO(),
+				format: FormatCSV,
+				body: "\ufefftitle,long_url,link,created_at\n" +
+					"Google,https://www.google.com,https://bit.ly/3abcXYZ,2025-03-01T10:00:00+0000\n" +
+					"\"Say \"\"hi\"\"\",https://example.com,bit.ly/4defUVW,yesterday\n",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				runInTx(q)
+				q.EXPECT().CreateURL(mock.Anything, mock.MatchedBy(func(arg db.CreateURLParams) bool {
+					return arg.Shortcode == "3abcXYZ" && arg.Title.String == "Google"
+				})).RunAndReturn(createdURL).Once()
+				q.EXPECT().RestoreURLStatsByID(mock.Anything, db.RestoreURLStatsByIDParams{
+					CreatedAt: sql.NullTime{Time: mustParseTime(t, "2025-03-01T10:00:00Z"), Valid: true},
+					ID:        1,
+				}).Return(nil).Once()
+				return q
+			},
+			want:    []string{batchCreated, batchInvalid},
+			wantErr: false,
+		},
+		{
+			name: "ImportLinks YOURLS export",
+			args: args{
+				ctx:    context.TODO(),
+				format: FormatCSV,
+				body: "keyword,url,title,timestamp,ip,clicks\n" +
+					"sale,https://www.google.com,Google,2025-03-01 10:00:00,192.0.2.1,17\n" +
+					"ab,https://example.com,Example,2025-03-01 11:00:00,192.0.2.1,0\n" +
+					"export,https://example.org,Example,2025-03-01 12:00:00,192.0.2.1,0\n",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				runInTx(q)
+				q.EXPECT().CreateURL(mock.Anything, withShortCode("sale")).RunAndReturn(createdURL).Once()
+				// Codes that are not valid aliases are replaced by generated ones.
+				q.EXPECT().GetLastURLID(mock.Anything).Return(1, nil).Times(2)
+				q.EXPECT().CreateURL(mock.Anything, mock.Anything).RunAndReturn(createdURL).Times(2)
+				q.EXPECT().RestoreURLStatsByID(mock.Anything, mock.MatchedBy(func(arg db.RestoreURLStatsByIDParams) bool {
+					return arg.AccessCount == 17 && arg.CreatedAt.Time.Equal(mustParseTime(t, "2025-03-01T10:00:00Z"))
+				})).Return(nil).Once()
+				q.EXPECT().RestoreURLStatsByID(mock.Anything, mock.Anything).Return(nil).Times(2)
+				return q
+			},
+			want:    []string{batchCreated, batchRenamed, batchRenamed},
+			wantErr: false,
+		},
+		{
+			name: "ImportLinks NDJSON",
+			args: args{
+				ctx:    context.TODO(),
+				format: FormatNDJSON,
+				body: `{"shortCode":"taken","url":"https://www.google.com"}` + "\n" +
+					"\n" +
+					`{"shortCode":"broken",` + "\n" +
+					`{"url":"not a url"}` + "\n" +
+					`{"shortCode":"free","url":"https://www.google.com","accessCount":3}`,
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				runInTx(q)
+				q.EXPECT().CreateURL(mock.Anything, withShortCode("taken")).Return(db.CreateURLRow{}, sqlite3.Error{
+					Code:         sqlite3.ErrConstraint,
+					ExtendedCode: sqlite3.ErrConstraintUnique,
+				}).Once()
+				q.EXPECT().CreateURL(mock.Anything, withShortCode("free")).RunAndReturn(createdURL).Once()
+				q.EXPECT().RestoreURLStatsByID(mock.Anything, db.RestoreURLStatsByIDParams{AccessCount: 3, ID: 1}).Return(nil).Once()
+				return q
+			},
+			want:    []string{batchConflict, batchInvalid, batchInvalid, batchCreated},
+			wantErr: false,
+		},
+		{
+			name: "ImportLinks with failed transaction",
+			args: args{
+				ctx:    context.TODO(),
+				format: FormatCSV,
+				body:   "shortCode,url\nfirst,https://www.google.com\nsecond,https://www.google.com\n",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				runInTx(q)
+				q.EXPECT().CreateURL(mock.Anything, withShortCode("first")).RunAndReturn(createdURL).Once()
+				q.EXPECT().RestoreURLStatsByID(mock.Anything, mock.Anything).Return(assert.AnError).Once()
+				return q
+			},
+			want:    []string{batchFailed, batchFailed},
+			wantErr: false,
+		},
+		{
+			name: "ImportLinks with read error",
+			args: args{
+				ctx:     context.TODO(),
+				format:  FormatNDJSON,
+				body:    `{"shortCode":"first","url":"https://www.google.com"}` + "\n",
+				readErr: assert.AnError,
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				runInTx(q)
+				q.EXPECT().CreateURL(mock.Anything, withShortCode("first")).RunAndReturn(createdURL).Once()
+				q.EXPECT().RestoreURLStatsByID(mock.Anything, mock.Anything).Return(nil).Once()
+				return q
+			},
+			// The links read before the error are imported and reported.
+			want:    []string{batchCreated},
+			wantErr: true,
+			errIs:   assert.AnError,
+		},
+		{
+			name: "ImportLinks empty file",
+			args: args{
+				ctx:    context.TODO(),
+				format: FormatNDJSON,
+				body:   "",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				return storeMock.NewMockStore(t)
+			},
+			want:    []string{},
+			wantErr: false,
+		},
+		{
+			name: "ImportLinks without url column",
+			args: args{
+				ctx:    context.TODO(),
+				format: FormatCSV,
+				body:   "keyword,title\nsale,Google\n",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				return storeMock.NewMockStore(t)
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrInvalidImportHeader,
+		},
+		{
+			name: "ImportLinks invalid format",
+			args: args{
+				ctx:    context.TODO(),
+				format: "xml",
+				body:   "<links/>",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				return storeMock.NewMockStore(t)
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrInvalidFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.mockExpectations(t)
+			r := recorderMock.NewMockRecorder(t)
+
+			c := NewController(q, generator.NewRandom(), r, Options{})
+
+			var body io.Reader = strings.NewReader(tt.args.body)
+			if tt.args.readErr != nil {
+				body = io.MultiReader(body, iotest.ErrReader(tt.args.readErr))
+			}
+
+			got, err := c.ImportLinks(tt.args.ctx, tt.args.format, body)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs, "El error no es el esperado")
+			}
+
+			if tt.want == nil {
+				assert.Nil(t, got, "El valor de got debe ser nulo cuando se espera un error")
+				return
+			}
+
+			statuses := make([]string, len(got.Results))
+			for i, result := range got.Results {
+				assert.Equal(t, i, result.Index, "Los resultados deben seguir el orden del archivo")
+				statuses[i] = result.Status
+
+				if result.Status == batchRenamed {
+					assert.NotEmpty(t, result.Message, "Un link renombrado debe explicar el motivo")
+				}
+			}
+
+			assert.Equal(t, tt.want, statuses, "Los estados no coinciden")
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	"github.com/DarcoProgramador/shortener-go-backend/utils"
+)
+
+const (
+	sortCreatedAt   = "createdAt"
+	sortAccessCount = "accessCount"
+	sortUpdatedAt   = "updatedAt"
+
+	orderAsc  = "asc"
+	orderDesc = "desc"
+
+	defaultListLimit = 20
+	maxListLimit     = 100
+
+	// lastSQLiteTime sorts after every time SQLite's datetime() returns.
+	lastSQLiteTime = "9999-12-31 23:59:59"
+)
+
+// likeEscaper escapes the LIKE wildcards of a search so it matches literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// cursor is the position of the last link of a page. Key is the sort value
+// of that link as the queries compare it: a UTC time in SQLite's datetime()
+// format or an access count. The sort and order are kept so a cursor cannot
+// be used with another listing.
+type cursor struct {
+	Sort  string `json:"s"`
+	Order string `json:"o"`
+	Key   string `json:"k"`
+	ID    int64  `json:"id"`
+}
+
+func (cur cursor) encode() string {
+	data, _ := json.Marshal(cur)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(value, sort, order string) (cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor{}, utils.ErrInvalidCursor
+	}
+
+	var cur cursor
+	if err := json.Unmarshal(data, &cur); err != nil || cur.Sort != sort || cur.Order != order {
+		return cursor{}, utils.ErrInvalidCursor
+	}
+
+	return cur, nil
+}
+
+// firstCursor returns a position before every link, so the first page is
+// read with the same queries as the next ones.
+func firstCursor(sort, order string) cursor {
+	cur := cursor{Sort: sort, Order: order}
+
+	switch {
+	case order == orderAsc && sort == sortAccessCount:
+		cur.Key = "-1"
+	case order == orderAsc:
+		cur.Key = ""
+	case sort == sortAccessCount:
+		cur.Key = strconv.FormatInt(math.MaxInt64, 10)
+		cur.ID = math.MaxInt64
+	default:
+		cur.Key = lastSQLiteTime
+		cur.ID = math.MaxInt64
+	}
+
+	return cur
+}
+
+// sqliteTime formats t the way SQLite's datetime() does, which is how the
+// list queries compare times whatever format they were stored in.
+func sqliteTime(t time.Time) string {
+	return t.UTC().Format(time.DateTime)
+}
+
+// sortKey returns the value a link is sorted by. A link that was never
+// updated sorts by its creation time.
+func sortKey(link db.Url, sort string) string {
+	switch sort {
+	case sortAccessCount:
+		return strconv.FormatInt(link.Accesscount.Int64, 10)
+	case sortUpdatedAt:
+		if link.Updatedat.Valid {
+			return sqliteTime(link.Updatedat.Time)
+		}
+	}
+
+	return sqliteTime(link.Createdat.Time)
+}
+
+// listURLs runs the query of a sort and order. Every query takes the same
+// filters, so the parameters are built once and converted.
+func (c *Controller) listURLs(ctx context.Context, sort, order string, params db.ListURLsByCreatedAtParams) ([]db.Url, error) {
+	switch {
+	case sort == sortCreatedAt && order == orderAsc:
+		return c.queries.ListURLsByCreatedAt(ctx, params)
+	case sort == sortCreatedAt:
+		return c.queries.ListURLsByCreatedAtDesc(ctx, db.ListURLsByCreatedAtDescParams(params))
+	case sort == sortUpdatedAt && order == orderAsc:
+		return c.queries.ListURLsByUpdatedAt(ctx, db.ListURLsByUpdatedAtParams(params))
+	case sort == sortUpdatedAt:
+		return c.queries.ListURLsByUpdatedAtDesc(ctx, db.ListURLsByUpdatedAtDescParams(params))
+	}
+
+	count, err := strconv.ParseInt(params.AfterKey, 10, 64)
+	if err != nil {
+		return nil, utils.ErrInvalidCursor
+	}
+
+	countParams := db.ListURLsByAccessCountParams{
+		AfterKey:    count,
+		AfterID:     params.AfterID,
+		Domain:      params.Domain,
+		Search:      params.Search,
+		UrlSearch:   params.UrlSearch,
+		CreatedFrom: params.CreatedFrom,
+		CreatedTo:   params.CreatedTo,
+		Tag:         params.Tag,
+		CampaignID:  params.CampaignID,
+		RowLimit:    params.RowLimit,
+	}
+
+	if order == orderAsc {
+		return c.queries.ListURLsByAccessCount(ctx, countParams)
+	}
+	return c.queries.ListURLsByAccessCountDesc(ctx, db.ListURLsByAccessCountDescParams(countParams))
+}
+
+func (c *Controller) ListLinks(ctx context.Context, request models.ListLinksRequest) (*models.ListLinksResponse, error) {
+	sort := request.Sort
+	if sort == "" {
+		sort = sortCreatedAt
+	}
+	if sort != sortCreatedAt && sort != sortAccessCount && sort != sortUpdatedAt {
+		return nil, utils.ErrInvalidSort
+	}
+
+	order := request.Order
+	if order == "" {
+		order = orderDesc
+	}
+	if order != orderAsc && order != orderDesc {
+		return nil, utils.ErrInvalidOrder
+	}
+
+	if request.CampaignId < 0 {
+		return nil, utils.ErrInvalidCampaignID
+	}
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultListLimit
+	}
+	if limit < 0 || limit > maxListLimit {
+		return nil, utils.ErrInvalidLimit
+	}
+
+	loc, err := utils.ParseTimezone(request.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := utils.ParseDate(request.CreatedFrom, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := utils.ParseDate(request.CreatedTo, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, utils.ErrInvalidTimeRange
+	}
+
+	after := firstCursor(sort, order)
+	if request.Cursor != "" {
+		if after, err = decodeCursor(request.Cursor, sort, order); err != nil {
+			return nil, err
+		}
+	}
+
+	// The search is a substring, so it is matched as it is. A full URL is
+	// also matched as links store it, so HTTP://Example.com:80 finds the
+	// links to http://example.com/.
+	var urlSearch string
+	if link, err := c.linkURL(request.Search); err == nil && link != request.Search {
+		urlSearch = link
+	}
+
+	params := db.ListURLsByCreatedAtParams{
+		AfterKey:  after.Key,
+		AfterID:   after.ID,
+		Domain:    nullString(strings.TrimPrefix(strings.ToLower(request.Domain), "www.")),
+		Search:    nullString(likeEscaper.Replace(request.Search)),
+		UrlSearch: nullString(likeEscaper.Replace(urlSearch)),
+		Tag:       nullString(strings.ToLower(strings.TrimSpace(request.Tag))),
+		CampaignID: sql.NullInt64{
+			Int64: int64(request.CampaignId),
+			Valid: request.CampaignId != 0,
+		},
+		// One more link than asked tells whether there is a next page.
+		RowLimit: int64(limit + 1),
+	}
+	if from != nil {
+		params.CreatedFrom = nullString(sqliteTime(*from))
+	}
+	if to != nil {
+		params.CreatedTo = nullString(sqliteTime(*to))
+	}
+
+	links, err := c.listURLs(ctx, sort, order, params)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.ListLinksResponse{}
+	if len(links) > limit {
+		links = links[:limit]
+		last := links[len(links)-1]
+		response.NextCursor = cursor{Sort: sort, Order: order, Key: sortKey(last, sort), ID: last.ID}.encode()
+	}
+
+	ids := make([]int64, len(links))
+	for i, link := range links {
+		ids[i] = link.ID
+	}
+
+	tags, err := c.tagsByURLID(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Links = make([]models.ListedShortLink, len(links))
+	for i, link := range links {
+		// Listing needs no password, so protected links are listed without
+		// their URL.
+		destination := link.Url
+		if link.Passwordhash.Valid {
+			destination = ""
+		}
+
+		response.Links[i] = models.ListedShortLink{
+			Id:             int(link.ID),
+			Url:            destination,
+			ShortCode:      link.Shortcode,
+			Title:          link.Title.String,
+			Description:    link.Description.String,
+			Notes:          link.Notes.String,
+			RedirectStatus: int(link.Redirectstatus),
+			ExpiresAt:      timePtr(link.Expiresat),
+			NotBefore:      timePtr(link.Notbefore),
+			MaxClicks:      int(link.Maxclicks.Int64),
+			Protected:      link.Passwordhash.Valid,
+			CreatedAt:      timePtr(link.Createdat),
+			UpdatedAt:      timePtr(link.Updatedat),
+			Tags:           tags[link.ID],
+			CampaignId:     int(link.Campaignid.Int64),
+			AccessCount:    uint(link.Accesscount.Int64),
+		}
+	}
+
+	return response, nil
+}
@@ -0,0 +1,489 @@
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"testing"
+
+	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/generator"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	recorderMock "github.com/DarcoProgramador/shortener-go-backend/mocks/recorder_mock"
+	storeMock "github.com/DarcoProgramador/shortener-go-backend/mocks/store_mock"
+	"github.com/DarcoProgramador/shortener-go-backend/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// listedURL returns a link row as the list queries return it.
+func listedURL(t *testing.T, id int64, shortCode, createdAt string, accessCount int64) db.Url {
+	return db.Url{
+		ID:          id,
+		Url:         "https://www.google.com/" + shortCode,
+		Shortcode:   shortCode,
+		Createdat:   sql.NullTime{Time: mustParseTime(t, createdAt), Valid: true},
+		Accesscount: sql.NullInt64{Int64: accessCount, Valid: true},
+	}
+}
+
+func TestController_ListLinks(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		request models.ListLinksRequest
+	}
+	tests := []struct {
+		name             string
+		args             args
+		mockExpectations func(t *testing.T) *storeMock.MockStore
+		want             []string
+		wantTags         [][]string
+		wantURLs         []string
+		wantCursor       *cursor
+		wantErr          bool
+		errIs            error
+	}{
+		{
+			name: "ListLinks_OK newest first",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ListLinksRequest{Limit: 2},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().ListURLsByCreatedAtDesc(mock.Anything, db.ListURLsByCreatedAtDescParams{
+					AfterKey: lastSQLiteTime,
+					AfterID:  math.MaxInt64,
+					RowLimit: 3,
+				}).Return([]db.Url{
+					listedURL(t, 3, "ccc", "2025-03-03T10:00:00+01:00", 0),
+					listedURL(t, 2, "bbb", "2025-03-02T10:00:00Z", 0),
+					listedURL(t, 1, "aaa", "2025-03-01T10:00:00Z", 0),
+				}, nil)
+				q.EXPECT().ListTagsByURLIDs(mock.Anything, "[3,2]").Return([]db.ListTagsByURLIDsRow{
+					{Urlid: 3, Name: "promo"},
+					{Urlid: 3, Name: "spring"},
+				}, nil)
+				return q
+			},
+			want:       []string{"ccc", "bbb"},
+			wantTags:   [][]string{{"promo", "spring"}, nil},
+			wantCursor: &cursor{Sort: sortCreatedAt, Order: orderDesc, Key: "2025-03-02 10:00:00", ID: 2},
+			wantErr:    false,
+		},
+		{
+			name: "ListLinks last page",
+			args: args{
+				ctx: context.TODO(),
+				request: models.ListLinksRequest{
+					Limit:  2,
+					Cursor: cursor{Sort: sortCreatedAt, Order: orderDesc, Key: "2025-03-02 10:00:00", ID: 2}.encode(),
+				},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().ListURLsByCreatedAtDesc(mock.Anything, db.ListURLsByCreatedAtDescParams{
+					AfterKey: "2025-03-02 10:00:00",
+					AfterID:  2,
+					RowLimit: 3,
+				}).Return([]db.Url{
+					listedURL(t, 1, "aaa", "2025-03-01T10:00:00Z", 0),
+				}, nil)
+				q.EXPECT().ListTagsByURLIDs(mock.Anything, "[1]").Return(nil, nil)
+				return q
+			},
+			want:    []string{"aaa"},
+			wantErr: false,
+		},
+		{
+			name: "ListLinks hides the URL of protected links",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ListLinksRequest{},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				protected := listedURL(t, 2, "bbb", "2025-03-02T10:00:00Z", 0)
+				protected.Passwordhash = sql.NullString{String: linkPasswordHash, Valid: true}
+
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().ListURLsByCreatedAtDesc(mock.Anything, mock.Anything).Return([]db.Url{
+					protected,
+					listedURL(t, 1, "aaa", "2025-03-01T10:00:00Z", 0),
+				}, nil)
+				q.EXPECT().ListTagsByURLIDs(mock.Anything, "[2,1]").Return(nil, nil)
+				return q
+			},
+			want:     []string{"bbb", "aaa"},
+			wantURLs: []string{"", "https://www.google.com/aaa"},
+			wantErr:  false,
+		},
+		{
+			name: "ListLinks by access count with filters",
+			args: args{
+				ctx: context.TODO(),
+				request: models.ListLinksRequest{
+					Sort:        "accessCount",
+					Order:       "asc",
+					Limit:       1,
+					Domain:      "WWW.Google.com",
+					Search:      "50%_off",
+					CreatedFrom: "2025-03-01",
+					CreatedTo:   "2025-04-01",
+					Timezone:    "Europe/Madrid",
+				},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().ListURLsByAccessCount(mock.Anything, db.ListURLsByAccessCountParams{
+					AfterKey:    -1,
+					AfterID:     0,
+					Domain:      sql.NullString{String: "google.com", Valid: true},
+					Search:      sql.NullString{String: `50\%\_off`, Valid: true},
+					CreatedFrom: sql.NullString{String: "2025-02-28 23:00:00", Valid: true},
+					CreatedTo:   sql.NullString{String: "2025-03-31 22:00:00", Valid: true},
+					RowLimit:    2,
+				}).Return([]db.Url{
+					listedURL(t, 4, "ddd", "2025-03-04T10:00:00Z", 7),
+					listedURL(t, 5, "eee", "2025-03-05T10:00:00Z", 9),
+				}, nil)
+				q.EXPECT().ListTagsByURLIDs(mock.Anything, "[4]").Return(nil, nil)
+				return q
+			},
+			want:       []string{"ddd"},
+			wantCursor: &cursor{Sort: sortAccessCount, Order: orderAsc, Key: "7", ID: 4},
+			wantErr:    false,
+		},
+		{
+			name: "ListLinks by update of a link never updated",
+			args: args{
+				ctx: context.TODO(),
+				request: models.ListLinksRequest{
+					Sort:  "updatedAt",
+					Limit: 1,
+				},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().ListURLsByUpdatedAtDesc(mock.Anything, mock.Anything).Return([]db.Url{
+					listedURL(t, 1, "aaa", "2025-03-01T10:00:00Z", 0),
+					listedURL(t, 2, "bbb", "2025-02-01T10:00:00Z", 0),
+				}, nil)
+				q.EXPECT().ListTagsByURLIDs(mock.Anything, "[1]").Return(nil, nil)
+				return q
+			},
+			want:       []string{"aaa"},
+			wantCursor: &cursor{Sort: sortUpdatedAt, Order: orderDesc, Key: "2025-03-01 10:00:00", ID: 1},
+			wantErr:    false,
+		},
+		{
+			name: "ListLinks searching a URL",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ListLinksRequest{Search: "HTTPS://WWW.Google.com:443/a/../search_page"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().ListURLsByCreatedAtDesc(mock.Anything, db.ListURLsByCreatedAtDescParams{
+					AfterKey:  lastSQLiteTime,
+					AfterID:   math.MaxInt64,
+					Search:    sql.NullString{String: `HTTPS://WWW.Google.com:443/a/../search\_page`, Valid: true},
+					UrlSearch: sql.NullString{String: `https://www.google.com/search\_page`, Valid: true},
+					RowLimit:  defaultListLimit + 1,
+				}).Return([]db.Url{
+					listedURL(t, 3, "ccc", "2025-03-03T10:00:00Z", 0),
+				}, nil)
+				q.EXPECT().ListTagsByURLIDs(mock.Anything, "[3]").Return(nil, nil)
+				return q
+			},
+			want:    []string{"ccc"},
+			wantErr: false,
+		},
+		{
+			name: "ListLinks searching the start of a URL",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ListLinksRequest{Search: "https://docs"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().ListURLsByCreatedAtDesc(mock.Anything, db.ListURLsByCreatedAtDescParams{
+					AfterKey:  lastSQLiteTime,
+					AfterID:   math.MaxInt64,
+					Search:    sql.NullString{String: "https://docs", Valid: true},
+					UrlSearch: sql.NullString{String: "https://docs/", Valid: true},
+					RowLimit:  defaultListLimit + 1,
+				}).Return([]db.Url{
+					listedURL(t, 3, "ccc", "2025-03-03T10:00:00Z", 0),
+				}, nil)
+				q.EXPECT().ListTagsByURLIDs(mock.Anything, "[3]").Return(nil, nil)
+				return q
+			},
+			want:    []string{"ccc"},
+			wantErr: false,
+		},
+		{
+			name: "ListLinks searching a normalized URL",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ListLinksRequest{Search: "https://www.google.com/"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().ListURLsByCreatedAtDesc(mock.Anything, db.ListURLsByCreatedAtDescParams{
+					AfterKey: lastSQLiteTime,
+					AfterID:  math.MaxInt64,
+					Search:   sql.NullString{String: "https://www.google.com/", Valid: true},
+					RowLimit: defaultListLimit + 1,
+				}).Return([]db.Url{
+					listedURL(t, 3, "ccc", "2025-03-03T10:00:00Z", 0),
+				}, nil)
+				q.EXPECT().ListTagsByURLIDs(mock.Anything, "[3]").Return(nil, nil)
+				return q
+			},
+			want:    []string{"ccc"},
+			wantErr: false,
+		},
+		{
+			name: "ListLinks by tag",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ListLinksRequest{Tag: " Spring "},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().ListURLsByCreatedAtDesc(mock.Anything, db.ListURLsByCreatedAtDescParams{
+					AfterKey: lastSQLiteTime,
+					AfterID:  math.MaxInt64,
+					Tag:      sql.NullString{String: "spring", Valid: true},
+					RowLimit: defaultListLimit + 1,
+				}).Return([]db.Url{
+					listedURL(t, 3, "ccc", "2025-03-03T10:00:00Z", 0),
+				}, nil)
+				q.EXPECT().ListTagsByURLIDs(mock.Anything, "[3]").Return([]db.ListTagsByURLIDsRow{
+					{Urlid: 3, Name: "spring"},
+				}, nil)
+				return q
+			},
+			want:     []string{"ccc"},
+			wantTags: [][]string{{"spring"}},
+			wantErr:  false,
+		},
+		{
+			name: "ListLinks by campaign",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ListLinksRequest{CampaignId: 3},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().ListURLsByCreatedAtDesc(mock.Anything, db.ListURLsByCreatedAtDescParams{
+					AfterKey:   lastSQLiteTime,
+					AfterID:    math.MaxInt64,
+					CampaignID: sql.NullInt64{Int64: 3, Valid: true},
+					RowLimit:   defaultListLimit + 1,
+				}).Return([]db.Url{
+					listedURL(t, 3, "ccc", "2025-03-03T10:00:00Z", 0),
+				}, nil)
+				q.EXPECT().ListTagsByURLIDs(mock.Anything, "[3]").Return(nil, nil)
+				return q
+			},
+			want:    []string{"ccc"},
+			wantErr: false,
+		},
+		{
+			name: "ListLinks with invalid campaign",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ListLinksRequest{CampaignId: -1},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				return storeMock.NewMockStore(t)
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrInvalidCampaignID,
+		},
+		{
+			name: "ListLinks empty",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ListLinksRequest{},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().ListURLsByCreatedAtDesc(mock.Anything, mock.Anything).Return([]db.Url{}, nil)
+				return q
+			},
+			want:    []string{},
+			wantErr: false,
+		},
+		{
+			name: "ListLinks with invalid sort",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ListLinksRequest{Sort: "url"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				return storeMock.NewMockStore(t)
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrInvalidSort,
+		},
+		{
+			name: "ListLinks with invalid order",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ListLinksRequest{Order: "up"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				return storeMock.NewMockStore(t)
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrInvalidOrder,
+		},
+		{
+			name: "ListLinks with invalid limit",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ListLinksRequest{Limit: 101},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				return storeMock.NewMockStore(t)
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrInvalidLimit,
+		},
+		{
+			name: "ListLinks with invalid cursor",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ListLinksRequest{Cursor: "not a cursor"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				return storeMock.NewMockStore(t)
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrInvalidCursor,
+		},
+		{
+			name: "ListLinks with cursor of another sort",
+			args: args{
+				ctx: context.TODO(),
+				request: models.ListLinksRequest{
+					Sort:   "accessCount",
+					Cursor: cursor{Sort: sortCreatedAt, Order: orderDesc, Key: "2025-03-02 10:00:00", ID: 2}.encode(),
+				},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				return storeMock.NewMockStore(t)
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrInvalidCursor,
+		},
+		{
+			name: "ListLinks with invalid range",
+			args: args{
+				ctx: context.TODO(),
+				request: models.ListLinksRequest{
+					CreatedFrom: "2025-04-01",
+					CreatedTo:   "2025-03-01",
+				},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				return storeMock.NewMockStore(t)
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrInvalidTimeRange,
+		},
+		{
+			name: "ListLinks with error",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ListLinksRequest{},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().ListURLsByCreatedAtDesc(mock.Anything, mock.Anything).Return(nil, assert.AnError)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   assert.AnError,
+		},
+		{
+			name: "ListLinks with error listing tags",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ListLinksRequest{},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().ListURLsByCreatedAtDesc(mock.Anything, mock.Anything).Return([]db.Url{
+					listedURL(t, 1, "aaa", "2025-03-01T10:00:00Z", 0),
+				}, nil)
+				q.EXPECT().ListTagsByURLIDs(mock.Anything, "[1]").Return(nil, assert.AnError)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.mockExpectations(t)
+			r := recorderMock.NewMockRecorder(t)
+
+			c := NewController(q, generator.NewRandom(), r, Options{})
+
+			got, err := c.ListLinks(tt.args.ctx, tt.args.request)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs, "El error no es el esperado")
+			}
+
+			if err != nil {
+				assert.Nil(t, got, "El valor de got debe ser nulo cuando se espera un error")
+				return
+			}
+
+			codes := make([]string, len(got.Links))
+			for i, link := range got.Links {
+				codes[i] = link.ShortCode
+			}
+			assert.Equal(t, tt.want, codes, "Los links no coinciden")
+
+			if tt.wantTags != nil {
+				tags := make([][]string, len(got.Links))
+				for i, link := range got.Links {
+					tags[i] = link.Tags
+				}
+				assert.Equal(t, tt.wantTags, tags, "Los tags no coinciden")
+			}
+
+			if tt.wantURLs != nil {
+				urls := make([]string, len(got.Links))
+				for i, link := range got.Links {
+					urls[i] = link.Url
+				}
+				assert.Equal(t, tt.wantURLs, urls, "Las URLs no coinciden")
+			}
+
+			if tt.wantCursor == nil {
+				assert.Empty(t, got.NextCursor, "La última página no debe tener cursor")
+				return
+			}
+
+			next, err := decodeCursor(got.NextCursor, tt.wantCursor.Sort, tt.wantCursor.Order)
+			assert.NoError(t, err, "El cursor debe poder leerse")
+			assert.Equal(t, *tt.wantCursor, next, "Los valores del cursor no coinciden")
+		})
+	}
+}
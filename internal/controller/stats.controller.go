@@ -0,0 +1,306 @@
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	"github.com/DarcoProgramador/shortener-go-backend/utils"
+)
+
+const (
+	intervalHour = "hour"
+	intervalDay  = "day"
+	intervalWeek = "week"
+
+	defaultStatsDays     = 30
+	maxTimeSeriesBuckets = 1000
+
+	defaultBreakdownLimit = 10
+	maxBreakdownLimit     = 100
+)
+
+type breakdownRow interface {
+	db.ListTopReferrersByURLIDRow | db.ListTopBrowsersByURLIDRow |
+		db.ListTopOSByURLIDRow | db.ListTopDevicesByURLIDRow
+}
+
+// breakdownItems converts the rows of any of the top lists; they all share
+// the same value and clicks columns.
+func breakdownItems[T breakdownRow](rows []T) []models.BreakdownItem {
+	items := make([]models.BreakdownItem, len(rows))
+	for i, row := range rows {
+		r := db.ListTopReferrersByURLIDRow(row)
+		items[i] = models.BreakdownItem{
+			Value:  r.Value,
+			Clicks: uint(r.Clicks),
+		}
+	}
+
+	return items
+}
+
+// statsRange parses the from, to and timezone of a stats query. Without
+// dates it covers the last defaultStatsDays days up to now.
+func statsRange(fromValue, toValue, timezone string) (time.Time, time.Time, *time.Location, error) {
+	loc, err := utils.ParseTimezone(timezone)
+	if err != nil {
+		return time.Time{}, time.Time{}, nil, err
+	}
+
+	to, err := utils.ParseDate(toValue, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, nil, err
+	}
+	if to == nil {
+		now := time.Now()
+		to = &now
+	}
+
+	from, err := utils.ParseDate(fromValue, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, nil, err
+	}
+	if from == nil {
+		start := to.AddDate(0, 0, -defaultStatsDays)
+		from = &start
+	}
+
+	if !from.Before(*to) {
+		return time.Time{}, time.Time{}, nil, utils.ErrInvalidTimeRange
+	}
+
+	return from.In(loc), to.In(loc), loc, nil
+}
+
+// statsLink returns the link a stats query is about.
+func (c *Controller) statsLink(ctx context.Context, shortCode string) (db.Url, error) {
+	link, err := c.queries.GetURLStatsByShortCode(ctx, shortCode)
+	if errors.Is(err, sql.ErrNoRows) {
+		return db.Url{}, ErrLinkNotFound
+	}
+
+	return link, err
+}
+
+// bucketStart returns the start of the bucket holding t, using the calendar
+// of loc. Weeks start on Monday.
+func bucketStart(t time.Time, interval string, loc *time.Location) time.Time {
+	t = t.In(loc)
+
+	switch interval {
+	case intervalHour:
+		// Subtracting the minutes keeps zones with a half hour offset and
+		// repeated DST hours apart.
+		return t.Add(-time.Duration(t.Minute())*time.Minute -
+			time.Duration(t.Second())*time.Second -
+			time.Duration(t.Nanosecond()))
+	case intervalWeek:
+		sinceMonday := (int(t.Weekday()) + 6) % 7
+		return time.Date(t.Year(), t.Month(), t.Day()-sinceMonday, 0, 0, 0, 0, loc)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	}
+}
+
+// nextBucket returns the start of the bucket following start. Days and
+// weeks follow the calendar, so they may be 23 or 25 hours long around a
+// DST change.
+func nextBucket(start time.Time, interval string) time.Time {
+	switch interval {
+	case intervalHour:
+		return start.Add(time.Hour)
+	case intervalWeek:
+		return time.Date(start.Year(), start.Month(), start.Day()+7, 0, 0, 0, 0, start.Location())
+	default:
+		return time.Date(start.Year(), start.Month(), start.Day()+1, 0, 0, 0, 0, start.Location())
+	}
+}
+
+// timeSeriesBuckets lists every bucket touching [from, to), so the series has
+// no gaps. The first and the last bucket may be partial.
+func timeSeriesBuckets(from, to time.Time, interval string, loc *time.Location) ([]models.TimeSeriesBucket, error) {
+	buckets := []models.TimeSeriesBucket{}
+
+	for start := bucketStart(from, interval, loc); start.Before(to); start = nextBucket(start, interval) {
+		if len(buckets) == maxTimeSeriesBuckets {
+			return nil, utils.ErrTimeRangeTooLarge
+		}
+		buckets = append(buckets, models.TimeSeriesBucket{Start: start})
+	}
+
+	return buckets, nil
+}
+
+// sqliteTimeLayout is the layout the sqlite driver stores times with, so
+// bounds built with it compare with clickedAt as the driver's own arguments.
+const sqliteTimeLayout = "2006-01-02 15:04:05.999999999-07:00"
+
+// bucketBounds encodes the bounds of buckets as the JSON array expected by
+// CountClicksByBucket: the start of every bucket followed by the end of the
+// last one. The first and last bounds are clamped to [from, to).
+func bucketBounds(buckets []models.TimeSeriesBucket, from, to time.Time) (string, error) {
+	bounds := make([]string, 0, len(buckets)+1)
+	for i, bucket := range buckets {
+		start := bucket.Start
+		if i == 0 && start.Before(from) {
+			start = from
+		}
+		bounds = append(bounds, start.UTC().Format(sqliteTimeLayout))
+	}
+	bounds = append(bounds, to.UTC().Format(sqliteTimeLayout))
+
+	encoded, err := json.Marshal(bounds)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
+
+func (c *Controller) GetTimeSeries(ctx context.Context, shortCode string, request models.TimeSeriesRequest) (*models.TimeSeriesResponse, error) {
+	interval := request.Interval
+	switch interval {
+	case "":
+		interval = intervalDay
+	case intervalHour, intervalDay, intervalWeek:
+	default:
+		return nil, utils.ErrInvalidInterval
+	}
+
+	from, to, loc, err := statsRange(request.From, request.To, request.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets, err := timeSeriesBuckets(from, to, interval, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := c.statsLink(ctx, shortCode)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds, err := bucketBounds(buckets, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := c.queries.CountClicksByBucket(ctx, db.CountClicksByBucketParams{
+		Bounds: bounds,
+		UrlID:  link.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Visitor hashes change every day, so a week bucket counts a visitor
+	// once per day it came back.
+	var total uint
+	for _, row := range rows {
+		if row.Bucket < 0 || row.Bucket >= int64(len(buckets)) {
+			continue
+		}
+		buckets[row.Bucket].Clicks = uint(row.Clicks)
+		buckets[row.Bucket].UniqueVisitors = uint(row.Visitors)
+		total += uint(row.Clicks)
+	}
+
+	return &models.TimeSeriesResponse{
+		ShortCode: link.Shortcode,
+		Interval:  interval,
+		Timezone:  loc.String(),
+		From:      from,
+		To:        to,
+		Total:     total,
+		Buckets:   buckets,
+	}, nil
+}
+
+func (c *Controller) GetBreakdown(ctx context.Context, shortCode string, request models.BreakdownRequest) (*models.BreakdownResponse, error) {
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultBreakdownLimit
+	}
+	if limit < 0 || limit > maxBreakdownLimit {
+		return nil, utils.ErrInvalidLimit
+	}
+
+	from, to, _, err := statsRange(request.From, request.To, request.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := c.statsLink(ctx, shortCode)
+	if err != nil {
+		return nil, err
+	}
+
+	fromTime, toTime := from.UTC(), to.UTC()
+
+	total, err := c.queries.CountClicksByURLID(ctx, db.CountClicksByURLIDParams{
+		UrlID:    link.ID,
+		FromTime: fromTime,
+		ToTime:   toTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	referrers, err := c.queries.ListTopReferrersByURLID(ctx, db.ListTopReferrersByURLIDParams{
+		UrlID:    link.ID,
+		FromTime: fromTime,
+		ToTime:   toTime,
+		RowLimit: int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	browsers, err := c.queries.ListTopBrowsersByURLID(ctx, db.ListTopBrowsersByURLIDParams{
+		UrlID:    link.ID,
+		FromTime: fromTime,
+		ToTime:   toTime,
+		RowLimit: int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	operatingSystems, err := c.queries.ListTopOSByURLID(ctx, db.ListTopOSByURLIDParams{
+		UrlID:    link.ID,
+		FromTime: fromTime,
+		ToTime:   toTime,
+		RowLimit: int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	devices, err := c.queries.ListTopDevicesByURLID(ctx, db.ListTopDevicesByURLIDParams{
+		UrlID:    link.ID,
+		FromTime: fromTime,
+		ToTime:   toTime,
+		RowLimit: int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.BreakdownResponse{
+		ShortCode:        link.Shortcode,
+		From:             from,
+		To:               to,
+		Total:            uint(total),
+		Referrers:        breakdownItems(referrers),
+		Browsers:         breakdownItems(browsers),
+		OperatingSystems: breakdownItems(operatingSystems),
+		Devices:          breakdownItems(devices),
+	}, nil
+}
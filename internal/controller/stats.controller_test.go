@@ -0,0 +1,505 @@
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/generator"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	recorderMock "github.com/DarcoProgramador/shortener-go-backend/mocks/recorder_mock"
+	storeMock "github.com/DarcoProgramador/shortener-go-backend/mocks/store_mock"
+	"github.com/DarcoProgramador/shortener-go-backend/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mustParseTime(t *testing.T, value string) time.Time {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatalf("cannot parse %q: %v", value, err)
+	}
+	return parsed
+}
+
+func TestController_GetTimeSeries(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		shortCode string
+		request   models.TimeSeriesRequest
+	}
+	tests := []struct {
+		name             string
+		args             args
+		mockExpectations func(t *testing.T) *storeMock.MockStore
+		wantStarts       []string
+		wantClicks       []uint
+		wantVisitors     []uint
+		wantErr          bool
+		errIs            error
+	}{
+		{
+			name: "GetTimeSeries per day in a time zone",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+				request: models.TimeSeriesRequest{
+					From:     "2025-03-01",
+					To:       "2025-03-04",
+					Interval: "day",
+					Timezone: "Europe/Madrid",
+				},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLStatsByShortCode(mock.Anything, "abc123").Return(db.Url{ID: 7, Shortcode: "abc123"}, nil)
+				q.EXPECT().CountClicksByBucket(mock.Anything, db.CountClicksByBucketParams{
+					// Days in Madrid start at 23:00 UTC.
+					Bounds: `["2025-02-28 23:00:00+00:00","2025-03-01 23:00:00+00:00","2025-03-02 23:00:00+00:00","2025-03-03 23:00:00+00:00"]`,
+					UrlID:  7,
+				}).Return([]db.CountClicksByBucketRow{
+					{Bucket: 0, Clicks: 2, Visitors: 1},
+					{Bucket: 1, Clicks: 1, Visitors: 1},
+					{Bucket: 2, Clicks: 1, Visitors: 1},
+				}, nil)
+				return q
+			},
+			wantStarts: []string{
+				"2025-03-01T00:00:00+01:00",
+				"2025-03-02T00:00:00+01:00",
+				"2025-03-03T00:00:00+01:00",
+			},
+			wantClicks:   []uint{2, 1, 1},
+			wantVisitors: []uint{1, 1, 1},
+			wantErr:      false,
+		},
+		{
+			name: "GetTimeSeries per hour in a half hour time zone",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+				request: models.TimeSeriesRequest{
+					From:     "2025-03-01T00:00:00Z",
+					To:       "2025-03-01T02:00:00Z",
+					Interval: "hour",
+					Timezone: "Asia/Kolkata",
+				},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLStatsByShortCode(mock.Anything, "abc123").Return(db.Url{ID: 7, Shortcode: "abc123"}, nil)
+				q.EXPECT().CountClicksByBucket(mock.Anything, db.CountClicksByBucketParams{
+					// The first hour starts at 23:30 UTC and is clamped to from.
+					Bounds: `["2025-03-01 00:00:00+00:00","2025-03-01 00:30:00+00:00","2025-03-01 01:30:00+00:00","2025-03-01 02:00:00+00:00"]`,
+					UrlID:  7,
+				}).Return([]db.CountClicksByBucketRow{
+					{Bucket: 0, Clicks: 1, Visitors: 1},
+					{Bucket: 1, Clicks: 1, Visitors: 1},
+					{Bucket: 2, Clicks: 1, Visitors: 1},
+				}, nil)
+				return q
+			},
+			wantStarts: []string{
+				"2025-03-01T05:00:00+05:30",
+				"2025-03-01T06:00:00+05:30",
+				"2025-03-01T07:00:00+05:30",
+			},
+			wantClicks:   []uint{1, 1, 1},
+			wantVisitors: []uint{1, 1, 1},
+			wantErr:      false,
+		},
+		{
+			name: "GetTimeSeries per day across a DST change",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+				request: models.TimeSeriesRequest{
+					From:     "2025-03-08",
+					To:       "2025-03-10",
+					Timezone: "America/New_York",
+				},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLStatsByShortCode(mock.Anything, "abc123").Return(db.Url{ID: 7, Shortcode: "abc123"}, nil)
+				q.EXPECT().CountClicksByBucket(mock.Anything, db.CountClicksByBucketParams{
+					// The day of the change is 23 hours long.
+					Bounds: `["2025-03-08 05:00:00+00:00","2025-03-09 05:00:00+00:00","2025-03-10 04:00:00+00:00"]`,
+					UrlID:  7,
+				}).Return([]db.CountClicksByBucketRow{
+					{Bucket: 1, Clicks: 1, Visitors: 0},
+				}, nil)
+				return q
+			},
+			wantStarts: []string{
+				"2025-03-08T00:00:00-05:00",
+				"2025-03-09T00:00:00-05:00",
+			},
+			wantClicks:   []uint{0, 1},
+			wantVisitors: []uint{0, 0},
+			wantErr:      false,
+		},
+		{
+			name: "GetTimeSeries per week",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+				request: models.TimeSeriesRequest{
+					From:     "2025-03-05",
+					To:       "2025-03-12",
+					Interval: "week",
+				},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLStatsByShortCode(mock.Anything, "abc123").Return(db.Url{ID: 7, Shortcode: "abc123"}, nil)
+				q.EXPECT().CountClicksByBucket(mock.Anything, db.CountClicksByBucketParams{
+					Bounds: `["2025-03-05 00:00:00+00:00","2025-03-10 00:00:00+00:00","2025-03-12 00:00:00+00:00"]`,
+					UrlID:  7,
+				}).Return([]db.CountClicksByBucketRow{
+					{Bucket: 0, Clicks: 1, Visitors: 1},
+					{Bucket: 1, Clicks: 1, Visitors: 1},
+				}, nil)
+				return q
+			},
+			wantStarts: []string{
+				"2025-03-03T00:00:00Z",
+				"2025-03-10T00:00:00Z",
+			},
+			wantClicks:   []uint{1, 1},
+			wantVisitors: []uint{1, 1},
+			wantErr:      false,
+		},
+		{
+			name: "GetTimeSeries with invalid interval",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+				request:   models.TimeSeriesRequest{Interval: "minute"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				// No se espera ninguna llamada a GetURLStatsByShortCode
+				return q
+			},
+			wantErr: true,
+			errIs:   utils.ErrInvalidInterval,
+		},
+		{
+			name: "GetTimeSeries with invalid time zone",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+				request:   models.TimeSeriesRequest{Timezone: "Mars/Olympus"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				return q
+			},
+			wantErr: true,
+			errIs:   utils.ErrInvalidTimezone,
+		},
+		{
+			name: "GetTimeSeries with invalid date",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+				request:   models.TimeSeriesRequest{From: "yesterday"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				return q
+			},
+			wantErr: true,
+			errIs:   utils.ErrInvalidDate,
+		},
+		{
+			name: "GetTimeSeries with from after to",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+				request:   models.TimeSeriesRequest{From: "2025-03-05", To: "2025-03-01"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				return q
+			},
+			wantErr: true,
+			errIs:   utils.ErrInvalidTimeRange,
+		},
+		{
+			name: "GetTimeSeries with too many buckets",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+				request:   models.TimeSeriesRequest{From: "2024-01-01", To: "2025-01-01", Interval: "hour"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				return q
+			},
+			wantErr: true,
+			errIs:   utils.ErrTimeRangeTooLarge,
+		},
+		{
+			name: "GetTimeSeries not found",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLStatsByShortCode(mock.Anything, "abc123").Return(db.Url{}, sql.ErrNoRows)
+				return q
+			},
+			wantErr: true,
+			errIs:   ErrLinkNotFound,
+		},
+		{
+			name: "GetTimeSeries with error counting clicks",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLStatsByShortCode(mock.Anything, "abc123").Return(db.Url{ID: 7, Shortcode: "abc123"}, nil)
+				q.EXPECT().CountClicksByBucket(mock.Anything, mock.Anything).Return(nil, assert.AnError)
+				return q
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.mockExpectations(t)
+			r := recorderMock.NewMockRecorder(t)
+
+			c := NewController(q, generator.NewRandom(), r, Options{})
+
+			got, err := c.GetTimeSeries(tt.args.ctx, tt.args.shortCode, tt.args.request)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs, "El error no es el esperado")
+			}
+
+			if err != nil {
+				assert.Nil(t, got, "El valor de got debe ser nulo cuando se espera un error")
+				return
+			}
+
+			starts := make([]string, len(got.Buckets))
+			clicks := make([]uint, len(got.Buckets))
+			visitors := make([]uint, len(got.Buckets))
+			var total uint
+			for i, bucket := range got.Buckets {
+				starts[i] = bucket.Start.Format(time.RFC3339)
+				clicks[i] = bucket.Clicks
+				visitors[i] = bucket.UniqueVisitors
+				total += bucket.Clicks
+			}
+
+			assert.Equal(t, tt.wantStarts, starts, "Los inicios de los intervalos no coinciden")
+			assert.Equal(t, tt.wantClicks, clicks, "Los clics por intervalo no coinciden")
+			assert.Equal(t, tt.wantVisitors, visitors, "Los visitantes únicos por intervalo no coinciden")
+			assert.Equal(t, total, got.Total, "El total no coincide con la suma de los intervalos")
+		})
+	}
+}
+
+func TestController_GetBreakdown(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		shortCode string
+		request   models.BreakdownRequest
+	}
+	tests := []struct {
+		name             string
+		args             args
+		mockExpectations func(t *testing.T) *storeMock.MockStore
+		want             *models.BreakdownResponse
+		wantErr          bool
+		errIs            error
+	}{
+		{
+			name: "GetBreakdown_OK",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+				request: models.BreakdownRequest{
+					From:  "2025-03-01",
+					To:    "2025-03-08",
+					Limit: 5,
+				},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				from := mustParseTime(t, "2025-03-01T00:00:00Z")
+				to := mustParseTime(t, "2025-03-08T00:00:00Z")
+				q.EXPECT().GetURLStatsByShortCode(mock.Anything, "abc123").Return(db.Url{ID: 7, Shortcode: "abc123"}, nil)
+				q.EXPECT().CountClicksByURLID(mock.Anything, db.CountClicksByURLIDParams{
+					UrlID:    7,
+					FromTime: from,
+					ToTime:   to,
+				}).Return(12, nil)
+				q.EXPECT().ListTopReferrersByURLID(mock.Anything, db.ListTopReferrersByURLIDParams{
+					UrlID:    7,
+					FromTime: from,
+					ToTime:   to,
+					RowLimit: 5,
+				}).Return([]db.ListTopReferrersByURLIDRow{
+					{Value: "t.co", Clicks: 8},
+					{Value: "(direct)", Clicks: 4},
+				}, nil)
+				q.EXPECT().ListTopBrowsersByURLID(mock.Anything, mock.Anything).Return([]db.ListTopBrowsersByURLIDRow{
+					{Value: "Chrome", Clicks: 12},
+				}, nil)
+				q.EXPECT().ListTopOSByURLID(mock.Anything, mock.Anything).Return([]db.ListTopOSByURLIDRow{
+					{Value: "Android", Clicks: 7},
+					{Value: "Windows", Clicks: 5},
+				}, nil)
+				q.EXPECT().ListTopDevicesByURLID(mock.Anything, mock.Anything).Return([]db.ListTopDevicesByURLIDRow{
+					{Value: "Mobile", Clicks: 7},
+					{Value: "Desktop", Clicks: 5},
+				}, nil)
+				return q
+			},
+			want: &models.BreakdownResponse{
+				ShortCode: "abc123",
+				Total:     12,
+				Referrers: []models.BreakdownItem{
+					{Value: "t.co", Clicks: 8},
+					{Value: "(direct)", Clicks: 4},
+				},
+				Browsers: []models.BreakdownItem{
+					{Value: "Chrome", Clicks: 12},
+				},
+				OperatingSystems: []models.BreakdownItem{
+					{Value: "Android", Clicks: 7},
+					{Value: "Windows", Clicks: 5},
+				},
+				Devices: []models.BreakdownItem{
+					{Value: "Mobile", Clicks: 7},
+					{Value: "Desktop", Clicks: 5},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GetBreakdown with default limit",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLStatsByShortCode(mock.Anything, "abc123").Return(db.Url{ID: 7, Shortcode: "abc123"}, nil)
+				q.EXPECT().CountClicksByURLID(mock.Anything, mock.Anything).Return(0, nil)
+				q.EXPECT().ListTopReferrersByURLID(mock.Anything, mock.MatchedBy(func(arg db.ListTopReferrersByURLIDParams) bool {
+					return arg.RowLimit == defaultBreakdownLimit
+				})).Return([]db.ListTopReferrersByURLIDRow{}, nil)
+				q.EXPECT().ListTopBrowsersByURLID(mock.Anything, mock.Anything).Return([]db.ListTopBrowsersByURLIDRow{}, nil)
+				q.EXPECT().ListTopOSByURLID(mock.Anything, mock.Anything).Return([]db.ListTopOSByURLIDRow{}, nil)
+				q.EXPECT().ListTopDevicesByURLID(mock.Anything, mock.Anything).Return([]db.ListTopDevicesByURLIDRow{}, nil)
+				return q
+			},
+			want: &models.BreakdownResponse{
+				ShortCode:        "abc123",
+				Referrers:        []models.BreakdownItem{},
+				Browsers:         []models.BreakdownItem{},
+				OperatingSystems: []models.BreakdownItem{},
+				Devices:          []models.BreakdownItem{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GetBreakdown with invalid limit",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+				request:   models.BreakdownRequest{Limit: maxBreakdownLimit + 1},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				// No se espera ninguna llamada a GetURLStatsByShortCode
+				return q
+			},
+			wantErr: true,
+			errIs:   utils.ErrInvalidLimit,
+		},
+		{
+			name: "GetBreakdown with invalid date",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+				request:   models.BreakdownRequest{To: "tomorrow"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				return q
+			},
+			wantErr: true,
+			errIs:   utils.ErrInvalidDate,
+		},
+		{
+			name: "GetBreakdown not found",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLStatsByShortCode(mock.Anything, "abc123").Return(db.Url{}, sql.ErrNoRows)
+				return q
+			},
+			wantErr: true,
+			errIs:   ErrLinkNotFound,
+		},
+		{
+			name: "GetBreakdown with error listing browsers",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLStatsByShortCode(mock.Anything, "abc123").Return(db.Url{ID: 7, Shortcode: "abc123"}, nil)
+				q.EXPECT().CountClicksByURLID(mock.Anything, mock.Anything).Return(3, nil)
+				q.EXPECT().ListTopReferrersByURLID(mock.Anything, mock.Anything).Return([]db.ListTopReferrersByURLIDRow{}, nil)
+				q.EXPECT().ListTopBrowsersByURLID(mock.Anything, mock.Anything).Return(nil, assert.AnError)
+				return q
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.mockExpectations(t)
+			r := recorderMock.NewMockRecorder(t)
+
+			c := NewController(q, generator.NewRandom(), r, Options{})
+
+			got, err := c.GetBreakdown(tt.args.ctx, tt.args.shortCode, tt.args.request)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs, "El error no es el esperado")
+			}
+
+			if err != nil {
+				assert.Nil(t, got, "El valor de got debe ser nulo cuando se espera un error")
+				return
+			}
+
+			assert.Equal(t, tt.want.ShortCode, got.ShortCode, "Los valores de los campos ShortCode no coinciden")
+			assert.Equal(t, tt.want.Total, got.Total, "Los valores de los campos Total no coinciden")
+			assert.Equal(t, tt.want.Referrers, got.Referrers, "Los valores de los campos Referrers no coinciden")
+			assert.Equal(t, tt.want.Browsers, got.Browsers, "Los valores de los campos Browsers no coinciden")
+			assert.Equal(t, tt.want.OperatingSystems, got.OperatingSystems, "Los valores de los campos OperatingSystems no coinciden")
+			assert.Equal(t, tt.want.Devices, got.Devices, "Los valores de los campos Devices no coinciden")
+		})
+	}
+}
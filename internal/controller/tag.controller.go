@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/visitor"
+)
+
+// addTags adds tags, normalized by utils.NormalizeTags, to a link. Tags are
+// created the first time a link uses them.
+func addTags(ctx context.Context, q db.Querier, urlID int64, tags []string) error {
+	for _, tag := range tags {
+		tagID, err := q.UpsertTag(ctx, tag)
+		if err != nil {
+			return err
+		}
+
+		if err := q.AddURLTag(ctx, db.AddURLTagParams{Urlid: urlID, Tagid: tagID}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setTags replaces the tags of a link.
+func setTags(ctx context.Context, q db.Querier, urlID int64, tags []string) error {
+	if err := q.DeleteURLTagsByURLID(ctx, urlID); err != nil {
+		return err
+	}
+
+	return addTags(ctx, q, urlID, tags)
+}
+
+// tagsByURLID returns the tags of a page of links in one query.
+func (c *Controller) tagsByURLID(ctx context.Context, ids []int64) (map[int64][]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	urlIDs, err := json.Marshal(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := c.queries.ListTagsByURLIDs(ctx, string(urlIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[int64][]string, len(ids))
+	for _, row := range rows {
+		tags[row.Urlid] = append(tags[row.Urlid], row.Name)
+	}
+
+	return tags, nil
+}
+
+func (c *Controller) ListTags(ctx context.Context) (*models.ListTagsResponse, error) {
+	rows, err := c.queries.ListTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.ListTagsResponse{
+		Tags: make([]models.TagSummary, len(rows)),
+	}
+	for i, row := range rows {
+		response.Tags[i] = models.TagSummary{
+			Name:        row.Name,
+			Links:       uint(row.Links),
+			AccessCount: uint(row.Accesscount),
+			BotCount:    uint(row.Botcount),
+		}
+	}
+
+	return response, nil
+}
+
+func (c *Controller) GetTagStats(ctx context.Context, tag string) (*models.TagStatsResponse, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+
+	data, err := c.queries.GetTagStats(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tags are only kept in use by their links; one without links is as
+	// good as unknown.
+	if data.Links == 0 {
+		return nil, ErrTagNotFound
+	}
+
+	sketch, err := c.queries.ListVisitorSketchByTag(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	ranks := make(map[int64]int64, len(sketch))
+	for _, register := range sketch {
+		ranks[register.Register] = register.Rank
+	}
+
+	return &models.TagStatsResponse{
+		Tag:            tag,
+		Links:          uint(data.Links),
+		AccessCount:    uint(data.Accesscount),
+		BotCount:       uint(data.Botcount),
+		UniqueVisitors: visitor.Estimate(ranks),
+	}, nil
+}
@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/generator"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	recorderMock "github.com/DarcoProgramador/shortener-go-backend/mocks/recorder_mock"
+	storeMock "github.com/DarcoProgramador/shortener-go-backend/mocks/store_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestController_ListTags(t *testing.T) {
+	tests := []struct {
+		name             string
+		mockExpectations func(t *testing.T) *storeMock.MockStore
+		want             *models.ListTagsResponse
+		wantErr          bool
+	}{
+		{
+			name: "ListTags_OK",
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().ListTags(mock.Anything).Return([]db.ListTagsRow{
+					{Name: "promo", Links: 2, Accesscount: 15, Botcount: 3},
+					{Name: "spring", Links: 1, Accesscount: 10, Botcount: 0},
+				}, nil)
+				return q
+			},
+			want: &models.ListTagsResponse{
+				Tags: []models.TagSummary{
+					{Name: "promo", Links: 2, AccessCount: 15, BotCount: 3},
+					{Name: "spring", Links: 1, AccessCount: 10, BotCount: 0},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ListTags without tags",
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().ListTags(mock.Anything).Return([]db.ListTagsRow{}, nil)
+				return q
+			},
+			want: &models.ListTagsResponse{
+				Tags: []models.TagSummary{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ListTags with error",
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().ListTags(mock.Anything).Return(nil, assert.AnError)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.mockExpectations(t)
+			r := recorderMock.NewMockRecorder(t)
+
+			c := NewController(q, generator.NewRandom(), r, Options{})
+
+			got, err := c.ListTags(context.TODO())
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.want, got, "Los tags no coinciden")
+		})
+	}
+}
+
+func TestController_GetTagStats(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		tag string
+	}
+	tests := []struct {
+		name             string
+		args             args
+		mockExpectations func(t *testing.T) *storeMock.MockStore
+		want             *models.TagStatsResponse
+		wantErr          bool
+		errIs            error
+	}{
+		{
+			name: "GetTagStats_OK",
+			args: args{
+				ctx: context.TODO(),
+				tag: "Promo",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetTagStats(mock.Anything, "promo").Return(db.GetTagStatsRow{
+					Links:       2,
+					Accesscount: 15,
+					Botcount:    3,
+				}, nil)
+				q.EXPECT().ListVisitorSketchByTag(mock.Anything, "promo").Return([]db.ListVisitorSketchByTagRow{
+					{Register: 12, Rank: 1},
+					{Register: 345, Rank: 3},
+					{Register: 6789, Rank: 2},
+				}, nil)
+				return q
+			},
+			want: &models.TagStatsResponse{
+				Tag:            "promo",
+				Links:          2,
+				AccessCount:    15,
+				BotCount:       3,
+				UniqueVisitors: 3,
+			},
+			wantErr: false,
+		},
+		{
+			name: "GetTagStats not found",
+			args: args{
+				ctx: context.TODO(),
+				tag: "winter",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetTagStats(mock.Anything, "winter").Return(db.GetTagStatsRow{}, nil)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   ErrTagNotFound,
+		},
+		{
+			name: "GetTagStats with error",
+			args: args{
+				ctx: context.TODO(),
+				tag: "promo",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetTagStats(mock.Anything, "promo").Return(db.GetTagStatsRow{}, assert.AnError)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   assert.AnError,
+		},
+		{
+			name: "GetTagStats with error counting visitors",
+			args: args{
+				ctx: context.TODO(),
+				tag: "promo",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetTagStats(mock.Anything, "promo").Return(db.GetTagStatsRow{Links: 1}, nil)
+				q.EXPECT().ListVisitorSketchByTag(mock.Anything, "promo").Return(nil, assert.AnError)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.mockExpectations(t)
+			r := recorderMock.NewMockRecorder(t)
+
+			c := NewController(q, generator.NewRandom(), r, Options{})
+
+			got, err := c.GetTagStats(tt.args.ctx, tt.args.tag)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs, "El error no es el esperado")
+			}
+
+			assert.Equal(t, tt.want, got, "Las estadísticas no coinciden")
+		})
+	}
+}
@@ -3,49 +3,628 @@ package controller
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
+	"github.com/DarcoProgramador/shortener-go-backend/internal/database"
 	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/generator"
 	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/recorder"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/useragent"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/visitor"
 	"github.com/DarcoProgramador/shortener-go-backend/utils"
 )
 
-func (c *Controller) CreateShortLink(ctx context.Context, url string) (*models.ShortLinkResponse, error) {
-	if err := utils.ValidateURL(url); err != nil {
-		return nil, err
+const (
+	minCodeLength   = 6
+	maxCodeAttempts = 5
+)
+
+// redirectStatus returns the redirect status to store for a link, falling
+// back to 302 Found when the request does not choose one.
+func redirectStatus(status int) (int64, error) {
+	if status == 0 {
+		return http.StatusFound, nil
 	}
 
-	code := utils.RandomString(6)
+	if err := utils.ValidateRedirectStatus(status); err != nil {
+		return 0, err
+	}
 
-	data, err := c.queries.CreateURL(ctx, db.CreateURLParams{
-		Url:       url,
-		Shortcode: code,
-	})
+	return int64(status), nil
+}
+
+// nullTime stores an optional timestamp in UTC so every row uses the same
+// representation.
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{
+		Time:  t.UTC(),
+		Valid: true,
+	}
+}
+
+// maxClicks returns the click limit to store for a link; zero means the link
+// has no limit.
+func maxClicks(limit int) (sql.NullInt64, error) {
+	if limit < 0 {
+		return sql.NullInt64{}, utils.ErrInvalidMaxClicks
+	}
+
+	return sql.NullInt64{
+		Int64: int64(limit),
+		Valid: limit > 0,
+	}, nil
+}
+
+// passwordHash returns the hash to store for a link password; an empty
+// password leaves the link unprotected.
+func passwordHash(password string) (sql.NullString, error) {
+	if password == "" {
+		return sql.NullString{}, nil
+	}
+
+	if err := utils.ValidateLinkPassword(password); err != nil {
+		return sql.NullString{}, err
+	}
+
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+
+	return sql.NullString{
+		String: hash,
+		Valid:  true,
+	}, nil
+}
+
+// checkPassword refuses a visit to a protected link without the right
+// password.
+func checkPassword(hash sql.NullString, password string) error {
+	if !hash.Valid {
+		return nil
+	}
+
+	if password == "" {
+		return ErrPasswordRequired
+	}
+
+	if !utils.CheckPassword(hash.String, password) {
+		return ErrWrongPassword
+	}
+
+	return nil
+}
+
+// visibleURL returns the URL of a link for a read that is not a visit. A
+// protected link only shows it to a read that carries its password, so the
+// destination is not revealed to anyone who just knows the short code; a
+// wrong password is refused as on a visit.
+func visibleURL(link string, hash sql.NullString, password string) (string, error) {
+	if !hash.Valid {
+		return link, nil
+	}
+
+	if password == "" {
+		return "", nil
+	}
 
+	if !utils.CheckPassword(hash.String, password) {
+		return "", ErrWrongPassword
+	}
+
+	return link, nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{
+		String: s,
+		Valid:  s != "",
+	}
+}
+
+func timePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	return &t.Time
+}
+
+// checkWindow refuses a link outside of its activation window.
+func checkWindow(now time.Time, notBefore, expiresAt sql.NullTime) error {
+	if notBefore.Valid && now.Before(notBefore.Time) {
+		return ErrLinkNotActive
+	}
+
+	if expiresAt.Valid && !now.Before(expiresAt.Time) {
+		return ErrLinkExpired
+	}
+
+	return nil
+}
+
+// createWithGeneratedCode inserts a link under a generated short code. The
+// code length grows with the number of stored links and after repeated
+// collisions; a collision or a reserved word just triggers another attempt.
+func (c *Controller) createWithGeneratedCode(ctx context.Context, q db.Querier, params db.CreateURLParams) (db.CreateURLRow, error) {
+	lastID, err := q.GetLastURLID(ctx)
+	if err != nil {
+		return db.CreateURLRow{}, err
+	}
+
+	length := generator.LengthFor(lastID, minCodeLength)
+
+	for attempt := 0; attempt < maxCodeAttempts; attempt++ {
+		code, err := c.generator.Generate(ctx, length+attempt/2)
+		if err != nil {
+			return db.CreateURLRow{}, err
+		}
+
+		if utils.IsReservedAlias(code) {
+			continue
+		}
+
+		params.Shortcode = code
+		data, err := q.CreateURL(ctx, params)
+		if database.IsUniqueViolation(err) {
+			continue
+		}
+
+		return data, err
+	}
+
+	return db.CreateURLRow{}, ErrCodeExhausted
+}
+
+// plainLink reports whether a new link only redirects: it has no alias,
+// password, activation window, click limit, campaign, metadata or tags, and
+// uses the default redirect status. Only plain links are deduplicated, as any
+// of those makes a link different from another to the same URL.
+func plainLink(params db.CreateURLParams, tags []string) bool {
+	return params.Shortcode == "" &&
+		params.Redirectstatus == http.StatusFound &&
+		!params.Campaignid.Valid &&
+		!params.Passwordhash.Valid &&
+		!params.Expiresat.Valid &&
+		!params.Notbefore.Valid &&
+		!params.Maxclicks.Valid &&
+		!params.Title.Valid &&
+		!params.Description.Valid &&
+		!params.Notes.Valid &&
+		len(tags) == 0
+}
+
+// existingLink returns the oldest plain link to a normalized URL, marked as
+// reused, or nil when there is none. Links are matched across the whole
+// service, as they have no owner yet.
+func existingLink(ctx context.Context, q db.Querier, link string) (*models.ShortLinkResponse, error) {
+	shortCode, err := q.GetPlainURLByURL(ctx, link)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Only links without a password are reused, so the URL is never hidden.
+	response, err := getLink(ctx, q, shortCode, "")
 	if err != nil {
 		return nil, err
 	}
 
+	response.Reused = true
+	return response, nil
+}
+
+// linkURL validates the URL of a link and returns it normalized, without its
+// tracking parameters when the controller strips them.
+func (c *Controller) linkURL(link string) (string, error) {
+	link, err := utils.NormalizeURL(link)
+	if err != nil {
+		return "", err
+	}
+
+	if c.options.StripTracking {
+		link = utils.StripTrackingParams(link)
+	}
+
+	return link, nil
+}
+
+func (c *Controller) NormalizeLinks(ctx context.Context) (int, error) {
+	var normalized int
+	var afterID int64
+	for {
+		links, err := c.queries.ListURLsAfterID(ctx, db.ListURLsAfterIDParams{
+			AfterID:  afterID,
+			RowLimit: exportPageSize,
+		})
+		if err != nil {
+			return normalized, err
+		}
+
+		if len(links) == 0 {
+			return normalized, nil
+		}
+
+		for _, link := range links {
+			// A URL the normalizer rejects is kept as it was stored.
+			normal, err := utils.NormalizeURL(link.Url)
+			if err != nil || normal == link.Url {
+				continue
+			}
+
+			err = c.queries.NormalizeURLByID(ctx, db.NormalizeURLByIDParams{
+				Url:    normal,
+				Domain: nullString(utils.Domain(normal)),
+				ID:     link.ID,
+			})
+			if err != nil {
+				return normalized, err
+			}
+			normalized++
+		}
+
+		afterID = links[len(links)-1].ID
+	}
+}
+
+// newLinkParams validates a new link and builds the row to insert, along with
+// its normalized URL and tags. The short code is the requested alias, or
+// empty to have one generated.
+func (c *Controller) newLinkParams(request models.ShortLinkRequest) (db.CreateURLParams, []string, error) {
+	link, err := c.linkURL(request.Url)
+	if err != nil {
+		return db.CreateURLParams{}, nil, err
+	}
+
+	status, err := redirectStatus(request.RedirectStatus)
+	if err != nil {
+		return db.CreateURLParams{}, nil, err
+	}
+
+	if err := utils.ValidateLinkWindow(request.NotBefore, request.ExpiresAt); err != nil {
+		return db.CreateURLParams{}, nil, err
+	}
+
+	limit, err := maxClicks(request.MaxClicks)
+	if err != nil {
+		return db.CreateURLParams{}, nil, err
+	}
+
+	if request.Alias != "" {
+		if err := utils.ValidateAlias(request.Alias); err != nil {
+			return db.CreateURLParams{}, nil, err
+		}
+	}
+
+	if err := utils.ValidateLinkMetadata(request.Title, request.Description, request.Notes); err != nil {
+		return db.CreateURLParams{}, nil, err
+	}
+
+	tags, err := utils.NormalizeTags(request.Tags)
+	if err != nil {
+		return db.CreateURLParams{}, nil, err
+	}
+
+	var password string
+	if request.Password != nil {
+		password = *request.Password
+	}
+
+	hash, err := passwordHash(password)
+	if err != nil {
+		return db.CreateURLParams{}, nil, err
+	}
+
+	return db.CreateURLParams{
+		Url:            link,
+		Shortcode:      request.Alias,
+		Redirectstatus: status,
+		Expiresat:      nullTime(request.ExpiresAt),
+		Notbefore:      nullTime(request.NotBefore),
+		Maxclicks:      limit,
+		Passwordhash:   hash,
+		Domain:         nullString(utils.Domain(link)),
+		Title:          nullString(strings.TrimSpace(request.Title)),
+		Description:    nullString(strings.TrimSpace(request.Description)),
+		Notes:          nullString(strings.TrimSpace(request.Notes)),
+	}, tags, nil
+}
+
+// insertLink stores a link built by newLinkParams with its tags, generating
+// its short code when no alias was requested.
+func (c *Controller) insertLink(ctx context.Context, q db.Querier, params db.CreateURLParams, tags []string) (db.CreateURLRow, error) {
+	var data db.CreateURLRow
+	var err error
+
+	if params.Shortcode == "" {
+		data, err = c.createWithGeneratedCode(ctx, q, params)
+	} else {
+		data, err = q.CreateURL(ctx, params)
+		if database.IsUniqueViolation(err) {
+			return db.CreateURLRow{}, ErrAliasTaken
+		}
+	}
+	if err != nil {
+		return db.CreateURLRow{}, err
+	}
+
+	if err := addTags(ctx, q, data.ID, tags); err != nil {
+		return db.CreateURLRow{}, err
+	}
+
+	return data, nil
+}
+
+func createdLinkResponse(data db.CreateURLRow, tags []string) *models.ShortLinkResponse {
 	return &models.ShortLinkResponse{
-		Id:        int(data.ID),
-		Url:       data.Url,
-		ShortCode: data.Shortcode,
-		CreatedAt: &data.Createdat.Time,
+		Id:             int(data.ID),
+		Url:            data.Url,
+		ShortCode:      data.Shortcode,
+		Title:          data.Title.String,
+		Description:    data.Description.String,
+		Notes:          data.Notes.String,
+		RedirectStatus: int(data.Redirectstatus),
+		ExpiresAt:      timePtr(data.Expiresat),
+		NotBefore:      timePtr(data.Notbefore),
+		MaxClicks:      int(data.Maxclicks.Int64),
+		Protected:      data.Passwordhash.Valid,
+		CreatedAt:      &data.Createdat.Time,
+		Tags:           tags,
+		CampaignId:     int(data.Campaignid.Int64),
+		Version:        int(data.Version),
+	}
+}
+
+func (c *Controller) CreateShortLink(ctx context.Context, request models.ShortLinkRequest) (*models.ShortLinkResponse, error) {
+	return c.createShortLink(ctx, request, nil)
+}
+
+// createShortLink creates a link. When record is not nil it runs in the same
+// transaction as the insert, so the link is only kept if record succeeds.
+func (c *Controller) createShortLink(ctx context.Context, request models.ShortLinkRequest, record func(db.Querier, *models.ShortLinkResponse) error) (*models.ShortLinkResponse, error) {
+	params, tags, err := c.newLinkParams(request)
+	if err != nil {
+		return nil, err
+	}
+
+	params.Campaignid, params.Url, err = campaignLink(ctx, c.queries, request.CampaignId, params.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	dedupe := c.options.Dedupe
+	if request.Dedupe != nil {
+		dedupe = *request.Dedupe
+	}
+	if dedupe && plainLink(params, tags) {
+		existing, err := existingLink(ctx, c.queries, params.Url)
+		if err != nil {
+			return nil, err
+		}
+
+		if existing != nil {
+			if record != nil {
+				if err := record(c.queries, existing); err != nil {
+					return nil, err
+				}
+			}
+			return existing, nil
+		}
+	}
+
+	var response *models.ShortLinkResponse
+	insert := func(q db.Querier) error {
+		data, err := c.insertLink(ctx, q, params, tags)
+		if err != nil {
+			return err
+		}
+
+		response = createdLinkResponse(data, tags)
+		if record != nil {
+			return record(q, response)
+		}
+
+		return nil
+	}
+
+	// A link and its tags are written together; a link without tags is a
+	// single insert and needs no transaction.
+	if len(tags) == 0 && record == nil {
+		err = insert(c.queries)
+	} else {
+		err = c.queries.ExecTx(ctx, insert)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// isBot reports whether a visit comes from a crawler, a link preview or a
+// prefetch instead of a person.
+func isBot(visit models.VisitRequest) bool {
+	return visit.Prefetch || useragent.IsBot(visit.UserAgent)
+}
+
+// notCounted returns why a link could not count a visit: it reached its
+// click limit or it was deleted since it was read.
+func notCounted(data db.GetURLByShortCodeRow) error {
+	if data.Maxclicks.Valid {
+		return ErrLinkExhausted
+	}
+	return ErrLinkNotFound
+}
+
+// newClick builds the click of a visit from a person, with the update of the
+// unique visitor sketch it brings.
+func (c *Controller) newClick(ctx context.Context, data db.GetURLByShortCodeRow, visit models.VisitRequest) (recorder.Visit, error) {
+	agent := useragent.Parse(visit.UserAgent)
+	now := time.Now().UTC()
+
+	salt, err := c.visitorSalt(ctx, now)
+	if err != nil {
+		return recorder.Visit{}, err
+	}
+	visitorHash := visitor.Hash(salt, data.ID, visit.IP, visit.UserAgent)
+	register, rank := visitor.Register(visitorHash)
+
+	return recorder.Visit{
+		URLID: data.ID,
+		Click: &db.CreateClickParams{
+			Urlid:          data.ID,
+			Clickedat:      now,
+			Referrer:       nullString(visit.Referrer),
+			Useragent:      nullString(visit.UserAgent),
+			Ipaddress:      nullString(utils.AnonymizeIP(visit.IP)),
+			Acceptlanguage: nullString(visit.AcceptLanguage),
+			Referrerdomain: nullString(utils.Domain(visit.Referrer)),
+			Browser:        nullString(agent.Browser),
+			Os:             nullString(agent.OS),
+			Device:         nullString(agent.Device),
+			Visitorhash:    nullString(visitorHash),
+		},
+		Register: register,
+		Rank:     rank,
 	}, nil
 }
 
-func (c *Controller) GetOriginalLink(ctx context.Context, shortCode string) (*models.ShortLinkResponse, error) {
-	data, err := c.queries.GetURLByShortCode(ctx, shortCode)
+// count counts a visit. Links with a click limit are counted right away, as
+// the limit is checked and the count incremented in one step; every other
+// visit is handed to the recorder and written in the background.
+func (c *Controller) count(ctx context.Context, data db.GetURLByShortCodeRow, visit models.VisitRequest) error {
+	if isBot(visit) {
+		if data.Maxclicks.Valid {
+			return c.countBot(ctx, data)
+		}
+		c.recorder.Record(recorder.Visit{URLID: data.ID})
+		return nil
+	}
+
+	click, err := c.newClick(ctx, data, visit)
+	if err != nil {
+		return err
+	}
+
+	if data.Maxclicks.Valid {
+		return c.countClick(ctx, data, click)
+	}
+	c.recorder.Record(click)
+	return nil
+}
+
+// countClick counts a visit from a person and records its click at once.
+func (c *Controller) countClick(ctx context.Context, data db.GetURLByShortCodeRow, click recorder.Visit) error {
+	// accessCount is kept next to the click log as a running total, so both
+	// are written in the same transaction.
+	return c.queries.ExecTx(ctx, func(q db.Querier) error {
+		counted, err := q.IncrementURLAccessCountByShortCode(ctx, data.Shortcode)
+		if err != nil {
+			return err
+		}
+
+		if counted == 0 {
+			return notCounted(data)
+		}
+
+		if err := q.CreateClick(ctx, *click.Click); err != nil {
+			return err
+		}
+
+		return q.UpsertVisitorSketch(ctx, db.UpsertVisitorSketchParams{
+			Urlid:    data.ID,
+			Register: click.Register,
+			Rank:     click.Rank,
+		})
+	})
+}
+
+// countBot counts a bot visit apart from the clicks. Bots do not use up the
+// click limit, but an exhausted link is gone for them too.
+func (c *Controller) countBot(ctx context.Context, data db.GetURLByShortCodeRow) error {
+	counted, err := c.queries.IncrementURLBotCountByShortCode(ctx, data.Shortcode)
+	if err != nil {
+		return err
+	}
+
+	if counted == 0 {
+		return notCounted(data)
+	}
+
+	return nil
+}
+
+func (c *Controller) GetLink(ctx context.Context, shortCode, password string) (*models.ShortLinkResponse, error) {
+	return getLink(ctx, c.queries, shortCode, password)
+}
+
+// getLink reads a link as GetLink returns it through q, so a transaction
+// also sees the links it created.
+func getLink(ctx context.Context, q db.Querier, shortCode, password string) (*models.ShortLinkResponse, error) {
+	data, err := q.GetURLByShortCode(ctx, shortCode)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrLinkNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := linkResponse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Url, err = visibleURL(data.Url, data.Passwordhash, password)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Tags, err = q.ListTagsByURLID(ctx, data.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = c.queries.IncrementURLAccessCountByShortCode(ctx, shortCode)
+	return response, nil
+}
+
+func (c *Controller) ResolveLink(ctx context.Context, shortCode string, visit models.VisitRequest) (*models.ShortLinkResponse, error) {
+	data, err := c.queries.GetURLByShortCode(ctx, shortCode)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrLinkNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	if err := checkWindow(time.Now(), data.Notbefore, data.Expiresat); err != nil {
+		return nil, err
+	}
+
+	if err := checkPassword(data.Passwordhash, visit.Password); err != nil {
+		return nil, err
+	}
+
+	if err := c.count(ctx, data, visit); err != nil {
+		return nil, err
+	}
+
+	return linkResponse(data)
+}
+
+// linkResponse returns the details of a link as sent to clients.
+func linkResponse(data db.GetURLByShortCodeRow) (*models.ShortLinkResponse, error) {
 	var createdAt, updatedAt *time.Time
 	if !data.Createdat.Valid {
 		return nil, fmt.Errorf("invalid date")
@@ -56,30 +635,139 @@ func (c *Controller) GetOriginalLink(ctx context.Context, shortCode string) (*mo
 	}
 
 	return &models.ShortLinkResponse{
-		Id:        int(data.ID),
-		Url:       data.Url,
-		ShortCode: data.Shortcode,
-		CreatedAt: createdAt,
-		UpdatedAt: updatedAt,
+		Id:             int(data.ID),
+		Url:            data.Url,
+		ShortCode:      data.Shortcode,
+		Title:          data.Title.String,
+		Description:    data.Description.String,
+		Notes:          data.Notes.String,
+		RedirectStatus: int(data.Redirectstatus),
+		ExpiresAt:      timePtr(data.Expiresat),
+		NotBefore:      timePtr(data.Notbefore),
+		MaxClicks:      int(data.Maxclicks.Int64),
+		Protected:      data.Passwordhash.Valid,
+		CreatedAt:      createdAt,
+		UpdatedAt:      updatedAt,
+		CampaignId:     int(data.Campaignid.Int64),
+		Version:        int(data.Version),
 	}, nil
 }
 
-func (c *Controller) UpdateLink(ctx context.Context, url, shortCode string) (*models.ShortLinkResponse, error) {
-	if err := utils.ValidateURL(url); err != nil {
+func (c *Controller) UpdateLink(ctx context.Context, request models.ShortLinkRequest, shortCode string, version int64) (*models.ShortLinkResponse, error) {
+	link, err := c.linkURL(request.Url)
+	if err != nil {
+		return nil, err
+	}
+	request.Url = link
+
+	status, err := redirectStatus(request.RedirectStatus)
+	if err != nil {
 		return nil, err
 	}
 
+	if err := utils.ValidateLinkWindow(request.NotBefore, request.ExpiresAt); err != nil {
+		return nil, err
+	}
+
+	limit, err := maxClicks(request.MaxClicks)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := utils.ValidateLinkMetadata(request.Title, request.Description, request.Notes); err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	if request.Tags != nil {
+		if tags, err = utils.NormalizeTags(request.Tags); err != nil {
+			return nil, err
+		}
+	}
+
+	var hash sql.NullString
+	if request.Password != nil {
+		if hash, err = passwordHash(*request.Password); err != nil {
+			return nil, err
+		}
+	}
+
 	updatedAt := sql.NullTime{
 		Time:  time.Now(),
 		Valid: true,
 	}
 
-	data, err := c.queries.UpdateURLByShortCode(ctx, db.UpdateURLByShortCodeParams{
-		Url:       url,
-		Updatedat: updatedAt,
-		Shortcode: shortCode,
-	})
+	var data db.UpdateURLByShortCodeRow
+	err = c.queries.ExecTx(ctx, func(q db.Querier) error {
+		if err := claimVersion(ctx, q, shortCode, version); err != nil {
+			return err
+		}
+
+		// A link the request leaves in its campaign gets the campaign's UTM
+		// defaults on its new URL too. Both are read once the version is
+		// claimed, so a campaign changed meanwhile is not applied stale.
+		var err error
+		campaign := request.CampaignId
+		if campaign == nil {
+			if campaign, err = linkCampaign(ctx, q, shortCode); err != nil {
+				return err
+			}
+		}
+
+		var campaignID sql.NullInt64
+		campaignID, request.Url, err = campaignLink(ctx, q, campaign, request.Url)
+		if err != nil {
+			return err
+		}
+
+		// The password is only replaced when the request carries one; an
+		// empty string removes it.
+		if request.Password != nil {
+			err := q.UpdateURLPasswordByShortCode(ctx, db.UpdateURLPasswordByShortCodeParams{
+				Passwordhash: hash,
+				Shortcode:    shortCode,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// So is the campaign; 0 takes the link out of its campaign.
+		if request.CampaignId != nil {
+			err := q.UpdateURLCampaignByShortCode(ctx, db.UpdateURLCampaignByShortCodeParams{
+				Campaignid: campaignID,
+				Shortcode:  shortCode,
+			})
+			if err != nil {
+				return err
+			}
+		}
 
+		data, err = q.UpdateURLByShortCode(ctx, db.UpdateURLByShortCodeParams{
+			Url:            request.Url,
+			Redirectstatus: status,
+			Expiresat:      nullTime(request.ExpiresAt),
+			Notbefore:      nullTime(request.NotBefore),
+			Maxclicks:      limit,
+			Updatedat:      updatedAt,
+			Domain:         nullString(utils.Domain(request.Url)),
+			Title:          nullString(strings.TrimSpace(request.Title)),
+			Description:    nullString(strings.TrimSpace(request.Description)),
+			Notes:          nullString(strings.TrimSpace(request.Notes)),
+			Shortcode:      shortCode,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Tags, like the password, are only replaced when the request
+		// carries them; an empty list removes them.
+		if request.Tags != nil {
+			return setTags(ctx, q, data.ID, tags)
+		}
+
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -90,25 +778,188 @@ func (c *Controller) UpdateLink(ctx context.Context, url, shortCode string) (*mo
 	}
 	createdAt = &data.Createdat.Time
 
+	if request.Tags == nil {
+		if tags, err = c.queries.ListTagsByURLID(ctx, data.ID); err != nil {
+			return nil, err
+		}
+	}
+
 	return &models.ShortLinkResponse{
-		Id:        int(data.ID),
-		Url:       data.Url,
-		ShortCode: data.Shortcode,
-		CreatedAt: createdAt,
-		UpdatedAt: &updatedAt.Time,
+		Id:             int(data.ID),
+		Url:            data.Url,
+		ShortCode:      data.Shortcode,
+		Title:          data.Title.String,
+		Description:    data.Description.String,
+		Notes:          data.Notes.String,
+		RedirectStatus: int(data.Redirectstatus),
+		ExpiresAt:      timePtr(data.Expiresat),
+		NotBefore:      timePtr(data.Notbefore),
+		MaxClicks:      int(data.Maxclicks.Int64),
+		Protected:      data.Passwordhash.Valid,
+		CreatedAt:      createdAt,
+		UpdatedAt:      &updatedAt.Time,
+		Tags:           tags,
+		CampaignId:     int(data.Campaignid.Int64),
+		Version:        int(data.Version),
 	}, nil
 }
 
-func (c *Controller) DeleteShortLink(ctx context.Context, shortCode string) error {
-	_, err := c.queries.GetURLStatsByShortCode(ctx, shortCode)
+// linkCampaign returns the id of the campaign a link is in, or nil when it is
+// in none.
+func linkCampaign(ctx context.Context, q db.Querier, shortCode string) (*int, error) {
+	data, err := q.GetURLByShortCode(ctx, shortCode)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrLinkNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !data.Campaignid.Valid {
+		return nil, nil
+	}
+
+	id := int(data.Campaignid.Int64)
+	return &id, nil
+}
+
+// claimVersion moves a link to its next version as the first step of a
+// change, so that of two changes made from the same version only the first
+// goes through. A zero version accepts whatever version the link has.
+func claimVersion(ctx context.Context, q db.Querier, shortCode string, version int64) error {
+	claimed, err := q.BumpURLVersionByShortCode(ctx, db.BumpURLVersionByShortCodeParams{
+		ShortCode: shortCode,
+		Version: sql.NullInt64{
+			Int64: version,
+			Valid: version != 0,
+		},
+	})
 	if err != nil {
 		return err
 	}
-	return c.queries.DeleteURLByShortCode(ctx, shortCode)
+
+	if claimed > 0 {
+		return nil
+	}
+
+	_, err = q.GetURLByShortCode(ctx, shortCode)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrLinkNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	return ErrVersionMismatch
+}
+
+func (c *Controller) PatchLink(ctx context.Context, patch []byte, shortCode string, version int64) (*models.ShortLinkResponse, error) {
+	data, err := c.queries.GetURLByShortCode(ctx, shortCode)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrLinkNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if version != 0 && version != data.Version {
+		return nil, ErrVersionMismatch
+	}
+
+	// The patch applies to the link as a PUT body would describe it, so a
+	// member it removes goes back to its default like a field a PUT omits.
+	current, err := json.Marshal(models.ShortLinkRequest{
+		Url:            data.Url,
+		RedirectStatus: int(data.Redirectstatus),
+		ExpiresAt:      timePtr(data.Expiresat),
+		NotBefore:      timePtr(data.Notbefore),
+		MaxClicks:      int(data.Maxclicks.Int64),
+		Title:          data.Title.String,
+		Description:    data.Description.String,
+		Notes:          data.Notes.String,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &members); err != nil {
+		return nil, utils.ErrInvalidPatch
+	}
+	for member := range members {
+		if !patchableMembers[member] {
+			return nil, utils.ErrInvalidPatch
+		}
+	}
+
+	merged, err := utils.MergePatch(current, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	var request models.ShortLinkRequest
+	if err := json.Unmarshal(merged, &request); err != nil {
+		return nil, utils.ErrInvalidPatch
+	}
+
+	// The password, tags and campaign are kept when missing from the
+	// document, so a null in the patch has to remove them explicitly.
+	if isNull(members["password"]) {
+		request.Password = new(string)
+	}
+	if isNull(members["tags"]) {
+		request.Tags = []string{}
+	}
+	if isNull(members["campaignId"]) {
+		request.CampaignId = new(int)
+	}
+
+	// The patch is applied to the version just read, so a change made in
+	// the meantime is not overwritten with what was read before it.
+	return c.UpdateLink(ctx, request, shortCode, data.Version)
 }
 
-func (c *Controller) GetStatShortLink(ctx context.Context, shortCode string) (*models.StatShortLinkResponse, error) {
+// patchableMembers are the members of a PUT body a patch may change. The
+// alias and dedupe only apply when a link is created.
+var patchableMembers = map[string]bool{
+	"url":            true,
+	"redirectStatus": true,
+	"expiresAt":      true,
+	"notBefore":      true,
+	"maxClicks":      true,
+	"title":          true,
+	"description":    true,
+	"notes":          true,
+	"password":       true,
+	"tags":           true,
+	"campaignId":     true,
+}
+
+// isNull reports whether a JSON member is present and null.
+func isNull(value json.RawMessage) bool {
+	return string(value) == "null"
+}
+
+func (c *Controller) DeleteShortLink(ctx context.Context, shortCode string, version int64) error {
+	return c.queries.ExecTx(ctx, func(q db.Querier) error {
+		if err := claimVersion(ctx, q, shortCode, version); err != nil {
+			return err
+		}
+
+		return q.DeleteURLByShortCode(ctx, shortCode)
+	})
+}
+
+func (c *Controller) GetStatShortLink(ctx context.Context, shortCode string, password string) (*models.StatShortLinkResponse, error) {
 	data, err := c.queries.GetURLStatsByShortCode(ctx, shortCode)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrLinkNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := visibleURL(data.Url, data.Passwordhash, password)
 	if err != nil {
 		return nil, err
 	}
@@ -122,12 +973,36 @@ func (c *Controller) GetStatShortLink(ctx context.Context, shortCode string) (*m
 		updatedAt = &data.Updatedat.Time
 	}
 
+	uniqueVisitors, uniqueVisitorsToday, err := c.uniqueVisitors(ctx, data.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := c.queries.ListTagsByURLID(ctx, data.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &models.StatShortLinkResponse{
-		Id:          int(data.ID),
-		Url:         data.Url,
-		ShortCode:   data.Shortcode,
-		CreatedAt:   createdAt,
-		UpdatedAt:   updatedAt,
-		AccessCount: uint(data.Accesscount.Int64),
+		Id:             int(data.ID),
+		Url:            link,
+		ShortCode:      data.Shortcode,
+		Title:          data.Title.String,
+		Description:    data.Description.String,
+		Notes:          data.Notes.String,
+		RedirectStatus: int(data.Redirectstatus),
+		ExpiresAt:      timePtr(data.Expiresat),
+		NotBefore:      timePtr(data.Notbefore),
+		MaxClicks:      int(data.Maxclicks.Int64),
+		Protected:      data.Passwordhash.Valid,
+		CreatedAt:      createdAt,
+		UpdatedAt:      updatedAt,
+		Tags:           tags,
+		CampaignId:     int(data.Campaignid.Int64),
+		AccessCount:    uint(data.Accesscount.Int64),
+		BotCount:       uint(data.Botcount),
+
+		UniqueVisitors:      uniqueVisitors,
+		UniqueVisitorsToday: uniqueVisitorsToday,
 	}, nil
 }
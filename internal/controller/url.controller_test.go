@@ -3,39 +3,2006 @@ package controller
 import (
 	"context"
 	"database/sql"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/generator"
 	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
-	dbMock "github.com/DarcoProgramador/shortener-go-backend/mocks/db_mock"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/recorder"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/visitor"
+	recorderMock "github.com/DarcoProgramador/shortener-go-backend/mocks/recorder_mock"
+	storeMock "github.com/DarcoProgramador/shortener-go-backend/mocks/store_mock"
+	"github.com/DarcoProgramador/shortener-go-backend/utils"
+	"github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+var (
+	past   = time.Now().Add(-time.Hour)
+	future = time.Now().Add(time.Hour)
+
+	linkPassword        = "s3cret"
+	linkPasswordHash, _ = utils.HashPassword(linkPassword)
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+// springCampaign is a campaign with default UTM source and medium.
+var springCampaign = db.Campaign{
+	ID:        3,
+	Name:      "Spring sale",
+	Utmsource: sql.NullString{String: "newsletter", Valid: true},
+	Utmmedium: sql.NullString{String: "email", Valid: true},
+}
+
+// expectLinkCampaign makes link abc123 be read in the campaign with
+// campaignID, or in none when it is 0.
+func expectLinkCampaign(q *storeMock.MockStore, campaignID int64) {
+	q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(db.GetURLByShortCodeRow{
+		Shortcode:  "abc123",
+		Campaignid: sql.NullInt64{Int64: campaignID, Valid: campaignID != 0},
+	}, nil).Once()
+}
+
+// runInTx makes ExecTx run its function against the same mock, so the
+// queries inside the transaction keep their own expectations.
+func runInTx(q *storeMock.MockStore) {
+	q.EXPECT().ExecTx(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, fn func(db.Querier) error) error {
+			return fn(q)
+		},
+	)
+}
+
+// expectVersionClaim runs the next ExecTx against q and expects it to move
+// link abc123 on from version, where 0 is any version.
+func expectVersionClaim(q *storeMock.MockStore, version int64) {
+	runInTx(q)
+	q.EXPECT().BumpURLVersionByShortCode(mock.Anything, db.BumpURLVersionByShortCodeParams{
+		ShortCode: "abc123",
+		Version:   sql.NullInt64{Int64: version, Valid: version != 0},
+	}).Return(1, nil)
+}
+
+// expectTags expects the tags of link 1 to be replaced by tags, given ids
+// from 1 in order.
+func expectTags(q *storeMock.MockStore, tags ...string) {
+	for i, tag := range tags {
+		q.EXPECT().UpsertTag(mock.Anything, tag).Return(int64(i+1), nil).Once()
+		q.EXPECT().AddURLTag(mock.Anything, db.AddURLTagParams{Urlid: 1, Tagid: int64(i + 1)}).Return(nil).Once()
+	}
+}
+
+// expectVisitorSalt lets the controller create the salt of the day on the
+// first counted visit.
+func expectVisitorSalt(q *storeMock.MockStore) {
+	q.EXPECT().CreateVisitorSalt(mock.Anything, mock.Anything).Return(nil)
+	q.EXPECT().GetVisitorSalt(mock.Anything, mock.Anything).Return([]byte("salt"), nil)
+	q.EXPECT().DeleteVisitorSaltsBefore(mock.Anything, mock.Anything).Return(nil)
+}
+
 func TestController_CreateShortLink(t *testing.T) {
 	type args struct {
-		ctx context.Context
-		url string
+		ctx     context.Context
+		request models.ShortLinkRequest
 	}
 	tests := []struct {
 		name             string
+		options          Options
 		args             args
-		mockExpectations func(t *testing.T) *dbMock.MockQuerier
+		mockExpectations func(t *testing.T) *storeMock.MockStore
 		want             *models.ShortLinkResponse
 		wantErr          bool
+		errIs            error
 	}{
 		{
-			name: "CreateShortLink",
+			name: "CreateShortLink",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetLastURLID(mock.Anything).Return(0, nil)
+				q.EXPECT().CreateURL(mock.Anything, mock.Anything).RunAndReturn(
+					func(ctx context.Context, arg db.CreateURLParams) (db.CreateURLRow, error) {
+						return db.CreateURLRow{
+							ID:        1,
+							Url:       arg.Url,
+							Shortcode: arg.Shortcode,
+							Createdat: sql.NullTime{
+								Time:  time.Now(),
+								Valid: true,
+							},
+							Redirectstatus: arg.Redirectstatus,
+						}, nil
+					},
+				)
+
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:             1,
+				Url:            "http://www.google.com/",
+				RedirectStatus: http.StatusFound,
+			},
+			wantErr: false,
+		},
+		{
+			name: "CreateShortLink normalizes the URL",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "HTTP://Bücher.EXAMPLE:80/a/../b?fbclid=abc"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetLastURLID(mock.Anything).Return(0, nil)
+				q.EXPECT().CreateURL(mock.Anything, mock.MatchedBy(func(arg db.CreateURLParams) bool {
+					return arg.Url == "http://xn--bcher-kva.example/b?fbclid=abc" &&
+						arg.Domain.String == "xn--bcher-kva.example"
+				})).RunAndReturn(createdURL)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:             1,
+				Url:            "http://xn--bcher-kva.example/b?fbclid=abc",
+				RedirectStatus: http.StatusFound,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "CreateShortLink stripping tracking parameters",
+			options: Options{StripTracking: true},
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "https://www.google.com/search?fbclid=abc&q=go&utm_source=newsletter&gclid=xyz"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetLastURLID(mock.Anything).Return(0, nil)
+				q.EXPECT().CreateURL(mock.Anything, mock.Anything).RunAndReturn(createdURL)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:             1,
+				Url:            "https://www.google.com/search?q=go&utm_source=newsletter",
+				RedirectStatus: http.StatusFound,
+			},
+			wantErr: false,
+		},
+		{
+			name: "CreateShortLink with redirect status",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com", RedirectStatus: http.StatusMovedPermanently},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetLastURLID(mock.Anything).Return(0, nil)
+				q.EXPECT().CreateURL(mock.Anything, mock.Anything).RunAndReturn(
+					func(ctx context.Context, arg db.CreateURLParams) (db.CreateURLRow, error) {
+						return db.CreateURLRow{
+							ID:        1,
+							Url:       arg.Url,
+							Shortcode: arg.Shortcode,
+							Createdat: sql.NullTime{
+								Time:  time.Now(),
+								Valid: true,
+							},
+							Redirectstatus: arg.Redirectstatus,
+						}, nil
+					},
+				)
+
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:             1,
+				Url:            "http://www.google.com/",
+				RedirectStatus: http.StatusMovedPermanently,
+			},
+			wantErr: false,
+		},
+		{
+			name: "CreateShortLink with invalid redirect status",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com", RedirectStatus: http.StatusOK},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				// No se espera ninguna llamada a CreateURL
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "CreateShortLink with invalid URL",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "asdasd"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				// No se espera ninguna llamada a CreateURL
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "CreateShortLink with alias",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com", Alias: "spring-sale"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().CreateURL(mock.Anything, mock.MatchedBy(func(arg db.CreateURLParams) bool {
+					return arg.Shortcode == "spring-sale"
+				})).RunAndReturn(
+					func(ctx context.Context, arg db.CreateURLParams) (db.CreateURLRow, error) {
+						return db.CreateURLRow{
+							ID:        1,
+							Url:       arg.Url,
+							Shortcode: arg.Shortcode,
+							Createdat: sql.NullTime{
+								Time:  time.Now(),
+								Valid: true,
+							},
+							Redirectstatus: arg.Redirectstatus,
+						}, nil
+					},
+				)
+
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:             1,
+				Url:            "http://www.google.com/",
+				ShortCode:      "spring-sale",
+				RedirectStatus: http.StatusFound,
+			},
+			wantErr: false,
+		},
+		{
+			name: "CreateShortLink with invalid alias",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com", Alias: "spring sale!"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				// No se espera ninguna llamada a CreateURL
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrInvalidAlias,
+		},
+		{
+			name: "CreateShortLink with reserved alias",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com", Alias: "Shorten"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				// No se espera ninguna llamada a CreateURL
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrReservedAlias,
+		},
+		{
+			name: "CreateShortLink with alias already taken",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com", Alias: "spring-sale"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().CreateURL(mock.Anything, mock.Anything).Return(db.CreateURLRow{}, sqlite3.Error{
+					Code:         sqlite3.ErrConstraint,
+					ExtendedCode: sqlite3.ErrConstraintUnique,
+				})
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   ErrAliasTaken,
+		},
+		{
+			name: "CreateShortLink retries on short code collision",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetLastURLID(mock.Anything).Return(0, nil)
+				q.EXPECT().CreateURL(mock.Anything, mock.Anything).Return(db.CreateURLRow{}, sqlite3.Error{
+					Code:         sqlite3.ErrConstraint,
+					ExtendedCode: sqlite3.ErrConstraintUnique,
+				}).Once()
+				q.EXPECT().CreateURL(mock.Anything, mock.Anything).RunAndReturn(
+					func(ctx context.Context, arg db.CreateURLParams) (db.CreateURLRow, error) {
+						return db.CreateURLRow{
+							ID:        1,
+							Url:       arg.Url,
+							Shortcode: arg.Shortcode,
+							Createdat: sql.NullTime{
+								Time:  time.Now(),
+								Valid: true,
+							},
+							Redirectstatus: arg.Redirectstatus,
+						}, nil
+					},
+				).Once()
+
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:             1,
+				Url:            "http://www.google.com/",
+				RedirectStatus: http.StatusFound,
+			},
+			wantErr: false,
+		},
+		{
+			name: "CreateShortLink gives up after repeated collisions",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetLastURLID(mock.Anything).Return(0, nil)
+				q.EXPECT().CreateURL(mock.Anything, mock.Anything).Return(db.CreateURLRow{}, sqlite3.Error{
+					Code:         sqlite3.ErrConstraint,
+					ExtendedCode: sqlite3.ErrConstraintUnique,
+				}).Times(maxCodeAttempts)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   ErrCodeExhausted,
+		},
+		{
+			name: "CreateShortLink with invalid window",
+			args: args{
+				ctx: context.TODO(),
+				request: models.ShortLinkRequest{
+					Url:       "http://www.google.com",
+					NotBefore: &future,
+					ExpiresAt: &past,
+				},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				// No se espera ninguna llamada a CreateURL
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrInvalidLinkWindow,
+		},
+		{
+			name: "CreateShortLink with negative click limit",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com", MaxClicks: -1},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				// No se espera ninguna llamada a CreateURL
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrInvalidMaxClicks,
+		},
+		{
+			name: "CreateShortLink with password",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com", Password: stringPtr(linkPassword)},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetLastURLID(mock.Anything).Return(0, nil)
+				q.EXPECT().CreateURL(mock.Anything, mock.Anything).RunAndReturn(
+					func(ctx context.Context, arg db.CreateURLParams) (db.CreateURLRow, error) {
+						assert.True(t, arg.Passwordhash.Valid, "El campo Passwordhash debe ser válido")
+						assert.NotEqual(t, linkPassword, arg.Passwordhash.String, "La contraseña no debe guardarse en claro")
+						assert.True(t, utils.CheckPassword(arg.Passwordhash.String, linkPassword), "El hash no corresponde a la contraseña")
+						return db.CreateURLRow{
+							ID:        1,
+							Url:       arg.Url,
+							Shortcode: arg.Shortcode,
+							Createdat: sql.NullTime{
+								Time:  time.Now(),
+								Valid: true,
+							},
+							Redirectstatus: arg.Redirectstatus,
+							Passwordhash:   arg.Passwordhash,
+						}, nil
+					},
+				)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:             1,
+				Url:            "http://www.google.com/",
+				RedirectStatus: http.StatusFound,
+				Protected:      true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "CreateShortLink with tags",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com", Tags: []string{"Spring", " promo", "spring"}},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				runInTx(q)
+				q.EXPECT().GetLastURLID(mock.Anything).Return(0, nil)
+				q.EXPECT().CreateURL(mock.Anything, mock.Anything).RunAndReturn(
+					func(ctx context.Context, arg db.CreateURLParams) (db.CreateURLRow, error) {
+						return db.CreateURLRow{
+							ID:        1,
+							Url:       arg.Url,
+							Shortcode: arg.Shortcode,
+							Createdat: sql.NullTime{
+								Time:  time.Now(),
+								Valid: true,
+							},
+							Redirectstatus: arg.Redirectstatus,
+						}, nil
+					},
+				)
+				expectTags(q, "promo", "spring")
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:             1,
+				Url:            "http://www.google.com/",
+				RedirectStatus: http.StatusFound,
+				Tags:           []string{"promo", "spring"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "CreateShortLink with campaign",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com/?utm_source=ads#top", CampaignId: intPtr(3)},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetCampaignByID(mock.Anything, int64(3)).Return(springCampaign, nil)
+				q.EXPECT().GetLastURLID(mock.Anything).Return(0, nil)
+				q.EXPECT().CreateURL(mock.Anything, mock.MatchedBy(func(arg db.CreateURLParams) bool {
+					return arg.Campaignid == sql.NullInt64{Int64: 3, Valid: true}
+				})).RunAndReturn(createdURL)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:             1,
+				Url:            "http://www.google.com/?utm_source=ads&utm_medium=email#top",
+				RedirectStatus: http.StatusFound,
+				CampaignId:     3,
+			},
+			wantErr: false,
+		},
+		{
+			name: "CreateShortLink with unknown campaign",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com", CampaignId: intPtr(9)},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetCampaignByID(mock.Anything, int64(9)).Return(db.Campaign{}, sql.ErrNoRows)
+				// No se espera ninguna llamada a CreateURL
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   ErrUnknownCampaign,
+		},
+		{
+			name: "CreateShortLink with invalid campaign",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com", CampaignId: intPtr(-1)},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				// No se espera ninguna llamada a GetCampaignByID
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrInvalidCampaignID,
+		},
+		{
+			name: "CreateShortLink with title, description and notes",
+			args: args{
+				ctx: context.TODO(),
+				request: models.ShortLinkRequest{
+					Url:         "http://www.google.com",
+					Title:       "  Q3 webinar ",
+					Description: "Registration page of the Q3 webinar",
+					Notes:       "Shared on the partners newsletter",
+				},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetLastURLID(mock.Anything).Return(0, nil)
+				q.EXPECT().CreateURL(mock.Anything, mock.MatchedBy(func(arg db.CreateURLParams) bool {
+					return arg.Title == sql.NullString{String: "Q3 webinar", Valid: true}
+				})).RunAndReturn(createdURL)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:             1,
+				Url:            "http://www.google.com/",
+				Title:          "Q3 webinar",
+				Description:    "Registration page of the Q3 webinar",
+				Notes:          "Shared on the partners newsletter",
+				RedirectStatus: http.StatusFound,
+			},
+			wantErr: false,
+		},
+		{
+			name: "CreateShortLink with too long title",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com", Title: strings.Repeat("a", utils.MaxTitleLength+1)},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				// No se espera ninguna llamada a CreateURL
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrInvalidTitle,
+		},
+		{
+			name: "CreateShortLink with invalid tag",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com", Tags: []string{"spring sale"}},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				// No se espera ninguna llamada a CreateURL
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrInvalidTag,
+		},
+		{
+			name: "CreateShortLink with error adding tags",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com", Alias: "spring-sale", Tags: []string{"promo"}},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				runInTx(q)
+				q.EXPECT().CreateURL(mock.Anything, mock.Anything).Return(db.CreateURLRow{ID: 1}, nil)
+				q.EXPECT().UpsertTag(mock.Anything, "promo").Return(0, assert.AnError)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   assert.AnError,
+		},
+		{
+			name: "CreateShortLink with too short password",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com", Password: stringPtr("abc")},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				// No se espera ninguna llamada a CreateURL
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrInvalidLinkPassword,
+		},
+		{
+			name: "CreateShortLink with error",
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetLastURLID(mock.Anything).Return(0, nil)
+				q.EXPECT().CreateURL(mock.Anything, mock.Anything).Return(db.CreateURLRow{}, assert.AnError)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.mockExpectations(t)
+			r := recorderMock.NewMockRecorder(t)
+
+			c := NewController(q, generator.NewRandom(), r, tt.options)
+
+			got, err := c.CreateShortLink(tt.args.ctx, tt.args.request)
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs, "El error no es el esperado")
+			}
+
+			if err != nil {
+				assert.Nil(t, got, "El valor de got debe ser nulo cuando se espera un error")
+				return
+			}
+
+			assert.Equal(t, tt.want.Id, got.Id, "Los valores de los campos Id no coinciden")
+			assert.Equal(t, tt.want.Url, got.Url, "Los valores de los campos Url no coinciden")
+			assert.NotEqual(t, got.ShortCode, "", "El campo ShortCode no debe ser vacío")
+			if tt.args.request.Alias != "" {
+				assert.Equal(t, tt.want.ShortCode, got.ShortCode, "El campo ShortCode debe ser el alias")
+			} else {
+				assert.Len(t, got.ShortCode, 6, "El campo ShortCode debe tener 6 caracteres")
+			}
+			assert.Equal(t, tt.want.RedirectStatus, got.RedirectStatus, "Los valores de los campos RedirectStatus no coinciden")
+			assert.Equal(t, tt.want.Protected, got.Protected, "Los valores de los campos Protected no coinciden")
+			assert.Equal(t, tt.want.Tags, got.Tags, "Los valores de los campos Tags no coinciden")
+			assert.Equal(t, tt.want.CampaignId, got.CampaignId, "Los valores de los campos CampaignId no coinciden")
+			assert.Equal(t, tt.want.Title, got.Title, "Los valores de los campos Title no coinciden")
+			assert.Equal(t, tt.want.Description, got.Description, "Los valores de los campos Description no coinciden")
+			assert.Equal(t, tt.want.Notes, got.Notes, "Los valores de los campos Notes no coinciden")
+			assert.NotNil(t, got.CreatedAt, "El campo CreatedAt no debe ser nulo")
+		})
+	}
+}
+
+func TestController_ResolveLink(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		shortCode string
+		visit     models.VisitRequest
+	}
+	tests := []struct {
+		name             string
+		args             args
+		mockExpectations func(t *testing.T) *storeMock.MockStore
+		// recorderExpectations is only set when the visit is counted in the
+		// background.
+		recorderExpectations func(t *testing.T, r *recorderMock.MockRecorder)
+		want                 *models.ShortLinkResponse
+		wantErr              bool
+		errIs                error
+	}{
+		{
+			name: "ResolveLink_OK",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+				visit: models.VisitRequest{
+					Referrer:       "https://news.ycombinator.com/",
+					UserAgent:      "Mozilla/5.0 (X11; Linux x86_64; rv:133.0) Gecko/20100101 Firefox/133.0",
+					IP:             "203.0.113.42",
+					AcceptLanguage: "es-ES,es;q=0.9",
+				},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, mock.Anything).RunAndReturn(
+					func(ctx context.Context, shortCode string) (db.GetURLByShortCodeRow, error) {
+						return db.GetURLByShortCodeRow{
+							ID:        1,
+							Url:       "http://www.google.com",
+							Shortcode: shortCode,
+							Createdat: sql.NullTime{
+								Time:  time.Now(),
+								Valid: true,
+							},
+							Updatedat: sql.NullTime{
+								Time:  time.Now(),
+								Valid: true,
+							},
+						}, nil
+					},
+				)
+				expectVisitorSalt(q)
+				// No se espera ninguna llamada a IncrementURLAccessCountByShortCode
+				return q
+			},
+			recorderExpectations: func(t *testing.T, r *recorderMock.MockRecorder) {
+				r.EXPECT().Record(mock.Anything).Run(
+					func(visit recorder.Visit) {
+						assert.Equal(t, int64(1), visit.URLID, "Los valores de los campos URLID no coinciden")
+						arg := visit.Click
+						assert.Equal(t, int64(1), arg.Urlid, "Los valores de los campos Urlid no coinciden")
+						assert.Equal(t, "https://news.ycombinator.com/", arg.Referrer.String, "Los valores de los campos Referrer no coinciden")
+						assert.Equal(t, "Mozilla/5.0 (X11; Linux x86_64; rv:133.0) Gecko/20100101 Firefox/133.0", arg.Useragent.String, "Los valores de los campos Useragent no coinciden")
+						assert.Equal(t, "203.0.113.0", arg.Ipaddress.String, "La IP debe guardarse anonimizada")
+						assert.Equal(t, "es-ES,es;q=0.9", arg.Acceptlanguage.String, "Los valores de los campos Acceptlanguage no coinciden")
+						assert.Equal(t, "news.ycombinator.com", arg.Referrerdomain.String, "Los valores de los campos Referrerdomain no coinciden")
+						assert.Equal(t, "Firefox", arg.Browser.String, "Los valores de los campos Browser no coinciden")
+						assert.Equal(t, "Linux", arg.Os.String, "Los valores de los campos Os no coinciden")
+						assert.Equal(t, "Desktop", arg.Device.String, "Los valores de los campos Device no coinciden")
+						assert.False(t, arg.Clickedat.IsZero(), "El campo Clickedat no debe ser cero")
+						// The visitor is hashed with the full IP, before it is anonymised.
+						visitorHash := visitor.Hash([]byte("salt"), 1, "203.0.113.42", "Mozilla/5.0 (X11; Linux x86_64; rv:133.0) Gecko/20100101 Firefox/133.0")
+						assert.Equal(t, visitorHash, arg.Visitorhash.String, "Los valores de los campos Visitorhash no coinciden")
+						register, rank := visitor.Register(visitorHash)
+						assert.Equal(t, register, visit.Register, "Los valores de los campos Register no coinciden")
+						assert.Equal(t, rank, visit.Rank, "Los valores de los campos Rank no coinciden")
+					},
+				)
+			},
+			want: &models.ShortLinkResponse{
+				Id:        1,
+				Url:       "http://www.google.com",
+				ShortCode: "abc123",
+			},
+			wantErr: false,
+		},
+		{
+			name: "ResolveLink with error",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, mock.Anything).Return(db.GetURLByShortCodeRow{}, assert.AnError)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "ResolveLink not found",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, mock.Anything).Return(db.GetURLByShortCodeRow{}, sql.ErrNoRows)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   ErrLinkNotFound,
+		},
+		{
+			name: "ResolveLink expired",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, mock.Anything).Return(db.GetURLByShortCodeRow{
+					ID:        1,
+					Url:       "http://www.google.com",
+					Shortcode: "abc123",
+					Expiresat: sql.NullTime{
+						Time:  past,
+						Valid: true,
+					},
+				}, nil)
+				// No se espera ninguna llamada a IncrementURLAccessCountByShortCode
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   ErrLinkExpired,
+		},
+		{
+			name: "ResolveLink not active yet",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, mock.Anything).Return(db.GetURLByShortCodeRow{
+					ID:        1,
+					Url:       "http://www.google.com",
+					Shortcode: "abc123",
+					Notbefore: sql.NullTime{
+						Time:  future,
+						Valid: true,
+					},
+				}, nil)
+				// No se espera ninguna llamada a IncrementURLAccessCountByShortCode
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   ErrLinkNotActive,
+		},
+		{
+			name: "ResolveLink click limit reached",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, mock.Anything).Return(db.GetURLByShortCodeRow{
+					ID:        1,
+					Url:       "http://www.google.com",
+					Shortcode: "abc123",
+					Maxclicks: sql.NullInt64{
+						Int64: 1,
+						Valid: true,
+					},
+				}, nil)
+				expectVisitorSalt(q)
+				runInTx(q)
+				q.EXPECT().IncrementURLAccessCountByShortCode(mock.Anything, "abc123").Return(0, nil)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   ErrLinkExhausted,
+		},
+		{
+			name: "ResolveLink with click limit counts at once",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, mock.Anything).Return(db.GetURLByShortCodeRow{
+					ID:        1,
+					Url:       "http://www.google.com",
+					Shortcode: "abc123",
+					Createdat: sql.NullTime{Time: time.Now(), Valid: true},
+					Maxclicks: sql.NullInt64{Int64: 10, Valid: true},
+				}, nil)
+				expectVisitorSalt(q)
+				runInTx(q)
+				q.EXPECT().IncrementURLAccessCountByShortCode(mock.Anything, "abc123").Return(1, nil)
+				q.EXPECT().CreateClick(mock.Anything, mock.Anything).Return(nil)
+				q.EXPECT().UpsertVisitorSketch(mock.Anything, mock.Anything).Return(nil)
+				// No se espera ninguna llamada a Record
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:        1,
+				Url:       "http://www.google.com",
+				ShortCode: "abc123",
+			},
+			wantErr: false,
+		},
+		{
+			name: "ResolveLink with invalid date",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, mock.Anything).Return(db.GetURLByShortCodeRow{
+					ID:        1,
+					Url:       "http://www.google.com",
+					Shortcode: "abc123",
+					Createdat: sql.NullTime{},
+				}, nil)
+				expectVisitorSalt(q)
+				return q
+			},
+			recorderExpectations: func(t *testing.T, r *recorderMock.MockRecorder) {
+				r.EXPECT().Record(mock.Anything).Return()
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "ResolveLink password required",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(db.GetURLByShortCodeRow{
+					ID:           1,
+					Url:          "http://www.google.com",
+					Shortcode:    "abc123",
+					Passwordhash: sql.NullString{String: linkPasswordHash, Valid: true},
+				}, nil)
+				// No se espera ninguna llamada a IncrementURLAccessCountByShortCode
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   ErrPasswordRequired,
+		},
+		{
+			name: "ResolveLink wrong password",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+				visit:     models.VisitRequest{Password: "wrong"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(db.GetURLByShortCodeRow{
+					ID:           1,
+					Url:          "http://www.google.com",
+					Shortcode:    "abc123",
+					Passwordhash: sql.NullString{String: linkPasswordHash, Valid: true},
+				}, nil)
+				// No se espera ninguna llamada a IncrementURLAccessCountByShortCode
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   ErrWrongPassword,
+		},
+		{
+			name: "ResolveLink with password",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+				visit:     models.VisitRequest{Password: linkPassword},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(db.GetURLByShortCodeRow{
+					ID:        1,
+					Url:       "http://www.google.com",
+					Shortcode: "abc123",
+					Createdat: sql.NullTime{
+						Time:  time.Now(),
+						Valid: true,
+					},
+					Passwordhash: sql.NullString{String: linkPasswordHash, Valid: true},
+				}, nil)
+				expectVisitorSalt(q)
+				return q
+			},
+			recorderExpectations: func(t *testing.T, r *recorderMock.MockRecorder) {
+				r.EXPECT().Record(mock.Anything).Return()
+			},
+			want: &models.ShortLinkResponse{
+				Id:        1,
+				Url:       "http://www.google.com",
+				ShortCode: "abc123",
+				Protected: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "ResolveLink with error recording click",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, mock.Anything).Return(db.GetURLByShortCodeRow{ID: 1, Maxclicks: sql.NullInt64{Int64: 10, Valid: true}}, nil)
+				expectVisitorSalt(q)
+				runInTx(q)
+				q.EXPECT().IncrementURLAccessCountByShortCode(mock.Anything, mock.Anything).Return(1, nil)
+				q.EXPECT().CreateClick(mock.Anything, mock.Anything).Return(assert.AnError)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "ResolveLink from a bot",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+				visit: models.VisitRequest{
+					UserAgent: "Slackbot-LinkExpanding 1.0 (+https://api.slack.com/robots)",
+				},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(db.GetURLByShortCodeRow{
+					ID:        1,
+					Url:       "http://www.google.com",
+					Shortcode: "abc123",
+					Createdat: sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil)
+				// No se espera ninguna llamada a IncrementURLAccessCountByShortCode ni a CreateClick
+				return q
+			},
+			recorderExpectations: func(t *testing.T, r *recorderMock.MockRecorder) {
+				r.EXPECT().Record(recorder.Visit{URLID: 1}).Return()
+			},
+			want: &models.ShortLinkResponse{
+				Id:        1,
+				Url:       "http://www.google.com",
+				ShortCode: "abc123",
+			},
+			wantErr: false,
+		},
+		{
+			name: "ResolveLink prefetch",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+				visit:     models.VisitRequest{Prefetch: true},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(db.GetURLByShortCodeRow{
+					ID:        1,
+					Url:       "http://www.google.com",
+					Shortcode: "abc123",
+					Createdat: sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil)
+				return q
+			},
+			recorderExpectations: func(t *testing.T, r *recorderMock.MockRecorder) {
+				r.EXPECT().Record(recorder.Visit{URLID: 1}).Return()
+			},
+			want: &models.ShortLinkResponse{
+				Id:        1,
+				Url:       "http://www.google.com",
+				ShortCode: "abc123",
+			},
+			wantErr: false,
+		},
+		{
+			name: "ResolveLink prefetch on exhausted link",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+				visit:     models.VisitRequest{Prefetch: true},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(db.GetURLByShortCodeRow{
+					ID:        1,
+					Shortcode: "abc123",
+					Maxclicks: sql.NullInt64{Int64: 1, Valid: true},
+				}, nil)
+				q.EXPECT().IncrementURLBotCountByShortCode(mock.Anything, "abc123").Return(0, nil)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   ErrLinkExhausted,
+		},
+		{
+			name: "ResolveLink with error counting bot",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+				visit:     models.VisitRequest{Prefetch: true},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(db.GetURLByShortCodeRow{
+					ID:        1,
+					Shortcode: "abc123",
+					Maxclicks: sql.NullInt64{Int64: 10, Valid: true},
+				}, nil)
+				q.EXPECT().IncrementURLBotCountByShortCode(mock.Anything, "abc123").Return(0, assert.AnError)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "ResolveLink with error creating visitor salt",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, mock.Anything).Return(db.GetURLByShortCodeRow{ID: 1}, nil)
+				q.EXPECT().CreateVisitorSalt(mock.Anything, mock.Anything).Return(assert.AnError)
+				// No se espera ninguna llamada a IncrementURLAccessCountByShortCode
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "ResolveLink with error updating visitor sketch",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, mock.Anything).Return(db.GetURLByShortCodeRow{ID: 1, Maxclicks: sql.NullInt64{Int64: 10, Valid: true}}, nil)
+				expectVisitorSalt(q)
+				runInTx(q)
+				q.EXPECT().IncrementURLAccessCountByShortCode(mock.Anything, mock.Anything).Return(1, nil)
+				q.EXPECT().CreateClick(mock.Anything, mock.Anything).Return(nil)
+				q.EXPECT().UpsertVisitorSketch(mock.Anything, mock.Anything).Return(assert.AnError)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "ResolveLink with error incrementing access count",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, mock.Anything).Return(db.GetURLByShortCodeRow{Maxclicks: sql.NullInt64{Int64: 10, Valid: true}}, nil)
+				expectVisitorSalt(q)
+				runInTx(q)
+				q.EXPECT().IncrementURLAccessCountByShortCode(mock.Anything, mock.Anything).Return(0, assert.AnError)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.mockExpectations(t)
+			r := recorderMock.NewMockRecorder(t)
+			if tt.recorderExpectations != nil {
+				tt.recorderExpectations(t, r)
+			}
+
+			c := NewController(q, generator.NewRandom(), r, Options{})
+
+			got, err := c.ResolveLink(tt.args.ctx, tt.args.shortCode, tt.args.visit)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs, "El error no es el esperado")
+			}
+
+			if err != nil {
+				assert.Nil(t, got, "El valor de got debe ser nulo cuando se espera un error")
+				return
+			}
+
+			assert.Equal(t, tt.want.Id, got.Id, "Los valores de los campos Id no coinciden")
+			assert.Equal(t, tt.want.Url, got.Url, "Los valores de los campos Url no coinciden")
+			assert.Equal(t, tt.want.ShortCode, got.ShortCode, "Los valores de los campos ShortCode no coinciden")
+			assert.Equal(t, tt.want.Protected, got.Protected, "Los valores de los campos Protected no coinciden")
+			assert.NotNil(t, got.CreatedAt, "El campo CreatedAt no debe ser nulo")
+		})
+	}
+}
+
+func TestController_CreateShortLinkDedupe(t *testing.T) {
+	canonical := "http://www.google.com/"
+
+	// expectExisting makes abc123 the plain link to http://www.google.com.
+	expectExisting := func(q *storeMock.MockStore) {
+		q.EXPECT().GetPlainURLByURL(mock.Anything, canonical).Return("abc123", nil)
+		q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(db.GetURLByShortCodeRow{
+			ID:        7,
+			Url:       "http://www.google.com",
+			Shortcode: "abc123",
+			Createdat: sql.NullTime{Time: time.Now(), Valid: true},
+			Version:   2,
+		}, nil)
+		q.EXPECT().ListTagsByURLID(mock.Anything, int64(7)).Return(nil, nil)
+	}
+
+	type args struct {
+		ctx     context.Context
+		request models.ShortLinkRequest
+	}
+	tests := []struct {
+		name             string
+		options          Options
+		args             args
+		mockExpectations func(t *testing.T) *storeMock.MockStore
+		want             *models.ShortLinkResponse
+		wantErr          bool
+	}{
+		{
+			name:    "CreateShortLink reuses existing link",
+			options: Options{Dedupe: true},
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "HTTP://WWW.Google.com:80"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				expectExisting(q)
+				// No se espera ninguna llamada a CreateURL
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:        7,
+				Url:       "http://www.google.com",
+				ShortCode: "abc123",
+				Version:   2,
+				Reused:    true,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "CreateShortLink asks for dedupe",
+			options: Options{},
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com", Dedupe: boolPtr(true)},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				expectExisting(q)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:        7,
+				Url:       "http://www.google.com",
+				ShortCode: "abc123",
+				Version:   2,
+				Reused:    true,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "CreateShortLink without existing link",
+			options: Options{Dedupe: true},
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetPlainURLByURL(mock.Anything, canonical).Return("", sql.ErrNoRows)
+				q.EXPECT().GetLastURLID(mock.Anything).Return(0, nil)
+				q.EXPECT().CreateURL(mock.Anything, mock.MatchedBy(func(arg db.CreateURLParams) bool {
+					return arg.Url == canonical
+				})).RunAndReturn(createdURL)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:  1,
+				Url: "http://www.google.com/",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "CreateShortLink opts out of dedupe",
+			options: Options{Dedupe: true},
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com", Dedupe: boolPtr(false)},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				// No se espera ninguna llamada a GetPlainURLByURL
+				q.EXPECT().GetLastURLID(mock.Anything).Return(0, nil)
+				q.EXPECT().CreateURL(mock.Anything, mock.Anything).RunAndReturn(createdURL)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:  1,
+				Url: "http://www.google.com/",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "CreateShortLink with password is not deduplicated",
+			options: Options{Dedupe: true},
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com", Password: stringPtr(linkPassword)},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetLastURLID(mock.Anything).Return(0, nil)
+				q.EXPECT().CreateURL(mock.Anything, mock.Anything).RunAndReturn(createdURL)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:  1,
+				Url: "http://www.google.com/",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "CreateShortLink with campaign is not deduplicated",
+			options: Options{Dedupe: true},
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com/", CampaignId: intPtr(3)},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetCampaignByID(mock.Anything, int64(3)).Return(springCampaign, nil)
+				// No se espera ninguna llamada a GetPlainURLByURL
+				q.EXPECT().GetLastURLID(mock.Anything).Return(0, nil)
+				q.EXPECT().CreateURL(mock.Anything, mock.Anything).RunAndReturn(createdURL)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:  1,
+				Url: "http://www.google.com/?utm_medium=email&utm_source=newsletter",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "CreateShortLink with metadata is not deduplicated",
+			options: Options{Dedupe: true},
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com", Title: "Spring sale", RedirectStatus: http.StatusMovedPermanently},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				// No se espera ninguna llamada a GetPlainURLByURL
+				q.EXPECT().GetLastURLID(mock.Anything).Return(0, nil)
+				q.EXPECT().CreateURL(mock.Anything, mock.Anything).RunAndReturn(createdURL)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:             1,
+				Url:            "http://www.google.com/",
+				Title:          "Spring sale",
+				RedirectStatus: http.StatusMovedPermanently,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "CreateShortLink with tags is not deduplicated",
+			options: Options{Dedupe: true},
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com", Tags: []string{"q3"}},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				// No se espera ninguna llamada a GetPlainURLByURL
+				runInTx(q)
+				q.EXPECT().GetLastURLID(mock.Anything).Return(0, nil)
+				q.EXPECT().CreateURL(mock.Anything, mock.Anything).RunAndReturn(createdURL)
+				expectTags(q, "q3")
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:   1,
+				Url:  "http://www.google.com/",
+				Tags: []string{"q3"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "CreateShortLink with alias is not deduplicated",
+			options: Options{Dedupe: true},
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com", Alias: "spring-sale"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().CreateURL(mock.Anything, mock.Anything).RunAndReturn(createdURL)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:        1,
+				Url:       "http://www.google.com/",
+				ShortCode: "spring-sale",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "CreateShortLink with error finding existing link",
+			options: Options{Dedupe: true},
+			args: args{
+				ctx:     context.TODO(),
+				request: models.ShortLinkRequest{Url: "http://www.google.com"},
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetPlainURLByURL(mock.Anything, canonical).Return("", assert.AnError)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.mockExpectations(t)
+			r := recorderMock.NewMockRecorder(t)
+
+			c := NewController(q, generator.NewRandom(), r, tt.options)
+
+			got, err := c.CreateShortLink(tt.args.ctx, tt.args.request)
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			if err != nil {
+				assert.Nil(t, got, "El valor de got debe ser nulo cuando se espera un error")
+				return
+			}
+
+			assert.Equal(t, tt.want.Id, got.Id, "Los valores de los campos Id no coinciden")
+			assert.Equal(t, tt.want.Url, got.Url, "Los valores de los campos Url no coinciden")
+			if tt.want.ShortCode != "" {
+				assert.Equal(t, tt.want.ShortCode, got.ShortCode, "Los valores de los campos ShortCode no coinciden")
+			}
+			assert.Equal(t, tt.want.Version, got.Version, "Los valores de los campos Version no coinciden")
+			assert.Equal(t, tt.want.Reused, got.Reused, "Los valores de los campos Reused no coinciden")
+		})
+	}
+}
+
+func TestController_GetLink(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		shortCode string
+		password  string
+	}
+	tests := []struct {
+		name             string
+		args             args
+		mockExpectations func(t *testing.T) *storeMock.MockStore
+		want             *models.ShortLinkResponse
+		wantErr          bool
+		errIs            error
+	}{
+		{
+			name: "GetLink_OK",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(db.GetURLByShortCodeRow{
+					ID:        1,
+					Url:       "http://www.google.com",
+					Shortcode: "abc123",
+					Createdat: sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil)
+				q.EXPECT().ListTagsByURLID(mock.Anything, int64(1)).Return([]string{"promo", "spring"}, nil)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:        1,
+				Url:       "http://www.google.com",
+				ShortCode: "abc123",
+				Tags:      []string{"promo", "spring"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GetLink on expired and protected link",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(db.GetURLByShortCodeRow{
+					ID:           1,
+					Url:          "http://www.google.com",
+					Shortcode:    "abc123",
+					Expiresat:    sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true},
+					Maxclicks:    sql.NullInt64{Int64: 1, Valid: true},
+					Passwordhash: sql.NullString{String: linkPasswordHash, Valid: true},
+					Createdat:    sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil)
+				q.EXPECT().ListTagsByURLID(mock.Anything, int64(1)).Return([]string{}, nil)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:        1,
+				ShortCode: "abc123",
+				Protected: true,
+				Tags:      []string{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GetLink on protected link with password",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+				password:  linkPassword,
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(db.GetURLByShortCodeRow{
+					ID:           1,
+					Url:          "http://www.google.com",
+					Shortcode:    "abc123",
+					Passwordhash: sql.NullString{String: linkPasswordHash, Valid: true},
+					Createdat:    sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil)
+				q.EXPECT().ListTagsByURLID(mock.Anything, int64(1)).Return([]string{}, nil)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:        1,
+				Url:       "http://www.google.com",
+				ShortCode: "abc123",
+				Protected: true,
+				Tags:      []string{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GetLink on protected link with wrong password",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+				password:  "wrong",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(db.GetURLByShortCodeRow{
+					ID:           1,
+					Url:          "http://www.google.com",
+					Shortcode:    "abc123",
+					Passwordhash: sql.NullString{String: linkPasswordHash, Valid: true},
+					Createdat:    sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   ErrWrongPassword,
+		},
+		{
+			name: "GetLink not found",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(db.GetURLByShortCodeRow{}, sql.ErrNoRows)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   ErrLinkNotFound,
+		},
+		{
+			name: "GetLink with error",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(db.GetURLByShortCodeRow{}, assert.AnError)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   assert.AnError,
+		},
+		{
+			name: "GetLink with error listing tags",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(db.GetURLByShortCodeRow{
+					ID:        1,
+					Createdat: sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil)
+				q.EXPECT().ListTagsByURLID(mock.Anything, int64(1)).Return(nil, assert.AnError)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.mockExpectations(t)
+			// No visit is counted, so the recorder must not be called.
+			r := recorderMock.NewMockRecorder(t)
+
+			c := NewController(q, generator.NewRandom(), r, Options{})
+
+			got, err := c.GetLink(tt.args.ctx, tt.args.shortCode, tt.args.password)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs, "El error no es el esperado")
+			}
+
+			if err != nil {
+				assert.Nil(t, got, "El valor de got debe ser nulo cuando se espera un error")
+				return
+			}
+
+			assert.Equal(t, tt.want.Id, got.Id, "Los valores de los campos Id no coinciden")
+			assert.Equal(t, tt.want.Url, got.Url, "Los valores de los campos Url no coinciden")
+			assert.Equal(t, tt.want.ShortCode, got.ShortCode, "Los valores de los campos ShortCode no coinciden")
+			assert.Equal(t, tt.want.Protected, got.Protected, "Los valores de los campos Protected no coinciden")
+			assert.Equal(t, tt.want.Tags, got.Tags, "Los valores de los campos Tags no coinciden")
+			assert.Equal(t, tt.want.CampaignId, got.CampaignId, "Los valores de los campos CampaignId no coinciden")
+			assert.NotNil(t, got.CreatedAt, "El campo CreatedAt no debe ser nulo")
+		})
+	}
+}
+
+func TestController_UpdateLink(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		request   models.ShortLinkRequest
+		shortCode string
+		version   int64
+	}
+	tests := []struct {
+		name             string
+		options          Options
+		args             args
+		mockExpectations func(t *testing.T) *storeMock.MockStore
+		want             *models.ShortLinkResponse
+		wantErr          bool
+		errIs            error
+	}{
+		{
+			name: "UpdateLink_OK",
+			args: args{
+				ctx:       context.TODO(),
+				request:   models.ShortLinkRequest{Url: "http://www.google.com"},
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				expectLinkCampaign(q, 0)
+				expectVersionClaim(q, 0)
+				q.EXPECT().UpdateURLByShortCode(mock.Anything, mock.Anything).RunAndReturn(
+					func(ctx context.Context, arg db.UpdateURLByShortCodeParams) (db.UpdateURLByShortCodeRow, error) {
+						return db.UpdateURLByShortCodeRow{
+							ID:        1,
+							Url:       arg.Url,
+							Shortcode: arg.Shortcode,
+							Createdat: sql.NullTime{
+								Time:  time.Now(),
+								Valid: true,
+							},
+							Updatedat: sql.NullTime{
+								Time:  time.Now(),
+								Valid: true,
+							},
+						}, nil
+					},
+				)
+				q.EXPECT().ListTagsByURLID(mock.Anything, int64(1)).Return([]string{"promo"}, nil)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:        1,
+				Url:       "http://www.google.com/",
+				ShortCode: "abc123",
+				Tags:      []string{"promo"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "UpdateLink normalizes the URL",
+			options: Options{StripTracking: true},
+			args: args{
+				ctx:       context.TODO(),
+				request:   models.ShortLinkRequest{Url: "https://WWW.Google.com:443/./search?gclid=xyz"},
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				expectLinkCampaign(q, 0)
+				expectVersionClaim(q, 0)
+				q.EXPECT().UpdateURLByShortCode(mock.Anything, mock.MatchedBy(func(arg db.UpdateURLByShortCodeParams) bool {
+					return arg.Url == "https://www.google.com/search"
+				})).Return(db.UpdateURLByShortCodeRow{
+					ID:        1,
+					Url:       "https://www.google.com/search",
+					Shortcode: "abc123",
+					Createdat: sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil)
+				q.EXPECT().ListTagsByURLID(mock.Anything, int64(1)).Return(nil, nil)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:        1,
+				Url:       "https://www.google.com/search",
+				ShortCode: "abc123",
+			},
+			wantErr: false,
+		},
+		{
+			name: "UpdateLink with invalid URL",
+			args: args{
+				ctx:       context.TODO(),
+				request:   models.ShortLinkRequest{Url: "asdasd"},
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				// No se espera ninguna llamada a UpdateURLByShortCode
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "UpdateLink with error",
+			args: args{
+				ctx:       context.TODO(),
+				request:   models.ShortLinkRequest{Url: "http://www.google.com"},
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				expectLinkCampaign(q, 0)
+				expectVersionClaim(q, 0)
+				q.EXPECT().UpdateURLByShortCode(mock.Anything, mock.Anything).Return(db.UpdateURLByShortCodeRow{}, assert.AnError)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "UpdateLink not found",
+			args: args{
+				ctx:       context.TODO(),
+				request:   models.ShortLinkRequest{Url: "http://www.google.com"},
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				runInTx(q)
+				q.EXPECT().BumpURLVersionByShortCode(mock.Anything, mock.Anything).Return(0, nil)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(db.GetURLByShortCodeRow{}, sql.ErrNoRows)
+				// No se espera ninguna llamada a UpdateURLByShortCode
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   ErrLinkNotFound,
+		},
+		{
+			name: "UpdateLink at its version",
+			args: args{
+				ctx:       context.TODO(),
+				request:   models.ShortLinkRequest{Url: "http://www.google.com"},
+				shortCode: "abc123",
+				version:   2,
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				expectLinkCampaign(q, 0)
+				expectVersionClaim(q, 2)
+				q.EXPECT().UpdateURLByShortCode(mock.Anything, mock.Anything).Return(db.UpdateURLByShortCodeRow{
+					ID:        1,
+					Url:       "http://www.google.com",
+					Shortcode: "abc123",
+					Createdat: sql.NullTime{
+						Time:  time.Now(),
+						Valid: true,
+					},
+					Version: 3,
+				}, nil)
+				q.EXPECT().ListTagsByURLID(mock.Anything, int64(1)).Return(nil, nil)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:        1,
+				Url:       "http://www.google.com",
+				ShortCode: "abc123",
+				Version:   3,
+			},
+			wantErr: false,
+		},
+		{
+			name: "UpdateLink with stale version",
 			args: args{
-				ctx: context.TODO(),
-				url: "http://www.google.com",
+				ctx:       context.TODO(),
+				request:   models.ShortLinkRequest{Url: "http://www.google.com", Password: stringPtr(linkPassword)},
+				shortCode: "abc123",
+				version:   2,
 			},
-			mockExpectations: func(t *testing.T) *dbMock.MockQuerier {
-				q := dbMock.NewMockQuerier(t)
-				q.EXPECT().CreateURL(mock.Anything, mock.Anything).RunAndReturn(
-					func(ctx context.Context, arg db.CreateURLParams) (db.CreateURLRow, error) {
-						return db.CreateURLRow{
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				runInTx(q)
+				q.EXPECT().BumpURLVersionByShortCode(mock.Anything, mock.Anything).Return(0, nil)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(db.GetURLByShortCodeRow{Version: 3}, nil).Once()
+				// No se espera ninguna llamada a UpdateURLPasswordByShortCode ni a UpdateURLByShortCode
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   ErrVersionMismatch,
+		},
+		{
+			name: "UpdateLink with new password",
+			args: args{
+				ctx:       context.TODO(),
+				request:   models.ShortLinkRequest{Url: "http://www.google.com", Password: stringPtr(linkPassword)},
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				expectLinkCampaign(q, 0)
+				expectVersionClaim(q, 0)
+				q.EXPECT().UpdateURLPasswordByShortCode(mock.Anything, mock.Anything).RunAndReturn(
+					func(ctx context.Context, arg db.UpdateURLPasswordByShortCodeParams) error {
+						assert.Equal(t, "abc123", arg.Shortcode, "Los valores de los campos Shortcode no coinciden")
+						assert.True(t, utils.CheckPassword(arg.Passwordhash.String, linkPassword), "El hash no corresponde a la contraseña")
+						return nil
+					},
+				)
+				q.EXPECT().UpdateURLByShortCode(mock.Anything, mock.Anything).Return(db.UpdateURLByShortCodeRow{
+					ID:        1,
+					Url:       "http://www.google.com",
+					Shortcode: "abc123",
+					Createdat: sql.NullTime{
+						Time:  time.Now(),
+						Valid: true,
+					},
+					Passwordhash: sql.NullString{String: linkPasswordHash, Valid: true},
+				}, nil)
+				q.EXPECT().ListTagsByURLID(mock.Anything, int64(1)).Return(nil, nil)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:        1,
+				Url:       "http://www.google.com",
+				ShortCode: "abc123",
+				Protected: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "UpdateLink removing password",
+			args: args{
+				ctx:       context.TODO(),
+				request:   models.ShortLinkRequest{Url: "http://www.google.com", Password: new(string)},
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				expectLinkCampaign(q, 0)
+				expectVersionClaim(q, 0)
+				q.EXPECT().UpdateURLPasswordByShortCode(mock.Anything, db.UpdateURLPasswordByShortCodeParams{
+					Shortcode: "abc123",
+				}).Return(nil)
+				q.EXPECT().UpdateURLByShortCode(mock.Anything, mock.Anything).Return(db.UpdateURLByShortCodeRow{
+					ID:        1,
+					Url:       "http://www.google.com",
+					Shortcode: "abc123",
+					Createdat: sql.NullTime{
+						Time:  time.Now(),
+						Valid: true,
+					},
+				}, nil)
+				q.EXPECT().ListTagsByURLID(mock.Anything, int64(1)).Return(nil, nil)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:        1,
+				Url:       "http://www.google.com",
+				ShortCode: "abc123",
+			},
+			wantErr: false,
+		},
+		{
+			name: "UpdateLink with tags",
+			args: args{
+				ctx:       context.TODO(),
+				request:   models.ShortLinkRequest{Url: "http://www.google.com", Tags: []string{"spring", "Promo"}},
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				expectLinkCampaign(q, 0)
+				expectVersionClaim(q, 0)
+				q.EXPECT().UpdateURLByShortCode(mock.Anything, mock.Anything).Return(db.UpdateURLByShortCodeRow{
+					ID:        1,
+					Url:       "http://www.google.com",
+					Shortcode: "abc123",
+					Createdat: sql.NullTime{
+						Time:  time.Now(),
+						Valid: true,
+					},
+				}, nil)
+				q.EXPECT().DeleteURLTagsByURLID(mock.Anything, int64(1)).Return(nil)
+				expectTags(q, "promo", "spring")
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:        1,
+				Url:       "http://www.google.com",
+				ShortCode: "abc123",
+				Tags:      []string{"promo", "spring"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "UpdateLink removing tags",
+			args: args{
+				ctx:       context.TODO(),
+				request:   models.ShortLinkRequest{Url: "http://www.google.com", Tags: []string{}},
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				expectLinkCampaign(q, 0)
+				expectVersionClaim(q, 0)
+				q.EXPECT().UpdateURLByShortCode(mock.Anything, mock.Anything).Return(db.UpdateURLByShortCodeRow{
+					ID:        1,
+					Url:       "http://www.google.com",
+					Shortcode: "abc123",
+					Createdat: sql.NullTime{
+						Time:  time.Now(),
+						Valid: true,
+					},
+				}, nil)
+				q.EXPECT().DeleteURLTagsByURLID(mock.Anything, int64(1)).Return(nil)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:        1,
+				Url:       "http://www.google.com",
+				ShortCode: "abc123",
+			},
+			wantErr: false,
+		},
+		{
+			name: "UpdateLink moving to a campaign",
+			args: args{
+				ctx:       context.TODO(),
+				request:   models.ShortLinkRequest{Url: "http://www.google.com/", CampaignId: intPtr(3)},
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetCampaignByID(mock.Anything, int64(3)).Return(springCampaign, nil)
+				expectVersionClaim(q, 0)
+				q.EXPECT().UpdateURLCampaignByShortCode(mock.Anything, db.UpdateURLCampaignByShortCodeParams{
+					Campaignid: sql.NullInt64{Int64: 3, Valid: true},
+					Shortcode:  "abc123",
+				}).Return(nil)
+				q.EXPECT().UpdateURLByShortCode(mock.Anything, mock.Anything).RunAndReturn(
+					func(ctx context.Context, arg db.UpdateURLByShortCodeParams) (db.UpdateURLByShortCodeRow, error) {
+						return db.UpdateURLByShortCodeRow{
 							ID:        1,
 							Url:       arg.Url,
 							Shortcode: arg.Shortcode,
@@ -43,41 +2010,174 @@ func TestController_CreateShortLink(t *testing.T) {
 								Time:  time.Now(),
 								Valid: true,
 							},
+							Campaignid: sql.NullInt64{Int64: 3, Valid: true},
 						}, nil
 					},
 				)
-
+				q.EXPECT().ListTagsByURLID(mock.Anything, int64(1)).Return(nil, nil)
 				return q
 			},
 			want: &models.ShortLinkResponse{
-				Id:  1,
-				Url: "http://www.google.com",
+				Id:         1,
+				Url:        "http://www.google.com/?utm_medium=email&utm_source=newsletter",
+				ShortCode:  "abc123",
+				CampaignId: 3,
 			},
 			wantErr: false,
 		},
 		{
-			name: "CreateShortLink with invalid URL",
+			name: "UpdateLink leaving its campaign",
 			args: args{
-				ctx: context.TODO(),
-				url: "asdasd",
+				ctx:       context.TODO(),
+				request:   models.ShortLinkRequest{Url: "http://www.google.com", CampaignId: intPtr(0)},
+				shortCode: "abc123",
 			},
-			mockExpectations: func(t *testing.T) *dbMock.MockQuerier {
-				q := dbMock.NewMockQuerier(t)
-				// No se espera ninguna llamada a CreateURL
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				expectVersionClaim(q, 0)
+				q.EXPECT().UpdateURLCampaignByShortCode(mock.Anything, db.UpdateURLCampaignByShortCodeParams{
+					Shortcode: "abc123",
+				}).Return(nil)
+				q.EXPECT().UpdateURLByShortCode(mock.Anything, mock.Anything).Return(db.UpdateURLByShortCodeRow{
+					ID:        1,
+					Url:       "http://www.google.com",
+					Shortcode: "abc123",
+					Createdat: sql.NullTime{
+						Time:  time.Now(),
+						Valid: true,
+					},
+				}, nil)
+				q.EXPECT().ListTagsByURLID(mock.Anything, int64(1)).Return(nil, nil)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:        1,
+				Url:       "http://www.google.com",
+				ShortCode: "abc123",
+			},
+			wantErr: false,
+		},
+		{
+			name: "UpdateLink in a campaign",
+			args: args{
+				ctx:       context.TODO(),
+				request:   models.ShortLinkRequest{Url: "https://www.bing.com/?utm_source=ads"},
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				expectLinkCampaign(q, 3)
+				q.EXPECT().GetCampaignByID(mock.Anything, int64(3)).Return(springCampaign, nil)
+				expectVersionClaim(q, 0)
+				// No se espera ninguna llamada a UpdateURLCampaignByShortCode
+				q.EXPECT().UpdateURLByShortCode(mock.Anything, mock.MatchedBy(func(arg db.UpdateURLByShortCodeParams) bool {
+					return arg.Url == "https://www.bing.com/?utm_source=ads&utm_medium=email"
+				})).RunAndReturn(
+					func(ctx context.Context, arg db.UpdateURLByShortCodeParams) (db.UpdateURLByShortCodeRow, error) {
+						return db.UpdateURLByShortCodeRow{
+							ID:         1,
+							Url:        arg.Url,
+							Shortcode:  arg.Shortcode,
+							Createdat:  sql.NullTime{Time: time.Now(), Valid: true},
+							Campaignid: sql.NullInt64{Int64: 3, Valid: true},
+						}, nil
+					},
+				)
+				q.EXPECT().ListTagsByURLID(mock.Anything, int64(1)).Return(nil, nil)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:         1,
+				Url:        "https://www.bing.com/?utm_source=ads&utm_medium=email",
+				ShortCode:  "abc123",
+				CampaignId: 3,
+			},
+			wantErr: false,
+		},
+		{
+			name: "UpdateLink with unknown campaign",
+			args: args{
+				ctx:       context.TODO(),
+				request:   models.ShortLinkRequest{Url: "http://www.google.com", CampaignId: intPtr(9)},
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				expectVersionClaim(q, 0)
+				q.EXPECT().GetCampaignByID(mock.Anything, int64(9)).Return(db.Campaign{}, sql.ErrNoRows)
+				// No se espera ninguna llamada a UpdateURLByShortCode
 				return q
 			},
 			want:    nil,
 			wantErr: true,
+			errIs:   ErrUnknownCampaign,
 		},
 		{
-			name: "CreateShortLink with error",
+			name: "UpdateLink with too many tags",
 			args: args{
-				ctx: context.TODO(),
-				url: "http://www.google.com",
+				ctx:       context.TODO(),
+				request:   models.ShortLinkRequest{Url: "http://www.google.com", Tags: strings.Split("a,b,c,d,e,f,g,h,i,j,k,l,m,n,o,p,q,r,s,t,u", ",")},
+				shortCode: "abc123",
 			},
-			mockExpectations: func(t *testing.T) *dbMock.MockQuerier {
-				q := dbMock.NewMockQuerier(t)
-				q.EXPECT().CreateURL(mock.Anything, mock.Anything).Return(db.CreateURLRow{}, assert.AnError)
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				// No se espera ninguna llamada a UpdateURLByShortCode
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "UpdateLink with too long notes",
+			args: args{
+				ctx:       context.TODO(),
+				request:   models.ShortLinkRequest{Url: "http://www.google.com", Notes: strings.Repeat("a", utils.MaxNotesLength+1)},
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				// No se espera ninguna llamada a UpdateURLByShortCode
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "UpdateLink with too long password",
+			args: args{
+				ctx:       context.TODO(),
+				request:   models.ShortLinkRequest{Url: "http://www.google.com", Password: stringPtr(strings.Repeat("a", utils.MaxPasswordLength+1))},
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				// No se espera ninguna llamada a UpdateURLPasswordByShortCode
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "UpdateLink with nil createdAt date",
+			args: args{
+				ctx:       context.TODO(),
+				request:   models.ShortLinkRequest{Url: "http://www.google.com"},
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				expectLinkCampaign(q, 0)
+				expectVersionClaim(q, 0)
+				q.EXPECT().UpdateURLByShortCode(mock.Anything, mock.Anything).Return(db.UpdateURLByShortCodeRow{
+					ID:        1,
+					Url:       "http://www.google.com",
+					Shortcode: "abc123",
+					Createdat: sql.NullTime{},
+					Updatedat: sql.NullTime{
+						Time:  time.Now(),
+						Valid: true,
+					},
+				}, nil)
 				return q
 			},
 			want:    nil,
@@ -87,11 +2187,16 @@ func TestController_CreateShortLink(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			q := tt.mockExpectations(t)
+			r := recorderMock.NewMockRecorder(t)
 
-			c := NewController(q)
+			c := NewController(q, generator.NewRandom(), r, tt.options)
 
-			got, err := c.CreateShortLink(tt.args.ctx, tt.args.url)
-			assert.Equal(t, tt.wantErr, err != nil)
+			got, err := c.UpdateLink(tt.args.ctx, tt.args.request, tt.args.shortCode, tt.args.version)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs, "El error no es el esperado")
+			}
 
 			if err != nil {
 				assert.Nil(t, got, "El valor de got debe ser nulo cuando se espera un error")
@@ -100,254 +2205,360 @@ func TestController_CreateShortLink(t *testing.T) {
 
 			assert.Equal(t, tt.want.Id, got.Id, "Los valores de los campos Id no coinciden")
 			assert.Equal(t, tt.want.Url, got.Url, "Los valores de los campos Url no coinciden")
-			assert.NotEqual(t, got.ShortCode, "", "El campo ShortCode no debe ser vacío")
-			assert.Len(t, got.ShortCode, 6, "El campo ShortCode debe tener 6 caracteres")
+			assert.Equal(t, tt.want.ShortCode, got.ShortCode, "Los valores de los campos ShortCode no coinciden")
+			assert.Equal(t, tt.want.Protected, got.Protected, "Los valores de los campos Protected no coinciden")
+			assert.Equal(t, tt.want.Tags, got.Tags, "Los valores de los campos Tags no coinciden")
+			assert.Equal(t, tt.want.CampaignId, got.CampaignId, "Los valores de los campos CampaignId no coinciden")
+			assert.Equal(t, tt.want.Version, got.Version, "Los valores de los campos Version no coinciden")
 			assert.NotNil(t, got.CreatedAt, "El campo CreatedAt no debe ser nulo")
+			assert.NotNil(t, got.UpdatedAt, "El campo UpdatedAt no debe ser nulo")
 		})
 	}
 }
 
-func TestController_GetOriginalLink(t *testing.T) {
+// updatedURL returns the row UpdateURLByShortCode would store for arg.
+func updatedURL(ctx context.Context, arg db.UpdateURLByShortCodeParams) (db.UpdateURLByShortCodeRow, error) {
+	return db.UpdateURLByShortCodeRow{
+		ID:             1,
+		Url:            arg.Url,
+		Shortcode:      arg.Shortcode,
+		Createdat:      sql.NullTime{Time: time.Now(), Valid: true},
+		Updatedat:      arg.Updatedat,
+		Redirectstatus: arg.Redirectstatus,
+		Expiresat:      arg.Expiresat,
+		Notbefore:      arg.Notbefore,
+		Maxclicks:      arg.Maxclicks,
+		Title:          arg.Title,
+		Description:    arg.Description,
+		Notes:          arg.Notes,
+	}, nil
+}
+
+func TestController_PatchLink(t *testing.T) {
+	// current is the link before the patch: a 301 that expires, with a title.
+	current := db.GetURLByShortCodeRow{
+		ID:             1,
+		Url:            "http://www.google.com",
+		Shortcode:      "abc123",
+		Redirectstatus: http.StatusMovedPermanently,
+		Expiresat:      sql.NullTime{Time: future, Valid: true},
+		Title:          sql.NullString{String: "Spring sale", Valid: true},
+		Passwordhash:   sql.NullString{String: linkPasswordHash, Valid: true},
+		Createdat:      sql.NullTime{Time: past, Valid: true},
+		Version:        4,
+	}
+
 	type args struct {
 		ctx       context.Context
+		patch     string
 		shortCode string
+		version   int64
 	}
 	tests := []struct {
 		name             string
 		args             args
-		mockExpectations func(t *testing.T) *dbMock.MockQuerier
+		mockExpectations func(t *testing.T) *storeMock.MockStore
 		want             *models.ShortLinkResponse
 		wantErr          bool
+		errIs            error
 	}{
 		{
-			name: "GetOriginalLink_OK",
+			name: "PatchLink_OK",
+			args: args{
+				ctx:       context.TODO(),
+				patch:     `{"title":"Q3 webinar"}`,
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(current, nil)
+				expectVersionClaim(q, 4)
+				q.EXPECT().UpdateURLByShortCode(mock.Anything, mock.MatchedBy(func(arg db.UpdateURLByShortCodeParams) bool {
+					return arg.Url == "http://www.google.com/" &&
+						arg.Redirectstatus == http.StatusMovedPermanently &&
+						arg.Expiresat.Valid &&
+						arg.Title.String == "Q3 webinar"
+				})).RunAndReturn(updatedURL)
+				q.EXPECT().ListTagsByURLID(mock.Anything, int64(1)).Return([]string{"promo"}, nil)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:             1,
+				Url:            "http://www.google.com/",
+				ShortCode:      "abc123",
+				Title:          "Q3 webinar",
+				RedirectStatus: http.StatusMovedPermanently,
+				ExpiresAt:      &future,
+				Tags:           []string{"promo"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "PatchLink URL of a link in a campaign",
+			args: args{
+				ctx:       context.TODO(),
+				patch:     `{"url":"https://www.bing.com"}`,
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				inCampaign := current
+				inCampaign.Campaignid = sql.NullInt64{Int64: 3, Valid: true}
+
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(inCampaign, nil)
+				q.EXPECT().GetCampaignByID(mock.Anything, int64(3)).Return(springCampaign, nil)
+				expectVersionClaim(q, 4)
+				q.EXPECT().UpdateURLByShortCode(mock.Anything, mock.MatchedBy(func(arg db.UpdateURLByShortCodeParams) bool {
+					return arg.Url == "https://www.bing.com/?utm_medium=email&utm_source=newsletter"
+				})).RunAndReturn(updatedURL)
+				q.EXPECT().ListTagsByURLID(mock.Anything, int64(1)).Return(nil, nil)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:             1,
+				Url:            "https://www.bing.com/?utm_medium=email&utm_source=newsletter",
+				ShortCode:      "abc123",
+				Title:          "Spring sale",
+				RedirectStatus: http.StatusMovedPermanently,
+				ExpiresAt:      &future,
+			},
+			wantErr: false,
+		},
+		{
+			name: "PatchLink removing the expiry",
+			args: args{
+				ctx:       context.TODO(),
+				patch:     `{"expiresAt":null}`,
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(current, nil)
+				expectVersionClaim(q, 4)
+				q.EXPECT().UpdateURLByShortCode(mock.Anything, mock.MatchedBy(func(arg db.UpdateURLByShortCodeParams) bool {
+					return !arg.Expiresat.Valid && arg.Title.String == "Spring sale"
+				})).RunAndReturn(updatedURL)
+				q.EXPECT().ListTagsByURLID(mock.Anything, int64(1)).Return(nil, nil)
+				return q
+			},
+			want: &models.ShortLinkResponse{
+				Id:             1,
+				Url:            "http://www.google.com/",
+				ShortCode:      "abc123",
+				Title:          "Spring sale",
+				RedirectStatus: http.StatusMovedPermanently,
+			},
+			wantErr: false,
+		},
+		{
+			name: "PatchLink removing password and tags",
 			args: args{
 				ctx:       context.TODO(),
+				patch:     `{"password":null,"tags":null}`,
 				shortCode: "abc123",
 			},
-			mockExpectations: func(t *testing.T) *dbMock.MockQuerier {
-				q := dbMock.NewMockQuerier(t)
-				q.EXPECT().GetURLByShortCode(mock.Anything, mock.Anything).RunAndReturn(
-					func(ctx context.Context, shortCode string) (db.GetURLByShortCodeRow, error) {
-						return db.GetURLByShortCodeRow{
-							ID:        1,
-							Url:       "http://www.google.com",
-							Shortcode: shortCode,
-							Createdat: sql.NullTime{
-								Time:  time.Now(),
-								Valid: true,
-							},
-							Updatedat: sql.NullTime{
-								Time:  time.Now(),
-								Valid: true,
-							},
-						}, nil
-					},
-				)
-				q.EXPECT().IncrementURLAccessCountByShortCode(mock.Anything, mock.Anything).Return(nil)
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(current, nil)
+				expectVersionClaim(q, 4)
+				q.EXPECT().UpdateURLPasswordByShortCode(mock.Anything, db.UpdateURLPasswordByShortCodeParams{
+					Passwordhash: sql.NullString{},
+					Shortcode:    "abc123",
+				}).Return(nil)
+				q.EXPECT().UpdateURLByShortCode(mock.Anything, mock.Anything).RunAndReturn(updatedURL)
+				q.EXPECT().DeleteURLTagsByURLID(mock.Anything, int64(1)).Return(nil)
 				return q
 			},
 			want: &models.ShortLinkResponse{
-				Id:        1,
-				Url:       "http://www.google.com",
-				ShortCode: "abc123",
+				Id:             1,
+				Url:            "http://www.google.com/",
+				ShortCode:      "abc123",
+				Title:          "Spring sale",
+				RedirectStatus: http.StatusMovedPermanently,
+				ExpiresAt:      &future,
 			},
 			wantErr: false,
 		},
 		{
-			name: "GetOriginalLink with error",
+			name: "PatchLink removing the URL",
 			args: args{
 				ctx:       context.TODO(),
+				patch:     `{"url":null}`,
 				shortCode: "abc123",
 			},
-			mockExpectations: func(t *testing.T) *dbMock.MockQuerier {
-				q := dbMock.NewMockQuerier(t)
-				q.EXPECT().GetURLByShortCode(mock.Anything, mock.Anything).Return(db.GetURLByShortCodeRow{}, assert.AnError)
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(current, nil)
+				// No se espera ninguna llamada a UpdateURLByShortCode
 				return q
 			},
 			want:    nil,
 			wantErr: true,
+			errIs:   utils.ErrInvalidURL,
 		},
 		{
-			name: "GetOriginalLink with invalid date",
+			name: "PatchLink not an object",
 			args: args{
 				ctx:       context.TODO(),
+				patch:     `["title"]`,
 				shortCode: "abc123",
 			},
-			mockExpectations: func(t *testing.T) *dbMock.MockQuerier {
-				q := dbMock.NewMockQuerier(t)
-				q.EXPECT().GetURLByShortCode(mock.Anything, mock.Anything).Return(db.GetURLByShortCodeRow{
-					ID:        1,
-					Url:       "http://www.google.com",
-					Shortcode: "abc123",
-					Createdat: sql.NullTime{},
-				}, nil)
-				q.EXPECT().IncrementURLAccessCountByShortCode(mock.Anything, mock.Anything).Return(nil)
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(current, nil)
 				return q
 			},
 			want:    nil,
 			wantErr: true,
+			errIs:   utils.ErrInvalidPatch,
 		},
 		{
-			name: "GetOriginalLink with error incrementing access count",
+			name: "PatchLink with wrong type",
 			args: args{
 				ctx:       context.TODO(),
+				patch:     `{"maxClicks":"ten"}`,
 				shortCode: "abc123",
 			},
-			mockExpectations: func(t *testing.T) *dbMock.MockQuerier {
-				q := dbMock.NewMockQuerier(t)
-				q.EXPECT().GetURLByShortCode(mock.Anything, mock.Anything).Return(db.GetURLByShortCodeRow{}, nil)
-				q.EXPECT().IncrementURLAccessCountByShortCode(mock.Anything, mock.Anything).Return(assert.AnError)
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(current, nil)
 				return q
 			},
 			want:    nil,
 			wantErr: true,
+			errIs:   utils.ErrInvalidPatch,
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			q := tt.mockExpectations(t)
-
-			c := NewController(q)
-
-			got, err := c.GetOriginalLink(tt.args.ctx, tt.args.shortCode)
-			assert.Equal(t, tt.wantErr, err != nil, err)
-
-			if err != nil {
-				assert.Nil(t, got, "El valor de got debe ser nulo cuando se espera un error")
-				return
-			}
-
-			assert.Equal(t, tt.want.Id, got.Id, "Los valores de los campos Id no coinciden")
-			assert.Equal(t, tt.want.Url, got.Url, "Los valores de los campos Url no coinciden")
-			assert.Equal(t, tt.want.ShortCode, got.ShortCode, "Los valores de los campos ShortCode no coinciden")
-			assert.NotNil(t, got.CreatedAt, "El campo CreatedAt no debe ser nulo")
-		})
-	}
-}
-
-func TestController_UpdateLink(t *testing.T) {
-	type args struct {
-		ctx       context.Context
-		url       string
-		shortCode string
-	}
-	tests := []struct {
-		name             string
-		args             args
-		mockExpectations func(t *testing.T) *dbMock.MockQuerier
-		want             *models.ShortLinkResponse
-		wantErr          bool
-	}{
 		{
-			name: "UpdateLink_OK",
+			name: "PatchLink with alias",
 			args: args{
 				ctx:       context.TODO(),
-				url:       "http://www.google.com",
+				patch:     `{"alias":"promo","title":"Q3 webinar"}`,
 				shortCode: "abc123",
 			},
-			mockExpectations: func(t *testing.T) *dbMock.MockQuerier {
-				q := dbMock.NewMockQuerier(t)
-				q.EXPECT().UpdateURLByShortCode(mock.Anything, mock.Anything).RunAndReturn(
-					func(ctx context.Context, arg db.UpdateURLByShortCodeParams) (db.UpdateURLByShortCodeRow, error) {
-						return db.UpdateURLByShortCodeRow{
-							ID:        1,
-							Url:       arg.Url,
-							Shortcode: arg.Shortcode,
-							Createdat: sql.NullTime{
-								Time:  time.Now(),
-								Valid: true,
-							},
-							Updatedat: sql.NullTime{
-								Time:  time.Now(),
-								Valid: true,
-							},
-						}, nil
-					},
-				)
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(current, nil)
 				return q
 			},
-			want: &models.ShortLinkResponse{
-				Id:        1,
-				Url:       "http://www.google.com",
-				ShortCode: "abc123",
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrInvalidPatch,
+		},
+		{
+			name: "PatchLink with read-only member",
+			args: args{
+				ctx:       context.TODO(),
+				patch:     `{"accessCount":0}`,
+				shortCode: "abc123",
 			},
-			wantErr: false,
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(current, nil)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   utils.ErrInvalidPatch,
 		},
 		{
-			name: "UpdateLink with invalid URL",
+			name: "PatchLink with unknown member",
 			args: args{
 				ctx:       context.TODO(),
-				url:       "asdasd",
+				patch:     `{"tittle":"Q3 webinar"}`,
 				shortCode: "abc123",
 			},
-			mockExpectations: func(t *testing.T) *dbMock.MockQuerier {
-				q := dbMock.NewMockQuerier(t)
-				// No se espera ninguna llamada a UpdateURLByShortCode
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(current, nil)
 				return q
 			},
 			want:    nil,
 			wantErr: true,
+			errIs:   utils.ErrInvalidPatch,
 		},
 		{
-			name: "UpdateLink with error",
+			name: "PatchLink with stale version",
 			args: args{
 				ctx:       context.TODO(),
-				url:       "http://www.google.com",
+				patch:     `{"title":"Q3 webinar"}`,
 				shortCode: "abc123",
+				version:   3,
 			},
-			mockExpectations: func(t *testing.T) *dbMock.MockQuerier {
-				q := dbMock.NewMockQuerier(t)
-				q.EXPECT().UpdateURLByShortCode(mock.Anything, mock.Anything).Return(db.UpdateURLByShortCodeRow{}, assert.AnError)
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(current, nil)
+				// No se espera ninguna llamada a UpdateURLByShortCode
 				return q
 			},
 			want:    nil,
 			wantErr: true,
+			errIs:   ErrVersionMismatch,
 		},
 		{
-			name: "UpdateLink with nil createdAt date",
+			name: "PatchLink changed while patching",
 			args: args{
 				ctx:       context.TODO(),
-				url:       "http://www.google.com",
+				patch:     `{"title":"Q3 webinar"}`,
 				shortCode: "abc123",
+				version:   4,
 			},
-			mockExpectations: func(t *testing.T) *dbMock.MockQuerier {
-				q := dbMock.NewMockQuerier(t)
-				q.EXPECT().UpdateURLByShortCode(mock.Anything, mock.Anything).Return(db.UpdateURLByShortCodeRow{
-					ID:        1,
-					Url:       "http://www.google.com",
-					Shortcode: "abc123",
-					Createdat: sql.NullTime{},
-					Updatedat: sql.NullTime{
-						Time:  time.Now(),
-						Valid: true,
-					},
-				}, nil)
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(current, nil).Once()
+				runInTx(q)
+				q.EXPECT().BumpURLVersionByShortCode(mock.Anything, db.BumpURLVersionByShortCodeParams{
+					ShortCode: "abc123",
+					Version:   sql.NullInt64{Int64: 4, Valid: true},
+				}).Return(0, nil)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(db.GetURLByShortCodeRow{Version: 5}, nil).Once()
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   ErrVersionMismatch,
+		},
+		{
+			name: "PatchLink not found",
+			args: args{
+				ctx:       context.TODO(),
+				patch:     `{"title":"Q3 webinar"}`,
+				shortCode: "nope00",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "nope00").Return(db.GetURLByShortCodeRow{}, sql.ErrNoRows)
 				return q
 			},
 			want:    nil,
 			wantErr: true,
+			errIs:   ErrLinkNotFound,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			q := tt.mockExpectations(t)
+			r := recorderMock.NewMockRecorder(t)
 
-			c := NewController(q)
+			c := NewController(q, generator.NewRandom(), r, Options{})
 
-			got, err := c.UpdateLink(tt.args.ctx, tt.args.url, tt.args.shortCode)
+			got, err := c.PatchLink(tt.args.ctx, []byte(tt.args.patch), tt.args.shortCode, tt.args.version)
 			assert.Equal(t, tt.wantErr, err != nil, err)
 
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs, "El error no es el esperado")
+			}
+
 			if err != nil {
 				assert.Nil(t, got, "El valor de got debe ser nulo cuando se espera un error")
 				return
 			}
 
-			assert.Equal(t, tt.want.Id, got.Id, "Los valores de los campos Id no coinciden")
 			assert.Equal(t, tt.want.Url, got.Url, "Los valores de los campos Url no coinciden")
 			assert.Equal(t, tt.want.ShortCode, got.ShortCode, "Los valores de los campos ShortCode no coinciden")
-			assert.NotNil(t, got.CreatedAt, "El campo CreatedAt no debe ser nulo")
-			assert.NotNil(t, got.UpdatedAt, "El campo UpdatedAt no debe ser nulo")
+			assert.Equal(t, tt.want.Title, got.Title, "Los valores de los campos Title no coinciden")
+			assert.Equal(t, tt.want.RedirectStatus, got.RedirectStatus, "Los valores de los campos RedirectStatus no coinciden")
+			assert.Equal(t, tt.want.ExpiresAt == nil, got.ExpiresAt == nil, "Los valores de los campos ExpiresAt no coinciden")
+			assert.Equal(t, tt.want.Tags, got.Tags, "Los valores de los campos Tags no coinciden")
 		})
 	}
 }
@@ -356,13 +2567,15 @@ func TestController_GetStatShortLink(t *testing.T) {
 	type args struct {
 		ctx       context.Context
 		shortCode string
+		password  string
 	}
 	tests := []struct {
 		name             string
-		mockExpectations func(t *testing.T) *dbMock.MockQuerier
+		mockExpectations func(t *testing.T) *storeMock.MockStore
 		args             args
 		want             *models.StatShortLinkResponse
 		wantErr          bool
+		errIs            error
 	}{
 		{
 			name: "GetStatShortLink_OK",
@@ -370,8 +2583,8 @@ func TestController_GetStatShortLink(t *testing.T) {
 				ctx:       context.TODO(),
 				shortCode: "abc123",
 			},
-			mockExpectations: func(t *testing.T) *dbMock.MockQuerier {
-				q := dbMock.NewMockQuerier(t)
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
 				q.EXPECT().GetURLStatsByShortCode(mock.Anything, mock.Anything).RunAndReturn(
 					func(ctx context.Context, shortCode string) (db.Url, error) {
 						return db.Url{
@@ -390,41 +2603,168 @@ func TestController_GetStatShortLink(t *testing.T) {
 								Int64: 10,
 								Valid: true,
 							},
+							Botcount: 4,
 						}, nil
 					},
 				)
+				q.EXPECT().ListVisitorSketchByURLID(mock.Anything, int64(1)).Return([]db.ListVisitorSketchByURLIDRow{
+					{Register: 12, Rank: 1},
+					{Register: 345, Rank: 3},
+					{Register: 6789, Rank: 2},
+				}, nil)
+				q.EXPECT().CountUniqueVisitorsByURLID(mock.Anything, mock.Anything).RunAndReturn(
+					func(ctx context.Context, arg db.CountUniqueVisitorsByURLIDParams) (int64, error) {
+						assert.Equal(t, int64(1), arg.UrlID, "Los valores de los campos UrlID no coinciden")
+						assert.Equal(t, 24*time.Hour, arg.ToTime.Sub(arg.FromTime), "El rango debe cubrir el día actual")
+						return 2, nil
+					},
+				)
+				q.EXPECT().ListTagsByURLID(mock.Anything, int64(1)).Return([]string{"promo"}, nil)
+				return q
+			},
+			want: &models.StatShortLinkResponse{
+				Id:                  1,
+				Url:                 "http://www.google.com",
+				ShortCode:           "abc123",
+				Tags:                []string{"promo"},
+				AccessCount:         10,
+				BotCount:            4,
+				UniqueVisitors:      3,
+				UniqueVisitorsToday: 2,
+			},
+			wantErr: false,
+		},
+		{
+			name: "GetStatShortLink on protected link",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLStatsByShortCode(mock.Anything, "abc123").Return(db.Url{
+					ID:           1,
+					Url:          "http://www.google.com",
+					Shortcode:    "abc123",
+					Createdat:    sql.NullTime{Time: time.Now(), Valid: true},
+					Passwordhash: sql.NullString{String: linkPasswordHash, Valid: true},
+				}, nil)
+				q.EXPECT().ListVisitorSketchByURLID(mock.Anything, int64(1)).Return(nil, nil)
+				q.EXPECT().CountUniqueVisitorsByURLID(mock.Anything, mock.Anything).Return(0, nil)
+				q.EXPECT().ListTagsByURLID(mock.Anything, int64(1)).Return(nil, nil)
+				return q
+			},
+			want: &models.StatShortLinkResponse{
+				Id:        1,
+				ShortCode: "abc123",
+			},
+			wantErr: false,
+		},
+		{
+			name: "GetStatShortLink on protected link with password",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+				password:  linkPassword,
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLStatsByShortCode(mock.Anything, "abc123").Return(db.Url{
+					ID:           1,
+					Url:          "http://www.google.com",
+					Shortcode:    "abc123",
+					Createdat:    sql.NullTime{Time: time.Now(), Valid: true},
+					Passwordhash: sql.NullString{String: linkPasswordHash, Valid: true},
+				}, nil)
+				q.EXPECT().ListVisitorSketchByURLID(mock.Anything, int64(1)).Return(nil, nil)
+				q.EXPECT().CountUniqueVisitorsByURLID(mock.Anything, mock.Anything).Return(0, nil)
+				q.EXPECT().ListTagsByURLID(mock.Anything, int64(1)).Return(nil, nil)
 				return q
 			},
 			want: &models.StatShortLinkResponse{
-				Id:          1,
-				Url:         "http://www.google.com",
-				ShortCode:   "abc123",
-				AccessCount: 10,
+				Id:        1,
+				Url:       "http://www.google.com",
+				ShortCode: "abc123",
 			},
 			wantErr: false,
 		},
+		{
+			name: "GetStatShortLink on protected link with wrong password",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+				password:  "wrong",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLStatsByShortCode(mock.Anything, "abc123").Return(db.Url{
+					ID:           1,
+					Url:          "http://www.google.com",
+					Shortcode:    "abc123",
+					Createdat:    sql.NullTime{Time: time.Now(), Valid: true},
+					Passwordhash: sql.NullString{String: linkPasswordHash, Valid: true},
+				}, nil)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   ErrWrongPassword,
+		},
 		{
 			name: "GetStatShortLink with error",
 			args: args{
 				ctx:       context.TODO(),
 				shortCode: "abc123",
 			},
-			mockExpectations: func(t *testing.T) *dbMock.MockQuerier {
-				q := dbMock.NewMockQuerier(t)
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
 				q.EXPECT().GetURLStatsByShortCode(mock.Anything, mock.Anything).Return(db.Url{}, assert.AnError)
 				return q
 			},
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "GetStatShortLink not found",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLStatsByShortCode(mock.Anything, "abc123").Return(db.Url{}, sql.ErrNoRows)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+			errIs:   ErrLinkNotFound,
+		},
+		{
+			name: "GetStatShortLink with error counting visitors",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().GetURLStatsByShortCode(mock.Anything, mock.Anything).Return(db.Url{
+					ID:        1,
+					Createdat: sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil)
+				q.EXPECT().ListVisitorSketchByURLID(mock.Anything, int64(1)).Return(nil, assert.AnError)
+				return q
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "GetStatShortLink with invalid date",
 			args: args{
 				ctx:       context.TODO(),
 				shortCode: "abc123",
 			},
-			mockExpectations: func(t *testing.T) *dbMock.MockQuerier {
-				q := dbMock.NewMockQuerier(t)
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
 				q.EXPECT().GetURLStatsByShortCode(mock.Anything, mock.Anything).RunAndReturn(
 					func(ctx context.Context, shortCode string) (db.Url, error) {
 						return db.Url{
@@ -448,12 +2788,17 @@ func TestController_GetStatShortLink(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			q := tt.mockExpectations(t)
+			r := recorderMock.NewMockRecorder(t)
 
-			c := NewController(q)
+			c := NewController(q, generator.NewRandom(), r, Options{})
 
-			got, err := c.GetStatShortLink(tt.args.ctx, tt.args.shortCode)
+			got, err := c.GetStatShortLink(tt.args.ctx, tt.args.shortCode, tt.args.password)
 			assert.Equal(t, tt.wantErr, err != nil, err)
 
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs, "El error no es el esperado")
+			}
+
 			if err != nil {
 				assert.Nil(t, got, "El valor de got debe ser nulo cuando se espera un error")
 				return
@@ -463,6 +2808,11 @@ func TestController_GetStatShortLink(t *testing.T) {
 			assert.Equal(t, tt.want.Url, got.Url, "Los valores de los campos Url no coinciden")
 			assert.Equal(t, tt.want.ShortCode, got.ShortCode, "Los valores de los campos ShortCode no coinciden")
 			assert.Equal(t, tt.want.AccessCount, got.AccessCount, "Los valores de los campos AccessCount no coinciden")
+			assert.Equal(t, tt.want.BotCount, got.BotCount, "Los valores de los campos BotCount no coinciden")
+			assert.Equal(t, tt.want.UniqueVisitors, got.UniqueVisitors, "Los valores de los campos UniqueVisitors no coinciden")
+			assert.Equal(t, tt.want.UniqueVisitorsToday, got.UniqueVisitorsToday, "Los valores de los campos UniqueVisitorsToday no coinciden")
+			assert.Equal(t, tt.want.Tags, got.Tags, "Los valores de los campos Tags no coinciden")
+			assert.Equal(t, tt.want.CampaignId, got.CampaignId, "Los valores de los campos CampaignId no coinciden")
 			assert.NotNil(t, got.CreatedAt, "El campo CreatedAt no debe ser nulo")
 		})
 	}
@@ -472,12 +2822,14 @@ func TestController_DeleteShortLink(t *testing.T) {
 	type args struct {
 		ctx       context.Context
 		shortCode string
+		version   int64
 	}
 	tests := []struct {
 		name             string
 		args             args
-		mockExpectations func(t *testing.T) *dbMock.MockQuerier
+		mockExpectations func(t *testing.T) *storeMock.MockStore
 		wantErr          bool
+		errIs            error
 	}{
 		{
 			name: "DeleteShortLink_OK",
@@ -485,10 +2837,25 @@ func TestController_DeleteShortLink(t *testing.T) {
 				ctx:       context.TODO(),
 				shortCode: "abc123",
 			},
-			mockExpectations: func(t *testing.T) *dbMock.MockQuerier {
-				q := dbMock.NewMockQuerier(t)
-				q.EXPECT().GetURLStatsByShortCode(mock.Anything, mock.Anything).Return(db.Url{}, nil)
-				q.EXPECT().DeleteURLByShortCode(mock.Anything, mock.Anything).Return(nil)
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				expectVersionClaim(q, 0)
+				q.EXPECT().DeleteURLByShortCode(mock.Anything, "abc123").Return(nil)
+				return q
+			},
+			wantErr: false,
+		},
+		{
+			name: "DeleteShortLink at its version",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+				version:   2,
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				expectVersionClaim(q, 2)
+				q.EXPECT().DeleteURLByShortCode(mock.Anything, "abc123").Return(nil)
 				return q
 			},
 			wantErr: false,
@@ -499,12 +2866,47 @@ func TestController_DeleteShortLink(t *testing.T) {
 				ctx:       context.TODO(),
 				shortCode: "abc123",
 			},
-			mockExpectations: func(t *testing.T) *dbMock.MockQuerier {
-				q := dbMock.NewMockQuerier(t)
-				q.EXPECT().GetURLStatsByShortCode(mock.Anything, mock.Anything).Return(db.Url{}, assert.AnError)
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				runInTx(q)
+				q.EXPECT().BumpURLVersionByShortCode(mock.Anything, mock.Anything).Return(0, assert.AnError)
+				return q
+			},
+			wantErr: true,
+		},
+		{
+			name: "DeleteShortLink not found",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				runInTx(q)
+				q.EXPECT().BumpURLVersionByShortCode(mock.Anything, mock.Anything).Return(0, nil)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(db.GetURLByShortCodeRow{}, sql.ErrNoRows)
+				return q
+			},
+			wantErr: true,
+			errIs:   ErrLinkNotFound,
+		},
+		{
+			name: "DeleteShortLink with stale version",
+			args: args{
+				ctx:       context.TODO(),
+				shortCode: "abc123",
+				version:   2,
+			},
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				runInTx(q)
+				q.EXPECT().BumpURLVersionByShortCode(mock.Anything, mock.Anything).Return(0, nil)
+				q.EXPECT().GetURLByShortCode(mock.Anything, "abc123").Return(db.GetURLByShortCodeRow{Version: 3}, nil)
+				// No se espera ninguna llamada a DeleteURLByShortCode
 				return q
 			},
 			wantErr: true,
+			errIs:   ErrVersionMismatch,
 		},
 		{
 			name: "DeleteShortLink with error deleting",
@@ -512,9 +2914,9 @@ func TestController_DeleteShortLink(t *testing.T) {
 				ctx:       context.TODO(),
 				shortCode: "abc123",
 			},
-			mockExpectations: func(t *testing.T) *dbMock.MockQuerier {
-				q := dbMock.NewMockQuerier(t)
-				q.EXPECT().GetURLStatsByShortCode(mock.Anything, mock.Anything).Return(db.Url{}, nil)
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				expectVersionClaim(q, 0)
 				q.EXPECT().DeleteURLByShortCode(mock.Anything, mock.Anything).Return(assert.AnError)
 				return q
 			},
@@ -524,11 +2926,79 @@ func TestController_DeleteShortLink(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			q := tt.mockExpectations(t)
+			r := recorderMock.NewMockRecorder(t)
+
+			c := NewController(q, generator.NewRandom(), r, Options{})
+
+			err := c.DeleteShortLink(tt.args.ctx, tt.args.shortCode, tt.args.version)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs, "El error no es el esperado")
+			}
+		})
+	}
+}
+
+func TestController_NormalizeLinks(t *testing.T) {
+	tests := []struct {
+		name             string
+		mockExpectations func(t *testing.T) *storeMock.MockStore
+		want             int
+		wantErr          bool
+	}{
+		{
+			name: "NormalizeLinks_OK",
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().ListURLsAfterID(mock.Anything, db.ListURLsAfterIDParams{AfterID: 0, RowLimit: exportPageSize}).Return([]db.Url{
+					{ID: 1, Url: "https://www.google.com/"},
+					{ID: 2, Url: "HTTPS://Bücher.de:443/a/../b"},
+					{ID: 3, Url: "not a url"},
+				}, nil)
+				q.EXPECT().NormalizeURLByID(mock.Anything, db.NormalizeURLByIDParams{
+					Url:    "https://xn--bcher-kva.de/b",
+					Domain: sql.NullString{String: "xn--bcher-kva.de", Valid: true},
+					ID:     2,
+				}).Return(nil)
+				q.EXPECT().ListURLsAfterID(mock.Anything, db.ListURLsAfterIDParams{AfterID: 3, RowLimit: exportPageSize}).Return([]db.Url{
+					{ID: 4, Url: "http://www.google.com:80"},
+				}, nil)
+				q.EXPECT().NormalizeURLByID(mock.Anything, db.NormalizeURLByIDParams{
+					Url:    "http://www.google.com/",
+					Domain: sql.NullString{String: "google.com", Valid: true},
+					ID:     4,
+				}).Return(nil)
+				q.EXPECT().ListURLsAfterID(mock.Anything, db.ListURLsAfterIDParams{AfterID: 4, RowLimit: exportPageSize}).Return(nil, nil)
+				return q
+			},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name: "NormalizeLinks with error",
+			mockExpectations: func(t *testing.T) *storeMock.MockStore {
+				q := storeMock.NewMockStore(t)
+				q.EXPECT().ListURLsAfterID(mock.Anything, mock.Anything).Return([]db.Url{
+					{ID: 1, Url: "http://www.google.com:80"},
+				}, nil)
+				q.EXPECT().NormalizeURLByID(mock.Anything, mock.Anything).Return(assert.AnError)
+				return q
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.mockExpectations(t)
+			r := recorderMock.NewMockRecorder(t)
 
-			c := NewController(q)
+			c := NewController(q, generator.NewRandom(), r, Options{})
 
-			err := c.DeleteShortLink(tt.args.ctx, tt.args.shortCode)
+			got, err := c.NormalizeLinks(context.TODO())
 			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.want, got, "El número de links normalizados no coincide")
 		})
 	}
 }
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/visitor"
+)
+
+// saltCache keeps the salt of the current day so visits only read it from
+// the database once a day.
+type saltCache struct {
+	mu   sync.Mutex
+	day  string
+	salt []byte
+}
+
+// visitorSalt returns the salt of the day of now, creating it on the first
+// visit of the day. The salt is stored so every instance and restart hashes
+// a visitor the same way; older salts are deleted so past hashes can no
+// longer be recomputed.
+func (c *Controller) visitorSalt(ctx context.Context, now time.Time) ([]byte, error) {
+	day := visitor.Day(now)
+
+	c.salts.mu.Lock()
+	defer c.salts.mu.Unlock()
+
+	if c.salts.day == day {
+		return c.salts.salt, nil
+	}
+
+	salt, err := visitor.NewSalt()
+	if err != nil {
+		return nil, err
+	}
+
+	// Another instance may have created the salt first; reading it back
+	// makes everyone use the same one.
+	err = c.queries.CreateVisitorSalt(ctx, db.CreateVisitorSaltParams{Day: day, Salt: salt})
+	if err != nil {
+		return nil, err
+	}
+
+	salt, err = c.queries.GetVisitorSalt(ctx, day)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.queries.DeleteVisitorSaltsBefore(ctx, day); err != nil {
+		return nil, err
+	}
+
+	c.salts.day, c.salts.salt = day, salt
+	return salt, nil
+}
+
+// uniqueVisitors returns the lifetime unique visitors of a link and the
+// unique visitors of the current UTC day.
+func (c *Controller) uniqueVisitors(ctx context.Context, urlID int64, now time.Time) (lifetime, today uint, err error) {
+	sketch, err := c.queries.ListVisitorSketchByURLID(ctx, urlID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	ranks := make(map[int64]int64, len(sketch))
+	for _, register := range sketch {
+		ranks[register.Register] = register.Rank
+	}
+
+	dayStart := now.UTC().Truncate(24 * time.Hour)
+	visitors, err := c.queries.CountUniqueVisitorsByURLID(ctx, db.CountUniqueVisitorsByURLIDParams{
+		UrlID:    urlID,
+		FromTime: dayStart,
+		ToTime:   dayStart.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return visitor.Estimate(ranks), uint(visitors), nil
+}
@@ -8,7 +8,7 @@ import (
 )
 
 func InitDB(ctx context.Context, path string) (*sql.DB, error) {
-	db, err := sql.Open("sqlite3", "./urls.db")
+	db, err := sql.Open("sqlite3", "./urls.db?_foreign_keys=on")
 	if err != nil {
 		return nil, err
 	}
@@ -2,8 +2,18 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
 	_ "github.com/mattn/go-sqlite3"
 )
 
@@ -19,3 +29,966 @@ func TestInitDB(t *testing.T) {
 		t.Errorf("cannot ping db: %v", err)
 	}
 }
+
+func TestIsUniqueViolation(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("cannot open db: %v", err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE urls (shortCode TEXT NOT NULL UNIQUE)`)
+	if err != nil {
+		t.Fatalf("cannot create table: %v", err)
+	}
+
+	_, err = db.Exec(`INSERT INTO urls (shortCode) VALUES ('abc123')`)
+	if IsUniqueViolation(err) {
+		t.Errorf("first insert must not be a unique violation: %v", err)
+	}
+
+	_, err = db.Exec(`INSERT INTO urls (shortCode) VALUES ('abc123')`)
+	if !IsUniqueViolation(err) {
+		t.Errorf("duplicated insert must be a unique violation: %v", err)
+	}
+
+	if IsUniqueViolation(errors.New("other error")) {
+		t.Errorf("unrelated errors must not be unique violations")
+	}
+}
+
+// migrate applies the Up section of every migration, as goose would.
+func migrate(t *testing.T, conn *sql.DB) {
+	files, err := filepath.Glob("migrations/*.sql")
+	if err != nil {
+		t.Fatalf("cannot list migrations: %v", err)
+	}
+
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("cannot read migration %s: %v", file, err)
+		}
+
+		up, _, _ := strings.Cut(string(content), "-- +goose Down")
+		if _, err := conn.Exec(up); err != nil {
+			t.Fatalf("cannot apply migration %s: %v", file, err)
+		}
+	}
+}
+
+func TestSQLStore_ExecTx(t *testing.T) {
+	conn, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("cannot open db: %v", err)
+	}
+	defer conn.Close()
+	// Every connection to :memory: is a different database.
+	conn.SetMaxOpenConns(1)
+
+	migrate(t, conn)
+
+	_, err = conn.Exec(`INSERT INTO urls (url, shortCode) VALUES ('https://www.google.com', 'abc123')`)
+	if err != nil {
+		t.Fatalf("cannot insert url: %v", err)
+	}
+
+	store := NewStore(conn)
+	click := db.CreateClickParams{Urlid: 1, Clickedat: time.Now()}
+
+	err = store.ExecTx(context.TODO(), func(q db.Querier) error {
+		if err := q.CreateClick(context.TODO(), click); err != nil {
+			return err
+		}
+		return errors.New("abort")
+	})
+	if err == nil || err.Error() != "abort" {
+		t.Errorf("ExecTx must return the error of fn, got: %v", err)
+	}
+
+	err = store.ExecTx(context.TODO(), func(q db.Querier) error {
+		return q.CreateClick(context.TODO(), click)
+	})
+	if err != nil {
+		t.Errorf("cannot commit transaction: %v", err)
+	}
+
+	var count int
+	if err := conn.QueryRow(`SELECT COUNT(*) FROM clicks`).Scan(&count); err != nil {
+		t.Fatalf("cannot count clicks: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("only the committed click must be stored, got %d", count)
+	}
+}
+
+func TestQueries_ClickStats(t *testing.T) {
+	conn, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("cannot open db: %v", err)
+	}
+	defer conn.Close()
+	conn.SetMaxOpenConns(1)
+
+	migrate(t, conn)
+
+	q := db.New(conn)
+	ctx := context.TODO()
+
+	link, err := q.CreateURL(ctx, db.CreateURLParams{Url: "https://www.google.com", Shortcode: "abc123", Redirectstatus: 302})
+	if err != nil {
+		t.Fatalf("cannot create url: %v", err)
+	}
+
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	clicks := []db.CreateClickParams{
+		{Clickedat: day.Add(-time.Nanosecond), Referrerdomain: sql.NullString{String: "t.co", Valid: true}},
+		{Clickedat: day, Referrerdomain: sql.NullString{String: "t.co", Valid: true}},
+		{Clickedat: day.Add(90 * time.Minute), Referrerdomain: sql.NullString{String: "t.co", Valid: true}},
+		{Clickedat: day.Add(2 * time.Hour)},
+		{Clickedat: day.Add(24 * time.Hour), Referrerdomain: sql.NullString{String: "google.com", Valid: true}},
+	}
+	for _, click := range clicks {
+		click.Urlid = link.ID
+		if err := q.CreateClick(ctx, click); err != nil {
+			t.Fatalf("cannot create click: %v", err)
+		}
+	}
+
+	count, err := q.CountClicksByURLID(ctx, db.CountClicksByURLIDParams{
+		UrlID:    link.ID,
+		FromTime: day,
+		ToTime:   day.Add(24 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("cannot count clicks: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("the range must include from and exclude to, got %d clicks", count)
+	}
+
+	referrers, err := q.ListTopReferrersByURLID(ctx, db.ListTopReferrersByURLIDParams{
+		UrlID:    link.ID,
+		FromTime: day,
+		ToTime:   day.Add(48 * time.Hour),
+		RowLimit: 2,
+	})
+	if err != nil {
+		t.Fatalf("cannot list referrers: %v", err)
+	}
+
+	want := []db.ListTopReferrersByURLIDRow{
+		{Value: "t.co", Clicks: 2},
+		{Value: "(direct)", Clicks: 1},
+	}
+	if len(referrers) != len(want) {
+		t.Fatalf("expected %d referrers, got %v", len(want), referrers)
+	}
+	for i := range want {
+		if referrers[i] != want[i] {
+			t.Errorf("referrer %d: expected %v, got %v", i, want[i], referrers[i])
+		}
+	}
+}
+
+func TestQueries_CountClicksByBucket(t *testing.T) {
+	conn, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("cannot open db: %v", err)
+	}
+	defer conn.Close()
+	conn.SetMaxOpenConns(1)
+
+	migrate(t, conn)
+
+	q := db.New(conn)
+	ctx := context.TODO()
+
+	link, err := q.CreateURL(ctx, db.CreateURLParams{Url: "https://www.google.com", Shortcode: "abc123", Redirectstatus: 302})
+	if err != nil {
+		t.Fatalf("cannot create url: %v", err)
+	}
+	other, err := q.CreateURL(ctx, db.CreateURLParams{Url: "https://www.bing.com", Shortcode: "xyz789", Redirectstatus: 302})
+	if err != nil {
+		t.Fatalf("cannot create url: %v", err)
+	}
+
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	clicks := []db.CreateClickParams{
+		{Urlid: link.ID, Clickedat: day.Add(-time.Nanosecond), Visitorhash: sql.NullString{String: "a", Valid: true}},
+		{Urlid: link.ID, Clickedat: day, Visitorhash: sql.NullString{String: "a", Valid: true}},
+		{Urlid: link.ID, Clickedat: day.Add(90*time.Minute + 500*time.Millisecond), Visitorhash: sql.NullString{String: "a", Valid: true}},
+		{Urlid: link.ID, Clickedat: day.Add(2 * time.Hour), Visitorhash: sql.NullString{String: "b", Valid: true}},
+		{Urlid: link.ID, Clickedat: day.Add(3 * time.Hour)},
+		{Urlid: link.ID, Clickedat: day.Add(4 * time.Hour), Visitorhash: sql.NullString{String: "c", Valid: true}},
+		{Urlid: other.ID, Clickedat: day, Visitorhash: sql.NullString{String: "d", Valid: true}},
+	}
+	for _, click := range clicks {
+		if err := q.CreateClick(ctx, click); err != nil {
+			t.Fatalf("cannot create click: %v", err)
+		}
+	}
+
+	rows, err := q.CountClicksByBucket(ctx, db.CountClicksByBucketParams{
+		Bounds: `["2025-03-01 00:00:00+00:00","2025-03-01 02:00:00+00:00","2025-03-01 03:00:00+00:00","2025-03-01 04:00:00+00:00"]`,
+		UrlID:  link.ID,
+	})
+	if err != nil {
+		t.Fatalf("cannot count clicks: %v", err)
+	}
+
+	want := []db.CountClicksByBucketRow{
+		{Bucket: 0, Clicks: 2, Visitors: 1},
+		{Bucket: 1, Clicks: 1, Visitors: 1},
+		{Bucket: 2, Clicks: 1, Visitors: 0},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("expected %d buckets, got %v", len(want), rows)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("bucket %d: expected %v, got %v", i, want[i], rows[i])
+		}
+	}
+}
+
+func TestQueries_UniqueVisitors(t *testing.T) {
+	conn, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("cannot open db: %v", err)
+	}
+	defer conn.Close()
+	conn.SetMaxOpenConns(1)
+
+	migrate(t, conn)
+
+	q := db.New(conn)
+	ctx := context.TODO()
+
+	link, err := q.CreateURL(ctx, db.CreateURLParams{Url: "https://www.google.com", Shortcode: "abc123", Redirectstatus: 302})
+	if err != nil {
+		t.Fatalf("cannot create url: %v", err)
+	}
+
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	for _, hash := range []string{"a", "a", "b", ""} {
+		err := q.CreateClick(ctx, db.CreateClickParams{
+			Urlid:       link.ID,
+			Clickedat:   day,
+			Visitorhash: sql.NullString{String: hash, Valid: hash != ""},
+		})
+		if err != nil {
+			t.Fatalf("cannot create click: %v", err)
+		}
+	}
+
+	visitors, err := q.CountUniqueVisitorsByURLID(ctx, db.CountUniqueVisitorsByURLIDParams{
+		UrlID:    link.ID,
+		FromTime: day,
+		ToTime:   day.Add(24 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("cannot count visitors: %v", err)
+	}
+	if visitors != 2 {
+		t.Errorf("expected 2 unique visitors, got %d", visitors)
+	}
+
+	for _, rank := range []int64{3, 5, 2} {
+		err := q.UpsertVisitorSketch(ctx, db.UpsertVisitorSketchParams{Urlid: link.ID, Register: 7, Rank: rank})
+		if err != nil {
+			t.Fatalf("cannot update sketch: %v", err)
+		}
+	}
+
+	sketch, err := q.ListVisitorSketchByURLID(ctx, link.ID)
+	if err != nil {
+		t.Fatalf("cannot list sketch: %v", err)
+	}
+	if len(sketch) != 1 || sketch[0] != (db.ListVisitorSketchByURLIDRow{Register: 7, Rank: 5}) {
+		t.Errorf("a register must keep its highest rank, got %v", sketch)
+	}
+
+	if err := q.CreateVisitorSalt(ctx, db.CreateVisitorSaltParams{Day: "2025-03-01", Salt: []byte("first")}); err != nil {
+		t.Fatalf("cannot create salt: %v", err)
+	}
+	if err := q.CreateVisitorSalt(ctx, db.CreateVisitorSaltParams{Day: "2025-03-01", Salt: []byte("second")}); err != nil {
+		t.Fatalf("cannot create salt twice: %v", err)
+	}
+	salt, err := q.GetVisitorSalt(ctx, "2025-03-01")
+	if err != nil {
+		t.Fatalf("cannot get salt: %v", err)
+	}
+	if string(salt) != "first" {
+		t.Errorf("the first salt of the day must be kept, got %q", salt)
+	}
+}
+
+func TestQueries_ListURLs(t *testing.T) {
+	conn, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("cannot open db: %v", err)
+	}
+	defer conn.Close()
+	conn.SetMaxOpenConns(1)
+
+	migrate(t, conn)
+
+	q := db.New(conn)
+	ctx := context.TODO()
+
+	links := []db.CreateURLParams{
+		{Url: "https://example.com/a", Shortcode: "first", Domain: sql.NullString{String: "example.com", Valid: true}},
+		{Url: "https://example.com/100%25", Shortcode: "second", Domain: sql.NullString{String: "example.com", Valid: true}},
+		{Url: "https://other.org/b", Shortcode: "third", Domain: sql.NullString{String: "other.org", Valid: true}, Title: sql.NullString{String: "Q3 Webinar", Valid: true}},
+	}
+	ids := make([]int64, len(links))
+	for i, link := range links {
+		link.Redirectstatus = 302
+		created, err := q.CreateURL(ctx, link)
+		if err != nil {
+			t.Fatalf("cannot create url: %v", err)
+		}
+		ids[i] = created.ID
+	}
+
+	if err := q.AddURLCountsByID(ctx, db.AddURLCountsByIDParams{Clicks: 5, ID: ids[0]}); err != nil {
+		t.Fatalf("cannot add counts: %v", err)
+	}
+
+	// updatedAt is written by Go, with an offset, while createdAt keeps
+	// SQLite's own format; both must sort together. Read as text, this
+	// update would look older than the links created before it.
+	newYork := time.FixedZone("EST", -5*3600)
+	_, err = q.UpdateURLByShortCode(ctx, db.UpdateURLByShortCodeParams{
+		Url:            "https://example.com/a",
+		Redirectstatus: 302,
+		Updatedat:      sql.NullTime{Time: time.Now().In(newYork).Add(time.Hour), Valid: true},
+		Domain:         sql.NullString{String: "example.com", Valid: true},
+		Shortcode:      "first",
+	})
+	if err != nil {
+		t.Fatalf("cannot update url: %v", err)
+	}
+
+	shortCodes := func(urls []db.Url) []string {
+		codes := make([]string, len(urls))
+		for i, url := range urls {
+			codes[i] = url.Shortcode
+		}
+		return codes
+	}
+	assertCodes := func(name string, got []db.Url, want ...string) {
+		t.Helper()
+		if codes := shortCodes(got); strings.Join(codes, ",") != strings.Join(want, ",") {
+			t.Errorf("%s: expected %v, got %v", name, want, codes)
+		}
+	}
+
+	page, err := q.ListURLsByCreatedAtDesc(ctx, db.ListURLsByCreatedAtDescParams{
+		AfterKey: "9999-12-31 23:59:59",
+		AfterID:  math.MaxInt64,
+		RowLimit: 2,
+	})
+	if err != nil {
+		t.Fatalf("cannot list urls: %v", err)
+	}
+	assertCodes("first page", page, "third", "second")
+
+	last := page[len(page)-1]
+	page, err = q.ListURLsByCreatedAtDesc(ctx, db.ListURLsByCreatedAtDescParams{
+		AfterKey: last.Createdat.Time.UTC().Format(time.DateTime),
+		AfterID:  last.ID,
+		RowLimit: 2,
+	})
+	if err != nil {
+		t.Fatalf("cannot list urls: %v", err)
+	}
+	assertCodes("second page", page, "first")
+
+	page, err = q.ListURLsByAccessCountDesc(ctx, db.ListURLsByAccessCountDescParams{
+		AfterKey: math.MaxInt64,
+		AfterID:  math.MaxInt64,
+		RowLimit: 10,
+	})
+	if err != nil {
+		t.Fatalf("cannot list urls: %v", err)
+	}
+	assertCodes("by access count", page, "first", "third", "second")
+
+	page, err = q.ListURLsByUpdatedAt(ctx, db.ListURLsByUpdatedAtParams{RowLimit: 10})
+	if err != nil {
+		t.Fatalf("cannot list urls: %v", err)
+	}
+	assertCodes("by update", page, "second", "third", "first")
+
+	page, err = q.ListURLsByCreatedAt(ctx, db.ListURLsByCreatedAtParams{
+		Domain:   sql.NullString{String: "example.com", Valid: true},
+		Search:   sql.NullString{String: `100\%`, Valid: true},
+		RowLimit: 10,
+	})
+	if err != nil {
+		t.Fatalf("cannot list urls: %v", err)
+	}
+	assertCodes("filtered", page, "second")
+
+	page, err = q.ListURLsByCreatedAt(ctx, db.ListURLsByCreatedAtParams{
+		Search:   sql.NullString{String: "webinar", Valid: true},
+		RowLimit: 10,
+	})
+	if err != nil {
+		t.Fatalf("cannot list urls: %v", err)
+	}
+	assertCodes("by title", page, "third")
+
+	page, err = q.ListURLsByCreatedAt(ctx, db.ListURLsByCreatedAtParams{
+		Search:    sql.NullString{String: "HTTPS://Other.org:443/b", Valid: true},
+		UrlSearch: sql.NullString{String: "https://other.org/b", Valid: true},
+		RowLimit:  10,
+	})
+	if err != nil {
+		t.Fatalf("cannot list urls: %v", err)
+	}
+	assertCodes("by normalized url", page, "third")
+
+	// The destination of a protected link is not shown, so it must not be
+	// found by it either.
+	_, err = q.CreateURL(ctx, db.CreateURLParams{
+		Url:            "https://other.org/private",
+		Shortcode:      "fourth",
+		Redirectstatus: 302,
+		Passwordhash:   sql.NullString{String: "hash", Valid: true},
+		Domain:         sql.NullString{String: "other.org", Valid: true},
+		Title:          sql.NullString{String: "Private", Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("cannot create url: %v", err)
+	}
+
+	page, err = q.ListURLsByCreatedAt(ctx, db.ListURLsByCreatedAtParams{
+		Search:   sql.NullString{String: "other.org/private", Valid: true},
+		RowLimit: 10,
+	})
+	if err != nil {
+		t.Fatalf("cannot list urls: %v", err)
+	}
+	assertCodes("protected url", page)
+
+	page, err = q.ListURLsByCreatedAt(ctx, db.ListURLsByCreatedAtParams{
+		Search:    sql.NullString{String: "nothing", Valid: true},
+		UrlSearch: sql.NullString{String: "other.org/private", Valid: true},
+		RowLimit:  10,
+	})
+	if err != nil {
+		t.Fatalf("cannot list urls: %v", err)
+	}
+	assertCodes("protected normalized url", page)
+
+	page, err = q.ListURLsByCreatedAt(ctx, db.ListURLsByCreatedAtParams{
+		Search:   sql.NullString{String: "private", Valid: true},
+		RowLimit: 10,
+	})
+	if err != nil {
+		t.Fatalf("cannot list urls: %v", err)
+	}
+	assertCodes("protected title", page, "fourth")
+
+	page, err = q.ListURLsByCreatedAt(ctx, db.ListURLsByCreatedAtParams{
+		Domain:   sql.NullString{String: "other.org", Valid: true},
+		RowLimit: 10,
+	})
+	if err != nil {
+		t.Fatalf("cannot list urls: %v", err)
+	}
+	assertCodes("protected domain", page, "third")
+
+	tomorrow := time.Now().UTC().Add(24 * time.Hour).Format(time.DateTime)
+	page, err = q.ListURLsByCreatedAt(ctx, db.ListURLsByCreatedAtParams{
+		CreatedFrom: sql.NullString{String: tomorrow, Valid: true},
+		RowLimit:    10,
+	})
+	if err != nil {
+		t.Fatalf("cannot list urls: %v", err)
+	}
+	assertCodes("created range", page)
+}
+
+func TestQueries_ExportAndRestoreURLs(t *testing.T) {
+	conn, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("cannot open db: %v", err)
+	}
+	defer conn.Close()
+	conn.SetMaxOpenConns(1)
+
+	migrate(t, conn)
+
+	q := db.New(conn)
+	ctx := context.TODO()
+
+	ids := make([]int64, 3)
+	for i, code := range []string{"first", "second", "third"} {
+		created, err := q.CreateURL(ctx, db.CreateURLParams{Url: "https://www.google.com", Shortcode: code, Redirectstatus: 302})
+		if err != nil {
+			t.Fatalf("cannot create url: %v", err)
+		}
+		ids[i] = created.ID
+	}
+
+	createdAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	err = q.RestoreURLStatsByID(ctx, db.RestoreURLStatsByIDParams{
+		CreatedAt:   sql.NullTime{Time: createdAt, Valid: true},
+		AccessCount: 42,
+		BotCount:    5,
+		ID:          ids[1],
+	})
+	if err != nil {
+		t.Fatalf("cannot restore stats: %v", err)
+	}
+
+	// Without a creation time the one set on insert is kept.
+	if err := q.RestoreURLStatsByID(ctx, db.RestoreURLStatsByIDParams{AccessCount: 1, ID: ids[2]}); err != nil {
+		t.Fatalf("cannot restore stats: %v", err)
+	}
+
+	page, err := q.ListURLsAfterID(ctx, db.ListURLsAfterIDParams{AfterID: ids[0], RowLimit: 10})
+	if err != nil {
+		t.Fatalf("cannot list urls: %v", err)
+	}
+	if len(page) != 2 || page[0].Shortcode != "second" || page[1].Shortcode != "third" {
+		t.Fatalf("expected the links after the first one, got %v", page)
+	}
+
+	restored := page[0]
+	if !restored.Createdat.Time.Equal(createdAt) || restored.Accesscount.Int64 != 42 || restored.Botcount != 5 {
+		t.Errorf("stats were not restored, got %v", restored)
+	}
+	if !page[1].Createdat.Valid || page[1].Accesscount.Int64 != 1 {
+		t.Errorf("creation time must be kept when none is restored, got %v", page[1])
+	}
+
+	for _, id := range ids {
+		err := q.UpsertVisitorSketch(ctx, db.UpsertVisitorSketchParams{Urlid: id, Register: 1, Rank: 2})
+		if err != nil {
+			t.Fatalf("cannot update sketch: %v", err)
+		}
+	}
+
+	sketches, err := q.ListVisitorSketchesByURLIDRange(ctx, db.ListVisitorSketchesByURLIDRangeParams{FirstID: ids[1], LastID: ids[2]})
+	if err != nil {
+		t.Fatalf("cannot list sketches: %v", err)
+	}
+	if len(sketches) != 2 || sketches[0].Urlid == ids[0] || sketches[1].Urlid == ids[0] {
+		t.Errorf("expected the sketches of the range only, got %v", sketches)
+	}
+}
+
+func TestQueries_Tags(t *testing.T) {
+	conn, err := sql.Open("sqlite3", ":memory:?_foreign_keys=on")
+	if err != nil {
+		t.Fatalf("cannot open db: %v", err)
+	}
+	defer conn.Close()
+	conn.SetMaxOpenConns(1)
+
+	migrate(t, conn)
+
+	q := db.New(conn)
+	ctx := context.TODO()
+
+	links := map[string][]string{
+		"first":  {"promo", "spring"},
+		"second": {"promo"},
+		"third":  nil,
+	}
+	ids := make(map[string]int64)
+	for _, code := range []string{"first", "second", "third"} {
+		created, err := q.CreateURL(ctx, db.CreateURLParams{Url: "https://www.google.com", Shortcode: code, Redirectstatus: 302})
+		if err != nil {
+			t.Fatalf("cannot create url: %v", err)
+		}
+		ids[code] = created.ID
+
+		for _, tag := range links[code] {
+			tagID, err := q.UpsertTag(ctx, tag)
+			if err != nil {
+				t.Fatalf("cannot upsert tag: %v", err)
+			}
+			if err := q.AddURLTag(ctx, db.AddURLTagParams{Urlid: created.ID, Tagid: tagID}); err != nil {
+				t.Fatalf("cannot add tag: %v", err)
+			}
+		}
+	}
+
+	promoID, err := q.UpsertTag(ctx, "promo")
+	if err != nil {
+		t.Fatalf("cannot upsert tag: %v", err)
+	}
+	if promoID != 1 {
+		t.Errorf("an existing tag must keep its id, got %d", promoID)
+	}
+
+	if err := q.RestoreURLStatsByID(ctx, db.RestoreURLStatsByIDParams{AccessCount: 10, BotCount: 1, ID: ids["first"]}); err != nil {
+		t.Fatalf("cannot restore stats: %v", err)
+	}
+	if err := q.RestoreURLStatsByID(ctx, db.RestoreURLStatsByIDParams{AccessCount: 5, ID: ids["second"]}); err != nil {
+		t.Fatalf("cannot restore stats: %v", err)
+	}
+
+	tagged, err := q.ListURLsByCreatedAt(ctx, db.ListURLsByCreatedAtParams{
+		Tag:      sql.NullString{String: "promo", Valid: true},
+		RowLimit: 10,
+	})
+	if err != nil {
+		t.Fatalf("cannot list urls: %v", err)
+	}
+	if len(tagged) != 2 || tagged[0].ID != ids["first"] || tagged[1].ID != ids["second"] {
+		t.Errorf("expected the links tagged promo, got %v", tagged)
+	}
+
+	tags, err := q.ListTagsByURLIDs(ctx, fmt.Sprintf("[%d,%d]", ids["first"], ids["third"]))
+	if err != nil {
+		t.Fatalf("cannot list tags: %v", err)
+	}
+	if len(tags) != 2 || tags[0].Name != "promo" || tags[1].Name != "spring" || tags[0].Urlid != ids["first"] {
+		t.Errorf("expected the tags of the first link, got %v", tags)
+	}
+
+	summaries, err := q.ListTags(ctx)
+	if err != nil {
+		t.Fatalf("cannot list tags: %v", err)
+	}
+	want := []db.ListTagsRow{
+		{Name: "promo", Links: 2, Accesscount: 15, Botcount: 1},
+		{Name: "spring", Links: 1, Accesscount: 10, Botcount: 1},
+	}
+	if !reflect.DeepEqual(summaries, want) {
+		t.Errorf("expected %v, got %v", want, summaries)
+	}
+
+	// Deleting a link removes it from its tags.
+	if err := q.DeleteURLByShortCode(ctx, "second"); err != nil {
+		t.Fatalf("cannot delete url: %v", err)
+	}
+
+	stats, err := q.GetTagStats(ctx, "promo")
+	if err != nil {
+		t.Fatalf("cannot get tag stats: %v", err)
+	}
+	if stats != (db.GetTagStatsRow{Links: 1, Accesscount: 10, Botcount: 1}) {
+		t.Errorf("expected the stats of the first link, got %v", stats)
+	}
+
+	if err := q.DeleteURLTagsByURLID(ctx, ids["first"]); err != nil {
+		t.Fatalf("cannot delete tags: %v", err)
+	}
+
+	remaining, err := q.ListTagsByURLID(ctx, ids["first"])
+	if err != nil {
+		t.Fatalf("cannot list tags: %v", err)
+	}
+	if len(remaining) != 0 {
+		t.Errorf("expected no tags, got %v", remaining)
+	}
+}
+
+func TestQueries_Campaigns(t *testing.T) {
+	conn, err := sql.Open("sqlite3", ":memory:?_foreign_keys=on")
+	if err != nil {
+		t.Fatalf("cannot open db: %v", err)
+	}
+	defer conn.Close()
+	conn.SetMaxOpenConns(1)
+
+	migrate(t, conn)
+
+	q := db.New(conn)
+	ctx := context.TODO()
+
+	campaign, err := q.CreateCampaign(ctx, db.CreateCampaignParams{
+		Name:      "Spring sale",
+		Utmsource: sql.NullString{String: "newsletter", Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("cannot create campaign: %v", err)
+	}
+	campaignID := sql.NullInt64{Int64: campaign.ID, Valid: true}
+
+	for _, code := range []string{"first", "second", "third"} {
+		params := db.CreateURLParams{Url: "https://www.google.com", Shortcode: code, Redirectstatus: 302}
+		if code != "third" {
+			params.Campaignid = campaignID
+		}
+
+		created, err := q.CreateURL(ctx, params)
+		if err != nil {
+			t.Fatalf("cannot create url: %v", err)
+		}
+		if created.Campaignid != params.Campaignid {
+			t.Errorf("expected campaign %v, got %v", params.Campaignid, created.Campaignid)
+		}
+
+		if err := q.RestoreURLStatsByID(ctx, db.RestoreURLStatsByIDParams{AccessCount: 5, BotCount: 1, ID: created.ID}); err != nil {
+			t.Fatalf("cannot restore stats: %v", err)
+		}
+	}
+
+	if _, err := q.CreateURL(ctx, db.CreateURLParams{
+		Url:            "https://www.google.com",
+		Shortcode:      "orphan",
+		Redirectstatus: 302,
+		Campaignid:     sql.NullInt64{Int64: campaign.ID + 1, Valid: true},
+	}); err == nil {
+		t.Errorf("a link must not join a campaign that does not exist")
+	}
+
+	listed, err := q.ListURLsByCreatedAt(ctx, db.ListURLsByCreatedAtParams{
+		CampaignID: campaignID,
+		RowLimit:   10,
+	})
+	if err != nil {
+		t.Fatalf("cannot list urls: %v", err)
+	}
+	if len(listed) != 2 || listed[0].Shortcode != "first" || listed[1].Shortcode != "second" {
+		t.Errorf("expected the links of the campaign, got %v", listed)
+	}
+
+	stats, err := q.GetCampaignStats(ctx, campaignID)
+	if err != nil {
+		t.Fatalf("cannot get campaign stats: %v", err)
+	}
+	if stats != (db.GetCampaignStatsRow{Links: 2, Accesscount: 10, Botcount: 2}) {
+		t.Errorf("expected the stats of two links, got %v", stats)
+	}
+
+	if err := q.UpdateURLCampaignByShortCode(ctx, db.UpdateURLCampaignByShortCodeParams{Shortcode: "second"}); err != nil {
+		t.Fatalf("cannot update url campaign: %v", err)
+	}
+
+	deleted, err := q.DeleteCampaignByID(ctx, campaign.ID)
+	if err != nil {
+		t.Fatalf("cannot delete campaign: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("expected one deleted campaign, got %d", deleted)
+	}
+
+	// Deleting a campaign keeps its links.
+	link, err := q.GetURLByShortCode(ctx, "first")
+	if err != nil {
+		t.Fatalf("cannot get url: %v", err)
+	}
+	if link.Campaignid.Valid {
+		t.Errorf("expected the link to leave the deleted campaign, got %v", link.Campaignid)
+	}
+
+	if _, err := q.GetCampaignByID(ctx, campaign.ID); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected the campaign to be deleted, got %v", err)
+	}
+}
+
+func TestQueries_URLVersion(t *testing.T) {
+	conn, err := sql.Open("sqlite3", ":memory:?_foreign_keys=on")
+	if err != nil {
+		t.Fatalf("cannot open db: %v", err)
+	}
+	defer conn.Close()
+	conn.SetMaxOpenConns(1)
+
+	migrate(t, conn)
+
+	q := db.New(conn)
+	ctx := context.TODO()
+
+	created, err := q.CreateURL(ctx, db.CreateURLParams{Url: "https://www.google.com", Shortcode: "abc123", Redirectstatus: 302})
+	if err != nil {
+		t.Fatalf("cannot create url: %v", err)
+	}
+	if created.Version != 1 {
+		t.Errorf("a new link must be at version 1, got %d", created.Version)
+	}
+
+	bumps := []struct {
+		version sql.NullInt64
+		want    int64
+	}{
+		{sql.NullInt64{Int64: 1, Valid: true}, 1},
+		{sql.NullInt64{Int64: 1, Valid: true}, 0},
+		{sql.NullInt64{}, 1},
+	}
+	for _, bump := range bumps {
+		claimed, err := q.BumpURLVersionByShortCode(ctx, db.BumpURLVersionByShortCodeParams{ShortCode: "abc123", Version: bump.version})
+		if err != nil {
+			t.Fatalf("cannot bump version: %v", err)
+		}
+		if claimed != bump.want {
+			t.Errorf("bumping from %v: expected %d rows, got %d", bump.version, bump.want, claimed)
+		}
+	}
+
+	data, err := q.GetURLByShortCode(ctx, "abc123")
+	if err != nil {
+		t.Fatalf("cannot get url: %v", err)
+	}
+	if data.Version != 3 {
+		t.Errorf("expected version 3, got %d", data.Version)
+	}
+}
+
+func TestQueries_IdempotencyKeys(t *testing.T) {
+	conn, err := sql.Open("sqlite3", ":memory:?_foreign_keys=on")
+	if err != nil {
+		t.Fatalf("cannot open db: %v", err)
+	}
+	defer conn.Close()
+	conn.SetMaxOpenConns(1)
+
+	migrate(t, conn)
+
+	q := db.New(conn)
+	ctx := context.TODO()
+	now := time.Now().UTC()
+
+	keys := map[string]time.Time{
+		"old": now.Add(-25 * time.Hour),
+		"new": now.Add(-time.Hour),
+	}
+	for key, createdAt := range keys {
+		err := q.CreateIdempotencyKey(ctx, db.CreateIdempotencyKeyParams{
+			Idempotencykey: key,
+			Requesthash:    "hash-" + key,
+			Response:       []byte(`{"id":1}`),
+			Createdat:      createdAt,
+			Passwordhash:   sql.NullString{String: "bcrypt-" + key, Valid: true},
+		})
+		if err != nil {
+			t.Fatalf("cannot create idempotency key: %v", err)
+		}
+	}
+
+	err = q.CreateIdempotencyKey(ctx, db.CreateIdempotencyKeyParams{Idempotencykey: "new", Requesthash: "other", Response: []byte(`{}`), Createdat: now})
+	if !IsUniqueViolation(err) {
+		t.Errorf("a stored key must not be stored again, got %v", err)
+	}
+
+	if err := q.DeleteIdempotencyKeysBefore(ctx, now.Add(-24*time.Hour)); err != nil {
+		t.Fatalf("cannot delete idempotency keys: %v", err)
+	}
+
+	if _, err := q.GetIdempotencyKey(ctx, "old"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected the old key to be deleted, got %v", err)
+	}
+
+	stored, err := q.GetIdempotencyKey(ctx, "new")
+	if err != nil {
+		t.Fatalf("cannot get idempotency key: %v", err)
+	}
+	if stored.Requesthash != "hash-new" || string(stored.Response) != `{"id":1}` || stored.Passwordhash.String != "bcrypt-new" {
+		t.Errorf("expected the new key as stored, got %v", stored)
+	}
+}
+
+func TestQueries_GetPlainURLByURL(t *testing.T) {
+	conn, err := sql.Open("sqlite3", ":memory:?_foreign_keys=on")
+	if err != nil {
+		t.Fatalf("cannot open db: %v", err)
+	}
+	defer conn.Close()
+	conn.SetMaxOpenConns(1)
+
+	migrate(t, conn)
+
+	q := db.New(conn)
+	ctx := context.TODO()
+
+	campaign, err := q.CreateCampaign(ctx, db.CreateCampaignParams{Name: "Spring sale"})
+	if err != nil {
+		t.Fatalf("cannot create campaign: %v", err)
+	}
+
+	google := "https://www.google.com/"
+	links := []db.CreateURLParams{
+		{Url: "https://WWW.google.com:443", Shortcode: "legacy", Redirectstatus: 302},
+		{Url: google, Shortcode: "locked", Redirectstatus: 302, Passwordhash: sql.NullString{String: "hash", Valid: true}},
+		{Url: google, Shortcode: "limited", Redirectstatus: 302, Maxclicks: sql.NullInt64{Int64: 10, Valid: true}},
+		{Url: google, Shortcode: "campaign", Redirectstatus: 302, Campaignid: sql.NullInt64{Int64: campaign.ID, Valid: true}},
+		{Url: google, Shortcode: "permanent", Redirectstatus: 301},
+		{Url: google, Shortcode: "titled", Redirectstatus: 302, Title: sql.NullString{String: "Spring sale", Valid: true}},
+		{Url: google, Shortcode: "tagged", Redirectstatus: 302},
+		{Url: google, Shortcode: "oldest", Redirectstatus: 302},
+		{Url: google, Shortcode: "newest", Redirectstatus: 302},
+	}
+	for _, link := range links {
+		created, err := q.CreateURL(ctx, link)
+		if err != nil {
+			t.Fatalf("cannot create url %s: %v", link.Shortcode, err)
+		}
+
+		if link.Shortcode == "tagged" {
+			tag, err := q.UpsertTag(ctx, "q3")
+			if err != nil {
+				t.Fatalf("cannot create tag: %v", err)
+			}
+			if err := q.AddURLTag(ctx, db.AddURLTagParams{Urlid: created.ID, Tagid: tag}); err != nil {
+				t.Fatalf("cannot tag url: %v", err)
+			}
+		}
+	}
+
+	shortCode, err := q.GetPlainURLByURL(ctx, google)
+	if err != nil {
+		t.Fatalf("cannot get url: %v", err)
+	}
+	if shortCode != "oldest" {
+		t.Errorf("expected the oldest plain link, got %s", shortCode)
+	}
+
+	_, err = q.GetPlainURLByURL(ctx, "https://www.bing.com/")
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestQueries_NormalizeURLByID(t *testing.T) {
+	conn, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("cannot open db: %v", err)
+	}
+	defer conn.Close()
+	conn.SetMaxOpenConns(1)
+
+	migrate(t, conn)
+
+	q := db.New(conn)
+	ctx := context.TODO()
+
+	created, err := q.CreateURL(ctx, db.CreateURLParams{
+		Url:            "HTTPS://Bücher.de:443",
+		Shortcode:      "legacy",
+		Redirectstatus: 302,
+		Domain:         sql.NullString{String: "bücher.de", Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("cannot create url: %v", err)
+	}
+
+	err = q.NormalizeURLByID(ctx, db.NormalizeURLByIDParams{
+		Url:    "https://xn--bcher-kva.de/",
+		Domain: sql.NullString{String: "xn--bcher-kva.de", Valid: true},
+		ID:     created.ID,
+	})
+	if err != nil {
+		t.Fatalf("cannot normalize url: %v", err)
+	}
+
+	stored, err := q.GetURLStatsByShortCode(ctx, "legacy")
+	if err != nil {
+		t.Fatalf("cannot get url: %v", err)
+	}
+	if stored.Url != "https://xn--bcher-kva.de/" || stored.Domain.String != "xn--bcher-kva.de" {
+		t.Errorf("expected the normalized url and domain, got %s and %s", stored.Url, stored.Domain.String)
+	}
+	if stored.Version != created.Version+1 {
+		t.Errorf("expected version %d, got %d", created.Version+1, stored.Version)
+	}
+}
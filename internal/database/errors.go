@@ -0,0 +1,19 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// IsUniqueViolation reports whether err was caused by a UNIQUE constraint,
+// e.g. inserting a short code that is already taken.
+func IsUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+
+	return sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique ||
+		sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey
+}
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE urls ADD COLUMN redirectStatus INTEGER NOT NULL DEFAULT 302;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE urls DROP COLUMN redirectStatus;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE urls ADD COLUMN expiresAt DATETIME;
+ALTER TABLE urls ADD COLUMN notBefore DATETIME;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE urls DROP COLUMN notBefore;
+ALTER TABLE urls DROP COLUMN expiresAt;
+-- +goose StatementEnd
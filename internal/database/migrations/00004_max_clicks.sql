@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE urls ADD COLUMN maxClicks INTEGER;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE urls DROP COLUMN maxClicks;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE urls ADD COLUMN passwordHash TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE urls DROP COLUMN passwordHash;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE clicks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    urlId INTEGER NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
+    clickedAt DATETIME NOT NULL,
+    referrer TEXT,
+    userAgent TEXT,
+    ipAddress TEXT,
+    acceptLanguage TEXT
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX clicks_urlId_clickedAt ON clicks (urlId, clickedAt);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS clicks;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE clicks ADD COLUMN referrerDomain TEXT;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE clicks ADD COLUMN browser TEXT;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE clicks ADD COLUMN os TEXT;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE clicks ADD COLUMN device TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE clicks DROP COLUMN device;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE clicks DROP COLUMN os;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE clicks DROP COLUMN browser;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE clicks DROP COLUMN referrerDomain;
+-- +goose StatementEnd
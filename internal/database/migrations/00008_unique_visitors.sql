@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE clicks ADD COLUMN visitorHash TEXT;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE visitor_salts (
+    day TEXT PRIMARY KEY,
+    salt BLOB NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE visitor_sketches (
+    urlId INTEGER NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
+    register INTEGER NOT NULL,
+    rank INTEGER NOT NULL,
+    PRIMARY KEY (urlId, register)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS visitor_sketches;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS visitor_salts;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE clicks DROP COLUMN visitorHash;
+-- +goose StatementEnd
@@ -0,0 +1,60 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE urls ADD COLUMN domain TEXT;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Backfill the host of existing links the way utils.Domain computes it:
+-- lower case, without userinfo, port or a leading "www.".
+UPDATE urls
+SET domain = (
+    SELECT CASE WHEN host LIKE 'www.%' THEN substr(host, 5) ELSE host END
+    FROM (
+        SELECT lower(CASE WHEN instr(hostport, ':') > 0 THEN substr(hostport, 1, instr(hostport, ':') - 1) ELSE hostport END) AS host
+        FROM (
+            SELECT substr(authority, instr(authority, '@') + 1) AS hostport
+            FROM (
+                SELECT CASE WHEN instr(rest, '/') > 0 THEN substr(rest, 1, instr(rest, '/') - 1) ELSE rest END AS authority
+                FROM (SELECT replace(replace(substr(urls.url, instr(urls.url, '://') + 3), '?', '/'), '#', '/') AS rest)
+            )
+        )
+    )
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX urls_domain ON urls (domain);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX urls_createdAt ON urls (datetime(createdAt), id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX urls_updatedAt ON urls (datetime(COALESCE(updatedAt, createdAt)), id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX urls_accessCount ON urls (accessCount, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS urls_accessCount;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP INDEX IF EXISTS urls_updatedAt;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP INDEX IF EXISTS urls_createdAt;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP INDEX IF EXISTS urls_domain;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE urls DROP COLUMN domain;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE tags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE url_tags (
+    urlId INTEGER NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
+    tagId INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (urlId, tagId)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX url_tags_tagId ON url_tags (tagId, urlId);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS url_tags_tagId;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS url_tags;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS tags;
+-- +goose StatementEnd
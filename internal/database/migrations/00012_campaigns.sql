@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE campaigns (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    owner TEXT,
+    startsAt DATETIME,
+    endsAt DATETIME,
+    utmSource TEXT,
+    utmMedium TEXT,
+    utmCampaign TEXT,
+    utmTerm TEXT,
+    utmContent TEXT,
+    createdAt DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updatedAt DATETIME
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Deleting a campaign keeps its links, which just no longer belong to one.
+ALTER TABLE urls ADD COLUMN campaignId INTEGER REFERENCES campaigns(id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX urls_campaignId ON urls (campaignId);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS urls_campaignId;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE urls DROP COLUMN campaignId;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS campaigns;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE urls ADD COLUMN title TEXT;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE urls ADD COLUMN description TEXT;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE urls ADD COLUMN notes TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE urls DROP COLUMN notes;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE urls DROP COLUMN description;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE urls DROP COLUMN title;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE idempotency_keys (
+    idempotencyKey TEXT PRIMARY KEY,
+    requestHash TEXT NOT NULL,
+    response BLOB NOT NULL,
+    createdAt DATETIME NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX idempotency_keys_createdAt ON idempotency_keys (createdAt);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idempotency_keys_createdAt;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS idempotency_keys;
+-- +goose StatementEnd
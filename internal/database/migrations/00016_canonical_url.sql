@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE urls ADD COLUMN canonicalUrl TEXT;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Existing links keep the URL they were stored with, so they are only
+-- matched by a URL that is already in canonical form.
+UPDATE urls SET canonicalUrl = url;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX urls_canonicalUrl ON urls (canonicalUrl, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS urls_canonicalUrl;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE urls DROP COLUMN canonicalUrl;
+-- +goose StatementEnd
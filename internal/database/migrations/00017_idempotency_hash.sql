@@ -0,0 +1,16 @@
+-- Stored request hashes were an unsalted SHA-256 of the request, password
+-- included, so every stored key is deleted rather than kept until it
+-- expires. A retry of a request made before this migration creates a new
+-- link instead of replaying the first one; keys only last for the
+-- idempotency window (24h by default), so few retries are affected.
+
+-- +goose Up
+-- +goose StatementBegin
+DELETE FROM idempotency_keys;
+-- +goose StatementEnd
+
+-- +goose Down
+-- The deleted keys cannot be restored, so there is nothing to undo.
+-- +goose StatementBegin
+SELECT 1;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+DROP INDEX IF EXISTS urls_canonicalUrl;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE urls DROP COLUMN canonicalUrl;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX urls_url ON urls (url, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS urls_url;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE urls ADD COLUMN canonicalUrl TEXT;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+UPDATE urls SET canonicalUrl = url;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX urls_canonicalUrl ON urls (canonicalUrl, id);
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE idempotency_keys ADD COLUMN passwordHash TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE idempotency_keys DROP COLUMN passwordHash;
+-- +goose StatementEnd
@@ -0,0 +1,63 @@
+-- name: CreateCampaign :one
+INSERT INTO campaigns (name, owner, startsAt, endsAt, utmSource, utmMedium, utmCampaign, utmTerm, utmContent)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, name, owner, startsAt, endsAt, utmSource, utmMedium, utmCampaign, utmTerm, utmContent, createdAt, updatedAt;
+
+-- name: GetCampaignByID :one
+SELECT
+    id,
+    name,
+    owner,
+    startsAt,
+    endsAt,
+    utmSource,
+    utmMedium,
+    utmCampaign,
+    utmTerm,
+    utmContent,
+    createdAt,
+    updatedAt
+FROM campaigns
+WHERE id = ?;
+
+-- name: ListCampaigns :many
+SELECT
+    id,
+    name,
+    owner,
+    startsAt,
+    endsAt,
+    utmSource,
+    utmMedium,
+    utmCampaign,
+    utmTerm,
+    utmContent,
+    createdAt,
+    updatedAt
+FROM campaigns
+ORDER BY id;
+
+-- name: UpdateCampaignByID :one
+UPDATE campaigns
+SET name = ?, owner = ?, startsAt = ?, endsAt = ?, utmSource = ?, utmMedium = ?, utmCampaign = ?, utmTerm = ?, utmContent = ?, updatedAt = ?
+WHERE id = ?
+RETURNING id, name, owner, startsAt, endsAt, utmSource, utmMedium, utmCampaign, utmTerm, utmContent, createdAt, updatedAt;
+
+-- name: DeleteCampaignByID :execrows
+DELETE FROM campaigns
+WHERE id = ?;
+
+-- name: GetCampaignStats :one
+SELECT
+    COUNT(*) AS links,
+    CAST(COALESCE(SUM(accessCount), 0) AS INTEGER) AS accessCount,
+    CAST(COALESCE(SUM(botCount), 0) AS INTEGER) AS botCount
+FROM urls
+WHERE campaignId = ?;
+
+-- name: ListVisitorSketchByCampaignID :many
+SELECT vs.register, CAST(MAX(vs.rank) AS INTEGER) AS rank
+FROM urls u
+JOIN visitor_sketches vs ON vs.urlId = u.id
+WHERE u.campaignId = ?
+GROUP BY vs.register;
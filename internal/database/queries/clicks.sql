@@ -0,0 +1,72 @@
+-- name: CreateClick :exec
+INSERT INTO clicks (urlId, clickedAt, referrer, userAgent, ipAddress, acceptLanguage, referrerDomain, browser, os, device, visitorHash)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: CountClicksByBucket :many
+WITH buckets AS (
+    SELECT CAST(key AS INTEGER) AS bucket,
+        value AS startsAt,
+        LEAD(value) OVER (ORDER BY key) AS endsAt
+    FROM json_each(CAST(sqlc.arg(bounds) AS TEXT))
+)
+SELECT buckets.bucket, COUNT(*) AS clicks, COUNT(DISTINCT clicks.visitorHash) AS visitors
+FROM buckets
+JOIN clicks ON clicks.urlId = sqlc.arg(url_id)
+    AND clicks.clickedAt >= buckets.startsAt
+    AND clicks.clickedAt < buckets.endsAt
+GROUP BY buckets.bucket
+ORDER BY buckets.bucket;
+
+-- name: CountClicksByURLID :one
+SELECT COUNT(*) AS clicks
+FROM clicks
+WHERE urlId = sqlc.arg(url_id)
+    AND clickedAt >= sqlc.arg(from_time)
+    AND clickedAt < sqlc.arg(to_time);
+
+-- name: CountUniqueVisitorsByURLID :one
+SELECT COUNT(DISTINCT visitorHash) AS visitors
+FROM clicks
+WHERE urlId = sqlc.arg(url_id)
+    AND clickedAt >= sqlc.arg(from_time)
+    AND clickedAt < sqlc.arg(to_time);
+
+-- name: ListTopReferrersByURLID :many
+SELECT CAST(COALESCE(referrerDomain, '(direct)') AS TEXT) AS value, COUNT(*) AS clicks
+FROM clicks
+WHERE urlId = sqlc.arg(url_id)
+    AND clickedAt >= sqlc.arg(from_time)
+    AND clickedAt < sqlc.arg(to_time)
+GROUP BY value
+ORDER BY clicks DESC, value
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListTopBrowsersByURLID :many
+SELECT CAST(COALESCE(browser, 'Unknown') AS TEXT) AS value, COUNT(*) AS clicks
+FROM clicks
+WHERE urlId = sqlc.arg(url_id)
+    AND clickedAt >= sqlc.arg(from_time)
+    AND clickedAt < sqlc.arg(to_time)
+GROUP BY value
+ORDER BY clicks DESC, value
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListTopOSByURLID :many
+SELECT CAST(COALESCE(os, 'Unknown') AS TEXT) AS value, COUNT(*) AS clicks
+FROM clicks
+WHERE urlId = sqlc.arg(url_id)
+    AND clickedAt >= sqlc.arg(from_time)
+    AND clickedAt < sqlc.arg(to_time)
+GROUP BY value
+ORDER BY clicks DESC, value
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListTopDevicesByURLID :many
+SELECT CAST(COALESCE(device, 'Unknown') AS TEXT) AS value, COUNT(*) AS clicks
+FROM clicks
+WHERE urlId = sqlc.arg(url_id)
+    AND clickedAt >= sqlc.arg(from_time)
+    AND clickedAt < sqlc.arg(to_time)
+GROUP BY value
+ORDER BY clicks DESC, value
+LIMIT sqlc.arg(row_limit);
@@ -0,0 +1,12 @@
+-- name: CreateIdempotencyKey :exec
+INSERT INTO idempotency_keys (idempotencyKey, requestHash, response, createdAt, passwordHash)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: GetIdempotencyKey :one
+SELECT requestHash, response, passwordHash
+FROM idempotency_keys
+WHERE idempotencyKey = ?;
+
+-- name: DeleteIdempotencyKeysBefore :exec
+DELETE FROM idempotency_keys
+WHERE createdAt < ?;
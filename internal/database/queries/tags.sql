@@ -0,0 +1,58 @@
+-- name: UpsertTag :one
+INSERT INTO tags (name)
+VALUES (?)
+ON CONFLICT (name) DO UPDATE SET name = excluded.name
+RETURNING id;
+
+-- name: AddURLTag :exec
+INSERT INTO url_tags (urlId, tagId)
+VALUES (?, ?)
+ON CONFLICT (urlId, tagId) DO NOTHING;
+
+-- name: DeleteURLTagsByURLID :exec
+DELETE FROM url_tags
+WHERE urlId = ?;
+
+-- name: ListTagsByURLID :many
+SELECT t.name
+FROM url_tags ut
+JOIN tags t ON t.id = ut.tagId
+WHERE ut.urlId = ?
+ORDER BY t.name;
+
+-- name: ListTagsByURLIDs :many
+SELECT ut.urlId, t.name
+FROM url_tags ut
+JOIN tags t ON t.id = ut.tagId
+WHERE ut.urlId IN (SELECT value FROM json_each(CAST(sqlc.arg(url_ids) AS TEXT)))
+ORDER BY ut.urlId, t.name;
+
+-- name: ListTags :many
+SELECT
+    t.name,
+    COUNT(*) AS links,
+    CAST(COALESCE(SUM(u.accessCount), 0) AS INTEGER) AS accessCount,
+    CAST(COALESCE(SUM(u.botCount), 0) AS INTEGER) AS botCount
+FROM tags t
+JOIN url_tags ut ON ut.tagId = t.id
+JOIN urls u ON u.id = ut.urlId
+GROUP BY t.id
+ORDER BY t.name;
+
+-- name: GetTagStats :one
+SELECT
+    COUNT(*) AS links,
+    CAST(COALESCE(SUM(u.accessCount), 0) AS INTEGER) AS accessCount,
+    CAST(COALESCE(SUM(u.botCount), 0) AS INTEGER) AS botCount
+FROM tags t
+JOIN url_tags ut ON ut.tagId = t.id
+JOIN urls u ON u.id = ut.urlId
+WHERE t.name = ?;
+
+-- name: ListVisitorSketchByTag :many
+SELECT vs.register, CAST(MAX(vs.rank) AS INTEGER) AS rank
+FROM tags t
+JOIN url_tags ut ON ut.tagId = t.id
+JOIN visitor_sketches vs ON vs.urlId = ut.urlId
+WHERE t.name = ?
+GROUP BY vs.register;
@@ -4,26 +4,82 @@ SELECT
     url,
     shortCode,
     createdAt,
-    updatedAt
+    updatedAt,
+    redirectStatus,
+    expiresAt,
+    notBefore,
+    maxClicks,
+    passwordHash,
+    campaignId,
+    title,
+    description,
+    notes,
+    version
 FROM urls
 WHERE shortCode = ?;
 
 -- name: CreateURL :one
-INSERT INTO urls (url, shortCode)
-VALUES (?, ?)
-RETURNING id, url, shortCode, createdAt, updatedAt;
+INSERT INTO urls (url, shortCode, redirectStatus, expiresAt, notBefore, maxClicks, passwordHash, domain, campaignId, title, description, notes)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, url, shortCode, createdAt, updatedAt, redirectStatus, expiresAt, notBefore, maxClicks, passwordHash, campaignId, title, description, notes, version;
 
 -- name: UpdateURLByShortCode :one
 UPDATE urls
-SET url = ?, updatedAt = ?
+SET url = ?, redirectStatus = ?, expiresAt = ?, notBefore = ?, maxClicks = ?, updatedAt = ?, domain = ?, title = ?, description = ?, notes = ?
 WHERE shortCode = ?
-RETURNING id, url, shortCode, createdAt, updatedAt;
+RETURNING id, url, shortCode, createdAt, updatedAt, redirectStatus, expiresAt, notBefore, maxClicks, passwordHash, campaignId, title, description, notes, version;
+
+-- name: GetPlainURLByURL :one
+SELECT shortCode
+FROM urls
+WHERE url = ?
+    AND passwordHash IS NULL
+    AND expiresAt IS NULL
+    AND notBefore IS NULL
+    AND maxClicks IS NULL
+    AND campaignId IS NULL
+    AND redirectStatus = 302
+    AND title IS NULL
+    AND description IS NULL
+    AND notes IS NULL
+    AND NOT EXISTS (SELECT 1 FROM url_tags WHERE url_tags.urlId = urls.id)
+ORDER BY id
+LIMIT 1;
 
--- name: IncrementURLAccessCountByShortCode :exec
+-- name: BumpURLVersionByShortCode :execrows
 UPDATE urls
-SET accessCount = accessCount + 1
+SET version = version + 1
+WHERE shortCode = sqlc.arg(short_code)
+    AND (sqlc.narg(version) IS NULL OR version = sqlc.narg(version));
+
+-- name: UpdateURLPasswordByShortCode :exec
+UPDATE urls
+SET passwordHash = ?
 WHERE shortCode = ?;
 
+-- name: UpdateURLCampaignByShortCode :exec
+UPDATE urls
+SET campaignId = ?
+WHERE shortCode = ?;
+
+-- name: IncrementURLAccessCountByShortCode :execrows
+UPDATE urls
+SET accessCount = accessCount + 1
+WHERE shortCode = ?
+    AND (maxClicks IS NULL OR accessCount < maxClicks);
+
+-- name: IncrementURLBotCountByShortCode :execrows
+UPDATE urls
+SET botCount = botCount + 1
+WHERE shortCode = ?
+    AND (maxClicks IS NULL OR accessCount < maxClicks);
+
+-- name: AddURLCountsByID :exec
+UPDATE urls
+SET accessCount = accessCount + CAST(sqlc.arg(clicks) AS INTEGER),
+    botCount = botCount + CAST(sqlc.arg(bots) AS INTEGER)
+WHERE id = sqlc.arg(id);
+
 -- name: DeleteURLByShortCode :exec
 DELETE FROM urls
 WHERE shortCode = ?;
@@ -35,6 +91,320 @@ SELECT
     shortCode,
     createdAt,
     updatedAt,
-    accessCount
+    accessCount,
+    redirectStatus,
+    expiresAt,
+    notBefore,
+    maxClicks,
+    passwordHash,
+    botCount,
+    domain,
+    campaignId,
+    title,
+    description,
+    notes,
+    version
 FROM urls
-WHERE shortCode = ?;
\ No newline at end of file
+WHERE shortCode = ?;
+
+-- name: ListURLsByCreatedAt :many
+SELECT
+    id,
+    url,
+    shortCode,
+    createdAt,
+    updatedAt,
+    accessCount,
+    redirectStatus,
+    expiresAt,
+    notBefore,
+    maxClicks,
+    passwordHash,
+    botCount,
+    domain,
+    campaignId,
+    title,
+    description,
+    notes,
+    version
+FROM urls
+WHERE datetime(createdAt) >= CAST(sqlc.arg(after_key) AS TEXT)
+    AND (datetime(createdAt) > CAST(sqlc.arg(after_key) AS TEXT) OR id > sqlc.arg(after_id))
+    AND (sqlc.narg(domain) IS NULL OR (domain = sqlc.narg(domain) AND passwordHash IS NULL))
+    AND (sqlc.narg(search) IS NULL
+        OR ((url LIKE '%' || CAST(sqlc.narg(search) AS TEXT) || '%' ESCAPE '\'
+                OR url LIKE '%' || CAST(sqlc.narg(url_search) AS TEXT) || '%' ESCAPE '\')
+            AND passwordHash IS NULL)
+        OR title LIKE '%' || CAST(sqlc.narg(search) AS TEXT) || '%' ESCAPE '\'
+        OR description LIKE '%' || CAST(sqlc.narg(search) AS TEXT) || '%' ESCAPE '\'
+        OR notes LIKE '%' || CAST(sqlc.narg(search) AS TEXT) || '%' ESCAPE '\')
+    AND (sqlc.narg(created_from) IS NULL OR datetime(createdAt) >= CAST(sqlc.narg(created_from) AS TEXT))
+    AND (sqlc.narg(created_to) IS NULL OR datetime(createdAt) < CAST(sqlc.narg(created_to) AS TEXT))
+    AND (sqlc.narg(tag) IS NULL OR id IN (
+        SELECT ut.urlId
+        FROM url_tags ut
+        JOIN tags t ON t.id = ut.tagId
+        WHERE t.name = sqlc.narg(tag)
+    ))
+    AND (sqlc.narg(campaign_id) IS NULL OR campaignId = sqlc.narg(campaign_id))
+ORDER BY datetime(createdAt), id
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListURLsByCreatedAtDesc :many
+SELECT
+    id,
+    url,
+    shortCode,
+    createdAt,
+    updatedAt,
+    accessCount,
+    redirectStatus,
+    expiresAt,
+    notBefore,
+    maxClicks,
+    passwordHash,
+    botCount,
+    domain,
+    campaignId,
+    title,
+    description,
+    notes,
+    version
+FROM urls
+WHERE datetime(createdAt) <= CAST(sqlc.arg(after_key) AS TEXT)
+    AND (datetime(createdAt) < CAST(sqlc.arg(after_key) AS TEXT) OR id < sqlc.arg(after_id))
+    AND (sqlc.narg(domain) IS NULL OR (domain = sqlc.narg(domain) AND passwordHash IS NULL))
+    AND (sqlc.narg(search) IS NULL
+        OR ((url LIKE '%' || CAST(sqlc.narg(search) AS TEXT) || '%' ESCAPE '\'
+                OR url LIKE '%' || CAST(sqlc.narg(url_search) AS TEXT) || '%' ESCAPE '\')
+            AND passwordHash IS NULL)
+        OR title LIKE '%' || CAST(sqlc.narg(search) AS TEXT) || '%' ESCAPE '\'
+        OR description LIKE '%' || CAST(sqlc.narg(search) AS TEXT) || '%' ESCAPE '\'
+        OR notes LIKE '%' || CAST(sqlc.narg(search) AS TEXT) || '%' ESCAPE '\')
+    AND (sqlc.narg(created_from) IS NULL OR datetime(createdAt) >= CAST(sqlc.narg(created_from) AS TEXT))
+    AND (sqlc.narg(created_to) IS NULL OR datetime(createdAt) < CAST(sqlc.narg(created_to) AS TEXT))
+    AND (sqlc.narg(tag) IS NULL OR id IN (
+        SELECT ut.urlId
+        FROM url_tags ut
+        JOIN tags t ON t.id = ut.tagId
+        WHERE t.name = sqlc.narg(tag)
+    ))
+    AND (sqlc.narg(campaign_id) IS NULL OR campaignId = sqlc.narg(campaign_id))
+ORDER BY datetime(createdAt) DESC, id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListURLsByUpdatedAt :many
+SELECT
+    id,
+    url,
+    shortCode,
+    createdAt,
+    updatedAt,
+    accessCount,
+    redirectStatus,
+    expiresAt,
+    notBefore,
+    maxClicks,
+    passwordHash,
+    botCount,
+    domain,
+    campaignId,
+    title,
+    description,
+    notes,
+    version
+FROM urls
+WHERE datetime(COALESCE(updatedAt, createdAt)) >= CAST(sqlc.arg(after_key) AS TEXT)
+    AND (datetime(COALESCE(updatedAt, createdAt)) > CAST(sqlc.arg(after_key) AS TEXT) OR id > sqlc.arg(after_id))
+    AND (sqlc.narg(domain) IS NULL OR (domain = sqlc.narg(domain) AND passwordHash IS NULL))
+    AND (sqlc.narg(search) IS NULL
+        OR ((url LIKE '%' || CAST(sqlc.narg(search) AS TEXT) || '%' ESCAPE '\'
+                OR url LIKE '%' || CAST(sqlc.narg(url_search) AS TEXT) || '%' ESCAPE '\')
+            AND passwordHash IS NULL)
+        OR title LIKE '%' || CAST(sqlc.narg(search) AS TEXT) || '%' ESCAPE '\'
+        OR description LIKE '%' || CAST(sqlc.narg(search) AS TEXT) || '%' ESCAPE '\'
+        OR notes LIKE '%' || CAST(sqlc.narg(search) AS TEXT) || '%' ESCAPE '\')
+    AND (sqlc.narg(created_from) IS NULL OR datetime(createdAt) >= CAST(sqlc.narg(created_from) AS TEXT))
+    AND (sqlc.narg(created_to) IS NULL OR datetime(createdAt) < CAST(sqlc.narg(created_to) AS TEXT))
+    AND (sqlc.narg(tag) IS NULL OR id IN (
+        SELECT ut.urlId
+        FROM url_tags ut
+        JOIN tags t ON t.id = ut.tagId
+        WHERE t.name = sqlc.narg(tag)
+    ))
+    AND (sqlc.narg(campaign_id) IS NULL OR campaignId = sqlc.narg(campaign_id))
+ORDER BY datetime(COALESCE(updatedAt, createdAt)), id
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListURLsByUpdatedAtDesc :many
+SELECT
+    id,
+    url,
+    shortCode,
+    createdAt,
+    updatedAt,
+    accessCount,
+    redirectStatus,
+    expiresAt,
+    notBefore,
+    maxClicks,
+    passwordHash,
+    botCount,
+    domain,
+    campaignId,
+    title,
+    description,
+    notes,
+    version
+FROM urls
+WHERE datetime(COALESCE(updatedAt, createdAt)) <= CAST(sqlc.arg(after_key) AS TEXT)
+    AND (datetime(COALESCE(updatedAt, createdAt)) < CAST(sqlc.arg(after_key) AS TEXT) OR id < sqlc.arg(after_id))
+    AND (sqlc.narg(domain) IS NULL OR (domain = sqlc.narg(domain) AND passwordHash IS NULL))
+    AND (sqlc.narg(search) IS NULL
+        OR ((url LIKE '%' || CAST(sqlc.narg(search) AS TEXT) || '%' ESCAPE '\'
+                OR url LIKE '%' || CAST(sqlc.narg(url_search) AS TEXT) || '%' ESCAPE '\')
+            AND passwordHash IS NULL)
+        OR title LIKE '%' || CAST(sqlc.narg(search) AS TEXT) || '%' ESCAPE '\'
+        OR description LIKE '%' || CAST(sqlc.narg(search) AS TEXT) || '%' ESCAPE '\'
+        OR notes LIKE '%' || CAST(sqlc.narg(search) AS TEXT) || '%' ESCAPE '\')
+    AND (sqlc.narg(created_from) IS NULL OR datetime(createdAt) >= CAST(sqlc.narg(created_from) AS TEXT))
+    AND (sqlc.narg(created_to) IS NULL OR datetime(createdAt) < CAST(sqlc.narg(created_to) AS TEXT))
+    AND (sqlc.narg(tag) IS NULL OR id IN (
+        SELECT ut.urlId
+        FROM url_tags ut
+        JOIN tags t ON t.id = ut.tagId
+        WHERE t.name = sqlc.narg(tag)
+    ))
+    AND (sqlc.narg(campaign_id) IS NULL OR campaignId = sqlc.narg(campaign_id))
+ORDER BY datetime(COALESCE(updatedAt, createdAt)) DESC, id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListURLsByAccessCount :many
+SELECT
+    id,
+    url,
+    shortCode,
+    createdAt,
+    updatedAt,
+    accessCount,
+    redirectStatus,
+    expiresAt,
+    notBefore,
+    maxClicks,
+    passwordHash,
+    botCount,
+    domain,
+    campaignId,
+    title,
+    description,
+    notes,
+    version
+FROM urls
+WHERE accessCount >= CAST(sqlc.arg(after_key) AS INTEGER)
+    AND (accessCount > CAST(sqlc.arg(after_key) AS INTEGER) OR id > sqlc.arg(after_id))
+    AND (sqlc.narg(domain) IS NULL OR (domain = sqlc.narg(domain) AND passwordHash IS NULL))
+    AND (sqlc.narg(search) IS NULL
+        OR ((url LIKE '%' || CAST(sqlc.narg(search) AS TEXT) || '%' ESCAPE '\'
+                OR url LIKE '%' || CAST(sqlc.narg(url_search) AS TEXT) || '%' ESCAPE '\')
+            AND passwordHash IS NULL)
+        OR title LIKE '%' || CAST(sqlc.narg(search) AS TEXT) || '%' ESCAPE '\'
+        OR description LIKE '%' || CAST(sqlc.narg(search) AS TEXT) || '%' ESCAPE '\'
+        OR notes LIKE '%' || CAST(sqlc.narg(search) AS TEXT) || '%' ESCAPE '\')
+    AND (sqlc.narg(created_from) IS NULL OR datetime(createdAt) >= CAST(sqlc.narg(created_from) AS TEXT))
+    AND (sqlc.narg(created_to) IS NULL OR datetime(createdAt) < CAST(sqlc.narg(created_to) AS TEXT))
+    AND (sqlc.narg(tag) IS NULL OR id IN (
+        SELECT ut.urlId
+        FROM url_tags ut
+        JOIN tags t ON t.id = ut.tagId
+        WHERE t.name = sqlc.narg(tag)
+    ))
+    AND (sqlc.narg(campaign_id) IS NULL OR campaignId = sqlc.narg(campaign_id))
+ORDER BY accessCount, id
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListURLsByAccessCountDesc :many
+SELECT
+    id,
+    url,
+    shortCode,
+    createdAt,
+    updatedAt,
+    accessCount,
+    redirectStatus,
+    expiresAt,
+    notBefore,
+    maxClicks,
+    passwordHash,
+    botCount,
+    domain,
+    campaignId,
+    title,
+    description,
+    notes,
+    version
+FROM urls
+WHERE accessCount <= CAST(sqlc.arg(after_key) AS INTEGER)
+    AND (accessCount < CAST(sqlc.arg(after_key) AS INTEGER) OR id < sqlc.arg(after_id))
+    AND (sqlc.narg(domain) IS NULL OR (domain = sqlc.narg(domain) AND passwordHash IS NULL))
+    AND (sqlc.narg(search) IS NULL
+        OR ((url LIKE '%' || CAST(sqlc.narg(search) AS TEXT) || '%' ESCAPE '\'
+                OR url LIKE '%' || CAST(sqlc.narg(url_search) AS TEXT) || '%' ESCAPE '\')
+            AND passwordHash IS NULL)
+        OR title LIKE '%' || CAST(sqlc.narg(search) AS TEXT) || '%' ESCAPE '\'
+        OR description LIKE '%' || CAST(sqlc.narg(search) AS TEXT) || '%' ESCAPE '\'
+        OR notes LIKE '%' || CAST(sqlc.narg(search) AS TEXT) || '%' ESCAPE '\')
+    AND (sqlc.narg(created_from) IS NULL OR datetime(createdAt) >= CAST(sqlc.narg(created_from) AS TEXT))
+    AND (sqlc.narg(created_to) IS NULL OR datetime(createdAt) < CAST(sqlc.narg(created_to) AS TEXT))
+    AND (sqlc.narg(tag) IS NULL OR id IN (
+        SELECT ut.urlId
+        FROM url_tags ut
+        JOIN tags t ON t.id = ut.tagId
+        WHERE t.name = sqlc.narg(tag)
+    ))
+    AND (sqlc.narg(campaign_id) IS NULL OR campaignId = sqlc.narg(campaign_id))
+ORDER BY accessCount DESC, id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: GetLastURLID :one
+SELECT CAST(COALESCE(MAX(id), 0) AS INTEGER) AS lastId
+FROM urls;
+
+-- name: ListURLsAfterID :many
+SELECT
+    id,
+    url,
+    shortCode,
+    createdAt,
+    updatedAt,
+    accessCount,
+    redirectStatus,
+    expiresAt,
+    notBefore,
+    maxClicks,
+    passwordHash,
+    botCount,
+    domain,
+    campaignId,
+    title,
+    description,
+    notes,
+    version
+FROM urls
+WHERE id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(row_limit);
+
+-- name: RestoreURLStatsByID :exec
+UPDATE urls
+SET createdAt = COALESCE(sqlc.narg(created_at), createdAt),
+    updatedAt = sqlc.narg(updated_at),
+    accessCount = sqlc.arg(access_count),
+    botCount = sqlc.arg(bot_count)
+WHERE id = sqlc.arg(id);
+
+-- name: NormalizeURLByID :exec
+UPDATE urls
+SET url = sqlc.arg(url),
+    domain = sqlc.arg(domain),
+    version = version + 1
+WHERE id = sqlc.arg(id);
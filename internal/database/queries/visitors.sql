@@ -0,0 +1,29 @@
+-- name: CreateVisitorSalt :exec
+INSERT INTO visitor_salts (day, salt)
+VALUES (?, ?)
+ON CONFLICT (day) DO NOTHING;
+
+-- name: GetVisitorSalt :one
+SELECT salt
+FROM visitor_salts
+WHERE day = ?;
+
+-- name: DeleteVisitorSaltsBefore :exec
+DELETE FROM visitor_salts
+WHERE day < ?;
+
+-- name: UpsertVisitorSketch :exec
+INSERT INTO visitor_sketches (urlId, register, rank)
+VALUES (?, ?, ?)
+ON CONFLICT (urlId, register) DO UPDATE SET rank = MAX(rank, excluded.rank);
+
+-- name: ListVisitorSketchByURLID :many
+SELECT register, rank
+FROM visitor_sketches
+WHERE urlId = ?;
+
+-- name: ListVisitorSketchesByURLIDRange :many
+SELECT urlId, register, rank
+FROM visitor_sketches
+WHERE urlId >= sqlc.arg(first_id)
+    AND urlId <= sqlc.arg(last_id);
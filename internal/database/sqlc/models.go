@@ -6,13 +6,85 @@ package db
 
 import (
 	"database/sql"
+	"time"
 )
 
+type Campaign struct {
+	ID          int64          `json:"id"`
+	Name        string         `json:"name"`
+	Owner       sql.NullString `json:"owner"`
+	Startsat    sql.NullTime   `json:"startsat"`
+	Endsat      sql.NullTime   `json:"endsat"`
+	Utmsource   sql.NullString `json:"utmsource"`
+	Utmmedium   sql.NullString `json:"utmmedium"`
+	Utmcampaign sql.NullString `json:"utmcampaign"`
+	Utmterm     sql.NullString `json:"utmterm"`
+	Utmcontent  sql.NullString `json:"utmcontent"`
+	Createdat   sql.NullTime   `json:"createdat"`
+	Updatedat   sql.NullTime   `json:"updatedat"`
+}
+
+type Click struct {
+	ID             int64          `json:"id"`
+	Urlid          int64          `json:"urlid"`
+	Clickedat      time.Time      `json:"clickedat"`
+	Referrer       sql.NullString `json:"referrer"`
+	Useragent      sql.NullString `json:"useragent"`
+	Ipaddress      sql.NullString `json:"ipaddress"`
+	Acceptlanguage sql.NullString `json:"acceptlanguage"`
+	Referrerdomain sql.NullString `json:"referrerdomain"`
+	Browser        sql.NullString `json:"browser"`
+	Os             sql.NullString `json:"os"`
+	Device         sql.NullString `json:"device"`
+	Visitorhash    sql.NullString `json:"visitorhash"`
+}
+
+type IdempotencyKey struct {
+	Idempotencykey string         `json:"idempotencykey"`
+	Requesthash    string         `json:"requesthash"`
+	Response       []byte         `json:"response"`
+	Createdat      time.Time      `json:"createdat"`
+	Passwordhash   sql.NullString `json:"passwordhash"`
+}
+
+type Tag struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type UrlTag struct {
+	Urlid int64 `json:"urlid"`
+	Tagid int64 `json:"tagid"`
+}
+
 type Url struct {
-	ID          int64         `json:"id"`
-	Url         string        `json:"url"`
-	Shortcode   string        `json:"shortcode"`
-	Createdat   sql.NullTime  `json:"createdat"`
-	Updatedat   sql.NullTime  `json:"updatedat"`
-	Accesscount sql.NullInt64 `json:"accesscount"`
+	ID             int64          `json:"id"`
+	Url            string         `json:"url"`
+	Shortcode      string         `json:"shortcode"`
+	Createdat      sql.NullTime   `json:"createdat"`
+	Updatedat      sql.NullTime   `json:"updatedat"`
+	Accesscount    sql.NullInt64  `json:"accesscount"`
+	Redirectstatus int64          `json:"redirectstatus"`
+	Expiresat      sql.NullTime   `json:"expiresat"`
+	Notbefore      sql.NullTime   `json:"notbefore"`
+	Maxclicks      sql.NullInt64  `json:"maxclicks"`
+	Passwordhash   sql.NullString `json:"passwordhash"`
+	Botcount       int64          `json:"botcount"`
+	Domain         sql.NullString `json:"domain"`
+	Campaignid     sql.NullInt64  `json:"campaignid"`
+	Title          sql.NullString `json:"title"`
+	Description    sql.NullString `json:"description"`
+	Notes          sql.NullString `json:"notes"`
+	Version        int64          `json:"version"`
+}
+
+type VisitorSalt struct {
+	Day  string `json:"day"`
+	Salt []byte `json:"salt"`
+}
+
+type VisitorSketch struct {
+	Urlid    int64 `json:"urlid"`
+	Register int64 `json:"register"`
+	Rank     int64 `json:"rank"`
 }
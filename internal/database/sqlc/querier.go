@@ -6,15 +6,65 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
 type Querier interface {
+	AddURLCountsByID(ctx context.Context, arg AddURLCountsByIDParams) error
+	AddURLTag(ctx context.Context, arg AddURLTagParams) error
+	BumpURLVersionByShortCode(ctx context.Context, arg BumpURLVersionByShortCodeParams) (int64, error)
+	CountClicksByBucket(ctx context.Context, arg CountClicksByBucketParams) ([]CountClicksByBucketRow, error)
+	CountClicksByURLID(ctx context.Context, arg CountClicksByURLIDParams) (int64, error)
+	CountUniqueVisitorsByURLID(ctx context.Context, arg CountUniqueVisitorsByURLIDParams) (int64, error)
+	CreateCampaign(ctx context.Context, arg CreateCampaignParams) (Campaign, error)
+	CreateClick(ctx context.Context, arg CreateClickParams) error
+	CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) error
 	CreateURL(ctx context.Context, arg CreateURLParams) (CreateURLRow, error)
+	CreateVisitorSalt(ctx context.Context, arg CreateVisitorSaltParams) error
+	DeleteCampaignByID(ctx context.Context, id int64) (int64, error)
+	DeleteIdempotencyKeysBefore(ctx context.Context, createdat time.Time) error
 	DeleteURLByShortCode(ctx context.Context, shortcode string) error
+	DeleteURLTagsByURLID(ctx context.Context, urlid int64) error
+	DeleteVisitorSaltsBefore(ctx context.Context, day string) error
+	GetCampaignByID(ctx context.Context, id int64) (Campaign, error)
+	GetCampaignStats(ctx context.Context, campaignid sql.NullInt64) (GetCampaignStatsRow, error)
+	GetIdempotencyKey(ctx context.Context, idempotencykey string) (GetIdempotencyKeyRow, error)
+	GetLastURLID(ctx context.Context) (int64, error)
+	GetPlainURLByURL(ctx context.Context, url string) (string, error)
+	GetTagStats(ctx context.Context, name string) (GetTagStatsRow, error)
 	GetURLByShortCode(ctx context.Context, shortcode string) (GetURLByShortCodeRow, error)
 	GetURLStatsByShortCode(ctx context.Context, shortcode string) (Url, error)
-	IncrementURLAccessCountByShortCode(ctx context.Context, shortcode string) error
+	GetVisitorSalt(ctx context.Context, day string) ([]byte, error)
+	IncrementURLAccessCountByShortCode(ctx context.Context, shortcode string) (int64, error)
+	IncrementURLBotCountByShortCode(ctx context.Context, shortcode string) (int64, error)
+	ListCampaigns(ctx context.Context) ([]Campaign, error)
+	ListTags(ctx context.Context) ([]ListTagsRow, error)
+	ListTagsByURLID(ctx context.Context, urlid int64) ([]string, error)
+	ListTagsByURLIDs(ctx context.Context, url_ids string) ([]ListTagsByURLIDsRow, error)
+	ListTopBrowsersByURLID(ctx context.Context, arg ListTopBrowsersByURLIDParams) ([]ListTopBrowsersByURLIDRow, error)
+	ListTopDevicesByURLID(ctx context.Context, arg ListTopDevicesByURLIDParams) ([]ListTopDevicesByURLIDRow, error)
+	ListTopOSByURLID(ctx context.Context, arg ListTopOSByURLIDParams) ([]ListTopOSByURLIDRow, error)
+	ListTopReferrersByURLID(ctx context.Context, arg ListTopReferrersByURLIDParams) ([]ListTopReferrersByURLIDRow, error)
+	ListURLsAfterID(ctx context.Context, arg ListURLsAfterIDParams) ([]Url, error)
+	ListURLsByAccessCount(ctx context.Context, arg ListURLsByAccessCountParams) ([]Url, error)
+	ListURLsByAccessCountDesc(ctx context.Context, arg ListURLsByAccessCountDescParams) ([]Url, error)
+	ListURLsByCreatedAt(ctx context.Context, arg ListURLsByCreatedAtParams) ([]Url, error)
+	ListURLsByCreatedAtDesc(ctx context.Context, arg ListURLsByCreatedAtDescParams) ([]Url, error)
+	ListURLsByUpdatedAt(ctx context.Context, arg ListURLsByUpdatedAtParams) ([]Url, error)
+	ListURLsByUpdatedAtDesc(ctx context.Context, arg ListURLsByUpdatedAtDescParams) ([]Url, error)
+	ListVisitorSketchByCampaignID(ctx context.Context, campaignid sql.NullInt64) ([]ListVisitorSketchByCampaignIDRow, error)
+	ListVisitorSketchByTag(ctx context.Context, name string) ([]ListVisitorSketchByTagRow, error)
+	ListVisitorSketchByURLID(ctx context.Context, urlid int64) ([]ListVisitorSketchByURLIDRow, error)
+	ListVisitorSketchesByURLIDRange(ctx context.Context, arg ListVisitorSketchesByURLIDRangeParams) ([]ListVisitorSketchesByURLIDRangeRow, error)
+	NormalizeURLByID(ctx context.Context, arg NormalizeURLByIDParams) error
+	RestoreURLStatsByID(ctx context.Context, arg RestoreURLStatsByIDParams) error
+	UpdateCampaignByID(ctx context.Context, arg UpdateCampaignByIDParams) (Campaign, error)
 	UpdateURLByShortCode(ctx context.Context, arg UpdateURLByShortCodeParams) (UpdateURLByShortCodeRow, error)
+	UpdateURLCampaignByShortCode(ctx context.Context, arg UpdateURLCampaignByShortCodeParams) error
+	UpdateURLPasswordByShortCode(ctx context.Context, arg UpdateURLPasswordByShortCodeParams) error
+	UpsertTag(ctx context.Context, name string) (int64, error)
+	UpsertVisitorSketch(ctx context.Context, arg UpsertVisitorSketchParams) error
 }
 
 var _ Querier = (*Queries)(nil)
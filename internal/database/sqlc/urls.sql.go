@@ -10,27 +10,98 @@ import (
 	"database/sql"
 )
 
+const addURLCountsByID = `-- name: AddURLCountsByID :exec
+UPDATE urls
+SET accessCount = accessCount + CAST(? AS INTEGER),
+    botCount = botCount + CAST(? AS INTEGER)
+WHERE id = ?
+`
+
+type AddURLCountsByIDParams struct {
+	Clicks int64 `json:"clicks"`
+	Bots   int64 `json:"bots"`
+	ID     int64 `json:"id"`
+}
+
+func (q *Queries) AddURLCountsByID(ctx context.Context, arg AddURLCountsByIDParams) error {
+	_, err := q.db.ExecContext(ctx, addURLCountsByID, arg.Clicks, arg.Bots, arg.ID)
+	return err
+}
+
+const bumpURLVersionByShortCode = `-- name: BumpURLVersionByShortCode :execrows
+UPDATE urls
+SET version = version + 1
+WHERE shortCode = ?
+    AND (? IS NULL OR version = ?)
+`
+
+type BumpURLVersionByShortCodeParams struct {
+	ShortCode string        `json:"short_code"`
+	Version   sql.NullInt64 `json:"version"`
+}
+
+func (q *Queries) BumpURLVersionByShortCode(ctx context.Context, arg BumpURLVersionByShortCodeParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, bumpURLVersionByShortCode, arg.ShortCode, arg.Version, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const createURL = `-- name: CreateURL :one
-INSERT INTO urls (url, shortCode)
-VALUES (?, ?)
-RETURNING id, url, shortCode, createdAt, updatedAt
+INSERT INTO urls (url, shortCode, redirectStatus, expiresAt, notBefore, maxClicks, passwordHash, domain, campaignId, title, description, notes)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, url, shortCode, createdAt, updatedAt, redirectStatus, expiresAt, notBefore, maxClicks, passwordHash, campaignId, title, description, notes, version
 `
 
 type CreateURLParams struct {
-	Url       string `json:"url"`
-	Shortcode string `json:"shortcode"`
+	Url            string         `json:"url"`
+	Shortcode      string         `json:"shortcode"`
+	Redirectstatus int64          `json:"redirectstatus"`
+	Expiresat      sql.NullTime   `json:"expiresat"`
+	Notbefore      sql.NullTime   `json:"notbefore"`
+	Maxclicks      sql.NullInt64  `json:"maxclicks"`
+	Passwordhash   sql.NullString `json:"passwordhash"`
+	Domain         sql.NullString `json:"domain"`
+	Campaignid     sql.NullInt64  `json:"campaignid"`
+	Title          sql.NullString `json:"title"`
+	Description    sql.NullString `json:"description"`
+	Notes          sql.NullString `json:"notes"`
 }
 
 type CreateURLRow struct {
-	ID        int64        `json:"id"`
-	Url       string       `json:"url"`
-	Shortcode string       `json:"shortcode"`
-	Createdat sql.NullTime `json:"createdat"`
-	Updatedat sql.NullTime `json:"updatedat"`
+	ID             int64          `json:"id"`
+	Url            string         `json:"url"`
+	Shortcode      string         `json:"shortcode"`
+	Createdat      sql.NullTime   `json:"createdat"`
+	Updatedat      sql.NullTime   `json:"updatedat"`
+	Redirectstatus int64          `json:"redirectstatus"`
+	Expiresat      sql.NullTime   `json:"expiresat"`
+	Notbefore      sql.NullTime   `json:"notbefore"`
+	Maxclicks      sql.NullInt64  `json:"maxclicks"`
+	Passwordhash   sql.NullString `json:"passwordhash"`
+	Campaignid     sql.NullInt64  `json:"campaignid"`
+	Title          sql.NullString `json:"title"`
+	Description    sql.NullString `json:"description"`
+	Notes          sql.NullString `json:"notes"`
+	Version        int64          `json:"version"`
 }
 
 func (q *Queries) CreateURL(ctx context.Context, arg CreateURLParams) (CreateURLRow, error) {
-	row := q.db.QueryRowContext(ctx, createURL, arg.Url, arg.Shortcode)
+	row := q.db.QueryRowContext(ctx, createURL,
+		arg.Url,
+		arg.Shortcode,
+		arg.Redirectstatus,
+		arg.Expiresat,
+		arg.Notbefore,
+		arg.Maxclicks,
+		arg.Passwordhash,
+		arg.Domain,
+		arg.Campaignid,
+		arg.Title,
+		arg.Description,
+		arg.Notes,
+	)
 	var i CreateURLRow
 	err := row.Scan(
 		&i.ID,
@@ -38,6 +109,16 @@ func (q *Queries) CreateURL(ctx context.Context, arg CreateURLParams) (CreateURL
 		&i.Shortcode,
 		&i.Createdat,
 		&i.Updatedat,
+		&i.Redirectstatus,
+		&i.Expiresat,
+		&i.Notbefore,
+		&i.Maxclicks,
+		&i.Passwordhash,
+		&i.Campaignid,
+		&i.Title,
+		&i.Description,
+		&i.Notes,
+		&i.Version,
 	)
 	return i, err
 }
@@ -52,23 +133,80 @@ func (q *Queries) DeleteURLByShortCode(ctx context.Context, shortcode string) er
 	return err
 }
 
+const getLastURLID = `-- name: GetLastURLID :one
+SELECT CAST(COALESCE(MAX(id), 0) AS INTEGER) AS lastId
+FROM urls
+`
+
+func (q *Queries) GetLastURLID(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getLastURLID)
+	var lastid int64
+	err := row.Scan(&lastid)
+	return lastid, err
+}
+
+const getPlainURLByURL = `-- name: GetPlainURLByURL :one
+SELECT shortCode
+FROM urls
+WHERE url = ?
+    AND passwordHash IS NULL
+    AND expiresAt IS NULL
+    AND notBefore IS NULL
+    AND maxClicks IS NULL
+    AND campaignId IS NULL
+    AND redirectStatus = 302
+    AND title IS NULL
+    AND description IS NULL
+    AND notes IS NULL
+    AND NOT EXISTS (SELECT 1 FROM url_tags WHERE url_tags.urlId = urls.id)
+ORDER BY id
+LIMIT 1
+`
+
+func (q *Queries) GetPlainURLByURL(ctx context.Context, url string) (string, error) {
+	row := q.db.QueryRowContext(ctx, getPlainURLByURL, url)
+	var shortcode string
+	err := row.Scan(&shortcode)
+	return shortcode, err
+}
+
 const getURLByShortCode = `-- name: GetURLByShortCode :one
 SELECT 
     id,
     url,
     shortCode,
     createdAt,
-    updatedAt
+    updatedAt,
+    redirectStatus,
+    expiresAt,
+    notBefore,
+    maxClicks,
+    passwordHash,
+    campaignId,
+    title,
+    description,
+    notes,
+    version
 FROM urls
 WHERE shortCode = ?
 `
 
 type GetURLByShortCodeRow struct {
-	ID        int64        `json:"id"`
-	Url       string       `json:"url"`
-	Shortcode string       `json:"shortcode"`
-	Createdat sql.NullTime `json:"createdat"`
-	Updatedat sql.NullTime `json:"updatedat"`
+	ID             int64          `json:"id"`
+	Url            string         `json:"url"`
+	Shortcode      string         `json:"shortcode"`
+	Createdat      sql.NullTime   `json:"createdat"`
+	Updatedat      sql.NullTime   `json:"updatedat"`
+	Redirectstatus int64          `json:"redirectstatus"`
+	Expiresat      sql.NullTime   `json:"expiresat"`
+	Notbefore      sql.NullTime   `json:"notbefore"`
+	Maxclicks      sql.NullInt64  `json:"maxclicks"`
+	Passwordhash   sql.NullString `json:"passwordhash"`
+	Campaignid     sql.NullInt64  `json:"campaignid"`
+	Title          sql.NullString `json:"title"`
+	Description    sql.NullString `json:"description"`
+	Notes          sql.NullString `json:"notes"`
+	Version        int64          `json:"version"`
 }
 
 func (q *Queries) GetURLByShortCode(ctx context.Context, shortcode string) (GetURLByShortCodeRow, error) {
@@ -80,6 +218,16 @@ func (q *Queries) GetURLByShortCode(ctx context.Context, shortcode string) (GetU
 		&i.Shortcode,
 		&i.Createdat,
 		&i.Updatedat,
+		&i.Redirectstatus,
+		&i.Expiresat,
+		&i.Notbefore,
+		&i.Maxclicks,
+		&i.Passwordhash,
+		&i.Campaignid,
+		&i.Title,
+		&i.Description,
+		&i.Notes,
+		&i.Version,
 	)
 	return i, err
 }
@@ -91,7 +239,19 @@ SELECT
     shortCode,
     createdAt,
     updatedAt,
-    accessCount
+    accessCount,
+    redirectStatus,
+    expiresAt,
+    notBefore,
+    maxClicks,
+    passwordHash,
+    botCount,
+    domain,
+    campaignId,
+    title,
+    description,
+    notes,
+    version
 FROM urls
 WHERE shortCode = ?
 `
@@ -106,44 +266,945 @@ func (q *Queries) GetURLStatsByShortCode(ctx context.Context, shortcode string)
 		&i.Createdat,
 		&i.Updatedat,
 		&i.Accesscount,
+		&i.Redirectstatus,
+		&i.Expiresat,
+		&i.Notbefore,
+		&i.Maxclicks,
+		&i.Passwordhash,
+		&i.Botcount,
+		&i.Domain,
+		&i.Campaignid,
+		&i.Title,
+		&i.Description,
+		&i.Notes,
+		&i.Version,
 	)
 	return i, err
 }
 
-const incrementURLAccessCountByShortCode = `-- name: IncrementURLAccessCountByShortCode :exec
+const incrementURLAccessCountByShortCode = `-- name: IncrementURLAccessCountByShortCode :execrows
 UPDATE urls
 SET accessCount = accessCount + 1
 WHERE shortCode = ?
+    AND (maxClicks IS NULL OR accessCount < maxClicks)
+`
+
+func (q *Queries) IncrementURLAccessCountByShortCode(ctx context.Context, shortcode string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, incrementURLAccessCountByShortCode, shortcode)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const incrementURLBotCountByShortCode = `-- name: IncrementURLBotCountByShortCode :execrows
+UPDATE urls
+SET botCount = botCount + 1
+WHERE shortCode = ?
+    AND (maxClicks IS NULL OR accessCount < maxClicks)
+`
+
+func (q *Queries) IncrementURLBotCountByShortCode(ctx context.Context, shortcode string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, incrementURLBotCountByShortCode, shortcode)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const listURLsAfterID = `-- name: ListURLsAfterID :many
+SELECT
+    id,
+    url,
+    shortCode,
+    createdAt,
+    updatedAt,
+    accessCount,
+    redirectStatus,
+    expiresAt,
+    notBefore,
+    maxClicks,
+    passwordHash,
+    botCount,
+    domain,
+    campaignId,
+    title,
+    description,
+    notes,
+    version
+FROM urls
+WHERE id > ?
+ORDER BY id
+LIMIT ?
+`
+
+type ListURLsAfterIDParams struct {
+	AfterID  int64 `json:"after_id"`
+	RowLimit int64 `json:"row_limit"`
+}
+
+func (q *Queries) ListURLsAfterID(ctx context.Context, arg ListURLsAfterIDParams) ([]Url, error) {
+	rows, err := q.db.QueryContext(ctx, listURLsAfterID, arg.AfterID, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Url{}
+	for rows.Next() {
+		var i Url
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Shortcode,
+			&i.Createdat,
+			&i.Updatedat,
+			&i.Accesscount,
+			&i.Redirectstatus,
+			&i.Expiresat,
+			&i.Notbefore,
+			&i.Maxclicks,
+			&i.Passwordhash,
+			&i.Botcount,
+			&i.Domain,
+			&i.Campaignid,
+			&i.Title,
+			&i.Description,
+			&i.Notes,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listURLsByAccessCount = `-- name: ListURLsByAccessCount :many
+SELECT
+    id,
+    url,
+    shortCode,
+    createdAt,
+    updatedAt,
+    accessCount,
+    redirectStatus,
+    expiresAt,
+    notBefore,
+    maxClicks,
+    passwordHash,
+    botCount,
+    domain,
+    campaignId,
+    title,
+    description,
+    notes,
+    version
+FROM urls
+WHERE accessCount >= CAST(? AS INTEGER)
+    AND (accessCount > CAST(? AS INTEGER) OR id > ?)
+    AND (? IS NULL OR (domain = ? AND passwordHash IS NULL))
+    AND (? IS NULL
+        OR ((url LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\'
+                OR url LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\')
+            AND passwordHash IS NULL)
+        OR title LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\'
+        OR description LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\'
+        OR notes LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\')
+    AND (? IS NULL OR datetime(createdAt) >= CAST(? AS TEXT))
+    AND (? IS NULL OR datetime(createdAt) < CAST(? AS TEXT))
+    AND (? IS NULL OR id IN (
+        SELECT ut.urlId
+        FROM url_tags ut
+        JOIN tags t ON t.id = ut.tagId
+        WHERE t.name = ?
+    ))
+    AND (? IS NULL OR campaignId = ?)
+ORDER BY accessCount, id
+LIMIT ?
+`
+
+type ListURLsByAccessCountParams struct {
+	AfterKey    int64          `json:"after_key"`
+	AfterID     int64          `json:"after_id"`
+	Domain      sql.NullString `json:"domain"`
+	Search      sql.NullString `json:"search"`
+	UrlSearch   sql.NullString `json:"url_search"`
+	CreatedFrom sql.NullString `json:"created_from"`
+	CreatedTo   sql.NullString `json:"created_to"`
+	Tag         sql.NullString `json:"tag"`
+	CampaignID  sql.NullInt64  `json:"campaign_id"`
+	RowLimit    int64          `json:"row_limit"`
+}
+
+func (q *Queries) ListURLsByAccessCount(ctx context.Context, arg ListURLsByAccessCountParams) ([]Url, error) {
+	rows, err := q.db.QueryContext(ctx, listURLsByAccessCount,
+		arg.AfterKey,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.Domain,
+		arg.Domain,
+		arg.Search,
+		arg.Search,
+		arg.UrlSearch,
+		arg.Search,
+		arg.Search,
+		arg.Search,
+		arg.CreatedFrom,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.CreatedTo,
+		arg.Tag,
+		arg.Tag,
+		arg.CampaignID,
+		arg.CampaignID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Url{}
+	for rows.Next() {
+		var i Url
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Shortcode,
+			&i.Createdat,
+			&i.Updatedat,
+			&i.Accesscount,
+			&i.Redirectstatus,
+			&i.Expiresat,
+			&i.Notbefore,
+			&i.Maxclicks,
+			&i.Passwordhash,
+			&i.Botcount,
+			&i.Domain,
+			&i.Campaignid,
+			&i.Title,
+			&i.Description,
+			&i.Notes,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listURLsByAccessCountDesc = `-- name: ListURLsByAccessCountDesc :many
+SELECT
+    id,
+    url,
+    shortCode,
+    createdAt,
+    updatedAt,
+    accessCount,
+    redirectStatus,
+    expiresAt,
+    notBefore,
+    maxClicks,
+    passwordHash,
+    botCount,
+    domain,
+    campaignId,
+    title,
+    description,
+    notes,
+    version
+FROM urls
+WHERE accessCount <= CAST(? AS INTEGER)
+    AND (accessCount < CAST(? AS INTEGER) OR id < ?)
+    AND (? IS NULL OR (domain = ? AND passwordHash IS NULL))
+    AND (? IS NULL
+        OR ((url LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\'
+                OR url LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\')
+            AND passwordHash IS NULL)
+        OR title LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\'
+        OR description LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\'
+        OR notes LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\')
+    AND (? IS NULL OR datetime(createdAt) >= CAST(? AS TEXT))
+    AND (? IS NULL OR datetime(createdAt) < CAST(? AS TEXT))
+    AND (? IS NULL OR id IN (
+        SELECT ut.urlId
+        FROM url_tags ut
+        JOIN tags t ON t.id = ut.tagId
+        WHERE t.name = ?
+    ))
+    AND (? IS NULL OR campaignId = ?)
+ORDER BY accessCount DESC, id DESC
+LIMIT ?
 `
 
-func (q *Queries) IncrementURLAccessCountByShortCode(ctx context.Context, shortcode string) error {
-	_, err := q.db.ExecContext(ctx, incrementURLAccessCountByShortCode, shortcode)
+type ListURLsByAccessCountDescParams struct {
+	AfterKey    int64          `json:"after_key"`
+	AfterID     int64          `json:"after_id"`
+	Domain      sql.NullString `json:"domain"`
+	Search      sql.NullString `json:"search"`
+	UrlSearch   sql.NullString `json:"url_search"`
+	CreatedFrom sql.NullString `json:"created_from"`
+	CreatedTo   sql.NullString `json:"created_to"`
+	Tag         sql.NullString `json:"tag"`
+	CampaignID  sql.NullInt64  `json:"campaign_id"`
+	RowLimit    int64          `json:"row_limit"`
+}
+
+func (q *Queries) ListURLsByAccessCountDesc(ctx context.Context, arg ListURLsByAccessCountDescParams) ([]Url, error) {
+	rows, err := q.db.QueryContext(ctx, listURLsByAccessCountDesc,
+		arg.AfterKey,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.Domain,
+		arg.Domain,
+		arg.Search,
+		arg.Search,
+		arg.UrlSearch,
+		arg.Search,
+		arg.Search,
+		arg.Search,
+		arg.CreatedFrom,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.CreatedTo,
+		arg.Tag,
+		arg.Tag,
+		arg.CampaignID,
+		arg.CampaignID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Url{}
+	for rows.Next() {
+		var i Url
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Shortcode,
+			&i.Createdat,
+			&i.Updatedat,
+			&i.Accesscount,
+			&i.Redirectstatus,
+			&i.Expiresat,
+			&i.Notbefore,
+			&i.Maxclicks,
+			&i.Passwordhash,
+			&i.Botcount,
+			&i.Domain,
+			&i.Campaignid,
+			&i.Title,
+			&i.Description,
+			&i.Notes,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listURLsByCreatedAt = `-- name: ListURLsByCreatedAt :many
+SELECT
+    id,
+    url,
+    shortCode,
+    createdAt,
+    updatedAt,
+    accessCount,
+    redirectStatus,
+    expiresAt,
+    notBefore,
+    maxClicks,
+    passwordHash,
+    botCount,
+    domain,
+    campaignId,
+    title,
+    description,
+    notes,
+    version
+FROM urls
+WHERE datetime(createdAt) >= CAST(? AS TEXT)
+    AND (datetime(createdAt) > CAST(? AS TEXT) OR id > ?)
+    AND (? IS NULL OR (domain = ? AND passwordHash IS NULL))
+    AND (? IS NULL
+        OR ((url LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\'
+                OR url LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\')
+            AND passwordHash IS NULL)
+        OR title LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\'
+        OR description LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\'
+        OR notes LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\')
+    AND (? IS NULL OR datetime(createdAt) >= CAST(? AS TEXT))
+    AND (? IS NULL OR datetime(createdAt) < CAST(? AS TEXT))
+    AND (? IS NULL OR id IN (
+        SELECT ut.urlId
+        FROM url_tags ut
+        JOIN tags t ON t.id = ut.tagId
+        WHERE t.name = ?
+    ))
+    AND (? IS NULL OR campaignId = ?)
+ORDER BY datetime(createdAt), id
+LIMIT ?
+`
+
+type ListURLsByCreatedAtParams struct {
+	AfterKey    string         `json:"after_key"`
+	AfterID     int64          `json:"after_id"`
+	Domain      sql.NullString `json:"domain"`
+	Search      sql.NullString `json:"search"`
+	UrlSearch   sql.NullString `json:"url_search"`
+	CreatedFrom sql.NullString `json:"created_from"`
+	CreatedTo   sql.NullString `json:"created_to"`
+	Tag         sql.NullString `json:"tag"`
+	CampaignID  sql.NullInt64  `json:"campaign_id"`
+	RowLimit    int64          `json:"row_limit"`
+}
+
+func (q *Queries) ListURLsByCreatedAt(ctx context.Context, arg ListURLsByCreatedAtParams) ([]Url, error) {
+	rows, err := q.db.QueryContext(ctx, listURLsByCreatedAt,
+		arg.AfterKey,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.Domain,
+		arg.Domain,
+		arg.Search,
+		arg.Search,
+		arg.UrlSearch,
+		arg.Search,
+		arg.Search,
+		arg.Search,
+		arg.CreatedFrom,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.CreatedTo,
+		arg.Tag,
+		arg.Tag,
+		arg.CampaignID,
+		arg.CampaignID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Url{}
+	for rows.Next() {
+		var i Url
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Shortcode,
+			&i.Createdat,
+			&i.Updatedat,
+			&i.Accesscount,
+			&i.Redirectstatus,
+			&i.Expiresat,
+			&i.Notbefore,
+			&i.Maxclicks,
+			&i.Passwordhash,
+			&i.Botcount,
+			&i.Domain,
+			&i.Campaignid,
+			&i.Title,
+			&i.Description,
+			&i.Notes,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listURLsByCreatedAtDesc = `-- name: ListURLsByCreatedAtDesc :many
+SELECT
+    id,
+    url,
+    shortCode,
+    createdAt,
+    updatedAt,
+    accessCount,
+    redirectStatus,
+    expiresAt,
+    notBefore,
+    maxClicks,
+    passwordHash,
+    botCount,
+    domain,
+    campaignId,
+    title,
+    description,
+    notes,
+    version
+FROM urls
+WHERE datetime(createdAt) <= CAST(? AS TEXT)
+    AND (datetime(createdAt) < CAST(? AS TEXT) OR id < ?)
+    AND (? IS NULL OR (domain = ? AND passwordHash IS NULL))
+    AND (? IS NULL
+        OR ((url LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\'
+                OR url LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\')
+            AND passwordHash IS NULL)
+        OR title LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\'
+        OR description LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\'
+        OR notes LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\')
+    AND (? IS NULL OR datetime(createdAt) >= CAST(? AS TEXT))
+    AND (? IS NULL OR datetime(createdAt) < CAST(? AS TEXT))
+    AND (? IS NULL OR id IN (
+        SELECT ut.urlId
+        FROM url_tags ut
+        JOIN tags t ON t.id = ut.tagId
+        WHERE t.name = ?
+    ))
+    AND (? IS NULL OR campaignId = ?)
+ORDER BY datetime(createdAt) DESC, id DESC
+LIMIT ?
+`
+
+type ListURLsByCreatedAtDescParams struct {
+	AfterKey    string         `json:"after_key"`
+	AfterID     int64          `json:"after_id"`
+	Domain      sql.NullString `json:"domain"`
+	Search      sql.NullString `json:"search"`
+	UrlSearch   sql.NullString `json:"url_search"`
+	CreatedFrom sql.NullString `json:"created_from"`
+	CreatedTo   sql.NullString `json:"created_to"`
+	Tag         sql.NullString `json:"tag"`
+	CampaignID  sql.NullInt64  `json:"campaign_id"`
+	RowLimit    int64          `json:"row_limit"`
+}
+
+func (q *Queries) ListURLsByCreatedAtDesc(ctx context.Context, arg ListURLsByCreatedAtDescParams) ([]Url, error) {
+	rows, err := q.db.QueryContext(ctx, listURLsByCreatedAtDesc,
+		arg.AfterKey,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.Domain,
+		arg.Domain,
+		arg.Search,
+		arg.Search,
+		arg.UrlSearch,
+		arg.Search,
+		arg.Search,
+		arg.Search,
+		arg.CreatedFrom,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.CreatedTo,
+		arg.Tag,
+		arg.Tag,
+		arg.CampaignID,
+		arg.CampaignID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Url{}
+	for rows.Next() {
+		var i Url
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Shortcode,
+			&i.Createdat,
+			&i.Updatedat,
+			&i.Accesscount,
+			&i.Redirectstatus,
+			&i.Expiresat,
+			&i.Notbefore,
+			&i.Maxclicks,
+			&i.Passwordhash,
+			&i.Botcount,
+			&i.Domain,
+			&i.Campaignid,
+			&i.Title,
+			&i.Description,
+			&i.Notes,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listURLsByUpdatedAt = `-- name: ListURLsByUpdatedAt :many
+SELECT
+    id,
+    url,
+    shortCode,
+    createdAt,
+    updatedAt,
+    accessCount,
+    redirectStatus,
+    expiresAt,
+    notBefore,
+    maxClicks,
+    passwordHash,
+    botCount,
+    domain,
+    campaignId,
+    title,
+    description,
+    notes,
+    version
+FROM urls
+WHERE datetime(COALESCE(updatedAt, createdAt)) >= CAST(? AS TEXT)
+    AND (datetime(COALESCE(updatedAt, createdAt)) > CAST(? AS TEXT) OR id > ?)
+    AND (? IS NULL OR (domain = ? AND passwordHash IS NULL))
+    AND (? IS NULL
+        OR ((url LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\'
+                OR url LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\')
+            AND passwordHash IS NULL)
+        OR title LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\'
+        OR description LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\'
+        OR notes LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\')
+    AND (? IS NULL OR datetime(createdAt) >= CAST(? AS TEXT))
+    AND (? IS NULL OR datetime(createdAt) < CAST(? AS TEXT))
+    AND (? IS NULL OR id IN (
+        SELECT ut.urlId
+        FROM url_tags ut
+        JOIN tags t ON t.id = ut.tagId
+        WHERE t.name = ?
+    ))
+    AND (? IS NULL OR campaignId = ?)
+ORDER BY datetime(COALESCE(updatedAt, createdAt)), id
+LIMIT ?
+`
+
+type ListURLsByUpdatedAtParams struct {
+	AfterKey    string         `json:"after_key"`
+	AfterID     int64          `json:"after_id"`
+	Domain      sql.NullString `json:"domain"`
+	Search      sql.NullString `json:"search"`
+	UrlSearch   sql.NullString `json:"url_search"`
+	CreatedFrom sql.NullString `json:"created_from"`
+	CreatedTo   sql.NullString `json:"created_to"`
+	Tag         sql.NullString `json:"tag"`
+	CampaignID  sql.NullInt64  `json:"campaign_id"`
+	RowLimit    int64          `json:"row_limit"`
+}
+
+func (q *Queries) ListURLsByUpdatedAt(ctx context.Context, arg ListURLsByUpdatedAtParams) ([]Url, error) {
+	rows, err := q.db.QueryContext(ctx, listURLsByUpdatedAt,
+		arg.AfterKey,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.Domain,
+		arg.Domain,
+		arg.Search,
+		arg.Search,
+		arg.UrlSearch,
+		arg.Search,
+		arg.Search,
+		arg.Search,
+		arg.CreatedFrom,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.CreatedTo,
+		arg.Tag,
+		arg.Tag,
+		arg.CampaignID,
+		arg.CampaignID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Url{}
+	for rows.Next() {
+		var i Url
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Shortcode,
+			&i.Createdat,
+			&i.Updatedat,
+			&i.Accesscount,
+			&i.Redirectstatus,
+			&i.Expiresat,
+			&i.Notbefore,
+			&i.Maxclicks,
+			&i.Passwordhash,
+			&i.Botcount,
+			&i.Domain,
+			&i.Campaignid,
+			&i.Title,
+			&i.Description,
+			&i.Notes,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listURLsByUpdatedAtDesc = `-- name: ListURLsByUpdatedAtDesc :many
+SELECT
+    id,
+    url,
+    shortCode,
+    createdAt,
+    updatedAt,
+    accessCount,
+    redirectStatus,
+    expiresAt,
+    notBefore,
+    maxClicks,
+    passwordHash,
+    botCount,
+    domain,
+    campaignId,
+    title,
+    description,
+    notes,
+    version
+FROM urls
+WHERE datetime(COALESCE(updatedAt, createdAt)) <= CAST(? AS TEXT)
+    AND (datetime(COALESCE(updatedAt, createdAt)) < CAST(? AS TEXT) OR id < ?)
+    AND (? IS NULL OR (domain = ? AND passwordHash IS NULL))
+    AND (? IS NULL
+        OR ((url LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\'
+                OR url LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\')
+            AND passwordHash IS NULL)
+        OR title LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\'
+        OR description LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\'
+        OR notes LIKE '%' || CAST(? AS TEXT) || '%' ESCAPE '\')
+    AND (? IS NULL OR datetime(createdAt) >= CAST(? AS TEXT))
+    AND (? IS NULL OR datetime(createdAt) < CAST(? AS TEXT))
+    AND (? IS NULL OR id IN (
+        SELECT ut.urlId
+        FROM url_tags ut
+        JOIN tags t ON t.id = ut.tagId
+        WHERE t.name = ?
+    ))
+    AND (? IS NULL OR campaignId = ?)
+ORDER BY datetime(COALESCE(updatedAt, createdAt)) DESC, id DESC
+LIMIT ?
+`
+
+type ListURLsByUpdatedAtDescParams struct {
+	AfterKey    string         `json:"after_key"`
+	AfterID     int64          `json:"after_id"`
+	Domain      sql.NullString `json:"domain"`
+	Search      sql.NullString `json:"search"`
+	UrlSearch   sql.NullString `json:"url_search"`
+	CreatedFrom sql.NullString `json:"created_from"`
+	CreatedTo   sql.NullString `json:"created_to"`
+	Tag         sql.NullString `json:"tag"`
+	CampaignID  sql.NullInt64  `json:"campaign_id"`
+	RowLimit    int64          `json:"row_limit"`
+}
+
+func (q *Queries) ListURLsByUpdatedAtDesc(ctx context.Context, arg ListURLsByUpdatedAtDescParams) ([]Url, error) {
+	rows, err := q.db.QueryContext(ctx, listURLsByUpdatedAtDesc,
+		arg.AfterKey,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.Domain,
+		arg.Domain,
+		arg.Search,
+		arg.Search,
+		arg.UrlSearch,
+		arg.Search,
+		arg.Search,
+		arg.Search,
+		arg.CreatedFrom,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.CreatedTo,
+		arg.Tag,
+		arg.Tag,
+		arg.CampaignID,
+		arg.CampaignID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Url{}
+	for rows.Next() {
+		var i Url
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Shortcode,
+			&i.Createdat,
+			&i.Updatedat,
+			&i.Accesscount,
+			&i.Redirectstatus,
+			&i.Expiresat,
+			&i.Notbefore,
+			&i.Maxclicks,
+			&i.Passwordhash,
+			&i.Botcount,
+			&i.Domain,
+			&i.Campaignid,
+			&i.Title,
+			&i.Description,
+			&i.Notes,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const normalizeURLByID = `-- name: NormalizeURLByID :exec
+UPDATE urls
+SET url = ?,
+    domain = ?,
+    version = version + 1
+WHERE id = ?
+`
+
+type NormalizeURLByIDParams struct {
+	Url    string         `json:"url"`
+	Domain sql.NullString `json:"domain"`
+	ID     int64          `json:"id"`
+}
+
+func (q *Queries) NormalizeURLByID(ctx context.Context, arg NormalizeURLByIDParams) error {
+	_, err := q.db.ExecContext(ctx, normalizeURLByID, arg.Url, arg.Domain, arg.ID)
+	return err
+}
+
+const restoreURLStatsByID = `-- name: RestoreURLStatsByID :exec
+UPDATE urls
+SET createdAt = COALESCE(?, createdAt),
+    updatedAt = ?,
+    accessCount = ?,
+    botCount = ?
+WHERE id = ?
+`
+
+type RestoreURLStatsByIDParams struct {
+	CreatedAt   sql.NullTime `json:"created_at"`
+	UpdatedAt   sql.NullTime `json:"updated_at"`
+	AccessCount int64        `json:"access_count"`
+	BotCount    int64        `json:"bot_count"`
+	ID          int64        `json:"id"`
+}
+
+func (q *Queries) RestoreURLStatsByID(ctx context.Context, arg RestoreURLStatsByIDParams) error {
+	_, err := q.db.ExecContext(ctx, restoreURLStatsByID,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+		arg.AccessCount,
+		arg.BotCount,
+		arg.ID,
+	)
 	return err
 }
 
 const updateURLByShortCode = `-- name: UpdateURLByShortCode :one
 UPDATE urls
-SET url = ?, updatedAt = ?
+SET url = ?, redirectStatus = ?, expiresAt = ?, notBefore = ?, maxClicks = ?, updatedAt = ?, domain = ?, title = ?, description = ?, notes = ?
 WHERE shortCode = ?
-RETURNING id, url, shortCode, createdAt, updatedAt
+RETURNING id, url, shortCode, createdAt, updatedAt, redirectStatus, expiresAt, notBefore, maxClicks, passwordHash, campaignId, title, description, notes, version
 `
 
 type UpdateURLByShortCodeParams struct {
-	Url       string       `json:"url"`
-	Updatedat sql.NullTime `json:"updatedat"`
-	Shortcode string       `json:"shortcode"`
+	Url            string         `json:"url"`
+	Redirectstatus int64          `json:"redirectstatus"`
+	Expiresat      sql.NullTime   `json:"expiresat"`
+	Notbefore      sql.NullTime   `json:"notbefore"`
+	Maxclicks      sql.NullInt64  `json:"maxclicks"`
+	Updatedat      sql.NullTime   `json:"updatedat"`
+	Domain         sql.NullString `json:"domain"`
+	Title          sql.NullString `json:"title"`
+	Description    sql.NullString `json:"description"`
+	Notes          sql.NullString `json:"notes"`
+	Shortcode      string         `json:"shortcode"`
 }
 
 type UpdateURLByShortCodeRow struct {
-	ID        int64        `json:"id"`
-	Url       string       `json:"url"`
-	Shortcode string       `json:"shortcode"`
-	Createdat sql.NullTime `json:"createdat"`
-	Updatedat sql.NullTime `json:"updatedat"`
+	ID             int64          `json:"id"`
+	Url            string         `json:"url"`
+	Shortcode      string         `json:"shortcode"`
+	Createdat      sql.NullTime   `json:"createdat"`
+	Updatedat      sql.NullTime   `json:"updatedat"`
+	Redirectstatus int64          `json:"redirectstatus"`
+	Expiresat      sql.NullTime   `json:"expiresat"`
+	Notbefore      sql.NullTime   `json:"notbefore"`
+	Maxclicks      sql.NullInt64  `json:"maxclicks"`
+	Passwordhash   sql.NullString `json:"passwordhash"`
+	Campaignid     sql.NullInt64  `json:"campaignid"`
+	Title          sql.NullString `json:"title"`
+	Description    sql.NullString `json:"description"`
+	Notes          sql.NullString `json:"notes"`
+	Version        int64          `json:"version"`
 }
 
 func (q *Queries) UpdateURLByShortCode(ctx context.Context, arg UpdateURLByShortCodeParams) (UpdateURLByShortCodeRow, error) {
-	row := q.db.QueryRowContext(ctx, updateURLByShortCode, arg.Url, arg.Updatedat, arg.Shortcode)
+	row := q.db.QueryRowContext(ctx, updateURLByShortCode,
+		arg.Url,
+		arg.Redirectstatus,
+		arg.Expiresat,
+		arg.Notbefore,
+		arg.Maxclicks,
+		arg.Updatedat,
+		arg.Domain,
+		arg.Title,
+		arg.Description,
+		arg.Notes,
+		arg.Shortcode,
+	)
 	var i UpdateURLByShortCodeRow
 	err := row.Scan(
 		&i.ID,
@@ -151,6 +1212,48 @@ func (q *Queries) UpdateURLByShortCode(ctx context.Context, arg UpdateURLByShort
 		&i.Shortcode,
 		&i.Createdat,
 		&i.Updatedat,
+		&i.Redirectstatus,
+		&i.Expiresat,
+		&i.Notbefore,
+		&i.Maxclicks,
+		&i.Passwordhash,
+		&i.Campaignid,
+		&i.Title,
+		&i.Description,
+		&i.Notes,
+		&i.Version,
 	)
 	return i, err
 }
+
+const updateURLCampaignByShortCode = `-- name: UpdateURLCampaignByShortCode :exec
+UPDATE urls
+SET campaignId = ?
+WHERE shortCode = ?
+`
+
+type UpdateURLCampaignByShortCodeParams struct {
+	Campaignid sql.NullInt64 `json:"campaignid"`
+	Shortcode  string        `json:"shortcode"`
+}
+
+func (q *Queries) UpdateURLCampaignByShortCode(ctx context.Context, arg UpdateURLCampaignByShortCodeParams) error {
+	_, err := q.db.ExecContext(ctx, updateURLCampaignByShortCode, arg.Campaignid, arg.Shortcode)
+	return err
+}
+
+const updateURLPasswordByShortCode = `-- name: UpdateURLPasswordByShortCode :exec
+UPDATE urls
+SET passwordHash = ?
+WHERE shortCode = ?
+`
+
+type UpdateURLPasswordByShortCodeParams struct {
+	Passwordhash sql.NullString `json:"passwordhash"`
+	Shortcode    string         `json:"shortcode"`
+}
+
+func (q *Queries) UpdateURLPasswordByShortCode(ctx context.Context, arg UpdateURLPasswordByShortCodeParams) error {
+	_, err := q.db.ExecContext(ctx, updateURLPasswordByShortCode, arg.Passwordhash, arg.Shortcode)
+	return err
+}
@@ -0,0 +1,46 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
+)
+
+// Store runs the generated queries, either one by one or grouped in a
+// transaction with ExecTx.
+type Store interface {
+	db.Querier
+	// ExecTx runs fn inside a transaction. The transaction is committed when
+	// fn returns nil and rolled back otherwise.
+	ExecTx(ctx context.Context, fn func(db.Querier) error) error
+}
+
+type SQLStore struct {
+	*db.Queries
+	conn *sql.DB
+}
+
+func NewStore(conn *sql.DB) Store {
+	return &SQLStore{
+		Queries: db.New(conn),
+		conn:    conn,
+	}
+}
+
+func (s *SQLStore) ExecTx(ctx context.Context, fn func(db.Querier) error) error {
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(s.Queries.WithTx(tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback: %v)", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"context"
+	"strings"
+)
+
+// Base62 encodes the next row id in base 62, left padded with the zero
+// digit. Codes are short and never collide with each other, but they are
+// sequential and therefore easy to enumerate.
+type Base62 struct {
+	sequence *Sequence
+}
+
+func NewBase62(sequence *Sequence) *Base62 {
+	return &Base62{
+		sequence: sequence,
+	}
+}
+
+func (g *Base62) Generate(ctx context.Context, length int) (string, error) {
+	id, err := g.sequence.Next(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	code := EncodeBase62(uint64(id))
+	if len(code) < length {
+		code = strings.Repeat(Base62Alphabet[:1], length-len(code)) + code
+	}
+
+	return code, nil
+}
+
+// EncodeBase62 returns n written with Base62Alphabet as digits.
+func EncodeBase62(n uint64) string {
+	base := uint64(len(Base62Alphabet))
+
+	var b []byte
+	for {
+		b = append([]byte{Base62Alphabet[n%base]}, b...)
+		n /= base
+		if n == 0 {
+			break
+		}
+	}
+
+	return string(b)
+}
@@ -0,0 +1,86 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+)
+
+// Base62Alphabet is the set of characters used by every generator.
+const Base62Alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// maxLoadFactor is the share of the keyspace of a given length that may be
+// used before LengthFor moves on to longer codes.
+const maxLoadFactor = 0.001
+
+const (
+	KindRandom = "random"
+	KindBase62 = "base62"
+	KindSqids  = "sqids"
+)
+
+// CodeGenerator produces candidate short codes. Implementations do not need
+// to guarantee uniqueness: the controller retries when a candidate collides
+// with an existing short code.
+type CodeGenerator interface {
+	// Generate returns a short code of at least length characters.
+	Generate(ctx context.Context, length int) (string, error)
+}
+
+// LastIDFunc returns the highest row id used so far.
+type LastIDFunc func(ctx context.Context) (int64, error)
+
+// New builds the generator named by kind. The row id based generators read
+// their starting point from lastID.
+func New(kind string, lastID LastIDFunc) (CodeGenerator, error) {
+	switch kind {
+	case "", KindRandom:
+		return NewRandom(), nil
+	case KindBase62:
+		return NewBase62(NewSequence(lastID)), nil
+	case KindSqids:
+		return NewSqids(NewSequence(lastID), Base62Alphabet)
+	}
+
+	return nil, fmt.Errorf("unknown code generator %q", kind)
+}
+
+// LengthFor returns the shortest code length, never below minLength, for
+// which count codes fill less than maxLoadFactor of the keyspace. Keeping the
+// keyspace sparse keeps the chance of a random collision low as links pile up.
+func LengthFor(count int64, minLength int) int {
+	length := minLength
+	for float64(count) >= math.Pow(float64(len(Base62Alphabet)), float64(length))*maxLoadFactor {
+		length++
+	}
+
+	return length
+}
+
+// Sequence hands out increasing numbers starting after the last row id, so a
+// retry after a collision never reuses a number.
+type Sequence struct {
+	mu     sync.Mutex
+	last   int64
+	lastID LastIDFunc
+}
+
+func NewSequence(lastID LastIDFunc) *Sequence {
+	return &Sequence{
+		lastID: lastID,
+	}
+}
+
+func (s *Sequence) Next(ctx context.Context) (int64, error) {
+	current, err := s.lastID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.last = max(s.last, current) + 1
+	return s.last, nil
+}
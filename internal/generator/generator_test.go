@@ -0,0 +1,107 @@
+package generator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func lastIDFrom(id int64) LastIDFunc {
+	return func(ctx context.Context) (int64, error) {
+		return id, nil
+	}
+}
+
+func TestRandom_Generate(t *testing.T) {
+	g := NewRandom()
+
+	for _, length := range []int{1, 6, 12} {
+		code, err := g.Generate(context.TODO(), length)
+		assert.NoError(t, err)
+		assert.Len(t, code, length, "The code length is not the expected")
+
+		for _, r := range code {
+			assert.True(t, strings.ContainsRune(Base62Alphabet, r), "The code has a character outside the alphabet")
+		}
+	}
+}
+
+func TestBase62_Generate(t *testing.T) {
+	g := NewBase62(NewSequence(lastIDFrom(61)))
+
+	code, err := g.Generate(context.TODO(), 6)
+	assert.NoError(t, err)
+	assert.Equal(t, "aaaaba", code, "The code is not the expected")
+
+	// A retry must not reuse the same id even if the table did not change.
+	code, err = g.Generate(context.TODO(), 6)
+	assert.NoError(t, err)
+	assert.Equal(t, "aaaabb", code, "The code is not the expected")
+}
+
+func TestEncodeBase62(t *testing.T) {
+	tests := []struct {
+		n    uint64
+		want string
+	}{
+		{n: 0, want: "a"},
+		{n: 61, want: "9"},
+		{n: 62, want: "ba"},
+		{n: 3843, want: "99"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, EncodeBase62(tt.n), "The encoded value is not the expected")
+	}
+}
+
+func TestSqids(t *testing.T) {
+	g, err := NewSqids(NewSequence(lastIDFrom(0)), Base62Alphabet)
+	assert.NoError(t, err)
+
+	// Reference values from the Sqids specification.
+	assert.Equal(t, "86Rf07", g.encode([]uint64{1, 2, 3}, 0))
+	assert.Equal(t, "86Rf07xd4z", g.encode([]uint64{1, 2, 3}, 10))
+	assert.Equal(t, []uint64{1, 2, 3}, g.decode("86Rf07xd4z"))
+
+	seen := map[string]struct{}{}
+	for i := 0; i < 1000; i++ {
+		code, err := g.Generate(context.TODO(), 6)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, len(code), 6, "The code is shorter than requested")
+
+		n, err := g.Decode(code)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(i+1), n, "The code does not decode to its id")
+
+		_, dup := seen[code]
+		assert.False(t, dup, "The code %s was generated twice", code)
+		seen[code] = struct{}{}
+	}
+
+	_, err = g.Decode("not a sqid")
+	assert.ErrorIs(t, err, ErrInvalidSqid)
+
+	_, err = NewSqids(NewSequence(lastIDFrom(0)), "aab")
+	assert.ErrorIs(t, err, ErrInvalidAlphabet)
+}
+
+func TestLengthFor(t *testing.T) {
+	assert.Equal(t, 6, LengthFor(0, 6))
+	assert.Equal(t, 6, LengthFor(50_000_000, 6))
+	assert.Equal(t, 7, LengthFor(60_000_000, 6))
+	assert.Equal(t, 4, LengthFor(10, 4))
+	assert.Equal(t, 5, LengthFor(20_000, 4))
+}
+
+func TestNew(t *testing.T) {
+	for _, kind := range []string{"", KindRandom, KindBase62, KindSqids} {
+		g, err := New(kind, lastIDFrom(0))
+		assert.NoError(t, err)
+		assert.NotNil(t, g)
+	}
+
+	_, err := New("uuid", lastIDFrom(0))
+	assert.Error(t, err)
+}
@@ -0,0 +1,30 @@
+package generator
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+)
+
+// Random generates codes from a cryptographically secure source, so codes
+// cannot be guessed from previous ones.
+type Random struct{}
+
+func NewRandom() *Random {
+	return &Random{}
+}
+
+func (g *Random) Generate(_ context.Context, length int) (string, error) {
+	size := big.NewInt(int64(len(Base62Alphabet)))
+
+	b := make([]byte, length)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, size)
+		if err != nil {
+			return "", err
+		}
+		b[i] = Base62Alphabet[n.Int64()]
+	}
+
+	return string(b), nil
+}
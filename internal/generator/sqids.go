@@ -0,0 +1,183 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidAlphabet = errors.New("sqids alphabet must have at least 3 unique ASCII characters")
+	ErrInvalidSqid     = errors.New("invalid sqid")
+)
+
+// Sqids encodes the next row id following the Sqids algorithm
+// (https://sqids.org): codes look random, are unique for every id and can be
+// decoded back into the id they were built from.
+type Sqids struct {
+	sequence *Sequence
+	alphabet []byte
+}
+
+func NewSqids(sequence *Sequence, alphabet string) (*Sqids, error) {
+	if len(alphabet) < 3 {
+		return nil, ErrInvalidAlphabet
+	}
+
+	seen := make(map[rune]struct{}, len(alphabet))
+	for _, r := range alphabet {
+		if r > 127 {
+			return nil, ErrInvalidAlphabet
+		}
+		if _, ok := seen[r]; ok {
+			return nil, ErrInvalidAlphabet
+		}
+		seen[r] = struct{}{}
+	}
+
+	return &Sqids{
+		sequence: sequence,
+		alphabet: shuffle([]byte(alphabet)),
+	}, nil
+}
+
+func (g *Sqids) Generate(ctx context.Context, length int) (string, error) {
+	id, err := g.sequence.Next(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return g.encode([]uint64{uint64(id)}, length), nil
+}
+
+// Encode returns the code for n padded to at least minLength characters.
+func (g *Sqids) Encode(n uint64, minLength int) string {
+	return g.encode([]uint64{n}, minLength)
+}
+
+// Decode returns the number a code was built from.
+func (g *Sqids) Decode(code string) (uint64, error) {
+	numbers := g.decode(code)
+	if len(numbers) != 1 || g.encode(numbers, len(code)) != code {
+		return 0, ErrInvalidSqid
+	}
+
+	return numbers[0], nil
+}
+
+func (g *Sqids) encode(numbers []uint64, minLength int) string {
+	size := uint64(len(g.alphabet))
+
+	offset := uint64(len(numbers))
+	for i, n := range numbers {
+		offset += uint64(g.alphabet[n%size]) + uint64(i)
+	}
+	offset %= size
+
+	alphabet := append(append([]byte{}, g.alphabet[offset:]...), g.alphabet[:offset]...)
+	prefix := alphabet[0]
+	reverse(alphabet)
+
+	var b strings.Builder
+	b.WriteByte(prefix)
+
+	for i, n := range numbers {
+		b.WriteString(toID(n, alphabet[1:]))
+
+		if i < len(numbers)-1 {
+			b.WriteByte(alphabet[0])
+			alphabet = shuffle(alphabet)
+		}
+	}
+
+	if b.Len() < minLength {
+		b.WriteByte(alphabet[0])
+
+		for b.Len() < minLength {
+			alphabet = shuffle(alphabet)
+			b.Write(alphabet[:min(minLength-b.Len(), len(alphabet))])
+		}
+	}
+
+	return b.String()
+}
+
+func (g *Sqids) decode(code string) []uint64 {
+	if code == "" {
+		return nil
+	}
+
+	for i := 0; i < len(code); i++ {
+		if strings.IndexByte(string(g.alphabet), code[i]) < 0 {
+			return nil
+		}
+	}
+
+	offset := strings.IndexByte(string(g.alphabet), code[0])
+	alphabet := append(append([]byte{}, g.alphabet[offset:]...), g.alphabet[:offset]...)
+	reverse(alphabet)
+
+	var numbers []uint64
+	rest := code[1:]
+	for rest != "" {
+		chunks := strings.SplitN(rest, string(alphabet[0]), 2)
+		if chunks[0] == "" {
+			break
+		}
+
+		numbers = append(numbers, toNumber(chunks[0], alphabet[1:]))
+		if len(chunks) == 1 {
+			break
+		}
+
+		alphabet = shuffle(alphabet)
+		rest = chunks[1]
+	}
+
+	return numbers
+}
+
+func toID(n uint64, alphabet []byte) string {
+	size := uint64(len(alphabet))
+
+	var id []byte
+	for {
+		id = append([]byte{alphabet[n%size]}, id...)
+		n /= size
+		if n == 0 {
+			break
+		}
+	}
+
+	return string(id)
+}
+
+func toNumber(id string, alphabet []byte) uint64 {
+	size := uint64(len(alphabet))
+
+	var n uint64
+	for i := 0; i < len(id); i++ {
+		n = n*size + uint64(strings.IndexByte(string(alphabet), id[i]))
+	}
+
+	return n
+}
+
+// shuffle deterministically reorders the alphabet as described by the Sqids
+// specification.
+func shuffle(alphabet []byte) []byte {
+	chars := append([]byte{}, alphabet...)
+
+	for i, j := 0, len(chars)-1; j > 0; i, j = i+1, j-1 {
+		r := (i*j + int(chars[i]) + int(chars[j])) % len(chars)
+		chars[i], chars[r] = chars[r], chars[i]
+	}
+
+	return chars
+}
+
+func reverse(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	"github.com/DarcoProgramador/shortener-go-backend/utils"
+)
+
+// campaignID reads the campaign id of the path.
+func campaignID(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, utils.ErrInvalidCampaignID
+	}
+
+	return id, nil
+}
+
+func (h *Handlers) CreateCampaign(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var requestData models.CampaignRequest
+
+	err := json.NewDecoder(r.Body).Decode(&requestData)
+	if err != nil {
+		h.writeProblem(w, r, errInvalidRequest)
+		return
+	}
+
+	data, err := h.controller.CreateCampaign(r.Context(), requestData)
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	responseData, err := json.Marshal(data)
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write(responseData)
+}
+
+func (h *Handlers) ListCampaigns(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	data, err := h.controller.ListCampaigns(r.Context())
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	responseData, err := json.Marshal(data)
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseData)
+}
+
+func (h *Handlers) GetCampaign(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id, err := campaignID(r)
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	data, err := h.controller.GetCampaign(r.Context(), id)
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	responseData, err := json.Marshal(data)
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseData)
+}
+
+func (h *Handlers) UpdateCampaign(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id, err := campaignID(r)
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	var requestData models.CampaignRequest
+
+	err = json.NewDecoder(r.Body).Decode(&requestData)
+	if err != nil {
+		h.writeProblem(w, r, errInvalidRequest)
+		return
+	}
+
+	data, err := h.controller.UpdateCampaign(r.Context(), requestData, id)
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	responseData, err := json.Marshal(data)
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseData)
+}
+
+func (h *Handlers) DeleteCampaign(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id, err := campaignID(r)
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	err = h.controller.DeleteCampaign(r.Context(), id)
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handlers) GetCampaignStats(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id, err := campaignID(r)
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	data, err := h.controller.GetCampaignStats(r.Context(), id)
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	responseData, err := json.Marshal(data)
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseData)
+}
@@ -0,0 +1,384 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DarcoProgramador/shortener-go-backend/internal/controller"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	controllerMock "github.com/DarcoProgramador/shortener-go-backend/mocks/controller_mock"
+	"github.com/DarcoProgramador/shortener-go-backend/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandlers_CreateCampaign(t *testing.T) {
+	tests := []struct {
+		name             string
+		body             string
+		mockExpectations func(t *testing.T) *controllerMock.MockControllerInterface
+		statusCode       int
+		response         string
+		headers          map[string]string
+	}{
+		{
+			name: "Create campaign OK",
+			body: `{"name":"Spring sale","utm":{"source":"newsletter"}}`,
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().CreateCampaign(mock.Anything, models.CampaignRequest{
+					Name: "Spring sale",
+					UTM:  models.UTMParameters{Source: "newsletter"},
+				}).Return(&models.CampaignResponse{
+					Id:   1,
+					Name: "Spring sale",
+					UTM:  models.UTMParameters{Source: "newsletter"},
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusCreated,
+			response:   `{"id":1,"name":"Spring sale","utm":{"source":"newsletter"}}`,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+			},
+		},
+		{
+			name: "Create campaign invalid request",
+			body: `{"name":`,
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				return controllerMock.NewMockControllerInterface(t)
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "invalid_request", errInvalidRequest.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Create campaign invalid name",
+			body: `{"name":""}`,
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().CreateCampaign(mock.Anything, mock.Anything).Return(nil, utils.ErrInvalidCampaignName)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "invalid_campaign_name", utils.ErrInvalidCampaignName.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Create campaign internal server error",
+			body: `{"name":"Spring sale"}`,
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().CreateCampaign(mock.Anything, mock.Anything).Return(nil, assert.AnError)
+				return c
+			},
+			statusCode: http.StatusInternalServerError,
+			response:   problemJSON(http.StatusInternalServerError, "internal_error", "internal server error"),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.mockExpectations(t)
+			h := NewHandlers(c, slog.New(slog.Default().Handler()))
+
+			req := httptest.NewRequest(http.MethodPost, "/campaigns", strings.NewReader(tt.body))
+
+			rr := httptest.NewRecorder()
+
+			handlerTest := http.HandlerFunc(h.CreateCampaign)
+
+			handlerTest.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code, "Status code is not the expected")
+
+			for key, value := range tt.headers {
+				assert.Equal(t, value, rr.Header().Get(key), "Header is not the expected")
+			}
+
+			assert.Equal(t, tt.response, rr.Body.String(), "Body is not the expected")
+		})
+	}
+}
+
+func TestHandlers_GetCampaign(t *testing.T) {
+	tests := []struct {
+		name             string
+		id               string
+		mockExpectations func(t *testing.T) *controllerMock.MockControllerInterface
+		statusCode       int
+		response         string
+	}{
+		{
+			name: "Get campaign OK",
+			id:   "1",
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetCampaign(mock.Anything, int64(1)).Return(&models.CampaignResponse{
+					Id:    1,
+					Name:  "Spring sale",
+					Owner: "marketing",
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response:   `{"id":1,"name":"Spring sale","owner":"marketing","utm":{}}`,
+		},
+		{
+			name: "Get campaign invalid id",
+			id:   "spring",
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				return controllerMock.NewMockControllerInterface(t)
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "invalid_campaign_id", utils.ErrInvalidCampaignID.Error()),
+		},
+		{
+			name: "Get campaign not found",
+			id:   "9",
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetCampaign(mock.Anything, int64(9)).Return(nil, controller.ErrCampaignNotFound)
+				return c
+			},
+			statusCode: http.StatusNotFound,
+			response:   problemJSON(http.StatusNotFound, "campaign_not_found", controller.ErrCampaignNotFound.Error()),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.mockExpectations(t)
+			h := NewHandlers(c, slog.New(slog.Default().Handler()))
+
+			req := httptest.NewRequest(http.MethodGet, "/campaigns/"+tt.id, nil)
+			req.SetPathValue("id", tt.id)
+
+			rr := httptest.NewRecorder()
+
+			handlerTest := http.HandlerFunc(h.GetCampaign)
+
+			handlerTest.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code, "Status code is not the expected")
+			contentType := "application/json"
+			if tt.statusCode >= http.StatusBadRequest {
+				contentType = problemContentType
+			}
+			assert.Equal(t, contentType, rr.Header().Get("Content-Type"), "Header is not the expected")
+			assert.Equal(t, tt.response, rr.Body.String(), "Body is not the expected")
+		})
+	}
+}
+
+func TestHandlers_UpdateCampaign(t *testing.T) {
+	tests := []struct {
+		name             string
+		id               string
+		body             string
+		mockExpectations func(t *testing.T) *controllerMock.MockControllerInterface
+		statusCode       int
+		response         string
+	}{
+		{
+			name: "Update campaign OK",
+			id:   "1",
+			body: `{"name":"Summer sale"}`,
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().UpdateCampaign(mock.Anything, models.CampaignRequest{Name: "Summer sale"}, int64(1)).Return(&models.CampaignResponse{
+					Id:   1,
+					Name: "Summer sale",
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response:   `{"id":1,"name":"Summer sale","utm":{}}`,
+		},
+		{
+			name: "Update campaign invalid range",
+			id:   "1",
+			body: `{"name":"Summer sale","startsAt":"2025-04-01T00:00:00Z","endsAt":"2025-03-01T00:00:00Z"}`,
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().UpdateCampaign(mock.Anything, mock.Anything, int64(1)).Return(nil, utils.ErrInvalidCampaignRange)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "invalid_campaign_range", utils.ErrInvalidCampaignRange.Error()),
+		},
+		{
+			name: "Update campaign not found",
+			id:   "9",
+			body: `{"name":"Summer sale"}`,
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().UpdateCampaign(mock.Anything, mock.Anything, int64(9)).Return(nil, controller.ErrCampaignNotFound)
+				return c
+			},
+			statusCode: http.StatusNotFound,
+			response:   problemJSON(http.StatusNotFound, "campaign_not_found", controller.ErrCampaignNotFound.Error()),
+		},
+		{
+			name: "Update campaign invalid id",
+			id:   "0",
+			body: `{"name":"Summer sale"}`,
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				return controllerMock.NewMockControllerInterface(t)
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "invalid_campaign_id", utils.ErrInvalidCampaignID.Error()),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.mockExpectations(t)
+			h := NewHandlers(c, slog.New(slog.Default().Handler()))
+
+			req := httptest.NewRequest(http.MethodPut, "/campaigns/"+tt.id, strings.NewReader(tt.body))
+			req.SetPathValue("id", tt.id)
+
+			rr := httptest.NewRecorder()
+
+			handlerTest := http.HandlerFunc(h.UpdateCampaign)
+
+			handlerTest.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code, "Status code is not the expected")
+			assert.Equal(t, tt.response, rr.Body.String(), "Body is not the expected")
+		})
+	}
+}
+
+func TestHandlers_DeleteCampaign(t *testing.T) {
+	tests := []struct {
+		name             string
+		id               string
+		mockExpectations func(t *testing.T) *controllerMock.MockControllerInterface
+		statusCode       int
+		response         string
+	}{
+		{
+			name: "Delete campaign OK",
+			id:   "1",
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().DeleteCampaign(mock.Anything, int64(1)).Return(nil)
+				return c
+			},
+			statusCode: http.StatusNoContent,
+			response:   "",
+		},
+		{
+			name: "Delete campaign not found",
+			id:   "9",
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().DeleteCampaign(mock.Anything, int64(9)).Return(controller.ErrCampaignNotFound)
+				return c
+			},
+			statusCode: http.StatusNotFound,
+			response:   problemJSON(http.StatusNotFound, "campaign_not_found", controller.ErrCampaignNotFound.Error()),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.mockExpectations(t)
+			h := NewHandlers(c, slog.New(slog.Default().Handler()))
+
+			req := httptest.NewRequest(http.MethodDelete, "/campaigns/"+tt.id, nil)
+			req.SetPathValue("id", tt.id)
+
+			rr := httptest.NewRecorder()
+
+			handlerTest := http.HandlerFunc(h.DeleteCampaign)
+
+			handlerTest.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code, "Status code is not the expected")
+			assert.Equal(t, tt.response, rr.Body.String(), "Body is not the expected")
+		})
+	}
+}
+
+func TestHandlers_GetCampaignStats(t *testing.T) {
+	tests := []struct {
+		name             string
+		id               string
+		mockExpectations func(t *testing.T) *controllerMock.MockControllerInterface
+		statusCode       int
+		response         string
+	}{
+		{
+			name: "Get campaign stats OK",
+			id:   "1",
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetCampaignStats(mock.Anything, int64(1)).Return(&models.CampaignStatsResponse{
+					CampaignId:     1,
+					Name:           "Spring sale",
+					Links:          2,
+					AccessCount:    15,
+					BotCount:       3,
+					UniqueVisitors: 9,
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response:   `{"campaignId":1,"name":"Spring sale","links":2,"accessCount":15,"botCount":3,"uniqueVisitors":9}`,
+		},
+		{
+			name: "Get campaign stats not found",
+			id:   "9",
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetCampaignStats(mock.Anything, int64(9)).Return(nil, controller.ErrCampaignNotFound)
+				return c
+			},
+			statusCode: http.StatusNotFound,
+			response:   problemJSON(http.StatusNotFound, "campaign_not_found", controller.ErrCampaignNotFound.Error()),
+		},
+		{
+			name: "Get campaign stats internal server error",
+			id:   "1",
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetCampaignStats(mock.Anything, int64(1)).Return(nil, assert.AnError)
+				return c
+			},
+			statusCode: http.StatusInternalServerError,
+			response:   problemJSON(http.StatusInternalServerError, "internal_error", "internal server error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.mockExpectations(t)
+			h := NewHandlers(c, slog.New(slog.Default().Handler()))
+
+			req := httptest.NewRequest(http.MethodGet, "/campaigns/"+tt.id+"/stats", nil)
+			req.SetPathValue("id", tt.id)
+
+			rr := httptest.NewRecorder()
+
+			handlerTest := http.HandlerFunc(h.GetCampaignStats)
+
+			handlerTest.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code, "Status code is not the expected")
+			contentType := "application/json"
+			if tt.statusCode >= http.StatusBadRequest {
+				contentType = problemContentType
+			}
+			assert.Equal(t, contentType, rr.Header().Get("Content-Type"), "Header is not the expected")
+			assert.Equal(t, tt.response, rr.Body.String(), "Body is not the expected")
+		})
+	}
+}
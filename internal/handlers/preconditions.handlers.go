@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// linkETag returns the ETag of a link version.
+func linkETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// contentETag returns the ETag of a response body, for representations such
+// as the stats of a link that change on every visit rather than on every
+// version.
+func contentETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// ifMatch returns the link version a request must change, or 0 when any
+// version will do. It accepts * or a single strong ETag, as a link has only
+// one current version to match.
+func ifMatch(r *http.Request) (int64, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return 0, errPreconditionFailed
+	}
+
+	version, err := strconv.ParseInt(value[1:len(value)-1], 10, 64)
+	if err != nil || version <= 0 {
+		return 0, errPreconditionFailed
+	}
+
+	return version, nil
+}
+
+// notModified reports whether the If-None-Match header of a read matches
+// etag. ETags are compared weakly, so W/"2" matches "2".
+func notModified(r *http.Request, etag string) bool {
+	value := r.Header.Get("If-None-Match")
+	if value == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(value, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeNotModified answers a read whose If-None-Match matched the link.
+func writeNotModified(w http.ResponseWriter, etag string) {
+	header := w.Header()
+	header.Del("Content-Type")
+	header.Set("ETag", etag)
+	w.WriteHeader(http.StatusNotModified)
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/DarcoProgramador/shortener-go-backend/internal/controller"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	"github.com/DarcoProgramador/shortener-go-backend/utils"
+)
+
+// problemContentType is the media type of error responses.
+const problemContentType = "application/problem+json"
+
+var (
+	errInvalidRequest       = errors.New("invalid request")
+	errCodeRequired         = errors.New("code is required")
+	errTagRequired          = errors.New("tag is required")
+	errUnsupportedMediaType = errors.New("Content-Type must be " + mergePatchType)
+	errPreconditionFailed   = errors.New("If-Match must be * or the ETag of the link")
+	errImportTooLarge       = errors.New("import file is too large")
+)
+
+// problemType is how an error is answered: its status and stable code.
+type problemType struct {
+	err    error
+	status int
+	code   string
+}
+
+// problemTypes maps the errors of the handlers, the controller and the
+// validations to responses. The first match wins, so an error that wraps
+// another comes before it.
+var problemTypes = []problemType{
+	{errInvalidRequest, http.StatusBadRequest, "invalid_request"},
+	{errCodeRequired, http.StatusBadRequest, "code_required"},
+	{errTagRequired, http.StatusBadRequest, "tag_required"},
+	{errUnsupportedMediaType, http.StatusUnsupportedMediaType, "unsupported_media_type"},
+	{errPreconditionFailed, http.StatusPreconditionFailed, "precondition_failed"},
+	{errImportTooLarge, http.StatusRequestEntityTooLarge, "import_too_large"},
+
+	{controller.ErrLinkNotFound, http.StatusNotFound, "link_not_found"},
+	{controller.ErrLinkExpired, http.StatusGone, "link_expired"},
+	{controller.ErrLinkExhausted, http.StatusGone, "link_exhausted"},
+	{controller.ErrPasswordRequired, http.StatusUnauthorized, "password_required"},
+	{controller.ErrWrongPassword, http.StatusForbidden, "wrong_password"},
+	{controller.ErrAliasTaken, http.StatusConflict, "alias_taken"},
+	{controller.ErrCodeExhausted, http.StatusServiceUnavailable, "code_exhausted"},
+	{controller.ErrTagNotFound, http.StatusNotFound, "tag_not_found"},
+	{controller.ErrUnknownCampaign, http.StatusBadRequest, "unknown_campaign"},
+	{controller.ErrCampaignNotFound, http.StatusNotFound, "campaign_not_found"},
+	{controller.ErrVersionMismatch, http.StatusPreconditionFailed, "version_mismatch"},
+	{controller.ErrIdempotencyKeyReused, http.StatusUnprocessableEntity, "idempotency_key_reused"},
+
+	{utils.ErrInvalidURL, http.StatusBadRequest, "invalid_url"},
+	{utils.ErrInvalidRedirectStatus, http.StatusBadRequest, "invalid_redirect_status"},
+	{utils.ErrInvalidAlias, http.StatusBadRequest, "invalid_alias"},
+	{utils.ErrReservedAlias, http.StatusBadRequest, "reserved_alias"},
+	{utils.ErrInvalidLinkWindow, http.StatusBadRequest, "invalid_link_window"},
+	{utils.ErrInvalidMaxClicks, http.StatusBadRequest, "invalid_max_clicks"},
+	{utils.ErrInvalidLinkPassword, http.StatusBadRequest, "invalid_password"},
+	{utils.ErrInvalidTitle, http.StatusBadRequest, "invalid_title"},
+	{utils.ErrInvalidDescription, http.StatusBadRequest, "invalid_description"},
+	{utils.ErrInvalidNotes, http.StatusBadRequest, "invalid_notes"},
+	{utils.ErrInvalidTag, http.StatusBadRequest, "invalid_tag"},
+	{utils.ErrTooManyTags, http.StatusBadRequest, "too_many_tags"},
+	{utils.ErrInvalidPatch, http.StatusBadRequest, "invalid_patch"},
+	{utils.ErrInvalidIdempotencyKey, http.StatusBadRequest, "invalid_idempotency_key"},
+	{utils.ErrInvalidTimezone, http.StatusBadRequest, "invalid_timezone"},
+	{utils.ErrInvalidDate, http.StatusBadRequest, "invalid_date"},
+	{utils.ErrInvalidTimeRange, http.StatusBadRequest, "invalid_time_range"},
+	{utils.ErrInvalidInterval, http.StatusBadRequest, "invalid_interval"},
+	{utils.ErrTimeRangeTooLarge, http.StatusBadRequest, "time_range_too_large"},
+	{utils.ErrInvalidLimit, http.StatusBadRequest, "invalid_limit"},
+	{utils.ErrInvalidSort, http.StatusBadRequest, "invalid_sort"},
+	{utils.ErrInvalidOrder, http.StatusBadRequest, "invalid_order"},
+	{utils.ErrInvalidCursor, http.StatusBadRequest, "invalid_cursor"},
+	{utils.ErrInvalidBatchSize, http.StatusBadRequest, "invalid_batch_size"},
+	{utils.ErrInvalidFormat, http.StatusBadRequest, "invalid_format"},
+	{utils.ErrInvalidImportHeader, http.StatusBadRequest, "invalid_import_header"},
+	{utils.ErrInvalidCampaignName, http.StatusBadRequest, "invalid_campaign_name"},
+	{utils.ErrInvalidCampaignRange, http.StatusBadRequest, "invalid_campaign_range"},
+	{utils.ErrInvalidCampaignID, http.StatusBadRequest, "invalid_campaign_id"},
+}
+
+// problem returns the response for err. Errors missing from problemTypes
+// are internal and their message is not sent.
+func problem(err error) models.Problem {
+	for _, known := range problemTypes {
+		if errors.Is(err, known.err) {
+			return models.Problem{
+				Type:   "about:blank",
+				Title:  http.StatusText(known.status),
+				Status: known.status,
+				Detail: err.Error(),
+				Code:   known.code,
+			}
+		}
+	}
+
+	return models.Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(http.StatusInternalServerError),
+		Status: http.StatusInternalServerError,
+		Detail: "internal server error",
+		Code:   "internal_error",
+	}
+}
+
+// writeProblem answers a request that failed with err as an RFC 7807
+// problem. Server errors are logged.
+func (h *Handlers) writeProblem(w http.ResponseWriter, r *http.Request, err error) {
+	response := problem(err)
+	if response.Status >= http.StatusInternalServerError {
+		h.logger.Error("Error handling request", "method", r.Method, "path", r.URL.Path, "error", err)
+	}
+
+	responseData, _ := json.Marshal(response)
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(response.Status)
+	w.Write(responseData)
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/DarcoProgramador/shortener-go-backend/internal/controller"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	"github.com/DarcoProgramador/shortener-go-backend/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// problemJSON is the body of a problem response.
+func problemJSON(status int, code, detail string) string {
+	return `{"type":"about:blank","title":"` + http.StatusText(status) + `","status":` + strconv.Itoa(status) +
+		`,"detail":"` + detail + `","code":"` + code + `"}`
+}
+
+func TestProblem(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want models.Problem
+	}{
+		{
+			name: "Problem not found",
+			err:  controller.ErrLinkNotFound,
+			want: models.Problem{
+				Type:   "about:blank",
+				Title:  "Not Found",
+				Status: http.StatusNotFound,
+				Detail: "short link not found",
+				Code:   "link_not_found",
+			},
+		},
+		{
+			name: "Problem wrapped validation error",
+			err:  fmt.Errorf("row 3: %w", utils.ErrInvalidURL),
+			want: models.Problem{
+				Type:   "about:blank",
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Detail: "row 3: invalid URL",
+				Code:   "invalid_url",
+			},
+		},
+		{
+			name: "Problem unknown campaign before campaign not found",
+			err:  controller.ErrUnknownCampaign,
+			want: models.Problem{
+				Type:   "about:blank",
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Detail: controller.ErrUnknownCampaign.Error(),
+				Code:   "unknown_campaign",
+			},
+		},
+		{
+			name: "Problem gone",
+			err:  controller.ErrLinkExhausted,
+			want: models.Problem{
+				Type:   "about:blank",
+				Title:  "Gone",
+				Status: http.StatusGone,
+				Detail: "short link has reached its click limit",
+				Code:   "link_exhausted",
+			},
+		},
+		{
+			name: "Problem internal error hides the message",
+			err:  fmt.Errorf("sql: no rows in result set"),
+			want: models.Problem{
+				Type:   "about:blank",
+				Title:  "Internal Server Error",
+				Status: http.StatusInternalServerError,
+				Detail: "internal server error",
+				Code:   "internal_error",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, problem(tt.err), "Problem is not the expected")
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"errors"
+	"html/template"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/DarcoProgramador/shortener-go-backend/internal/controller"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+)
+
+var notFoundPage = template.Must(template.New("notFound").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8">
+	<title>Link not found</title>
+</head>
+<body>
+	<h1>404 - Link not found</h1>
+	<p>The short link <strong>/{{.}}</strong> does not exist or has been removed.</p>
+</body>
+</html>
+`))
+
+var gonePage = template.Must(template.New("gone").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8">
+	<title>Link no longer available</title>
+</head>
+<body>
+	<h1>410 - Link no longer available</h1>
+	<p>The short link <strong>/{{.Code}}</strong> {{.Reason}} and no longer redirects.</p>
+</body>
+</html>
+`))
+
+var passwordPage = template.Must(template.New("password").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8">
+	<title>Password required</title>
+</head>
+<body>
+	<h1>Password required</h1>
+	<p>The short link <strong>/{{.Code}}</strong> is protected. Enter its password to continue.</p>
+	{{if .Wrong}}<p><strong>Wrong password, try again.</strong></p>{{end}}
+	<form method="post" action="/{{.Code}}">
+		<input type="password" name="password" autofocus required>
+		<button type="submit">Continue</button>
+	</form>
+</body>
+</html>
+`))
+
+// passwordHeader lets API clients and scripts send the password of a
+// protected link without going through the form.
+const passwordHeader = "X-Link-Password"
+
+type gonePageData struct {
+	Code   string
+	Reason string
+}
+
+type passwordPageData struct {
+	Code  string
+	Wrong bool
+}
+
+// Redirect resolves a short code and sends the browser to the original URL
+// using the redirect status stored for the link.
+// A protected link takes its password from the X-Link-Password header or,
+// when the password form is posted back, from the password field.
+func (h *Handlers) Redirect(w http.ResponseWriter, r *http.Request) {
+	// A HEAD route of its own would clash with GET /shorten, as both
+	// match HEAD /shorten.
+	if r.Method == http.MethodHead {
+		h.RedirectHead(w, r)
+		return
+	}
+
+	code := r.PathValue("code")
+
+	password := r.Header.Get(passwordHeader)
+	if r.Method == http.MethodPost {
+		password = r.PostFormValue("password")
+	}
+
+	data, err := h.controller.ResolveLink(r.Context(), code, newVisit(r, password))
+
+	// A link that is not active yet is reported as missing so its
+	// existence is not revealed ahead of time.
+	switch {
+	case errors.Is(err, controller.ErrLinkNotFound), errors.Is(err, controller.ErrLinkNotActive):
+		h.renderPage(w, http.StatusNotFound, notFoundPage, code)
+		return
+	case errors.Is(err, controller.ErrLinkExpired):
+		h.renderPage(w, http.StatusGone, gonePage, gonePageData{Code: code, Reason: "has expired"})
+		return
+	case errors.Is(err, controller.ErrLinkExhausted):
+		h.renderPage(w, http.StatusGone, gonePage, gonePageData{Code: code, Reason: "has reached its click limit"})
+		return
+	case errors.Is(err, controller.ErrPasswordRequired):
+		w.Header().Set("Cache-Control", "private, no-store")
+		h.renderPage(w, http.StatusUnauthorized, passwordPage, passwordPageData{Code: code})
+		return
+	case errors.Is(err, controller.ErrWrongPassword):
+		w.Header().Set("Cache-Control", "private, no-store")
+		h.renderPage(w, http.StatusForbidden, passwordPage, passwordPageData{Code: code, Wrong: true})
+		return
+	}
+
+	if err != nil {
+		h.logger.Error("Error resolving short link", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	status := data.RedirectStatus
+	if status == 0 {
+		status = http.StatusFound
+	}
+
+	// A cached redirect would skip the password check on the next visit, and
+	// a posted form must be followed with a GET.
+	if data.Protected {
+		w.Header().Set("Cache-Control", "private, no-store")
+	}
+	if r.Method == http.MethodPost {
+		status = http.StatusSeeOther
+	}
+
+	http.Redirect(w, r, data.Url, status)
+}
+
+// RedirectHead answers the HEAD requests sent to Redirect with the status
+// and Location a GET would get, without counting a visit. The click limit is
+// not checked, as that would need the visit to be counted.
+func (h *Handlers) RedirectHead(w http.ResponseWriter, r *http.Request) {
+	// Protected links are answered before their URL would be needed.
+	data, err := h.controller.GetLink(r.Context(), r.PathValue("code"), "")
+
+	if errors.Is(err, controller.ErrLinkNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		h.logger.Error("Error getting link", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	now := time.Now()
+	switch {
+	case data.NotBefore != nil && now.Before(*data.NotBefore):
+		w.WriteHeader(http.StatusNotFound)
+		return
+	case data.ExpiresAt != nil && !now.Before(*data.ExpiresAt):
+		w.WriteHeader(http.StatusGone)
+		return
+	case data.Protected:
+		w.Header().Set("Cache-Control", "private, no-store")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	status := data.RedirectStatus
+	if status == 0 {
+		status = http.StatusFound
+	}
+
+	w.Header().Set("Location", data.Url)
+	w.WriteHeader(status)
+}
+
+// newVisit collects what is recorded about a visit to a short link.
+func newVisit(r *http.Request, password string) models.VisitRequest {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	return models.VisitRequest{
+		Password:       password,
+		Referrer:       r.Referer(),
+		UserAgent:      r.UserAgent(),
+		IP:             ip,
+		AcceptLanguage: r.Header.Get("Accept-Language"),
+		Prefetch:       isPrefetch(r),
+	}
+}
+
+// isPrefetch reports whether the browser is fetching the link ahead of time
+// or for a preview, rather than because someone opened it.
+func isPrefetch(r *http.Request) bool {
+	for _, header := range []string{"Purpose", "Sec-Purpose", "X-Purpose", "X-Moz"} {
+		value := strings.ToLower(r.Header.Get(header))
+		if strings.Contains(value, "prefetch") || strings.Contains(value, "preview") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (h *Handlers) renderPage(w http.ResponseWriter, status int, page *template.Template, data any) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+
+	if err := page.Execute(w, data); err != nil {
+		h.logger.Error("Error rendering page", "error", err)
+	}
+}
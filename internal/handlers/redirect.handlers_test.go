@@ -0,0 +1,491 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DarcoProgramador/shortener-go-backend/internal/controller"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	controllerMock "github.com/DarcoProgramador/shortener-go-backend/mocks/controller_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandlers_Redirect(t *testing.T) {
+	type fields struct {
+		method    string
+		shortCode string
+		header    string
+		form      url.Values
+		visit     map[string]string
+	}
+	tests := []struct {
+		name             string
+		fields           fields
+		mockExpectations func(t *testing.T) *controllerMock.MockControllerInterface
+		statusCode       int
+		contains         string
+		headers          map[string]string
+	}{
+		{
+			name: "Redirect with default status",
+			fields: fields{
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ResolveLink(mock.Anything, "abc123", mock.Anything).Return(&models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusFound,
+			headers: map[string]string{
+				"Location": "https://www.google.com",
+			},
+		},
+		{
+			name: "Redirect with permanent status",
+			fields: fields{
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ResolveLink(mock.Anything, "abc123", mock.Anything).Return(&models.ShortLinkResponse{
+					Id:             1,
+					Url:            "https://www.google.com",
+					ShortCode:      "abc123",
+					RedirectStatus: http.StatusPermanentRedirect,
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusPermanentRedirect,
+			headers: map[string]string{
+				"Location": "https://www.google.com",
+			},
+		},
+		{
+			name: "Redirect records visit details",
+			fields: fields{
+				shortCode: "abc123",
+				visit: map[string]string{
+					"Referer":         "https://news.ycombinator.com/",
+					"User-Agent":      "Mozilla/5.0",
+					"Accept-Language": "es-ES,es;q=0.9",
+				},
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ResolveLink(mock.Anything, "abc123", models.VisitRequest{
+					Referrer:       "https://news.ycombinator.com/",
+					UserAgent:      "Mozilla/5.0",
+					IP:             "192.0.2.1",
+					AcceptLanguage: "es-ES,es;q=0.9",
+				}).Return(&models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusFound,
+			headers: map[string]string{
+				"Location": "https://www.google.com",
+			},
+		},
+		{
+			name: "Redirect HEAD request",
+			fields: fields{
+				method:    http.MethodHead,
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetLink(mock.Anything, "abc123", "").Return(&models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusFound,
+			headers: map[string]string{
+				"Location": "https://www.google.com",
+			},
+		},
+		{
+			name: "Redirect prefetch",
+			fields: fields{
+				shortCode: "abc123",
+				visit: map[string]string{
+					"Sec-Purpose": "prefetch;prerender",
+				},
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ResolveLink(mock.Anything, "abc123", models.VisitRequest{IP: "192.0.2.1", Prefetch: true}).Return(&models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusFound,
+			headers: map[string]string{
+				"Location": "https://www.google.com",
+			},
+		},
+		{
+			name: "Redirect not found",
+			fields: fields{
+				shortCode: "missing",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ResolveLink(mock.Anything, "missing", mock.Anything).Return(nil, controller.ErrLinkNotFound)
+				return c
+			},
+			statusCode: http.StatusNotFound,
+			contains:   "/missing",
+			headers: map[string]string{
+				"Content-Type": "text/html; charset=utf-8",
+			},
+		},
+		{
+			name: "Redirect expired",
+			fields: fields{
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ResolveLink(mock.Anything, "abc123", mock.Anything).Return(nil, controller.ErrLinkExpired)
+				return c
+			},
+			statusCode: http.StatusGone,
+			contains:   "has expired",
+			headers: map[string]string{
+				"Content-Type": "text/html; charset=utf-8",
+			},
+		},
+		{
+			name: "Redirect not active yet",
+			fields: fields{
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ResolveLink(mock.Anything, "abc123", mock.Anything).Return(nil, controller.ErrLinkNotActive)
+				return c
+			},
+			statusCode: http.StatusNotFound,
+			contains:   "does not exist",
+			headers: map[string]string{
+				"Content-Type": "text/html; charset=utf-8",
+			},
+		},
+		{
+			name: "Redirect click limit reached",
+			fields: fields{
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ResolveLink(mock.Anything, "abc123", mock.Anything).Return(nil, controller.ErrLinkExhausted)
+				return c
+			},
+			statusCode: http.StatusGone,
+			contains:   "has reached its click limit",
+			headers: map[string]string{
+				"Content-Type": "text/html; charset=utf-8",
+			},
+		},
+		{
+			name: "Redirect password required",
+			fields: fields{
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ResolveLink(mock.Anything, "abc123", models.VisitRequest{IP: "192.0.2.1"}).Return(nil, controller.ErrPasswordRequired)
+				return c
+			},
+			statusCode: http.StatusUnauthorized,
+			contains:   `<form method="post" action="/abc123">`,
+			headers: map[string]string{
+				"Content-Type":  "text/html; charset=utf-8",
+				"Cache-Control": "private, no-store",
+			},
+		},
+		{
+			name: "Redirect wrong password from form",
+			fields: fields{
+				method:    http.MethodPost,
+				shortCode: "abc123",
+				form:      url.Values{"password": {"wrong"}},
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ResolveLink(mock.Anything, "abc123", models.VisitRequest{Password: "wrong", IP: "192.0.2.1"}).Return(nil, controller.ErrWrongPassword)
+				return c
+			},
+			statusCode: http.StatusForbidden,
+			contains:   "Wrong password",
+			headers: map[string]string{
+				"Content-Type":  "text/html; charset=utf-8",
+				"Cache-Control": "private, no-store",
+			},
+		},
+		{
+			name: "Redirect with password from form",
+			fields: fields{
+				method:    http.MethodPost,
+				shortCode: "abc123",
+				form:      url.Values{"password": {"s3cret"}},
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ResolveLink(mock.Anything, "abc123", models.VisitRequest{Password: "s3cret", IP: "192.0.2.1"}).Return(&models.ShortLinkResponse{
+					Id:             1,
+					Url:            "https://www.google.com",
+					ShortCode:      "abc123",
+					RedirectStatus: http.StatusPermanentRedirect,
+					Protected:      true,
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusSeeOther,
+			headers: map[string]string{
+				"Location":      "https://www.google.com",
+				"Cache-Control": "private, no-store",
+			},
+		},
+		{
+			name: "Redirect with password header",
+			fields: fields{
+				shortCode: "abc123",
+				header:    "s3cret",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ResolveLink(mock.Anything, "abc123", models.VisitRequest{Password: "s3cret", IP: "192.0.2.1"}).Return(&models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+					Protected: true,
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusFound,
+			headers: map[string]string{
+				"Location":      "https://www.google.com",
+				"Cache-Control": "private, no-store",
+			},
+		},
+		{
+			name: "Redirect internal server error",
+			fields: fields{
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ResolveLink(mock.Anything, "abc123", mock.Anything).Return(nil, assert.AnError)
+				return c
+			},
+			statusCode: http.StatusInternalServerError,
+			contains:   "internal server error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.mockExpectations(t)
+			h := NewHandlers(c, slog.New(slog.Default().Handler()))
+
+			method := tt.fields.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			req := httptest.NewRequest(method, "/{code}", strings.NewReader(tt.fields.form.Encode()))
+			req.SetPathValue("code", tt.fields.shortCode)
+			if tt.fields.form != nil {
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
+			if tt.fields.header != "" {
+				req.Header.Set(passwordHeader, tt.fields.header)
+			}
+			for key, value := range tt.fields.visit {
+				req.Header.Set(key, value)
+			}
+
+			rr := httptest.NewRecorder()
+
+			handlerTest := http.HandlerFunc(h.Redirect)
+
+			handlerTest.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code, "Status code is not the expected")
+
+			for key, value := range tt.headers {
+				assert.Equal(t, value, rr.Header().Get(key), "Header is not the expected")
+			}
+
+			assert.Contains(t, rr.Body.String(), tt.contains, "Body is not the expected")
+		})
+	}
+}
+
+func TestHandlers_RedirectHead(t *testing.T) {
+	tests := []struct {
+		name             string
+		shortCode        string
+		mockExpectations func(t *testing.T) *controllerMock.MockControllerInterface
+		statusCode       int
+		headers          map[string]string
+	}{
+		{
+			name:      "RedirectHead with default status",
+			shortCode: "abc123",
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetLink(mock.Anything, "abc123", "").Return(&models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusFound,
+			headers: map[string]string{
+				"Location": "https://www.google.com",
+			},
+		},
+		{
+			name:      "RedirectHead with stored status",
+			shortCode: "abc123",
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetLink(mock.Anything, "abc123", "").Return(&models.ShortLinkResponse{
+					Id:             1,
+					Url:            "https://www.google.com",
+					ShortCode:      "abc123",
+					RedirectStatus: http.StatusMovedPermanently,
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusMovedPermanently,
+			headers: map[string]string{
+				"Location": "https://www.google.com",
+			},
+		},
+		{
+			name:      "RedirectHead not found",
+			shortCode: "abc123",
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetLink(mock.Anything, "abc123", "").Return(nil, controller.ErrLinkNotFound)
+				return c
+			},
+			statusCode: http.StatusNotFound,
+			headers: map[string]string{
+				"Location": "",
+			},
+		},
+		{
+			name:      "RedirectHead not active yet",
+			shortCode: "abc123",
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				notBefore := time.Now().Add(time.Hour)
+				c.EXPECT().GetLink(mock.Anything, "abc123", "").Return(&models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+					NotBefore: &notBefore,
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusNotFound,
+			headers: map[string]string{
+				"Location": "",
+			},
+		},
+		{
+			name:      "RedirectHead expired",
+			shortCode: "abc123",
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				expiresAt := time.Now().Add(-time.Hour)
+				c.EXPECT().GetLink(mock.Anything, "abc123", "").Return(&models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+					ExpiresAt: &expiresAt,
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusGone,
+			headers: map[string]string{
+				"Location": "",
+			},
+		},
+		{
+			name:      "RedirectHead protected",
+			shortCode: "abc123",
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetLink(mock.Anything, "abc123", "").Return(&models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+					Protected: true,
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusUnauthorized,
+			headers: map[string]string{
+				"Location":      "",
+				"Cache-Control": "private, no-store",
+			},
+		},
+		{
+			name:      "RedirectHead internal server error",
+			shortCode: "abc123",
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetLink(mock.Anything, "abc123", "").Return(nil, assert.AnError)
+				return c
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.mockExpectations(t)
+			h := NewHandlers(c, slog.New(slog.Default().Handler()))
+
+			req := httptest.NewRequest(http.MethodHead, "/{code}", nil)
+			req.SetPathValue("code", tt.shortCode)
+
+			rr := httptest.NewRecorder()
+
+			handlerTest := http.HandlerFunc(h.RedirectHead)
+
+			handlerTest.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code, "Status code is not the expected")
+
+			for key, value := range tt.headers {
+				assert.Equal(t, value, rr.Header().Get(key), "Header is not the expected")
+			}
+
+			assert.Empty(t, rr.Body.String(), "Body must be empty")
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	"github.com/DarcoProgramador/shortener-go-backend/utils"
+)
+
+func (h *Handlers) GetTimeSeries(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	code := r.PathValue("code")
+	if code == "" {
+		h.writeProblem(w, r, errCodeRequired)
+		return
+	}
+
+	query := r.URL.Query()
+	data, err := h.controller.GetTimeSeries(r.Context(), code, models.TimeSeriesRequest{
+		From:     query.Get("from"),
+		To:       query.Get("to"),
+		Interval: query.Get("interval"),
+		Timezone: query.Get("tz"),
+	})
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	responseData, err := json.Marshal(data)
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseData)
+}
+
+func (h *Handlers) GetBreakdown(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	code := r.PathValue("code")
+	if code == "" {
+		h.writeProblem(w, r, errCodeRequired)
+		return
+	}
+
+	query := r.URL.Query()
+
+	var limit int
+	if value := query.Get("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil {
+			h.writeProblem(w, r, utils.ErrInvalidLimit)
+			return
+		}
+	}
+
+	data, err := h.controller.GetBreakdown(r.Context(), code, models.BreakdownRequest{
+		From:     query.Get("from"),
+		To:       query.Get("to"),
+		Timezone: query.Get("tz"),
+		Limit:    limit,
+	})
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	responseData, err := json.Marshal(data)
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseData)
+}
@@ -0,0 +1,291 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DarcoProgramador/shortener-go-backend/internal/controller"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	controllerMock "github.com/DarcoProgramador/shortener-go-backend/mocks/controller_mock"
+	"github.com/DarcoProgramador/shortener-go-backend/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandlers_GetTimeSeries(t *testing.T) {
+	type fields struct {
+		shortCode string
+		query     string
+	}
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name             string
+		fields           fields
+		mockExpectations func(t *testing.T) *controllerMock.MockControllerInterface
+		statusCode       int
+		response         string
+		headers          map[string]string
+	}{
+		{
+			name: "Get time series OK",
+			fields: fields{
+				shortCode: "abc123",
+				query:     "?from=2025-03-01&to=2025-03-03&interval=day&tz=UTC",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetTimeSeries(mock.Anything, "abc123", models.TimeSeriesRequest{
+					From:     "2025-03-01",
+					To:       "2025-03-03",
+					Interval: "day",
+					Timezone: "UTC",
+				}).Return(&models.TimeSeriesResponse{
+					ShortCode: "abc123",
+					Interval:  "day",
+					Timezone:  "UTC",
+					From:      day,
+					To:        day.AddDate(0, 0, 2),
+					Total:     3,
+					Buckets: []models.TimeSeriesBucket{
+						{Start: day, Clicks: 3, UniqueVisitors: 2},
+						{Start: day.AddDate(0, 0, 1), Clicks: 0, UniqueVisitors: 0},
+					},
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response: `{"shortCode":"abc123","interval":"day","tz":"UTC","from":"2025-03-01T00:00:00Z","to":"2025-03-03T00:00:00Z","total":3,` +
+				`"buckets":[{"start":"2025-03-01T00:00:00Z","clicks":3,"uniqueVisitors":2},{"start":"2025-03-02T00:00:00Z","clicks":0,"uniqueVisitors":0}]}`,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+			},
+		},
+		{
+			name: "Get time series shortCode required",
+			fields: fields{
+				shortCode: "",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "code_required", errCodeRequired.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Get time series invalid interval",
+			fields: fields{
+				shortCode: "abc123",
+				query:     "?interval=minute",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetTimeSeries(mock.Anything, "abc123", models.TimeSeriesRequest{Interval: "minute"}).Return(nil, utils.ErrInvalidInterval)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "invalid_interval", utils.ErrInvalidInterval.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Get time series not found",
+			fields: fields{
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetTimeSeries(mock.Anything, "abc123", models.TimeSeriesRequest{}).Return(nil, controller.ErrLinkNotFound)
+				return c
+			},
+			statusCode: http.StatusNotFound,
+			response:   problemJSON(http.StatusNotFound, "link_not_found", controller.ErrLinkNotFound.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Get time series internal server error",
+			fields: fields{
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetTimeSeries(mock.Anything, "abc123", mock.Anything).Return(nil, assert.AnError)
+				return c
+			},
+			statusCode: http.StatusInternalServerError,
+			response:   problemJSON(http.StatusInternalServerError, "internal_error", "internal server error"),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.mockExpectations(t)
+			h := NewHandlers(c, slog.New(slog.Default().Handler()))
+
+			req := httptest.NewRequest(http.MethodGet, "/shorten/{code}/stats/timeseries"+tt.fields.query, nil)
+			req.SetPathValue("code", tt.fields.shortCode)
+
+			rr := httptest.NewRecorder()
+
+			handlerTest := http.HandlerFunc(h.GetTimeSeries)
+
+			handlerTest.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code, "Status code is not the expected")
+
+			for key, value := range tt.headers {
+				assert.Equal(t, value, rr.Header().Get(key), "Header is not the expected")
+			}
+
+			assert.Equal(t, tt.response, rr.Body.String(), "Body is not the expected")
+		})
+	}
+}
+
+func TestHandlers_GetBreakdown(t *testing.T) {
+	type fields struct {
+		shortCode string
+		query     string
+	}
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name             string
+		fields           fields
+		mockExpectations func(t *testing.T) *controllerMock.MockControllerInterface
+		statusCode       int
+		response         string
+		headers          map[string]string
+	}{
+		{
+			name: "Get breakdown OK",
+			fields: fields{
+				shortCode: "abc123",
+				query:     "?from=2025-03-01&to=2025-03-08&limit=1",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetBreakdown(mock.Anything, "abc123", models.BreakdownRequest{
+					From:  "2025-03-01",
+					To:    "2025-03-08",
+					Limit: 1,
+				}).Return(&models.BreakdownResponse{
+					ShortCode:        "abc123",
+					From:             day,
+					To:               day.AddDate(0, 0, 7),
+					Total:            3,
+					Referrers:        []models.BreakdownItem{{Value: "t.co", Clicks: 2}},
+					Browsers:         []models.BreakdownItem{{Value: "Chrome", Clicks: 3}},
+					OperatingSystems: []models.BreakdownItem{{Value: "Android", Clicks: 3}},
+					Devices:          []models.BreakdownItem{{Value: "Mobile", Clicks: 3}},
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response: `{"shortCode":"abc123","from":"2025-03-01T00:00:00Z","to":"2025-03-08T00:00:00Z","total":3,` +
+				`"referrers":[{"value":"t.co","clicks":2}],"browsers":[{"value":"Chrome","clicks":3}],` +
+				`"operatingSystems":[{"value":"Android","clicks":3}],"devices":[{"value":"Mobile","clicks":3}]}`,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+			},
+		},
+		{
+			name: "Get breakdown limit is not a number",
+			fields: fields{
+				shortCode: "abc123",
+				query:     "?limit=ten",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "invalid_limit", utils.ErrInvalidLimit.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Get breakdown invalid limit",
+			fields: fields{
+				shortCode: "abc123",
+				query:     "?limit=500",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetBreakdown(mock.Anything, "abc123", models.BreakdownRequest{Limit: 500}).Return(nil, utils.ErrInvalidLimit)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "invalid_limit", utils.ErrInvalidLimit.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Get breakdown not found",
+			fields: fields{
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetBreakdown(mock.Anything, "abc123", models.BreakdownRequest{}).Return(nil, controller.ErrLinkNotFound)
+				return c
+			},
+			statusCode: http.StatusNotFound,
+			response:   problemJSON(http.StatusNotFound, "link_not_found", controller.ErrLinkNotFound.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Get breakdown internal server error",
+			fields: fields{
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetBreakdown(mock.Anything, "abc123", mock.Anything).Return(nil, assert.AnError)
+				return c
+			},
+			statusCode: http.StatusInternalServerError,
+			response:   problemJSON(http.StatusInternalServerError, "internal_error", "internal server error"),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.mockExpectations(t)
+			h := NewHandlers(c, slog.New(slog.Default().Handler()))
+
+			req := httptest.NewRequest(http.MethodGet, "/shorten/{code}/stats/breakdown"+tt.fields.query, nil)
+			req.SetPathValue("code", tt.fields.shortCode)
+
+			rr := httptest.NewRecorder()
+
+			handlerTest := http.HandlerFunc(h.GetBreakdown)
+
+			handlerTest.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code, "Status code is not the expected")
+
+			for key, value := range tt.headers {
+				assert.Equal(t, value, rr.Header().Get(key), "Header is not the expected")
+			}
+
+			assert.Equal(t, tt.response, rr.Body.String(), "Body is not the expected")
+		})
+	}
+}
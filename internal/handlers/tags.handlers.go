@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+func (h *Handlers) ListTags(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	data, err := h.controller.ListTags(r.Context())
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	responseData, err := json.Marshal(data)
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseData)
+}
+
+func (h *Handlers) GetTagStats(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	tag := r.PathValue("tag")
+	if tag == "" {
+		h.writeProblem(w, r, errTagRequired)
+		return
+	}
+
+	data, err := h.controller.GetTagStats(r.Context(), tag)
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	responseData, err := json.Marshal(data)
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseData)
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DarcoProgramador/shortener-go-backend/internal/controller"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	controllerMock "github.com/DarcoProgramador/shortener-go-backend/mocks/controller_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandlers_ListTags(t *testing.T) {
+	tests := []struct {
+		name             string
+		mockExpectations func(t *testing.T) *controllerMock.MockControllerInterface
+		statusCode       int
+		response         string
+		headers          map[string]string
+	}{
+		{
+			name: "List tags OK",
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ListTags(mock.Anything).Return(&models.ListTagsResponse{
+					Tags: []models.TagSummary{
+						{Name: "promo", Links: 2, AccessCount: 15, BotCount: 3},
+					},
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response:   `{"tags":[{"name":"promo","links":2,"accessCount":15,"botCount":3}]}`,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+			},
+		},
+		{
+			name: "List tags internal server error",
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ListTags(mock.Anything).Return(nil, assert.AnError)
+				return c
+			},
+			statusCode: http.StatusInternalServerError,
+			response:   problemJSON(http.StatusInternalServerError, "internal_error", "internal server error"),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.mockExpectations(t)
+			h := NewHandlers(c, slog.New(slog.Default().Handler()))
+
+			req := httptest.NewRequest(http.MethodGet, "/tags", nil)
+
+			rr := httptest.NewRecorder()
+
+			handlerTest := http.HandlerFunc(h.ListTags)
+
+			handlerTest.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code, "Status code is not the expected")
+
+			for key, value := range tt.headers {
+				assert.Equal(t, value, rr.Header().Get(key), "Header is not the expected")
+			}
+
+			assert.Equal(t, tt.response, rr.Body.String(), "Body is not the expected")
+		})
+	}
+}
+
+func TestHandlers_GetTagStats(t *testing.T) {
+	type fields struct {
+		tag string
+	}
+	tests := []struct {
+		name             string
+		fields           fields
+		mockExpectations func(t *testing.T) *controllerMock.MockControllerInterface
+		statusCode       int
+		response         string
+		headers          map[string]string
+	}{
+		{
+			name: "Get tag stats OK",
+			fields: fields{
+				tag: "promo",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetTagStats(mock.Anything, "promo").Return(&models.TagStatsResponse{
+					Tag:            "promo",
+					Links:          2,
+					AccessCount:    15,
+					BotCount:       3,
+					UniqueVisitors: 9,
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response:   `{"tag":"promo","links":2,"accessCount":15,"botCount":3,"uniqueVisitors":9}`,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+			},
+		},
+		{
+			name: "Get tag stats tag required",
+			fields: fields{
+				tag: "",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "tag_required", errTagRequired.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Get tag stats not found",
+			fields: fields{
+				tag: "winter",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetTagStats(mock.Anything, "winter").Return(nil, controller.ErrTagNotFound)
+				return c
+			},
+			statusCode: http.StatusNotFound,
+			response:   problemJSON(http.StatusNotFound, "tag_not_found", controller.ErrTagNotFound.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Get tag stats internal server error",
+			fields: fields{
+				tag: "promo",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetTagStats(mock.Anything, "promo").Return(nil, assert.AnError)
+				return c
+			},
+			statusCode: http.StatusInternalServerError,
+			response:   problemJSON(http.StatusInternalServerError, "internal_error", "internal server error"),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.mockExpectations(t)
+			h := NewHandlers(c, slog.New(slog.Default().Handler()))
+
+			req := httptest.NewRequest(http.MethodGet, "/tags/{tag}/stats", nil)
+			req.SetPathValue("tag", tt.fields.tag)
+
+			rr := httptest.NewRecorder()
+
+			handlerTest := http.HandlerFunc(h.GetTagStats)
+
+			handlerTest.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code, "Status code is not the expected")
+
+			for key, value := range tt.headers {
+				assert.Equal(t, value, rr.Header().Get(key), "Header is not the expected")
+			}
+
+			assert.Equal(t, tt.response, rr.Body.String(), "Body is not the expected")
+		})
+	}
+}
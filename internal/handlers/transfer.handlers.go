@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/DarcoProgramador/shortener-go-backend/internal/controller"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	"github.com/DarcoProgramador/shortener-go-backend/utils"
+)
+
+// maxImportSize is the largest import body read, in bytes.
+const maxImportSize = 32 << 20
+
+var exportContentTypes = map[string]string{
+	controller.FormatCSV:    "text/csv; charset=utf-8",
+	controller.FormatNDJSON: "application/x-ndjson",
+}
+
+// importFormats gives the format of an import body from its media type when
+// the query does not name one.
+var importFormats = map[string]string{
+	"text/csv":             controller.FormatCSV,
+	"application/x-ndjson": controller.FormatNDJSON,
+	"application/ndjson":   controller.FormatNDJSON,
+}
+
+// exportWriter remembers whether anything was written, which tells whether
+// an error can still be answered with a status.
+type exportWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(p)
+}
+
+// Export streams every link with its stats as a CSV (the default) or NDJSON
+// download.
+func (h *Handlers) Export(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = controller.FormatCSV
+	}
+
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		h.writeProblem(w, r, utils.ErrInvalidFormat)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="links.`+format+`"`)
+
+	writer := &exportWriter{ResponseWriter: w}
+	err := h.controller.ExportLinks(r.Context(), format, writer)
+	if err == nil {
+		return
+	}
+
+	if writer.written {
+		// The status is already sent, so the connection is dropped for the
+		// client to see the file is incomplete.
+		h.logger.Error("Error exporting links", "error", err)
+		panic(http.ErrAbortHandler)
+	}
+
+	w.Header().Del("Content-Disposition")
+	h.writeProblem(w, r, err)
+}
+
+// Import creates the links of a CSV or NDJSON file sent as the request body.
+// The format comes from the query, or else from the Content-Type.
+func (h *Handlers) Import(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		format = importFormats[mediaType]
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxImportSize)
+	data, err := h.controller.ImportLinks(r.Context(), format, body)
+
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		err = fmt.Errorf("%w: the limit is %d bytes", errImportTooLarge, tooLarge.Limit)
+	}
+
+	if err != nil && data != nil {
+		h.writeImportProblem(w, r, err, data)
+		return
+	}
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	responseData, err := json.Marshal(data)
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseData)
+}
+
+// writeImportProblem answers an import that stopped halfway. Links read
+// before are already imported, so the problem also carries their results.
+func (h *Handlers) writeImportProblem(w http.ResponseWriter, r *http.Request, err error, data *models.BatchResponse) {
+	response := models.ImportProblem{Problem: problem(err), BatchResponse: *data}
+	if response.Status >= http.StatusInternalServerError {
+		h.logger.Error("Error handling request", "method", r.Method, "path", r.URL.Path, "error", err)
+	}
+
+	responseData, _ := json.Marshal(response)
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(response.Status)
+	w.Write(responseData)
+}
@@ -0,0 +1,312 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DarcoProgramador/shortener-go-backend/internal/controller"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
+	controllerMock "github.com/DarcoProgramador/shortener-go-backend/mocks/controller_mock"
+	"github.com/DarcoProgramador/shortener-go-backend/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandlers_Export(t *testing.T) {
+	type fields struct {
+		query string
+	}
+	tests := []struct {
+		name             string
+		fields           fields
+		mockExpectations func(t *testing.T) *controllerMock.MockControllerInterface
+		statusCode       int
+		response         string
+		headers          map[string]string
+	}{
+		{
+			name: "Export CSV by default",
+			fields: fields{
+				query: "",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ExportLinks(mock.Anything, controller.FormatCSV, mock.Anything).RunAndReturn(
+					func(ctx context.Context, format string, w io.Writer) error {
+						_, err := io.WriteString(w, "id,shortCode,url\n1,abc123,https://www.google.com\n")
+						return err
+					},
+				)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response:   "id,shortCode,url\n1,abc123,https://www.google.com\n",
+			headers: map[string]string{
+				"Content-Type":        "text/csv; charset=utf-8",
+				"Content-Disposition": `attachment; filename="links.csv"`,
+			},
+		},
+		{
+			name: "Export NDJSON",
+			fields: fields{
+				query: "?format=ndjson",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ExportLinks(mock.Anything, controller.FormatNDJSON, mock.Anything).RunAndReturn(
+					func(ctx context.Context, format string, w io.Writer) error {
+						_, err := io.WriteString(w, `{"id":1,"shortCode":"abc123","url":"https://www.google.com"}`+"\n")
+						return err
+					},
+				)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response:   `{"id":1,"shortCode":"abc123","url":"https://www.google.com"}` + "\n",
+			headers: map[string]string{
+				"Content-Type":        "application/x-ndjson",
+				"Content-Disposition": `attachment; filename="links.ndjson"`,
+			},
+		},
+		{
+			name: "Export invalid format",
+			fields: fields{
+				query: "?format=xml",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "invalid_format", utils.ErrInvalidFormat.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Export internal server error before writing",
+			fields: fields{
+				query: "?format=csv",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ExportLinks(mock.Anything, controller.FormatCSV, mock.Anything).Return(assert.AnError)
+				return c
+			},
+			statusCode: http.StatusInternalServerError,
+			response:   problemJSON(http.StatusInternalServerError, "internal_error", "internal server error"),
+			headers: map[string]string{
+				"Content-Type":        "application/problem+json",
+				"Content-Disposition": "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.mockExpectations(t)
+			h := NewHandlers(c, slog.New(slog.Default().Handler()))
+
+			req := httptest.NewRequest(http.MethodGet, "/shorten/export"+tt.fields.query, nil)
+
+			rr := httptest.NewRecorder()
+
+			handlerTest := http.HandlerFunc(h.Export)
+
+			handlerTest.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code, "Status code is not the expected")
+
+			for key, value := range tt.headers {
+				assert.Equal(t, value, rr.Header().Get(key), "Header is not the expected")
+			}
+
+			assert.Equal(t, tt.response, rr.Body.String(), "Body is not the expected")
+		})
+	}
+}
+
+func TestHandlers_Import(t *testing.T) {
+	type fields struct {
+		query       string
+		contentType string
+		body        string
+	}
+	tests := []struct {
+		name             string
+		fields           fields
+		mockExpectations func(t *testing.T) *controllerMock.MockControllerInterface
+		statusCode       int
+		response         string
+		headers          map[string]string
+	}{
+		{
+			name: "Import format from the query",
+			fields: fields{
+				query: "?format=csv",
+				body:  "url\nhttps://www.google.com\n",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ImportLinks(mock.Anything, controller.FormatCSV, mock.Anything).Return(&models.BatchResponse{
+					Created: 1,
+					Results: []models.BatchResult{
+						{Index: 0, Status: "created", Link: &models.ShortLinkResponse{Id: 1, Url: "https://www.google.com", ShortCode: "abc123"}},
+					},
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response:   `{"created":1,"failed":0,"results":[{"index":0,"status":"created","link":{"id":1,"url":"https://www.google.com","shortCode":"abc123"}}]}`,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+			},
+		},
+		{
+			name: "Import format from the content type",
+			fields: fields{
+				contentType: "application/x-ndjson; charset=utf-8",
+				body:        `{"shortCode":"taken","url":"https://www.google.com"}`,
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ImportLinks(mock.Anything, controller.FormatNDJSON, mock.Anything).Return(&models.BatchResponse{
+					Failed: 1,
+					Results: []models.BatchResult{
+						{Index: 0, Status: "conflict", Message: controller.ErrAliasTaken.Error()},
+					},
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response:   `{"created":0,"failed":1,"results":[{"index":0,"status":"conflict","message":"alias is already in use"}]}`,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+			},
+		},
+		{
+			name: "Import without format",
+			fields: fields{
+				contentType: "application/json",
+				body:        `[]`,
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ImportLinks(mock.Anything, "", mock.Anything).Return(nil, utils.ErrInvalidFormat)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "invalid_format", utils.ErrInvalidFormat.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Import invalid header",
+			fields: fields{
+				contentType: "text/csv",
+				body:        "keyword,title\nabc,Google\n",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ImportLinks(mock.Anything, controller.FormatCSV, mock.Anything).Return(nil, utils.ErrInvalidImportHeader)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "invalid_import_header", utils.ErrInvalidImportHeader.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Import stopped halfway",
+			fields: fields{
+				query: "?format=csv",
+				body:  "url\nhttps://www.google.com\n",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ImportLinks(mock.Anything, controller.FormatCSV, mock.Anything).Return(&models.BatchResponse{
+					Created: 1,
+					Results: []models.BatchResult{
+						{Index: 0, Status: "created", Link: &models.ShortLinkResponse{Id: 1, Url: "https://www.google.com", ShortCode: "abc123"}},
+					},
+				}, assert.AnError)
+				return c
+			},
+			statusCode: http.StatusInternalServerError,
+			response:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal server error","code":"internal_error","created":1,"failed":0,"results":[{"index":0,"status":"created","link":{"id":1,"url":"https://www.google.com","shortCode":"abc123"}}]}`,
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Import too large",
+			fields: fields{
+				query: "?format=csv",
+				body:  "url\n" + strings.Repeat("x", maxImportSize),
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ImportLinks(mock.Anything, controller.FormatCSV, mock.Anything).RunAndReturn(
+					func(ctx context.Context, format string, body io.Reader) (*models.BatchResponse, error) {
+						_, err := io.ReadAll(body)
+						return &models.BatchResponse{Results: []models.BatchResult{}}, err
+					},
+				)
+				return c
+			},
+			statusCode: http.StatusRequestEntityTooLarge,
+			response:   `{"type":"about:blank","title":"Request Entity Too Large","status":413,"detail":"import file is too large: the limit is 33554432 bytes","code":"import_too_large","created":0,"failed":0,"results":[]}`,
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Import internal server error",
+			fields: fields{
+				query: "?format=csv",
+				body:  "url\nhttps://www.google.com\n",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ImportLinks(mock.Anything, controller.FormatCSV, mock.Anything).Return(nil, assert.AnError)
+				return c
+			},
+			statusCode: http.StatusInternalServerError,
+			response:   problemJSON(http.StatusInternalServerError, "internal_error", "internal server error"),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.mockExpectations(t)
+			h := NewHandlers(c, slog.New(slog.Default().Handler()))
+
+			req := httptest.NewRequest(http.MethodPost, "/shorten/import"+tt.fields.query, strings.NewReader(tt.fields.body))
+			if tt.fields.contentType != "" {
+				req.Header.Set("Content-Type", tt.fields.contentType)
+			}
+
+			rr := httptest.NewRecorder()
+
+			handlerTest := http.HandlerFunc(h.Import)
+
+			handlerTest.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code, "Status code is not the expected")
+
+			for key, value := range tt.headers {
+				assert.Equal(t, value, rr.Header().Get(key), "Header is not the expected")
+			}
+
+			assert.Equal(t, tt.response, rr.Body.String(), "Body is not the expected")
+		})
+	}
+}
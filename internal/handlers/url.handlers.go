@@ -2,81 +2,256 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
+	"mime"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/DarcoProgramador/shortener-go-backend/internal/controller"
+	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
 	"github.com/DarcoProgramador/shortener-go-backend/utils"
 )
 
+// idempotencyKeyHeader lets clients that retry a creation get the link of
+// the first attempt instead of a new one.
+const idempotencyKeyHeader = "Idempotency-Key"
+
 func (h *Handlers) Create(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
-	var requestData struct {
-		URL string `json:"url"`
+	var requestData models.ShortLinkRequest
+
+	err := json.NewDecoder(r.Body).Decode(&requestData)
+	if err != nil {
+		h.writeProblem(w, r, errInvalidRequest)
+		return
+	}
+
+	if err = utils.ValidateURL(requestData.Url); err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	var (
+		data     *models.ShortLinkResponse
+		replayed bool
+	)
+	if key := r.Header.Get(idempotencyKeyHeader); key != "" {
+		data, replayed, err = h.controller.CreateShortLinkIdempotent(r.Context(), key, requestData)
+	} else {
+		data, err = h.controller.CreateShortLink(r.Context(), requestData)
+	}
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	responseData, err := json.Marshal(data)
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	if replayed {
+		w.Header().Set("Idempotent-Replayed", "true")
 	}
+	// A reused link already existed, so nothing was created.
+	status := http.StatusCreated
+	if data.Reused {
+		status = http.StatusOK
+	}
+
+	w.Header().Set("ETag", linkETag(data.Version))
+	w.WriteHeader(status)
+	w.Write(responseData)
+}
+
+// CreateBatch shortens a JSON array of links. Each link gets its own result,
+// so invalid URLs and taken aliases do not fail the rest of the batch.
+func (h *Handlers) CreateBatch(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var requestData []models.ShortLinkRequest
 
 	err := json.NewDecoder(r.Body).Decode(&requestData)
 	if err != nil {
-		h.logger.Error("Error decoding request body", "error", err)
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte(`{"message": "invalid request"}`))
+		h.writeProblem(w, r, errInvalidRequest)
 		return
 	}
 
-	url := requestData.URL
-	if err = utils.ValidateURL(url); err != nil {
-		h.logger.Error("Error validating URL", "error", err)
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte(`{"message": "url is required"}`))
+	data, err := h.controller.CreateShortLinks(r.Context(), requestData)
+	if err != nil {
+		h.writeProblem(w, r, err)
 		return
 	}
 
-	data, err := h.controller.CreateShortLink(r.Context(), url)
+	responseData, err := json.Marshal(data)
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
 
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseData)
+}
+
+// List returns a page of links. Filters and sorting come from the query
+// string; the nextCursor of a response asks for the following page.
+func (h *Handlers) List(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	query := r.URL.Query()
+
+	var limit int
+	if value := query.Get("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil {
+			h.writeProblem(w, r, utils.ErrInvalidLimit)
+			return
+		}
+	}
+
+	var campaignID int
+	if value := query.Get("campaignId"); value != "" {
+		var err error
+		if campaignID, err = strconv.Atoi(value); err != nil {
+			h.writeProblem(w, r, utils.ErrInvalidCampaignID)
+			return
+		}
+	}
+
+	data, err := h.controller.ListLinks(r.Context(), models.ListLinksRequest{
+		Sort:        query.Get("sort"),
+		Order:       query.Get("order"),
+		Cursor:      query.Get("cursor"),
+		Limit:       limit,
+		Domain:      query.Get("domain"),
+		Search:      query.Get("q"),
+		CreatedFrom: query.Get("createdFrom"),
+		CreatedTo:   query.Get("createdTo"),
+		Timezone:    query.Get("tz"),
+		Tag:         query.Get("tag"),
+		CampaignId:  campaignID,
+	})
 	if err != nil {
-		h.logger.Error("Error creating short link", "error", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(`{"message": "` + err.Error() + `"}`))
+		h.writeProblem(w, r, err)
 		return
 	}
 
 	responseData, err := json.Marshal(data)
 	if err != nil {
-		h.logger.Error("Error marshalling response data", "error", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(`{"message": "internal server error"}`))
+		h.writeProblem(w, r, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusCreated)
+	w.WriteHeader(http.StatusOK)
 	w.Write(responseData)
 }
 
 func (h *Handlers) GetOriginal(w http.ResponseWriter, r *http.Request) {
+	// A HEAD route of its own would clash with GET /shorten/export, as both
+	// match HEAD /shorten/export.
+	if r.Method == http.MethodHead {
+		h.Info(w, r)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	code := r.PathValue("code")
 	if code == "" {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte(`{"message": "code is required"}`))
+		h.writeProblem(w, r, errCodeRequired)
 		return
 	}
 
-	data, err := h.controller.GetOriginalLink(r.Context(), code)
+	password := r.Header.Get(passwordHeader)
+
+	// A revalidation is answered before the visit is counted. Any link
+	// ResolveLink would refuse is left to it, so it reports the same error.
+	if r.Header.Get("If-None-Match") != "" {
+		link, err := h.controller.GetLink(r.Context(), code, password)
+		if err == nil && resolvable(link, password, time.Now()) {
+			if etag := linkETag(link.Version); notModified(r, etag) {
+				writeNotModified(w, etag)
+				return
+			}
+		}
+	}
+
+	data, err := h.controller.ResolveLink(r.Context(), code, newVisit(r, password))
+
+	// A link that is not active yet is reported as missing so its
+	// existence is not revealed ahead of time.
+	if errors.Is(err, controller.ErrLinkNotActive) {
+		err = controller.ErrLinkNotFound
+	}
 
 	if err != nil {
-		h.logger.Error("Error getting original link", "error", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(`{"message": "` + err.Error() + `"}`))
+		h.writeProblem(w, r, err)
 		return
 	}
 
 	responseData, err := json.Marshal(data)
 	if err != nil {
-		h.logger.Error("Error marshalling response data", "error", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(`{"message": "internal server error"}`))
+		h.writeProblem(w, r, err)
 		return
 	}
 
+	w.Header().Set("ETag", linkETag(data.Version))
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseData)
+}
+
+// resolvable reports whether a visit would resolve link, as read by GetLink:
+// it is within its activation window and, when protected, a password was
+// sent. GetLink already refuses a wrong one.
+func resolvable(link *models.ShortLinkResponse, password string, now time.Time) bool {
+	if link.Protected && password == "" {
+		return false
+	}
+	if link.NotBefore != nil && now.Before(*link.NotBefore) {
+		return false
+	}
+
+	return link.ExpiresAt == nil || now.Before(*link.ExpiresAt)
+}
+
+// Info returns the details of a short link without counting a visit, for
+// tools that display links rather than follow them. It also answers HEAD
+// requests on /shorten/{code}.
+func (h *Handlers) Info(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	code := r.PathValue("code")
+	if code == "" {
+		h.writeProblem(w, r, errCodeRequired)
+		return
+	}
+
+	data, err := h.controller.GetLink(r.Context(), code, r.Header.Get(passwordHeader))
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	// The URL of a protected link depends on the password, not on the version.
+	if data.Protected {
+		w.Header().Set("Vary", passwordHeader)
+	}
+
+	etag := linkETag(data.Version)
+	if notModified(r, etag) {
+		writeNotModified(w, etag)
+		return
+	}
+
+	responseData, err := json.Marshal(data)
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("ETag", etag)
 	w.WriteHeader(http.StatusOK)
 	w.Write(responseData)
 }
@@ -85,48 +260,91 @@ func (h *Handlers) Update(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	code := r.PathValue("code")
 	if code == "" {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte(`{"message": "code is required"}`))
+		h.writeProblem(w, r, errCodeRequired)
 		return
 	}
 
-	var requestData struct {
-		URL string `json:"url"`
+	version, err := ifMatch(r)
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
 	}
 
-	err := json.NewDecoder(r.Body).Decode(&requestData)
+	var requestData models.ShortLinkRequest
+
+	err = json.NewDecoder(r.Body).Decode(&requestData)
+	if err != nil {
+		h.writeProblem(w, r, errInvalidRequest)
+		return
+	}
+
+	if err = utils.ValidateURL(requestData.Url); err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	data, err := h.controller.UpdateLink(r.Context(), requestData, code, version)
+	if err != nil {
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	responseData, err := json.Marshal(data)
 	if err != nil {
-		h.logger.Error("Error decoding request body", "error", err)
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte(`{"message": "invalid request"}`))
+		h.writeProblem(w, r, err)
 		return
 	}
 
-	url := requestData.URL
-	if err = utils.ValidateURL(url); err != nil {
-		h.logger.Error("Error validating URL", "error", err)
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte(`{"message": "url is required"}`))
+	w.Header().Set("ETag", linkETag(data.Version))
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseData)
+}
+
+// mergePatchType is the media type of the JSON Merge Patch bodies Patch
+// accepts.
+const mergePatchType = "application/merge-patch+json"
+
+// Patch changes only the fields of a link sent in a JSON Merge Patch.
+func (h *Handlers) Patch(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	code := r.PathValue("code")
+	if code == "" {
+		h.writeProblem(w, r, errCodeRequired)
 		return
 	}
 
-	data, err := h.controller.UpdateLink(r.Context(), url, code)
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != mergePatchType {
+		w.Header().Set("Accept-Patch", mergePatchType)
+		h.writeProblem(w, r, errUnsupportedMediaType)
+		return
+	}
 
+	version, err := ifMatch(r)
 	if err != nil {
-		h.logger.Error("Error updating short link", "error", err)
-		w.WriteHeader(http.StatusNotFound)
-		w.Write([]byte(`{"message": "` + err.Error() + `"}`))
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.writeProblem(w, r, errInvalidRequest)
+		return
+	}
+
+	data, err := h.controller.PatchLink(r.Context(), patch, code, version)
+	if err != nil {
+		h.writeProblem(w, r, err)
 		return
 	}
 
 	responseData, err := json.Marshal(data)
 	if err != nil {
-		h.logger.Error("Error marshalling response data", "error", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(`{"message": "internal server error"}`))
+		h.writeProblem(w, r, err)
 		return
 	}
 
+	w.Header().Set("ETag", linkETag(data.Version))
 	w.WriteHeader(http.StatusOK)
 	w.Write(responseData)
 }
@@ -135,16 +353,19 @@ func (h *Handlers) Delete(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	code := r.PathValue("code")
 	if code == "" {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte(`{"message": "code is required"}`))
+		h.writeProblem(w, r, errCodeRequired)
 		return
 	}
 
-	err := h.controller.DeleteShortLink(r.Context(), code)
+	version, err := ifMatch(r)
 	if err != nil {
-		h.logger.Error("Error deleting short link", "error", err)
-		w.WriteHeader(http.StatusNotFound)
-		w.Write([]byte(`{"message": "` + err.Error() + `"}`))
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	err = h.controller.DeleteShortLink(r.Context(), code, version)
+	if err != nil {
+		h.writeProblem(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -154,28 +375,34 @@ func (h *Handlers) GetStat(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	code := r.PathValue("code")
 	if code == "" {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte(`{"message": "code is required"}`))
+		h.writeProblem(w, r, errCodeRequired)
 		return
 	}
 
-	data, err := h.controller.GetStatShortLink(r.Context(), code)
-
+	data, err := h.controller.GetStatShortLink(r.Context(), code, r.Header.Get(passwordHeader))
 	if err != nil {
-		h.logger.Error("Error getting original link", "error", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(`{"message": "` + err.Error() + `"}`))
+		h.writeProblem(w, r, err)
 		return
 	}
 
+	// The URL of a protected link depends on the password.
+	if data.Protected {
+		w.Header().Set("Vary", passwordHeader)
+	}
+
 	responseData, err := json.Marshal(data)
 	if err != nil {
-		h.logger.Error("Error marshalling response data", "error", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(`{"message": "internal server error"}`))
+		h.writeProblem(w, r, err)
+		return
+	}
+
+	etag := contentETag(responseData)
+	if notModified(r, etag) {
+		writeNotModified(w, etag)
 		return
 	}
 
+	w.Header().Set("ETag", etag)
 	w.WriteHeader(http.StatusOK)
 	w.Write(responseData)
 }
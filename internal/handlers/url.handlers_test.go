@@ -7,14 +7,364 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/DarcoProgramador/shortener-go-backend/internal/controller"
 	"github.com/DarcoProgramador/shortener-go-backend/internal/models"
 	controllerMock "github.com/DarcoProgramador/shortener-go-backend/mocks/controller_mock"
+	"github.com/DarcoProgramador/shortener-go-backend/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
 func TestHandlers_Create(t *testing.T) {
+	type fields struct {
+		body           io.Reader
+		idempotencyKey string
+	}
+	tests := []struct {
+		name             string
+		fields           fields
+		mockExpectations func(t *testing.T) *controllerMock.MockControllerInterface
+		statusCode       int
+		response         string
+		headers          map[string]string
+	}{
+		{
+			name: "Create short link OK",
+			fields: fields{
+				body: strings.NewReader(`{"url":"https://www.google.com"}`),
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().CreateShortLink(mock.Anything, models.ShortLinkRequest{Url: "https://www.google.com"}).Return(&models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+					Version:   1,
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusCreated,
+			response:   `{"id":1,"url":"https://www.google.com","shortCode":"abc123","version":1}`,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"ETag":         `"1"`,
+			},
+		},
+		{
+			name: "Create short link reuses existing link",
+			fields: fields{
+				body: strings.NewReader(`{"url":"https://www.google.com","dedupe":true}`),
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				dedupe := true
+				c.EXPECT().CreateShortLink(mock.Anything, models.ShortLinkRequest{Url: "https://www.google.com", Dedupe: &dedupe}).Return(&models.ShortLinkResponse{
+					Id:        7,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+					Version:   2,
+					Reused:    true,
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response:   `{"id":7,"url":"https://www.google.com","shortCode":"abc123","version":2,"reused":true}`,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"ETag":         `"2"`,
+			},
+		},
+		{
+			name: "Create short link invalid URL",
+			fields: fields{
+				body: strings.NewReader(`{"url":"ssssdsad"}`),
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "invalid_url", utils.ErrInvalidURL.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Create short link invalid request body",
+			fields: fields{
+				body: strings.NewReader(`{url":"https://www.google.com"`),
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "invalid_request", errInvalidRequest.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Create short link invalid redirect status",
+			fields: fields{
+				body: strings.NewReader(`{"url":"https://www.google.com","redirectStatus":200}`),
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().CreateShortLink(mock.Anything, models.ShortLinkRequest{Url: "https://www.google.com", RedirectStatus: 200}).Return(nil, utils.ErrInvalidRedirectStatus)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "invalid_redirect_status", utils.ErrInvalidRedirectStatus.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Create short link invalid password",
+			fields: fields{
+				body: strings.NewReader(`{"url":"https://www.google.com","password":"abc"}`),
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().CreateShortLink(mock.Anything, mock.Anything).Return(nil, utils.ErrInvalidLinkPassword)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "invalid_password", utils.ErrInvalidLinkPassword.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Create short link with tags",
+			fields: fields{
+				body: strings.NewReader(`{"url":"https://www.google.com","tags":["Spring","promo"]}`),
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().CreateShortLink(mock.Anything, models.ShortLinkRequest{Url: "https://www.google.com", Tags: []string{"Spring", "promo"}}).Return(&models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+					Tags:      []string{"promo", "spring"},
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusCreated,
+			response:   `{"id":1,"url":"https://www.google.com","shortCode":"abc123","tags":["promo","spring"]}`,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+			},
+		},
+		{
+			name: "Create short link invalid tag",
+			fields: fields{
+				body: strings.NewReader(`{"url":"https://www.google.com","tags":["spring sale"]}`),
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().CreateShortLink(mock.Anything, mock.Anything).Return(nil, utils.ErrInvalidTag)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "invalid_tag", utils.ErrInvalidTag.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Create short link invalid title",
+			fields: fields{
+				body: strings.NewReader(`{"url":"https://www.google.com","title":"` + strings.Repeat("a", utils.MaxTitleLength+1) + `"}`),
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().CreateShortLink(mock.Anything, mock.Anything).Return(nil, utils.ErrInvalidTitle)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "invalid_title", utils.ErrInvalidTitle.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Create short link unknown campaign",
+			fields: fields{
+				body: strings.NewReader(`{"url":"https://www.google.com","campaignId":9}`),
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().CreateShortLink(mock.Anything, mock.Anything).Return(nil, controller.ErrUnknownCampaign)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "unknown_campaign", controller.ErrUnknownCampaign.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Create short link reserved alias",
+			fields: fields{
+				body: strings.NewReader(`{"url":"https://www.google.com","alias":"shorten"}`),
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().CreateShortLink(mock.Anything, models.ShortLinkRequest{Url: "https://www.google.com", Alias: "shorten"}).Return(nil, utils.ErrReservedAlias)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "reserved_alias", utils.ErrReservedAlias.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Create short link alias conflict",
+			fields: fields{
+				body: strings.NewReader(`{"url":"https://www.google.com","alias":"spring-sale"}`),
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().CreateShortLink(mock.Anything, models.ShortLinkRequest{Url: "https://www.google.com", Alias: "spring-sale"}).Return(nil, controller.ErrAliasTaken)
+				return c
+			},
+			statusCode: http.StatusConflict,
+			response:   problemJSON(http.StatusConflict, "alias_taken", controller.ErrAliasTaken.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Create short link with Idempotency-Key",
+			fields: fields{
+				body:           strings.NewReader(`{"url":"https://www.google.com"}`),
+				idempotencyKey: "job-42",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().CreateShortLinkIdempotent(mock.Anything, "job-42", models.ShortLinkRequest{Url: "https://www.google.com"}).Return(&models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+					Version:   1,
+				}, false, nil)
+				return c
+			},
+			statusCode: http.StatusCreated,
+			response:   `{"id":1,"url":"https://www.google.com","shortCode":"abc123","version":1}`,
+			headers: map[string]string{
+				"Content-Type":        "application/json",
+				"Idempotent-Replayed": "",
+			},
+		},
+		{
+			name: "Create short link replayed",
+			fields: fields{
+				body:           strings.NewReader(`{"url":"https://www.google.com"}`),
+				idempotencyKey: "job-42",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().CreateShortLinkIdempotent(mock.Anything, "job-42", models.ShortLinkRequest{Url: "https://www.google.com"}).Return(&models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+					Version:   1,
+				}, true, nil)
+				return c
+			},
+			statusCode: http.StatusCreated,
+			response:   `{"id":1,"url":"https://www.google.com","shortCode":"abc123","version":1}`,
+			headers: map[string]string{
+				"Content-Type":        "application/json",
+				"Idempotent-Replayed": "true",
+				"ETag":                `"1"`,
+			},
+		},
+		{
+			name: "Create short link Idempotency-Key reused",
+			fields: fields{
+				body:           strings.NewReader(`{"url":"https://www.bing.com"}`),
+				idempotencyKey: "job-42",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().CreateShortLinkIdempotent(mock.Anything, "job-42", models.ShortLinkRequest{Url: "https://www.bing.com"}).Return(nil, false, controller.ErrIdempotencyKeyReused)
+				return c
+			},
+			statusCode: http.StatusUnprocessableEntity,
+			response:   problemJSON(http.StatusUnprocessableEntity, "idempotency_key_reused", controller.ErrIdempotencyKeyReused.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Create short link invalid Idempotency-Key",
+			fields: fields{
+				body:           strings.NewReader(`{"url":"https://www.google.com"}`),
+				idempotencyKey: "job\u00e9",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().CreateShortLinkIdempotent(mock.Anything, "job\u00e9", mock.Anything).Return(nil, false, utils.ErrInvalidIdempotencyKey)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "invalid_idempotency_key", utils.ErrInvalidIdempotencyKey.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Create short link internal server error",
+			fields: fields{
+				body: strings.NewReader(`{"url":"https://www.google.com"}`),
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().CreateShortLink(mock.Anything, models.ShortLinkRequest{Url: "https://www.google.com"}).Return(nil, assert.AnError)
+				return c
+			},
+			statusCode: http.StatusInternalServerError,
+			response:   problemJSON(http.StatusInternalServerError, "internal_error", "internal server error"),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.mockExpectations(t)
+			h := NewHandlers(c, slog.New(slog.Default().Handler()))
+
+			req := httptest.NewRequest(http.MethodPost, "/shorten", tt.fields.body)
+			if tt.fields.idempotencyKey != "" {
+				req.Header.Set(idempotencyKeyHeader, tt.fields.idempotencyKey)
+			}
+
+			rr := httptest.NewRecorder()
+
+			handlerTest := http.HandlerFunc(h.Create)
+
+			handlerTest.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code, "Status code is not the expected")
+
+			for key, value := range tt.headers {
+				assert.Equal(t, value, rr.Header().Get(key), "Header is not the expected")
+			}
+
+			assert.Equal(t, tt.response, rr.Body.String(), "Body is not the expected")
+		})
+	}
+}
+
+func TestHandlers_CreateBatch(t *testing.T) {
 	type fields struct {
 		body io.Reader
 	}
@@ -27,69 +377,1084 @@ func TestHandlers_Create(t *testing.T) {
 		headers          map[string]string
 	}{
 		{
-			name: "Create short link OK",
+			name: "Create batch OK",
+			fields: fields{
+				body: strings.NewReader(`[{"url":"https://www.google.com"},{"url":"not a url"},{"url":"https://www.google.com","alias":"taken"}]`),
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().CreateShortLinks(mock.Anything, []models.ShortLinkRequest{
+					{Url: "https://www.google.com"},
+					{Url: "not a url"},
+					{Url: "https://www.google.com", Alias: "taken"},
+				}).Return(&models.BatchResponse{
+					Created: 1,
+					Failed:  2,
+					Results: []models.BatchResult{
+						{Index: 0, Status: "created", Link: &models.ShortLinkResponse{Id: 1, Url: "https://www.google.com", ShortCode: "abc123"}},
+						{Index: 1, Status: "invalid", Message: utils.ErrInvalidURL.Error()},
+						{Index: 2, Status: "conflict", Message: controller.ErrAliasTaken.Error()},
+					},
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response: `{"created":1,"failed":2,"results":[` +
+				`{"index":0,"status":"created","link":{"id":1,"url":"https://www.google.com","shortCode":"abc123"}},` +
+				`{"index":1,"status":"invalid","message":"invalid URL"},` +
+				`{"index":2,"status":"conflict","message":"alias is already in use"}]}`,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+			},
+		},
+		{
+			name: "Create batch invalid request",
+			fields: fields{
+				body: strings.NewReader(`{"url":"https://www.google.com"}`),
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "invalid_request", errInvalidRequest.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Create batch empty",
+			fields: fields{
+				body: strings.NewReader(`[]`),
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().CreateShortLinks(mock.Anything, []models.ShortLinkRequest{}).Return(nil, utils.ErrInvalidBatchSize)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "invalid_batch_size", utils.ErrInvalidBatchSize.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Create batch internal server error",
+			fields: fields{
+				body: strings.NewReader(`[{"url":"https://www.google.com"}]`),
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().CreateShortLinks(mock.Anything, mock.Anything).Return(nil, assert.AnError)
+				return c
+			},
+			statusCode: http.StatusInternalServerError,
+			response:   problemJSON(http.StatusInternalServerError, "internal_error", "internal server error"),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.mockExpectations(t)
+			h := NewHandlers(c, slog.New(slog.Default().Handler()))
+
+			req := httptest.NewRequest(http.MethodPost, "/shorten/batch", tt.fields.body)
+
+			rr := httptest.NewRecorder()
+
+			handlerTest := http.HandlerFunc(h.CreateBatch)
+
+			handlerTest.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code, "Status code is not the expected")
+
+			for key, value := range tt.headers {
+				assert.Equal(t, value, rr.Header().Get(key), "Header is not the expected")
+			}
+
+			assert.Equal(t, tt.response, rr.Body.String(), "Body is not the expected")
+		})
+	}
+}
+
+func TestHandlers_List(t *testing.T) {
+	tests := []struct {
+		name             string
+		query            string
+		mockExpectations func(t *testing.T) *controllerMock.MockControllerInterface
+		statusCode       int
+		response         string
+		headers          map[string]string
+	}{
+		{
+			name:  "List links OK",
+			query: "?sort=accessCount&order=desc&limit=1&domain=google.com&q=sale&createdFrom=2025-03-01&createdTo=2025-04-01&tz=Europe/Madrid&tag=promo",
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ListLinks(mock.Anything, models.ListLinksRequest{
+					Sort:        "accessCount",
+					Order:       "desc",
+					Limit:       1,
+					Domain:      "google.com",
+					Search:      "sale",
+					CreatedFrom: "2025-03-01",
+					CreatedTo:   "2025-04-01",
+					Timezone:    "Europe/Madrid",
+					Tag:         "promo",
+				}).Return(&models.ListLinksResponse{
+					Links: []models.ListedShortLink{
+						{Id: 1, Url: "https://www.google.com/sale", ShortCode: "abc123", Tags: []string{"promo"}, AccessCount: 42},
+					},
+					NextCursor: "eyJpZCI6MX0",
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response:   `{"links":[{"id":1,"url":"https://www.google.com/sale","shortCode":"abc123","tags":["promo"],"accessCount":42}],"nextCursor":"eyJpZCI6MX0"}`,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+			},
+		},
+		{
+			name:  "List links next page",
+			query: "?cursor=eyJpZCI6MX0",
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ListLinks(mock.Anything, models.ListLinksRequest{Cursor: "eyJpZCI6MX0"}).Return(&models.ListLinksResponse{
+					Links: []models.ListedShortLink{},
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response:   `{"links":[]}`,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+			},
+		},
+		{
+			name:  "List links limit is not a number",
+			query: "?limit=ten",
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "invalid_limit", utils.ErrInvalidLimit.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name:  "List links invalid cursor",
+			query: "?cursor=abc",
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ListLinks(mock.Anything, mock.Anything).Return(nil, utils.ErrInvalidCursor)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "invalid_cursor", utils.ErrInvalidCursor.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name:  "List links invalid sort",
+			query: "?sort=url",
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ListLinks(mock.Anything, mock.Anything).Return(nil, utils.ErrInvalidSort)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "invalid_sort", utils.ErrInvalidSort.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name:  "List links internal server error",
+			query: "",
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ListLinks(mock.Anything, mock.Anything).Return(nil, assert.AnError)
+				return c
+			},
+			statusCode: http.StatusInternalServerError,
+			response:   problemJSON(http.StatusInternalServerError, "internal_error", "internal server error"),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.mockExpectations(t)
+			h := NewHandlers(c, slog.New(slog.Default().Handler()))
+
+			req := httptest.NewRequest(http.MethodGet, "/shorten"+tt.query, nil)
+
+			rr := httptest.NewRecorder()
+
+			handlerTest := http.HandlerFunc(h.List)
+
+			handlerTest.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code, "Status code is not the expected")
+
+			for key, value := range tt.headers {
+				assert.Equal(t, value, rr.Header().Get(key), "Header is not the expected")
+			}
+
+			assert.Equal(t, tt.response, rr.Body.String(), "Body is not the expected")
+		})
+	}
+}
+
+func TestHandlers_GetOriginal(t *testing.T) {
+	type fields struct {
+		method      string
+		shortCode   string
+		password    string
+		ifNoneMatch string
+	}
+	tests := []struct {
+		name             string
+		fields           fields
+		mockExpectations func(t *testing.T) *controllerMock.MockControllerInterface
+		statusCode       int
+		response         string
+		headers          map[string]string
+	}{
+		{
+			name: "Get short link OK",
+			fields: fields{
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ResolveLink(mock.Anything, "abc123", mock.Anything).Return(&models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+					Version:   2,
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response:   `{"id":1,"url":"https://www.google.com","shortCode":"abc123","version":2}`,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"ETag":         `"2"`,
+			},
+		},
+		{
+			name: "Get short link not modified",
+			fields: fields{
+				shortCode:   "abc123",
+				ifNoneMatch: `"1", "2"`,
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				// A revalidation does not count a visit.
+				c.EXPECT().GetLink(mock.Anything, "abc123", "").Return(&models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+					Version:   2,
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusNotModified,
+			headers: map[string]string{
+				"Content-Type": "",
+				"ETag":         `"2"`,
+			},
+		},
+		{
+			name: "Get short link modified",
+			fields: fields{
+				shortCode:   "abc123",
+				ifNoneMatch: `"1"`,
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				link := &models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+					Version:   2,
+				}
+				c.EXPECT().GetLink(mock.Anything, "abc123", "").Return(link, nil)
+				c.EXPECT().ResolveLink(mock.Anything, "abc123", mock.Anything).Return(link, nil)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response:   `{"id":1,"url":"https://www.google.com","shortCode":"abc123","version":2}`,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"ETag":         `"2"`,
+			},
+		},
+		{
+			name: "Get short link expired with matching ETag",
+			fields: fields{
+				shortCode:   "abc123",
+				ifNoneMatch: `"2"`,
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				expiresAt := time.Now().Add(-time.Hour)
+				c.EXPECT().GetLink(mock.Anything, "abc123", "").Return(&models.ShortLinkResponse{
+					Id:        1,
+					ShortCode: "abc123",
+					ExpiresAt: &expiresAt,
+					Version:   2,
+				}, nil)
+				c.EXPECT().ResolveLink(mock.Anything, "abc123", mock.Anything).Return(nil, controller.ErrLinkExpired)
+				return c
+			},
+			statusCode: http.StatusGone,
+			response:   problemJSON(http.StatusGone, "link_expired", controller.ErrLinkExpired.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Get short link protected with matching ETag and no password",
+			fields: fields{
+				shortCode:   "abc123",
+				ifNoneMatch: `"2"`,
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetLink(mock.Anything, "abc123", "").Return(&models.ShortLinkResponse{
+					Id:        1,
+					ShortCode: "abc123",
+					Protected: true,
+					Version:   2,
+				}, nil)
+				c.EXPECT().ResolveLink(mock.Anything, "abc123", mock.Anything).Return(nil, controller.ErrPasswordRequired)
+				return c
+			},
+			statusCode: http.StatusUnauthorized,
+			response:   problemJSON(http.StatusUnauthorized, "password_required", controller.ErrPasswordRequired.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Get short link HEAD request",
+			fields: fields{
+				method:    http.MethodHead,
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetLink(mock.Anything, "abc123", "").Return(&models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response:   `{"id":1,"url":"https://www.google.com","shortCode":"abc123"}`,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+			},
+		},
+		{
+			name: "Get short link shortCode required",
+			fields: fields{
+				shortCode: "",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "code_required", errCodeRequired.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Get short link not found",
+			fields: fields{
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ResolveLink(mock.Anything, "abc123", mock.Anything).Return(nil, controller.ErrLinkNotFound)
+				return c
+			},
+			statusCode: http.StatusNotFound,
+			response:   problemJSON(http.StatusNotFound, "link_not_found", controller.ErrLinkNotFound.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Get short link expired",
+			fields: fields{
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ResolveLink(mock.Anything, "abc123", mock.Anything).Return(nil, controller.ErrLinkExpired)
+				return c
+			},
+			statusCode: http.StatusGone,
+			response:   problemJSON(http.StatusGone, "link_expired", controller.ErrLinkExpired.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Get short link with password header",
+			fields: fields{
+				shortCode: "abc123",
+				password:  "s3cret",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ResolveLink(mock.Anything, "abc123", models.VisitRequest{Password: "s3cret", IP: "192.0.2.1"}).Return(&models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+					Protected: true,
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response:   `{"id":1,"url":"https://www.google.com","shortCode":"abc123","protected":true}`,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+			},
+		},
+		{
+			name: "Get short link password required",
+			fields: fields{
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ResolveLink(mock.Anything, "abc123", mock.Anything).Return(nil, controller.ErrPasswordRequired)
+				return c
+			},
+			statusCode: http.StatusUnauthorized,
+			response:   problemJSON(http.StatusUnauthorized, "password_required", controller.ErrPasswordRequired.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Get short link wrong password",
+			fields: fields{
+				shortCode: "abc123",
+				password:  "wrong",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ResolveLink(mock.Anything, "abc123", models.VisitRequest{Password: "wrong", IP: "192.0.2.1"}).Return(nil, controller.ErrWrongPassword)
+				return c
+			},
+			statusCode: http.StatusForbidden,
+			response:   problemJSON(http.StatusForbidden, "wrong_password", controller.ErrWrongPassword.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Get short link internal server error",
+			fields: fields{
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().ResolveLink(mock.Anything, "abc123", mock.Anything).Return(nil, assert.AnError)
+				return c
+			},
+			statusCode: http.StatusInternalServerError,
+			response:   problemJSON(http.StatusInternalServerError, "internal_error", "internal server error"),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.mockExpectations(t)
+			h := NewHandlers(c, slog.New(slog.Default().Handler()))
+
+			method := tt.fields.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			req := httptest.NewRequest(method, "/shorten/{code}", nil)
+			req.SetPathValue("code", tt.fields.shortCode)
+			if tt.fields.password != "" {
+				req.Header.Set(passwordHeader, tt.fields.password)
+			}
+			if tt.fields.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.fields.ifNoneMatch)
+			}
+
+			rr := httptest.NewRecorder()
+
+			handlerTest := http.HandlerFunc(h.GetOriginal)
+
+			handlerTest.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code, "Status code is not the expected")
+
+			for key, value := range tt.headers {
+				assert.Equal(t, value, rr.Header().Get(key), "Header is not the expected")
+			}
+
+			assert.Equal(t, tt.response, rr.Body.String(), "Body is not the expected")
+		})
+	}
+}
+
+func TestHandlers_Info(t *testing.T) {
+	type fields struct {
+		method      string
+		shortCode   string
+		password    string
+		ifNoneMatch string
+	}
+	tests := []struct {
+		name             string
+		fields           fields
+		mockExpectations func(t *testing.T) *controllerMock.MockControllerInterface
+		statusCode       int
+		response         string
+		headers          map[string]string
+	}{
+		{
+			name: "Info OK",
+			fields: fields{
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetLink(mock.Anything, "abc123", "").Return(&models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+					Version:   3,
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response:   `{"id":1,"url":"https://www.google.com","shortCode":"abc123","version":3}`,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"ETag":         `"3"`,
+				"Vary":         "",
+			},
+		},
+		{
+			name: "Info protected link",
+			fields: fields{
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetLink(mock.Anything, "abc123", "").Return(&models.ShortLinkResponse{
+					Id:        1,
+					ShortCode: "abc123",
+					Protected: true,
+					Version:   3,
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response:   `{"id":1,"shortCode":"abc123","protected":true,"version":3}`,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"ETag":         `"3"`,
+				"Vary":         "X-Link-Password",
+			},
+		},
+		{
+			name: "Info protected link with password",
+			fields: fields{
+				shortCode: "abc123",
+				password:  "s3cret",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetLink(mock.Anything, "abc123", "s3cret").Return(&models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+					Protected: true,
+					Version:   3,
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response:   `{"id":1,"url":"https://www.google.com","shortCode":"abc123","protected":true,"version":3}`,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"ETag":         `"3"`,
+				"Vary":         "X-Link-Password",
+			},
+		},
+		{
+			name: "Info protected link with wrong password",
+			fields: fields{
+				shortCode: "abc123",
+				password:  "wrong",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetLink(mock.Anything, "abc123", "wrong").Return(nil, controller.ErrWrongPassword)
+				return c
+			},
+			statusCode: http.StatusForbidden,
+			response:   problemJSON(http.StatusForbidden, "wrong_password", controller.ErrWrongPassword.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Info not modified",
+			fields: fields{
+				shortCode:   "abc123",
+				ifNoneMatch: `W/"3"`,
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetLink(mock.Anything, "abc123", "").Return(&models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+					Version:   3,
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusNotModified,
+			headers: map[string]string{
+				"Content-Type": "",
+				"ETag":         `"3"`,
+			},
+		},
+		{
+			name: "Info modified",
+			fields: fields{
+				shortCode:   "abc123",
+				ifNoneMatch: `"2"`,
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetLink(mock.Anything, "abc123", "").Return(&models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+					Version:   3,
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response:   `{"id":1,"url":"https://www.google.com","shortCode":"abc123","version":3}`,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"ETag":         `"3"`,
+			},
+		},
+		{
+			name: "Info HEAD request",
+			fields: fields{
+				method:    http.MethodHead,
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetLink(mock.Anything, "abc123", "").Return(&models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response:   `{"id":1,"url":"https://www.google.com","shortCode":"abc123"}`,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+			},
+		},
+		{
+			name: "Info shortCode required",
+			fields: fields{
+				shortCode: "",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "code_required", errCodeRequired.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Info not found",
+			fields: fields{
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetLink(mock.Anything, "abc123", "").Return(nil, controller.ErrLinkNotFound)
+				return c
+			},
+			statusCode: http.StatusNotFound,
+			response:   problemJSON(http.StatusNotFound, "link_not_found", controller.ErrLinkNotFound.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Info internal server error",
+			fields: fields{
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetLink(mock.Anything, "abc123", "").Return(nil, assert.AnError)
+				return c
+			},
+			statusCode: http.StatusInternalServerError,
+			response:   problemJSON(http.StatusInternalServerError, "internal_error", "internal server error"),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.mockExpectations(t)
+			h := NewHandlers(c, slog.New(slog.Default().Handler()))
+
+			method := tt.fields.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			req := httptest.NewRequest(method, "/shorten/{code}/info", nil)
+			req.SetPathValue("code", tt.fields.shortCode)
+			if tt.fields.password != "" {
+				req.Header.Set("X-Link-Password", tt.fields.password)
+			}
+			if tt.fields.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.fields.ifNoneMatch)
+			}
+
+			rr := httptest.NewRecorder()
+
+			handlerTest := http.HandlerFunc(h.Info)
+
+			handlerTest.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code, "Status code is not the expected")
+
+			for key, value := range tt.headers {
+				assert.Equal(t, value, rr.Header().Get(key), "Header is not the expected")
+			}
+
+			assert.Equal(t, tt.response, rr.Body.String(), "Body is not the expected")
+		})
+	}
+}
+
+func TestHandlers_GetStat(t *testing.T) {
+	type fields struct {
+		shortCode   string
+		password    string
+		ifNoneMatch string
+	}
+	tests := []struct {
+		name             string
+		fields           fields
+		mockExpectations func(t *testing.T) *controllerMock.MockControllerInterface
+		statusCode       int
+		response         string
+		headers          map[string]string
+	}{
+		{
+			name: "Get short stat link OK",
+			fields: fields{
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetStatShortLink(mock.Anything, "abc123", "").Return(&models.StatShortLinkResponse{
+					Id:                  1,
+					Url:                 "https://www.google.com",
+					ShortCode:           "abc123",
+					AccessCount:         3,
+					BotCount:            4,
+					UniqueVisitors:      2,
+					UniqueVisitorsToday: 1,
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response:   `{"id":1,"url":"https://www.google.com","shortCode":"abc123","accessCount":3,"botCount":4,"uniqueVisitors":2,"uniqueVisitorsToday":1}`,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"ETag":         contentETag([]byte(`{"id":1,"url":"https://www.google.com","shortCode":"abc123","accessCount":3,"botCount":4,"uniqueVisitors":2,"uniqueVisitorsToday":1}`)),
+			},
+		},
+		{
+			name: "Get short stat link not modified",
+			fields: fields{
+				shortCode:   "abc123",
+				ifNoneMatch: contentETag([]byte(`{"id":1,"shortCode":"abc123","accessCount":3,"botCount":0,"uniqueVisitors":0,"uniqueVisitorsToday":0}`)),
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetStatShortLink(mock.Anything, "abc123", "").Return(&models.StatShortLinkResponse{
+					Id:          1,
+					ShortCode:   "abc123",
+					AccessCount: 3,
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusNotModified,
+			headers: map[string]string{
+				"Content-Type": "",
+				"ETag":         contentETag([]byte(`{"id":1,"shortCode":"abc123","accessCount":3,"botCount":0,"uniqueVisitors":0,"uniqueVisitorsToday":0}`)),
+			},
+		},
+		{
+			name: "Get short stat link modified after a visit",
+			fields: fields{
+				shortCode:   "abc123",
+				ifNoneMatch: contentETag([]byte(`{"id":1,"shortCode":"abc123","accessCount":3,"botCount":0,"uniqueVisitors":0,"uniqueVisitorsToday":0}`)),
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetStatShortLink(mock.Anything, "abc123", "").Return(&models.StatShortLinkResponse{
+					Id:          1,
+					ShortCode:   "abc123",
+					AccessCount: 4,
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response:   `{"id":1,"shortCode":"abc123","accessCount":4,"botCount":0,"uniqueVisitors":0,"uniqueVisitorsToday":0}`,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"ETag":         contentETag([]byte(`{"id":1,"shortCode":"abc123","accessCount":4,"botCount":0,"uniqueVisitors":0,"uniqueVisitorsToday":0}`)),
+			},
+		},
+		{
+			name: "Get short stat link with password",
+			fields: fields{
+				shortCode: "abc123",
+				password:  "s3cret",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetStatShortLink(mock.Anything, "abc123", "s3cret").Return(nil, controller.ErrWrongPassword)
+				return c
+			},
+			statusCode: http.StatusForbidden,
+			response:   problemJSON(http.StatusForbidden, "wrong_password", controller.ErrWrongPassword.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Get short stat link shortCode required",
+			fields: fields{
+				shortCode: "",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				return c
+			},
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "code_required", errCodeRequired.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Get short stat link not found",
+			fields: fields{
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetStatShortLink(mock.Anything, "abc123", "").Return(nil, controller.ErrLinkNotFound)
+				return c
+			},
+			statusCode: http.StatusNotFound,
+			response:   problemJSON(http.StatusNotFound, "link_not_found", controller.ErrLinkNotFound.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Get short stat link internal server error",
+			fields: fields{
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().GetStatShortLink(mock.Anything, "abc123", "").Return(nil, assert.AnError)
+				return c
+			},
+			statusCode: http.StatusInternalServerError,
+			response:   problemJSON(http.StatusInternalServerError, "internal_error", "internal server error"),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.mockExpectations(t)
+			h := NewHandlers(c, slog.New(slog.Default().Handler()))
+
+			req := httptest.NewRequest(http.MethodGet, "/shorten/{code}", nil)
+			req.SetPathValue("code", tt.fields.shortCode)
+			if tt.fields.password != "" {
+				req.Header.Set(passwordHeader, tt.fields.password)
+			}
+			if tt.fields.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.fields.ifNoneMatch)
+			}
+
+			rr := httptest.NewRecorder()
+
+			handlerTest := http.HandlerFunc(h.GetStat)
+
+			handlerTest.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code, "Status code is not the expected")
+
+			for key, value := range tt.headers {
+				assert.Equal(t, value, rr.Header().Get(key), "Header is not the expected")
+			}
+
+			assert.Equal(t, tt.response, rr.Body.String(), "Body is not the expected")
+		})
+	}
+}
+
+func TestHandlers_Delete(t *testing.T) {
+	type fields struct {
+		shortCode string
+		ifMatch   string
+	}
+	tests := []struct {
+		name             string
+		fields           fields
+		mockExpectations func(t *testing.T) *controllerMock.MockControllerInterface
+		statusCode       int
+		response         string
+		headers          map[string]string
+	}{
+		{
+			name: "Delete short link OK",
+			fields: fields{
+				shortCode: "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().DeleteShortLink(mock.Anything, "abc123", int64(0)).Return(nil)
+				return c
+			},
+			statusCode: http.StatusNoContent,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+			},
+		},
+		{
+			name: "Delete short link with If-Match",
 			fields: fields{
-				body: strings.NewReader(`{"url":"https://www.google.com"}`),
+				shortCode: "abc123",
+				ifMatch:   `"2"`,
 			},
 			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
 				c := controllerMock.NewMockControllerInterface(t)
-				c.EXPECT().CreateShortLink(mock.Anything, "https://www.google.com").Return(&models.ShortLinkResponse{
-					Id:        1,
-					Url:       "https://www.google.com",
-					ShortCode: "abc123",
-				}, nil)
+				c.EXPECT().DeleteShortLink(mock.Anything, "abc123", int64(2)).Return(nil)
 				return c
 			},
-			statusCode: http.StatusCreated,
-			response:   `{"id":1,"url":"https://www.google.com","shortCode":"abc123"}`,
+			statusCode: http.StatusNoContent,
+		},
+		{
+			name: "Delete short link version mismatch",
+			fields: fields{
+				shortCode: "abc123",
+				ifMatch:   `"2"`,
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().DeleteShortLink(mock.Anything, "abc123", int64(2)).Return(controller.ErrVersionMismatch)
+				return c
+			},
+			statusCode: http.StatusPreconditionFailed,
+			response:   problemJSON(http.StatusPreconditionFailed, "version_mismatch", controller.ErrVersionMismatch.Error()),
 			headers: map[string]string{
-				"Content-Type": "application/json",
+				"Content-Type": "application/problem+json",
 			},
 		},
 		{
-			name: "Create short link invalid URL",
+			name: "Delete short link invalid If-Match",
 			fields: fields{
-				body: strings.NewReader(`{"url":"ssssdsad"}`),
+				shortCode: "abc123",
+				ifMatch:   `W/"2"`,
 			},
 			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
 				c := controllerMock.NewMockControllerInterface(t)
 				return c
 			},
-			statusCode: http.StatusBadRequest,
-			response:   `{"message": "url is required"}`,
+			statusCode: http.StatusPreconditionFailed,
+			response:   problemJSON(http.StatusPreconditionFailed, "precondition_failed", errPreconditionFailed.Error()),
 			headers: map[string]string{
-				"Content-Type": "application/json",
+				"Content-Type": "application/problem+json",
 			},
 		},
 		{
-			name: "Create short link invalid request body",
+			name: "Delete short link shortCode required",
 			fields: fields{
-				body: strings.NewReader(`{url":"https://www.google.com"`),
+				shortCode: "",
 			},
 			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
 				c := controllerMock.NewMockControllerInterface(t)
 				return c
 			},
 			statusCode: http.StatusBadRequest,
-			response:   `{"message": "invalid request"}`,
+			response:   problemJSON(http.StatusBadRequest, "code_required", errCodeRequired.Error()),
 			headers: map[string]string{
-				"Content-Type": "application/json",
+				"Content-Type": "application/problem+json",
 			},
 		},
 		{
-			name: "Create short link internal server error",
+			name: "Delete short link Not Found",
 			fields: fields{
-				body: strings.NewReader(`{"url":"https://www.google.com"}`),
+				shortCode: "abc123",
 			},
 			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
 				c := controllerMock.NewMockControllerInterface(t)
-				c.EXPECT().CreateShortLink(mock.Anything, "https://www.google.com").Return(nil, assert.AnError)
+				c.EXPECT().DeleteShortLink(mock.Anything, "abc123", int64(0)).Return(controller.ErrLinkNotFound)
 				return c
 			},
-			statusCode: http.StatusInternalServerError,
-			response:   `{"message": "` + assert.AnError.Error() + `"}`,
+			statusCode: http.StatusNotFound,
+			response:   problemJSON(http.StatusNotFound, "link_not_found", controller.ErrLinkNotFound.Error()),
 			headers: map[string]string{
-				"Content-Type": "application/json",
+				"Content-Type": "application/problem+json",
 			},
 		},
 	}
@@ -98,11 +1463,15 @@ func TestHandlers_Create(t *testing.T) {
 			c := tt.mockExpectations(t)
 			h := NewHandlers(c, slog.New(slog.Default().Handler()))
 
-			req := httptest.NewRequest(http.MethodPost, "/shorten", tt.fields.body)
+			req := httptest.NewRequest(http.MethodGet, "/shorten/{code}", nil)
+			req.SetPathValue("code", tt.fields.shortCode)
+			if tt.fields.ifMatch != "" {
+				req.Header.Set("If-Match", tt.fields.ifMatch)
+			}
 
 			rr := httptest.NewRecorder()
 
-			handlerTest := http.HandlerFunc(h.Create)
+			handlerTest := http.HandlerFunc(h.Delete)
 
 			handlerTest.ServeHTTP(rr, req)
 
@@ -117,9 +1486,11 @@ func TestHandlers_Create(t *testing.T) {
 	}
 }
 
-func TestHandlers_GetOriginal(t *testing.T) {
+func TestHandlers_Update(t *testing.T) {
 	type fields struct {
+		body      io.Reader
 		shortCode string
+		ifMatch   string
 	}
 	tests := []struct {
 		name             string
@@ -130,13 +1501,14 @@ func TestHandlers_GetOriginal(t *testing.T) {
 		headers          map[string]string
 	}{
 		{
-			name: "Get short link OK",
+			name: "Update short link OK",
 			fields: fields{
+				body:      strings.NewReader(`{"url":"https://www.google.com"}`),
 				shortCode: "abc123",
 			},
 			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
 				c := controllerMock.NewMockControllerInterface(t)
-				c.EXPECT().GetOriginalLink(mock.Anything, "abc123").Return(&models.ShortLinkResponse{
+				c.EXPECT().UpdateLink(mock.Anything, models.ShortLinkRequest{Url: "https://www.google.com"}, "abc123", int64(0)).Return(&models.ShortLinkResponse{
 					Id:        1,
 					Url:       "https://www.google.com",
 					ShortCode: "abc123",
@@ -150,208 +1522,143 @@ func TestHandlers_GetOriginal(t *testing.T) {
 			},
 		},
 		{
-			name: "Get short link shortCode required",
+			name: "Update short link with If-Match",
 			fields: fields{
-				shortCode: "",
+				body:      strings.NewReader(`{"url":"https://www.google.com"}`),
+				shortCode: "abc123",
+				ifMatch:   `"2"`,
 			},
 			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
 				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().UpdateLink(mock.Anything, models.ShortLinkRequest{Url: "https://www.google.com"}, "abc123", int64(2)).Return(&models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+					Version:   3,
+				}, nil)
 				return c
 			},
-			statusCode: http.StatusBadRequest,
-			response:   `{"message": "code is required"}`,
+			statusCode: http.StatusOK,
+			response:   `{"id":1,"url":"https://www.google.com","shortCode":"abc123","version":3}`,
 			headers: map[string]string{
 				"Content-Type": "application/json",
+				"ETag":         `"3"`,
 			},
 		},
 		{
-			name: "Get short link internal server error",
+			name: "Update short link version mismatch",
 			fields: fields{
+				body:      strings.NewReader(`{"url":"https://www.google.com"}`),
 				shortCode: "abc123",
+				ifMatch:   `"2"`,
 			},
 			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
 				c := controllerMock.NewMockControllerInterface(t)
-				c.EXPECT().GetOriginalLink(mock.Anything, "abc123").Return(nil, assert.AnError)
+				c.EXPECT().UpdateLink(mock.Anything, mock.Anything, "abc123", int64(2)).Return(nil, controller.ErrVersionMismatch)
 				return c
 			},
-			statusCode: http.StatusInternalServerError,
-			response:   `{"message": "` + assert.AnError.Error() + `"}`,
+			statusCode: http.StatusPreconditionFailed,
+			response:   problemJSON(http.StatusPreconditionFailed, "version_mismatch", controller.ErrVersionMismatch.Error()),
 			headers: map[string]string{
-				"Content-Type": "application/json",
+				"Content-Type": "application/problem+json",
 			},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			c := tt.mockExpectations(t)
-			h := NewHandlers(c, slog.New(slog.Default().Handler()))
-
-			req := httptest.NewRequest(http.MethodGet, "/shorten/{code}", nil)
-			req.SetPathValue("code", tt.fields.shortCode)
-
-			rr := httptest.NewRecorder()
-
-			handlerTest := http.HandlerFunc(h.GetOriginal)
-
-			handlerTest.ServeHTTP(rr, req)
-
-			assert.Equal(t, tt.statusCode, rr.Code, "Status code is not the expected")
-
-			for key, value := range tt.headers {
-				assert.Equal(t, value, rr.Header().Get(key), "Header is not the expected")
-			}
-
-			assert.Equal(t, tt.response, rr.Body.String(), "Body is not the expected")
-		})
-	}
-}
-
-func TestHandlers_GetStat(t *testing.T) {
-	type fields struct {
-		shortCode string
-	}
-	tests := []struct {
-		name             string
-		fields           fields
-		mockExpectations func(t *testing.T) *controllerMock.MockControllerInterface
-		statusCode       int
-		response         string
-		headers          map[string]string
-	}{
 		{
-			name: "Get short stat link OK",
+			name: "Update short link invalid If-Match",
 			fields: fields{
+				body:      strings.NewReader(`{"url":"https://www.google.com"}`),
 				shortCode: "abc123",
+				ifMatch:   "2",
 			},
 			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
 				c := controllerMock.NewMockControllerInterface(t)
-				c.EXPECT().GetStatShortLink(mock.Anything, "abc123").Return(&models.StatShortLinkResponse{
-					Id:          1,
-					Url:         "https://www.google.com",
-					ShortCode:   "abc123",
-					AccessCount: 1,
-				}, nil)
 				return c
 			},
-			statusCode: http.StatusOK,
-			response:   `{"id":1,"url":"https://www.google.com","shortCode":"abc123","accessCount":1}`,
+			statusCode: http.StatusPreconditionFailed,
+			response:   problemJSON(http.StatusPreconditionFailed, "precondition_failed", errPreconditionFailed.Error()),
 			headers: map[string]string{
-				"Content-Type": "application/json",
+				"Content-Type": "application/problem+json",
 			},
 		},
 		{
-			name: "Get short stat link shortCode required",
+			name: "Update short link invalid URL",
 			fields: fields{
-				shortCode: "",
+				body:      strings.NewReader(`{"url":"ssssdsad"}`),
+				shortCode: "abc123",
 			},
 			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
 				c := controllerMock.NewMockControllerInterface(t)
 				return c
 			},
 			statusCode: http.StatusBadRequest,
-			response:   `{"message": "code is required"}`,
+			response:   problemJSON(http.StatusBadRequest, "invalid_url", utils.ErrInvalidURL.Error()),
 			headers: map[string]string{
-				"Content-Type": "application/json",
+				"Content-Type": "application/problem+json",
 			},
 		},
 		{
-			name: "Get short stat link internal server error",
+			name: "Update short link invalid request body",
 			fields: fields{
+				body:      strings.NewReader(`{url":"https://www.google.com"`),
 				shortCode: "abc123",
 			},
 			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
 				c := controllerMock.NewMockControllerInterface(t)
-				c.EXPECT().GetStatShortLink(mock.Anything, "abc123").Return(nil, assert.AnError)
 				return c
 			},
-			statusCode: http.StatusInternalServerError,
-			response:   `{"message": "` + assert.AnError.Error() + `"}`,
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "invalid_request", errInvalidRequest.Error()),
 			headers: map[string]string{
-				"Content-Type": "application/json",
+				"Content-Type": "application/problem+json",
 			},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			c := tt.mockExpectations(t)
-			h := NewHandlers(c, slog.New(slog.Default().Handler()))
-
-			req := httptest.NewRequest(http.MethodGet, "/shorten/{code}", nil)
-			req.SetPathValue("code", tt.fields.shortCode)
-
-			rr := httptest.NewRecorder()
-
-			handlerTest := http.HandlerFunc(h.GetStat)
-
-			handlerTest.ServeHTTP(rr, req)
-
-			assert.Equal(t, tt.statusCode, rr.Code, "Status code is not the expected")
-
-			for key, value := range tt.headers {
-				assert.Equal(t, value, rr.Header().Get(key), "Header is not the expected")
-			}
-
-			assert.Equal(t, tt.response, rr.Body.String(), "Body is not the expected")
-		})
-	}
-}
-
-func TestHandlers_Delete(t *testing.T) {
-	type fields struct {
-		shortCode string
-	}
-	tests := []struct {
-		name             string
-		fields           fields
-		mockExpectations func(t *testing.T) *controllerMock.MockControllerInterface
-		statusCode       int
-		response         string
-		headers          map[string]string
-	}{
 		{
-			name: "Delete short link OK",
+			name: "Update short link shortCode required",
 			fields: fields{
-				shortCode: "abc123",
+				shortCode: "",
 			},
 			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
 				c := controllerMock.NewMockControllerInterface(t)
-				c.EXPECT().DeleteShortLink(mock.Anything, "abc123").Return(nil)
 				return c
 			},
-			statusCode: http.StatusNoContent,
+			statusCode: http.StatusBadRequest,
+			response:   problemJSON(http.StatusBadRequest, "code_required", errCodeRequired.Error()),
 			headers: map[string]string{
-				"Content-Type": "application/json",
+				"Content-Type": "application/problem+json",
 			},
 		},
 		{
-			name: "Delete short link shortCode required",
+			name: "Update short link too many tags",
 			fields: fields{
-				shortCode: "",
+				body:      strings.NewReader(`{"url":"https://www.google.com","tags":["a","b"]}`),
+				shortCode: "abc123",
 			},
 			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
 				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().UpdateLink(mock.Anything, mock.Anything, "abc123", int64(0)).Return(nil, utils.ErrTooManyTags)
 				return c
 			},
 			statusCode: http.StatusBadRequest,
-			response:   `{"message": "code is required"}`,
+			response:   problemJSON(http.StatusBadRequest, "too_many_tags", utils.ErrTooManyTags.Error()),
 			headers: map[string]string{
-				"Content-Type": "application/json",
+				"Content-Type": "application/problem+json",
 			},
 		},
 		{
-			name: "Delete short link Not Found",
+			name: "Update short link Not Found",
 			fields: fields{
+				body:      strings.NewReader(`{"url":"https://www.google.com"}`),
 				shortCode: "abc123",
 			},
 			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
 				c := controllerMock.NewMockControllerInterface(t)
-				c.EXPECT().DeleteShortLink(mock.Anything, "abc123").Return(assert.AnError)
+				c.EXPECT().UpdateLink(mock.Anything, models.ShortLinkRequest{Url: "https://www.google.com"}, "abc123", int64(0)).Return(nil, controller.ErrLinkNotFound)
 				return c
 			},
 			statusCode: http.StatusNotFound,
-			response:   `{"message": "` + assert.AnError.Error() + `"}`,
+			response:   problemJSON(http.StatusNotFound, "link_not_found", controller.ErrLinkNotFound.Error()),
 			headers: map[string]string{
-				"Content-Type": "application/json",
+				"Content-Type": "application/problem+json",
 			},
 		},
 	}
@@ -360,12 +1667,15 @@ func TestHandlers_Delete(t *testing.T) {
 			c := tt.mockExpectations(t)
 			h := NewHandlers(c, slog.New(slog.Default().Handler()))
 
-			req := httptest.NewRequest(http.MethodGet, "/shorten/{code}", nil)
+			req := httptest.NewRequest(http.MethodPut, "/shorten", tt.fields.body)
 			req.SetPathValue("code", tt.fields.shortCode)
+			if tt.fields.ifMatch != "" {
+				req.Header.Set("If-Match", tt.fields.ifMatch)
+			}
 
 			rr := httptest.NewRecorder()
 
-			handlerTest := http.HandlerFunc(h.Delete)
+			handlerTest := http.HandlerFunc(h.Update)
 
 			handlerTest.ServeHTTP(rr, req)
 
@@ -380,10 +1690,12 @@ func TestHandlers_Delete(t *testing.T) {
 	}
 }
 
-func TestHandlers_Update(t *testing.T) {
+func TestHandlers_Patch(t *testing.T) {
 	type fields struct {
-		body      io.Reader
-		shortCode string
+		body        string
+		contentType string
+		shortCode   string
+		ifMatch     string
 	}
 	tests := []struct {
 		name             string
@@ -394,14 +1706,38 @@ func TestHandlers_Update(t *testing.T) {
 		headers          map[string]string
 	}{
 		{
-			name: "Update short link OK",
+			name: "Patch short link OK",
 			fields: fields{
-				body:      strings.NewReader(`{"url":"https://www.google.com"}`),
-				shortCode: "abc123",
+				body:        `{"title":"Q3 webinar"}`,
+				contentType: "application/merge-patch+json",
+				shortCode:   "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().PatchLink(mock.Anything, []byte(`{"title":"Q3 webinar"}`), "abc123", int64(0)).Return(&models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+					Title:     "Q3 webinar",
+				}, nil)
+				return c
+			},
+			statusCode: http.StatusOK,
+			response:   `{"id":1,"url":"https://www.google.com","shortCode":"abc123","title":"Q3 webinar"}`,
+			headers: map[string]string{
+				"Content-Type": "application/json",
+			},
+		},
+		{
+			name: "Patch short link with charset",
+			fields: fields{
+				body:        `{"expiresAt":null}`,
+				contentType: "application/merge-patch+json; charset=utf-8",
+				shortCode:   "abc123",
 			},
 			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
 				c := controllerMock.NewMockControllerInterface(t)
-				c.EXPECT().UpdateLink(mock.Anything, "https://www.google.com", "abc123").Return(&models.ShortLinkResponse{
+				c.EXPECT().PatchLink(mock.Anything, []byte(`{"expiresAt":null}`), "abc123", int64(0)).Return(&models.ShortLinkResponse{
 					Id:        1,
 					Url:       "https://www.google.com",
 					ShortCode: "abc123",
@@ -415,67 +1751,138 @@ func TestHandlers_Update(t *testing.T) {
 			},
 		},
 		{
-			name: "Update short link invalid URL",
+			name: "Patch short link with If-Match",
 			fields: fields{
-				body:      strings.NewReader(`{"url":"ssssdsad"}`),
-				shortCode: "abc123",
+				body:        `{"title":"Q3 webinar"}`,
+				contentType: "application/merge-patch+json",
+				shortCode:   "abc123",
+				ifMatch:     `"4"`,
 			},
 			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
 				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().PatchLink(mock.Anything, []byte(`{"title":"Q3 webinar"}`), "abc123", int64(4)).Return(&models.ShortLinkResponse{
+					Id:        1,
+					Url:       "https://www.google.com",
+					ShortCode: "abc123",
+					Title:     "Q3 webinar",
+					Version:   5,
+				}, nil)
 				return c
 			},
-			statusCode: http.StatusBadRequest,
-			response:   `{"message": "url is required"}`,
+			statusCode: http.StatusOK,
+			response:   `{"id":1,"url":"https://www.google.com","shortCode":"abc123","title":"Q3 webinar","version":5}`,
 			headers: map[string]string{
 				"Content-Type": "application/json",
+				"ETag":         `"5"`,
 			},
 		},
 		{
-			name: "Update short link invalid request body",
+			name: "Patch short link version mismatch",
 			fields: fields{
-				body:      strings.NewReader(`{url":"https://www.google.com"`),
-				shortCode: "abc123",
+				body:        `{"title":"Q3 webinar"}`,
+				contentType: "application/merge-patch+json",
+				shortCode:   "abc123",
+				ifMatch:     `"3"`,
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().PatchLink(mock.Anything, mock.Anything, "abc123", int64(3)).Return(nil, controller.ErrVersionMismatch)
+				return c
+			},
+			statusCode: http.StatusPreconditionFailed,
+			response:   problemJSON(http.StatusPreconditionFailed, "version_mismatch", controller.ErrVersionMismatch.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Patch short link unsupported media type",
+			fields: fields{
+				body:        `{"title":"Q3 webinar"}`,
+				contentType: "application/json",
+				shortCode:   "abc123",
 			},
 			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
 				c := controllerMock.NewMockControllerInterface(t)
 				return c
 			},
+			statusCode: http.StatusUnsupportedMediaType,
+			response:   problemJSON(http.StatusUnsupportedMediaType, "unsupported_media_type", errUnsupportedMediaType.Error()),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
+				"Accept-Patch": "application/merge-patch+json",
+			},
+		},
+		{
+			name: "Patch short link invalid patch",
+			fields: fields{
+				body:        `["title"]`,
+				contentType: "application/merge-patch+json",
+				shortCode:   "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().PatchLink(mock.Anything, mock.Anything, "abc123", int64(0)).Return(nil, utils.ErrInvalidPatch)
+				return c
+			},
 			statusCode: http.StatusBadRequest,
-			response:   `{"message": "invalid request"}`,
+			response:   problemJSON(http.StatusBadRequest, "invalid_patch", utils.ErrInvalidPatch.Error()),
 			headers: map[string]string{
-				"Content-Type": "application/json",
+				"Content-Type": "application/problem+json",
 			},
 		},
 		{
-			name: "Update short link shortCode required",
+			name: "Patch short link invalid title",
 			fields: fields{
-				shortCode: "",
+				body:        `{"title":"` + strings.Repeat("a", utils.MaxTitleLength+1) + `"}`,
+				contentType: "application/merge-patch+json",
+				shortCode:   "abc123",
 			},
 			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
 				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().PatchLink(mock.Anything, mock.Anything, "abc123", int64(0)).Return(nil, utils.ErrInvalidTitle)
 				return c
 			},
 			statusCode: http.StatusBadRequest,
-			response:   `{"message": "code is required"}`,
+			response:   problemJSON(http.StatusBadRequest, "invalid_title", utils.ErrInvalidTitle.Error()),
 			headers: map[string]string{
-				"Content-Type": "application/json",
+				"Content-Type": "application/problem+json",
 			},
 		},
 		{
-			name: "Update short link Not Found",
+			name: "Patch short link not found",
 			fields: fields{
-				body:      strings.NewReader(`{"url":"https://www.google.com"}`),
-				shortCode: "abc123",
+				body:        `{"title":"Q3 webinar"}`,
+				contentType: "application/merge-patch+json",
+				shortCode:   "nope00",
 			},
 			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
 				c := controllerMock.NewMockControllerInterface(t)
-				c.EXPECT().UpdateLink(mock.Anything, "https://www.google.com", "abc123").Return(nil, assert.AnError)
+				c.EXPECT().PatchLink(mock.Anything, mock.Anything, "nope00", int64(0)).Return(nil, controller.ErrLinkNotFound)
 				return c
 			},
 			statusCode: http.StatusNotFound,
-			response:   `{"message": "` + assert.AnError.Error() + `"}`,
+			response:   problemJSON(http.StatusNotFound, "link_not_found", controller.ErrLinkNotFound.Error()),
 			headers: map[string]string{
-				"Content-Type": "application/json",
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Patch short link internal server error",
+			fields: fields{
+				body:        `{"title":"Q3 webinar"}`,
+				contentType: "application/merge-patch+json",
+				shortCode:   "abc123",
+			},
+			mockExpectations: func(t *testing.T) *controllerMock.MockControllerInterface {
+				c := controllerMock.NewMockControllerInterface(t)
+				c.EXPECT().PatchLink(mock.Anything, mock.Anything, "abc123", int64(0)).Return(nil, assert.AnError)
+				return c
+			},
+			statusCode: http.StatusInternalServerError,
+			response:   problemJSON(http.StatusInternalServerError, "internal_error", "internal server error"),
+			headers: map[string]string{
+				"Content-Type": "application/problem+json",
 			},
 		},
 	}
@@ -484,12 +1891,16 @@ func TestHandlers_Update(t *testing.T) {
 			c := tt.mockExpectations(t)
 			h := NewHandlers(c, slog.New(slog.Default().Handler()))
 
-			req := httptest.NewRequest(http.MethodPut, "/shorten", tt.fields.body)
+			req := httptest.NewRequest(http.MethodPatch, "/shorten", strings.NewReader(tt.fields.body))
+			req.Header.Set("Content-Type", tt.fields.contentType)
 			req.SetPathValue("code", tt.fields.shortCode)
+			if tt.fields.ifMatch != "" {
+				req.Header.Set("If-Match", tt.fields.ifMatch)
+			}
 
 			rr := httptest.NewRecorder()
 
-			handlerTest := http.HandlerFunc(h.Update)
+			handlerTest := http.HandlerFunc(h.Patch)
 
 			handlerTest.ServeHTTP(rr, req)
 
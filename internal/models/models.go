@@ -3,19 +3,305 @@ package models
 import "time"
 
 type (
+	ShortLinkRequest struct {
+		Url            string     `json:"url"`
+		Alias          string     `json:"alias,omitempty"`
+		RedirectStatus int        `json:"redirectStatus,omitempty"`
+		ExpiresAt      *time.Time `json:"expiresAt,omitempty"`
+		NotBefore      *time.Time `json:"notBefore,omitempty"`
+		MaxClicks      int        `json:"maxClicks,omitempty"`
+		Title          string     `json:"title,omitempty"`
+		Description    string     `json:"description,omitempty"`
+		Notes          string     `json:"notes,omitempty"`
+		// Password protects the link when set. On update, nil keeps the
+		// current password and an empty string removes it.
+		Password *string `json:"password,omitempty"`
+		// Tags group links. On update, nil keeps the current tags and an
+		// empty list removes them.
+		Tags []string `json:"tags,omitempty"`
+		// CampaignId adds the link to a campaign, whose default UTM parameters
+		// are added to the URL. On update, nil keeps the current campaign and
+		// 0 takes the link out of it.
+		CampaignId *int `json:"campaignId,omitempty"`
+		// Dedupe returns an existing link to the same URL instead of creating
+		// one. Nil uses the server default.
+		Dedupe *bool `json:"dedupe,omitempty"`
+	}
+
+	// VisitRequest carries what a visitor sends along when following a link.
+	// Prefetch marks requests that do not come from a person opening the
+	// link.
+	VisitRequest struct {
+		Password       string
+		Referrer       string
+		UserAgent      string
+		IP             string
+		AcceptLanguage string
+		Prefetch       bool
+	}
+
 	ShortLinkResponse struct {
-		Id        int        `json:"id,omitempty"`
-		Url       string     `json:"url,omitempty"`
-		ShortCode string     `json:"shortCode,omitempty"`
-		CreatedAt *time.Time `json:"createdAt,omitempty"`
-		UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+		Id             int        `json:"id,omitempty"`
+		Url            string     `json:"url,omitempty"`
+		ShortCode      string     `json:"shortCode,omitempty"`
+		Title          string     `json:"title,omitempty"`
+		Description    string     `json:"description,omitempty"`
+		Notes          string     `json:"notes,omitempty"`
+		RedirectStatus int        `json:"redirectStatus,omitempty"`
+		ExpiresAt      *time.Time `json:"expiresAt,omitempty"`
+		NotBefore      *time.Time `json:"notBefore,omitempty"`
+		MaxClicks      int        `json:"maxClicks,omitempty"`
+		Protected      bool       `json:"protected,omitempty"`
+		CreatedAt      *time.Time `json:"createdAt,omitempty"`
+		UpdatedAt      *time.Time `json:"updatedAt,omitempty"`
+		Tags           []string   `json:"tags,omitempty"`
+		CampaignId     int        `json:"campaignId,omitempty"`
+		Version        int        `json:"version,omitempty"`
+		// Reused is set when a creation returned an existing link.
+		Reused bool `json:"reused,omitempty"`
 	}
+
+	// BatchResult is the outcome of one link of a batch: status is created,
+	// reused, invalid, conflict or error, and message says what went wrong.
+	BatchResult struct {
+		Index   int                `json:"index"`
+		Status  string             `json:"status"`
+		Link    *ShortLinkResponse `json:"link,omitempty"`
+		Message string             `json:"message,omitempty"`
+	}
+
+	BatchResponse struct {
+		Created int           `json:"created"`
+		Reused  int           `json:"reused,omitempty"`
+		Failed  int           `json:"failed"`
+		Results []BatchResult `json:"results"`
+	}
+
 	StatShortLinkResponse struct {
-		Id          int        `json:"id,omitempty"`
-		Url         string     `json:"url,omitempty"`
-		ShortCode   string     `json:"shortCode,omitempty"`
-		CreatedAt   *time.Time `json:"createdAt,omitempty"`
-		UpdatedAt   *time.Time `json:"updatedAt,omitempty"`
-		AccessCount uint       `json:"accessCount"`
+		Id             int        `json:"id,omitempty"`
+		Url            string     `json:"url,omitempty"`
+		ShortCode      string     `json:"shortCode,omitempty"`
+		Title          string     `json:"title,omitempty"`
+		Description    string     `json:"description,omitempty"`
+		Notes          string     `json:"notes,omitempty"`
+		RedirectStatus int        `json:"redirectStatus,omitempty"`
+		ExpiresAt      *time.Time `json:"expiresAt,omitempty"`
+		NotBefore      *time.Time `json:"notBefore,omitempty"`
+		MaxClicks      int        `json:"maxClicks,omitempty"`
+		Protected      bool       `json:"protected,omitempty"`
+		CreatedAt      *time.Time `json:"createdAt,omitempty"`
+		UpdatedAt      *time.Time `json:"updatedAt,omitempty"`
+		Tags           []string   `json:"tags,omitempty"`
+		CampaignId     int        `json:"campaignId,omitempty"`
+		AccessCount    uint       `json:"accessCount"`
+		// BotCount counts crawlers, link previews and prefetches, which are
+		// left out of AccessCount and every other statistic.
+		BotCount uint `json:"botCount"`
+		// UniqueVisitors is estimated over the whole life of the link;
+		// a visitor coming back on another day counts again.
+		UniqueVisitors      uint `json:"uniqueVisitors"`
+		UniqueVisitorsToday uint `json:"uniqueVisitorsToday"`
+	}
+
+	// ListLinksRequest holds the raw query of a link listing: sort is
+	// createdAt, accessCount or updatedAt, order asc or desc, and cursor the
+	// nextCursor of the previous page. CreatedFrom and CreatedTo are RFC 3339
+	// timestamps or dates in Timezone.
+	ListLinksRequest struct {
+		Sort        string
+		Order       string
+		Cursor      string
+		Limit       int
+		Domain      string
+		Search      string
+		CreatedFrom string
+		CreatedTo   string
+		Timezone    string
+		Tag         string
+		CampaignId  int
+	}
+
+	ListedShortLink struct {
+		Id             int        `json:"id,omitempty"`
+		Url            string     `json:"url,omitempty"`
+		ShortCode      string     `json:"shortCode,omitempty"`
+		Title          string     `json:"title,omitempty"`
+		Description    string     `json:"description,omitempty"`
+		Notes          string     `json:"notes,omitempty"`
+		RedirectStatus int        `json:"redirectStatus,omitempty"`
+		ExpiresAt      *time.Time `json:"expiresAt,omitempty"`
+		NotBefore      *time.Time `json:"notBefore,omitempty"`
+		MaxClicks      int        `json:"maxClicks,omitempty"`
+		Protected      bool       `json:"protected,omitempty"`
+		CreatedAt      *time.Time `json:"createdAt,omitempty"`
+		UpdatedAt      *time.Time `json:"updatedAt,omitempty"`
+		Tags           []string   `json:"tags,omitempty"`
+		CampaignId     int        `json:"campaignId,omitempty"`
+		AccessCount    uint       `json:"accessCount"`
+	}
+
+	// ListLinksResponse is one page of links. NextCursor is empty on the
+	// last page.
+	ListLinksResponse struct {
+		Links      []ListedShortLink `json:"links"`
+		NextCursor string            `json:"nextCursor,omitempty"`
+	}
+
+	// ExportedLink is a link with its stats as export files hold it. Imports
+	// read the same fields; Id and UniqueVisitors are ignored there, since
+	// links get new ids and visitors cannot be rebuilt from a count.
+	ExportedLink struct {
+		Id             int        `json:"id,omitempty"`
+		ShortCode      string     `json:"shortCode"`
+		Url            string     `json:"url,omitempty"`
+		Title          string     `json:"title,omitempty"`
+		Description    string     `json:"description,omitempty"`
+		Notes          string     `json:"notes,omitempty"`
+		RedirectStatus int        `json:"redirectStatus,omitempty"`
+		CreatedAt      *time.Time `json:"createdAt,omitempty"`
+		UpdatedAt      *time.Time `json:"updatedAt,omitempty"`
+		NotBefore      *time.Time `json:"notBefore,omitempty"`
+		ExpiresAt      *time.Time `json:"expiresAt,omitempty"`
+		MaxClicks      int        `json:"maxClicks,omitempty"`
+		Protected      bool       `json:"protected,omitempty"`
+		CampaignId     int        `json:"campaignId,omitempty"`
+		Tags           []string   `json:"tags,omitempty"`
+		AccessCount    uint       `json:"accessCount"`
+		BotCount       uint       `json:"botCount"`
+		UniqueVisitors uint       `json:"uniqueVisitors"`
+	}
+
+	TagSummary struct {
+		Name        string `json:"name"`
+		Links       uint   `json:"links"`
+		AccessCount uint   `json:"accessCount"`
+		BotCount    uint   `json:"botCount"`
+	}
+
+	ListTagsResponse struct {
+		Tags []TagSummary `json:"tags"`
+	}
+
+	// TagStatsResponse adds up the stats of every link with a tag.
+	// UniqueVisitors is estimated from the visitor sketches of those links;
+	// a person visiting two of them counts once per link.
+	TagStatsResponse struct {
+		Tag            string `json:"tag"`
+		Links          uint   `json:"links"`
+		AccessCount    uint   `json:"accessCount"`
+		BotCount       uint   `json:"botCount"`
+		UniqueVisitors uint   `json:"uniqueVisitors"`
+	}
+
+	// UTMParameters are the utm_* query parameters a campaign adds to the
+	// URLs of its links.
+	UTMParameters struct {
+		Source   string `json:"source,omitempty"`
+		Medium   string `json:"medium,omitempty"`
+		Campaign string `json:"campaign,omitempty"`
+		Term     string `json:"term,omitempty"`
+		Content  string `json:"content,omitempty"`
+	}
+
+	CampaignRequest struct {
+		Name     string        `json:"name"`
+		Owner    string        `json:"owner,omitempty"`
+		StartsAt *time.Time    `json:"startsAt,omitempty"`
+		EndsAt   *time.Time    `json:"endsAt,omitempty"`
+		UTM      UTMParameters `json:"utm"`
+	}
+
+	CampaignResponse struct {
+		Id        int           `json:"id"`
+		Name      string        `json:"name"`
+		Owner     string        `json:"owner,omitempty"`
+		StartsAt  *time.Time    `json:"startsAt,omitempty"`
+		EndsAt    *time.Time    `json:"endsAt,omitempty"`
+		UTM       UTMParameters `json:"utm"`
+		CreatedAt *time.Time    `json:"createdAt,omitempty"`
+		UpdatedAt *time.Time    `json:"updatedAt,omitempty"`
+	}
+
+	ListCampaignsResponse struct {
+		Campaigns []CampaignResponse `json:"campaigns"`
+	}
+
+	// CampaignStatsResponse adds up the stats of every link of a campaign,
+	// the same way TagStatsResponse does for a tag.
+	CampaignStatsResponse struct {
+		CampaignId     int    `json:"campaignId"`
+		Name           string `json:"name"`
+		Links          uint   `json:"links"`
+		AccessCount    uint   `json:"accessCount"`
+		BotCount       uint   `json:"botCount"`
+		UniqueVisitors uint   `json:"uniqueVisitors"`
+	}
+
+	// TimeSeriesRequest holds the raw query of a time series: from and to
+	// are RFC 3339 timestamps or dates, interval is hour, day or week and
+	// timezone an IANA name.
+	TimeSeriesRequest struct {
+		From     string
+		To       string
+		Interval string
+		Timezone string
+	}
+
+	TimeSeriesBucket struct {
+		Start          time.Time `json:"start"`
+		Clicks         uint      `json:"clicks"`
+		UniqueVisitors uint      `json:"uniqueVisitors"`
+	}
+
+	TimeSeriesResponse struct {
+		ShortCode string             `json:"shortCode"`
+		Interval  string             `json:"interval"`
+		Timezone  string             `json:"tz"`
+		From      time.Time          `json:"from"`
+		To        time.Time          `json:"to"`
+		Total     uint               `json:"total"`
+		Buckets   []TimeSeriesBucket `json:"buckets"`
+	}
+
+	// BreakdownRequest holds the raw query of a breakdown: the same from, to
+	// and timezone as a time series plus the number of entries per list.
+	BreakdownRequest struct {
+		From     string
+		To       string
+		Timezone string
+		Limit    int
+	}
+
+	BreakdownItem struct {
+		Value  string `json:"value"`
+		Clicks uint   `json:"clicks"`
+	}
+
+	BreakdownResponse struct {
+		ShortCode        string          `json:"shortCode"`
+		From             time.Time       `json:"from"`
+		To               time.Time       `json:"to"`
+		Total            uint            `json:"total"`
+		Referrers        []BreakdownItem `json:"referrers"`
+		Browsers         []BreakdownItem `json:"browsers"`
+		OperatingSystems []BreakdownItem `json:"operatingSystems"`
+		Devices          []BreakdownItem `json:"devices"`
+	}
+
+	// Problem is an error response as described by RFC 7807. Code names the
+	// error for clients to match on and, unlike Detail, never changes.
+	Problem struct {
+		Type   string `json:"type"`
+		Title  string `json:"title"`
+		Status int    `json:"status"`
+		Detail string `json:"detail,omitempty"`
+		Code   string `json:"code"`
+	}
+
+	// ImportProblem is the problem of an import that stopped halfway, with
+	// the results of the links read before it stopped.
+	ImportProblem struct {
+		Problem
+		BatchResponse
 	}
 )
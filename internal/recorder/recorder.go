@@ -0,0 +1,232 @@
+// Package recorder counts visits off the redirect path. Visits are buffered
+// in memory and written in batches by a background goroutine, so a redirect
+// never waits for SQLite's write lock.
+//
+// A crash loses the visits still in the buffer: at most one flush interval,
+// or one batch, of traffic. Close writes whatever is left on a graceful
+// shutdown.
+package recorder
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/DarcoProgramador/shortener-go-backend/internal/database"
+	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
+)
+
+const (
+	DefaultInterval  = time.Second
+	DefaultBatchSize = 500
+)
+
+// Visit is a counted visit waiting to be written.
+type Visit struct {
+	URLID int64
+	// Click is nil for bots, which are only counted.
+	Click *db.CreateClickParams
+	// Register and Rank update the unique visitor sketch of the link.
+	Register int64
+	Rank     int64
+}
+
+// Recorder takes the visits to count.
+type Recorder interface {
+	// Record queues a visit. It never waits for the database.
+	Record(Visit)
+}
+
+type counts struct {
+	clicks int64
+	bots   int64
+}
+
+type sketchKey struct {
+	urlID    int64
+	register int64
+}
+
+// batch merges the visits of one flush: counts are added up per link and
+// each sketch register only keeps its highest rank.
+type batch struct {
+	size     int
+	counts   map[int64]counts
+	clicks   []db.CreateClickParams
+	sketches map[sketchKey]int64
+}
+
+func newBatch() *batch {
+	return &batch{
+		counts:   make(map[int64]counts),
+		sketches: make(map[sketchKey]int64),
+	}
+}
+
+func (b *batch) add(visit Visit) {
+	b.size++
+
+	c := b.counts[visit.URLID]
+	if visit.Click == nil {
+		c.bots++
+		b.counts[visit.URLID] = c
+		return
+	}
+	c.clicks++
+	b.counts[visit.URLID] = c
+
+	b.clicks = append(b.clicks, *visit.Click)
+
+	key := sketchKey{urlID: visit.URLID, register: visit.Register}
+	b.sketches[key] = max(b.sketches[key], visit.Rank)
+}
+
+// write runs every query of the batch. With keepGoing it carries on after a
+// failed query and returns all the errors.
+func (b *batch) write(ctx context.Context, q db.Querier, keepGoing bool) error {
+	var errs []error
+	check := func(err error) error {
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if keepGoing {
+			return nil
+		}
+		return err
+	}
+
+	for urlID, c := range b.counts {
+		err := q.AddURLCountsByID(ctx, db.AddURLCountsByIDParams{Clicks: c.clicks, Bots: c.bots, ID: urlID})
+		if err := check(err); err != nil {
+			return err
+		}
+	}
+
+	for _, click := range b.clicks {
+		if err := check(q.CreateClick(ctx, click)); err != nil {
+			return err
+		}
+	}
+
+	for key, rank := range b.sketches {
+		err := q.UpsertVisitorSketch(ctx, db.UpsertVisitorSketchParams{Urlid: key.urlID, Register: key.register, Rank: rank})
+		if err := check(err); err != nil {
+			return err
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Batcher is the Recorder used by the server. It flushes every interval, or
+// as soon as batchSize visits are waiting.
+type Batcher struct {
+	store     database.Store
+	logger    *slog.Logger
+	batchSize int
+
+	mu      sync.Mutex
+	pending *batch
+
+	full    chan struct{}
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// NewBatcher starts a Batcher. Non positive values fall back to
+// DefaultInterval and DefaultBatchSize.
+func NewBatcher(store database.Store, logger *slog.Logger, interval time.Duration, batchSize int) *Batcher {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	b := &Batcher{
+		store:     store,
+		logger:    logger,
+		batchSize: batchSize,
+		pending:   newBatch(),
+		full:      make(chan struct{}, 1),
+		stop:      make(chan struct{}),
+		stopped:   make(chan struct{}),
+	}
+	go b.run(interval)
+
+	return b
+}
+
+func (b *Batcher) Record(visit Visit) {
+	b.mu.Lock()
+	b.pending.add(visit)
+	full := b.pending.size >= b.batchSize
+	b.mu.Unlock()
+
+	if full {
+		select {
+		case b.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (b *Batcher) run(interval time.Duration) {
+	defer close(b.stopped)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-b.full:
+		case <-b.stop:
+			return
+		}
+
+		if err := b.Flush(context.Background()); err != nil {
+			b.logger.Error("Error flushing visits", "error", err)
+		}
+	}
+}
+
+// Flush writes the queued visits in one transaction. If the transaction
+// fails, e.g. because a link was deleted after its visit was queued, the
+// visits are written one by one so the others are not lost.
+func (b *Batcher) Flush(ctx context.Context) error {
+	b.mu.Lock()
+	pending := b.pending
+	b.pending = newBatch()
+	b.mu.Unlock()
+
+	if pending.size == 0 {
+		return nil
+	}
+
+	err := b.store.ExecTx(ctx, func(q db.Querier) error {
+		return pending.write(ctx, q, false)
+	})
+	if err == nil {
+		return nil
+	}
+
+	b.logger.Warn("Error writing visits in a batch, writing them one by one", "error", err, "visits", pending.size)
+	return pending.write(ctx, b.store, true)
+}
+
+// Close stops the background flushes and writes the visits still queued.
+// Visits recorded after Close are not written.
+func (b *Batcher) Close(ctx context.Context) error {
+	close(b.stop)
+
+	select {
+	case <-b.stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	return b.Flush(ctx)
+}
@@ -0,0 +1,125 @@
+package recorder
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"testing"
+	"time"
+
+	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
+	storeMock "github.com/DarcoProgramador/shortener-go-backend/mocks/store_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// runInTx makes ExecTx run its function against the same mock.
+func runInTx(q *storeMock.MockStore) {
+	q.EXPECT().ExecTx(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, fn func(db.Querier) error) error {
+			return fn(q)
+		},
+	)
+}
+
+func click(urlID int64, register, rank int64) Visit {
+	return Visit{
+		URLID:    urlID,
+		Click:    &db.CreateClickParams{Urlid: urlID, Clickedat: time.Now().UTC()},
+		Register: register,
+		Rank:     rank,
+	}
+}
+
+func TestBatcher_Flush(t *testing.T) {
+	q := storeMock.NewMockStore(t)
+	runInTx(q)
+	q.EXPECT().AddURLCountsByID(mock.Anything, db.AddURLCountsByIDParams{Clicks: 2, Bots: 1, ID: 1}).Return(nil).Once()
+	q.EXPECT().AddURLCountsByID(mock.Anything, db.AddURLCountsByIDParams{Clicks: 1, Bots: 0, ID: 2}).Return(nil).Once()
+	q.EXPECT().CreateClick(mock.Anything, mock.Anything).Return(nil).Times(3)
+	// The two clicks on the same register of link 1 are merged into one update.
+	q.EXPECT().UpsertVisitorSketch(mock.Anything, db.UpsertVisitorSketchParams{Urlid: 1, Register: 7, Rank: 4}).Return(nil).Once()
+	q.EXPECT().UpsertVisitorSketch(mock.Anything, db.UpsertVisitorSketchParams{Urlid: 2, Register: 7, Rank: 1}).Return(nil).Once()
+
+	b := NewBatcher(q, slog.Default(), time.Hour, 100)
+
+	b.Record(click(1, 7, 4))
+	b.Record(click(1, 7, 2))
+	b.Record(Visit{URLID: 1})
+	b.Record(click(2, 7, 1))
+
+	assert.NoError(t, b.Flush(context.TODO()))
+	// Nothing is left to write.
+	assert.NoError(t, b.Flush(context.TODO()))
+	assert.NoError(t, b.Close(context.TODO()))
+}
+
+func TestBatcher_FlushFallsBackToOneByOne(t *testing.T) {
+	q := storeMock.NewMockStore(t)
+	q.EXPECT().ExecTx(mock.Anything, mock.Anything).Return(assert.AnError)
+	q.EXPECT().AddURLCountsByID(mock.Anything, mock.Anything).Return(nil).Times(2)
+	// The link of the first click was deleted after it was queued.
+	q.EXPECT().CreateClick(mock.Anything, mock.MatchedBy(func(arg db.CreateClickParams) bool {
+		return arg.Urlid == 1
+	})).Return(assert.AnError).Once()
+	q.EXPECT().CreateClick(mock.Anything, mock.MatchedBy(func(arg db.CreateClickParams) bool {
+		return arg.Urlid == 2
+	})).Return(nil).Once()
+	q.EXPECT().UpsertVisitorSketch(mock.Anything, mock.Anything).Return(nil).Times(2)
+
+	b := NewBatcher(q, slog.Default(), time.Hour, 100)
+
+	b.Record(click(1, 3, 1))
+	b.Record(click(2, 3, 1))
+
+	err := b.Flush(context.TODO())
+	assert.ErrorIs(t, err, assert.AnError, "Error is not the expected")
+	assert.NoError(t, b.Close(context.TODO()))
+}
+
+func TestBatcher_FlushWhenFull(t *testing.T) {
+	flushed := make(chan struct{})
+
+	q := storeMock.NewMockStore(t)
+	q.EXPECT().ExecTx(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, fn func(db.Querier) error) error {
+			defer close(flushed)
+			return fn(q)
+		},
+	).Once()
+	q.EXPECT().AddURLCountsByID(mock.Anything, db.AddURLCountsByIDParams{Bots: 2, ID: 1}).Return(nil).Once()
+
+	b := NewBatcher(q, slog.Default(), time.Hour, 2)
+
+	b.Record(Visit{URLID: 1})
+	b.Record(Visit{URLID: 1})
+
+	select {
+	case <-flushed:
+	case <-time.After(time.Second):
+		t.Fatal("a full batch must be flushed without waiting for the interval")
+	}
+
+	assert.NoError(t, b.Close(context.TODO()))
+}
+
+func TestBatcher_CloseWritesPendingVisits(t *testing.T) {
+	q := storeMock.NewMockStore(t)
+	runInTx(q)
+	q.EXPECT().AddURLCountsByID(mock.Anything, db.AddURLCountsByIDParams{Clicks: 1, ID: 1}).Return(nil).Once()
+	q.EXPECT().CreateClick(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, arg db.CreateClickParams) error {
+			assert.Equal(t, sql.NullString{String: "t.co", Valid: true}, arg.Referrerdomain, "Referrer domain is not the expected")
+			return nil
+		},
+	).Once()
+	q.EXPECT().UpsertVisitorSketch(mock.Anything, mock.Anything).Return(nil).Once()
+
+	b := NewBatcher(q, slog.Default(), time.Hour, 100)
+
+	visit := click(1, 5, 2)
+	visit.Click.Referrerdomain = sql.NullString{String: "t.co", Valid: true}
+	b.Record(visit)
+
+	assert.NoError(t, b.Close(context.TODO()))
+}
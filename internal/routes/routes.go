@@ -2,13 +2,19 @@ package routes
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/DarcoProgramador/shortener-go-backend/internal/handlers"
 )
 
+// shutdownTimeout is how long in-flight requests get to finish once the
+// server is asked to stop.
+const shutdownTimeout = 10 * time.Second
+
 type Routes struct {
 	mux      *http.ServeMux
 	handlers *handlers.Handlers
@@ -26,11 +32,53 @@ func StartServer(ctx context.Context, handlers *handlers.Handlers, logger *slog.
 	routes := newRoutes(mux, handlers)
 
 	routes.mux.HandleFunc("POST /shorten", routes.handlers.Create)
+	routes.mux.HandleFunc("POST /shorten/batch", routes.handlers.CreateBatch)
+	routes.mux.HandleFunc("GET /shorten", routes.handlers.List)
+	routes.mux.HandleFunc("GET /shorten/export", routes.handlers.Export)
+	routes.mux.HandleFunc("POST /shorten/import", routes.handlers.Import)
 	routes.mux.HandleFunc("GET /shorten/{code}", routes.handlers.GetOriginal)
+	routes.mux.HandleFunc("GET /shorten/{code}/info", routes.handlers.Info)
 	routes.mux.HandleFunc("PUT /shorten/{code}", routes.handlers.Update)
+	routes.mux.HandleFunc("PATCH /shorten/{code}", routes.handlers.Patch)
 	routes.mux.HandleFunc("DELETE /shorten/{code}", routes.handlers.Delete)
 	routes.mux.HandleFunc("GET /shorten/{code}/stats", routes.handlers.GetStat)
+	routes.mux.HandleFunc("GET /shorten/{code}/stats/timeseries", routes.handlers.GetTimeSeries)
+	routes.mux.HandleFunc("GET /shorten/{code}/stats/breakdown", routes.handlers.GetBreakdown)
+	routes.mux.HandleFunc("GET /tags", routes.handlers.ListTags)
+	routes.mux.HandleFunc("GET /tags/{tag}/stats", routes.handlers.GetTagStats)
+	routes.mux.HandleFunc("POST /campaigns", routes.handlers.CreateCampaign)
+	routes.mux.HandleFunc("GET /campaigns", routes.handlers.ListCampaigns)
+	routes.mux.HandleFunc("GET /campaigns/{id}", routes.handlers.GetCampaign)
+	routes.mux.HandleFunc("PUT /campaigns/{id}", routes.handlers.UpdateCampaign)
+	routes.mux.HandleFunc("DELETE /campaigns/{id}", routes.handlers.DeleteCampaign)
+	routes.mux.HandleFunc("GET /campaigns/{id}/stats", routes.handlers.GetCampaignStats)
+	routes.mux.HandleFunc("GET /{code}", routes.handlers.Redirect)
+	routes.mux.HandleFunc("POST /{code}", routes.handlers.Redirect)
+
+	server := &http.Server{
+		Addr:    ":8080",
+		Handler: routes.mux,
+	}
+
+	// StartServer returns once ctx is done and every in-flight request has
+	// finished.
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Error("Error shutting down server", "error", err)
+		}
+	}()
 
 	fmt.Println("Server is running on port 8080")
-	http.ListenAndServe(":8080", routes.mux)
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		logger.Error("Error running server", "error", err)
+		return
+	}
+
+	<-stopped
 }
@@ -0,0 +1,125 @@
+// Package useragent classifies User-Agent headers into the browser, operating
+// system and device class shown in the click breakdowns. It only looks for
+// the well known tokens of each family; anything else is reported as Other.
+package useragent
+
+import "strings"
+
+const (
+	Unknown = "Unknown"
+	Other   = "Other"
+
+	DeviceDesktop = "Desktop"
+	DeviceMobile  = "Mobile"
+	DeviceTablet  = "Tablet"
+)
+
+type Agent struct {
+	Browser string
+	OS      string
+	Device  string
+}
+
+type rule struct {
+	name   string
+	tokens []string
+}
+
+// browsers is checked in order: most browsers also announce the engines they
+// are compatible with, e.g. Edge sends Chrome and Safari tokens.
+var browsers = []rule{
+	{"Edge", []string{"edg/", "edga/", "edgios/", "edge/"}},
+	{"Opera", []string{"opr/", "opera"}},
+	{"Samsung Internet", []string{"samsungbrowser/"}},
+	{"Yandex", []string{"yabrowser/"}},
+	{"Firefox", []string{"firefox/", "fxios/"}},
+	{"Chrome", []string{"chrome/", "crios/", "chromium/"}},
+	{"Safari", []string{"safari/"}},
+	{"Internet Explorer", []string{"msie ", "trident/"}},
+}
+
+var operatingSystems = []rule{
+	{"Windows", []string{"windows"}},
+	{"iOS", []string{"iphone", "ipad", "ipod"}},
+	{"macOS", []string{"mac os x", "macintosh"}},
+	{"Android", []string{"android"}},
+	{"Chrome OS", []string{"cros"}},
+	{"Linux", []string{"linux", "x11"}},
+}
+
+// bots are the tokens of crawlers and link preview fetchers. Most of them
+// call themselves a bot, crawler or spider; the rest are listed by name.
+// Bare app names are left out: apps such as Pinterest add theirs to the user
+// agent of their in-app browser, which people click from.
+var bots = []string{
+	"bot", "crawler", "spider", "slurp",
+	"facebookexternalhit", "facebookcatalog", "skypeuripreview",
+	"slack-imgproxy", "embedly", "iframely", "vkshare", "pinterestbot",
+	"google-pagerenderer", "google-read-aloud", "bitlybot", "outbrain",
+	"headlesschrome", "lighthouse", "prerender",
+}
+
+// botPrefixes are fetchers recognised by how their user agent starts. The
+// WhatsApp preview fetcher sends nothing but its own token.
+var botPrefixes = []string{"whatsapp/"}
+
+func match(ua string, rules []rule) string {
+	for _, r := range rules {
+		for _, token := range r.tokens {
+			if strings.Contains(ua, token) {
+				return r.name
+			}
+		}
+	}
+
+	return Other
+}
+
+func device(ua, os string) string {
+	switch {
+	case strings.Contains(ua, "ipad"), strings.Contains(ua, "tablet"),
+		os == "Android" && !strings.Contains(ua, "mobile"):
+		return DeviceTablet
+	case strings.Contains(ua, "mobi"), strings.Contains(ua, "iphone"), strings.Contains(ua, "ipod"):
+		return DeviceMobile
+	case os == Other:
+		return Other
+	default:
+		return DeviceDesktop
+	}
+}
+
+// Parse classifies a User-Agent header. An empty header gives Unknown for
+// every field.
+func Parse(userAgent string) Agent {
+	ua := strings.ToLower(strings.TrimSpace(userAgent))
+	if ua == "" {
+		return Agent{Browser: Unknown, OS: Unknown, Device: Unknown}
+	}
+
+	os := match(ua, operatingSystems)
+
+	return Agent{
+		Browser: match(ua, browsers),
+		OS:      os,
+		Device:  device(ua, os),
+	}
+}
+
+// IsBot reports whether a User-Agent belongs to a known crawler or link
+// preview fetcher, such as Slackbot, Twitterbot or facebookexternalhit.
+func IsBot(userAgent string) bool {
+	ua := strings.ToLower(strings.TrimSpace(userAgent))
+	for _, token := range bots {
+		if strings.Contains(ua, token) {
+			return true
+		}
+	}
+	for _, prefix := range botPrefixes {
+		if strings.HasPrefix(ua, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
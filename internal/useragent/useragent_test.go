@@ -0,0 +1,101 @@
+package useragent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		want      Agent
+	}{
+		{
+			name:      "Chrome on Windows",
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36",
+			want:      Agent{Browser: "Chrome", OS: "Windows", Device: DeviceDesktop},
+		},
+		{
+			name:      "Edge on Windows",
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36 Edg/131.0.2903.86",
+			want:      Agent{Browser: "Edge", OS: "Windows", Device: DeviceDesktop},
+		},
+		{
+			name:      "Safari on macOS",
+			userAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_7_1) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/18.1 Safari/605.1.15",
+			want:      Agent{Browser: "Safari", OS: "macOS", Device: DeviceDesktop},
+		},
+		{
+			name:      "Safari on iPhone",
+			userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 18_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/18.1 Mobile/15E148 Safari/604.1",
+			want:      Agent{Browser: "Safari", OS: "iOS", Device: DeviceMobile},
+		},
+		{
+			name:      "Chrome on iPad",
+			userAgent: "Mozilla/5.0 (iPad; CPU OS 17_7 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/131.0.6778.73 Mobile/15E148 Safari/604.1",
+			want:      Agent{Browser: "Chrome", OS: "iOS", Device: DeviceTablet},
+		},
+		{
+			name:      "Firefox on Linux",
+			userAgent: "Mozilla/5.0 (X11; Linux x86_64; rv:133.0) Gecko/20100101 Firefox/133.0",
+			want:      Agent{Browser: "Firefox", OS: "Linux", Device: DeviceDesktop},
+		},
+		{
+			name:      "Samsung Internet on Android phone",
+			userAgent: "Mozilla/5.0 (Linux; Android 14; SM-S918B) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/26.0 Chrome/122.0.0.0 Mobile Safari/537.36",
+			want:      Agent{Browser: "Samsung Internet", OS: "Android", Device: DeviceMobile},
+		},
+		{
+			name:      "Chrome on Android tablet",
+			userAgent: "Mozilla/5.0 (Linux; Android 13; SM-X700) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36",
+			want:      Agent{Browser: "Chrome", OS: "Android", Device: DeviceTablet},
+		},
+		{
+			name:      "Unrecognised client",
+			userAgent: "curl/8.5.0",
+			want:      Agent{Browser: Other, OS: Other, Device: Other},
+		},
+		{
+			name:      "Empty header",
+			userAgent: "",
+			want:      Agent{Browser: Unknown, OS: Unknown, Device: Unknown},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Parse(tt.userAgent), "The agent is not the expected")
+		})
+	}
+}
+
+func TestIsBot(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		want      bool
+	}{
+		{"Slackbot", "Slackbot-LinkExpanding 1.0 (+https://api.slack.com/robots)", true},
+		{"Twitterbot", "Twitterbot/1.0", true},
+		{"Facebook", "facebookexternalhit/1.1 (+http://www.facebook.com/externalhit_uatext.php)", true},
+		{"WhatsApp", "WhatsApp/2.23.20.0", true},
+		{"Pinterestbot", "Mozilla/5.0 (compatible; Pinterestbot/1.0; +http://www.pinterest.com/bot.html)", true},
+		{"Googlebot", "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)", true},
+		{"Discord", "Mozilla/5.0 (compatible; Discordbot/2.0; +https://discordapp.com)", true},
+		{"Headless Chrome", "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) HeadlessChrome/131.0.0.0 Safari/537.36", true},
+		{"Chrome", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36", false},
+		{"Safari on iPhone", "Mozilla/5.0 (iPhone; CPU iPhone OS 18_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/18.1 Mobile/15E148 Safari/604.1", false},
+		{"Pinterest app on iPhone", "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 [Pinterest/iOS]", false},
+		{"Pinterest app on Android", "Mozilla/5.0 (Linux; Android 14; Pixel 8 Build/AP2A.240805.005; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/127.0.6533.103 Mobile Safari/537.36 [Pinterest/Android]", false},
+		{"WhatsApp on Android", "Mozilla/5.0 (Linux; Android 13; SM-A536B Build/TP1A.220624.014; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/126.0.6478.134 Mobile Safari/537.36 WhatsApp/2.24.14.79", false},
+		{"Facebook app on iPhone", "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 [FBAN/FBIOS;FBAV/470.0.0.40.108;FBBV/614458532;FBDV/iPhone15,2;FBMD/iPhone;FBSN/iOS;FBSV/17.5.1;FBSS/3;FBID/phone;FBLC/es_ES;FBOP/5;FBRV/616069520]", false},
+		{"Instagram app on iPhone", "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 Instagram 339.0.3.12.91 (iPhone14,5; iOS 17_5; es_ES; es; scale=3.00; 1170x2532; 618339398)", false},
+		{"Empty header", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsBot(tt.userAgent))
+		})
+	}
+}
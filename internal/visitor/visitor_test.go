@@ -0,0 +1,59 @@
+package visitor
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHash(t *testing.T) {
+	salt := []byte("salt")
+	ua := "Mozilla/5.0 (X11; Linux x86_64; rv:133.0) Gecko/20100101 Firefox/133.0"
+
+	hash := Hash(salt, 1, "203.0.113.42", ua)
+	assert.Len(t, hash, 32)
+	assert.Equal(t, hash, Hash(salt, 1, "203.0.113.42", ua), "The same visit must give the same hash")
+
+	assert.NotEqual(t, hash, Hash([]byte("other"), 1, "203.0.113.42", ua), "Another day must give another hash")
+	assert.NotEqual(t, hash, Hash(salt, 2, "203.0.113.42", ua), "Another link must give another hash")
+	assert.NotEqual(t, hash, Hash(salt, 1, "203.0.113.43", ua), "Another IP must give another hash")
+	assert.NotEqual(t, hash, Hash(salt, 1, "203.0.113.42", ua+" "), "Another user agent must give another hash")
+}
+
+func TestDay(t *testing.T) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "2025-02-28", Day(time.Date(2025, 3, 1, 0, 30, 0, 0, madrid)))
+}
+
+func TestRegister(t *testing.T) {
+	for i := range 1000 {
+		register, rank := Register(fmt.Sprint(i))
+		assert.True(t, register >= 0 && register < Registers, "register %d out of range", register)
+		assert.True(t, rank >= 1 && rank <= 64-Precision+1, "rank %d out of range", rank)
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	assert.Equal(t, uint(0), Estimate(nil))
+
+	for _, visitors := range []int{1, 10, 1000, 50000, 200000} {
+		t.Run(fmt.Sprint(visitors), func(t *testing.T) {
+			ranks := make(map[int64]int64)
+			for i := range visitors {
+				// Every visitor comes twice; only distinct ones must count.
+				for range 2 {
+					register, rank := Register(Hash([]byte("salt"), 1, fmt.Sprint(i), ""))
+					ranks[register] = max(ranks[register], rank)
+				}
+			}
+
+			got := float64(Estimate(ranks))
+			assert.InDelta(t, visitors, got, math.Max(1, 0.03*float64(visitors)))
+		})
+	}
+}
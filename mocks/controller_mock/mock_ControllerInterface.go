@@ -5,6 +5,8 @@ package controller
 import (
 	context "context"
 
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
 
 	models "github.com/DarcoProgramador/shortener-go-backend/internal/models"
@@ -23,8 +25,67 @@ func (_m *MockControllerInterface) EXPECT() *MockControllerInterface_Expecter {
 	return &MockControllerInterface_Expecter{mock: &_m.Mock}
 }
 
+// CreateCampaign provides a mock function with given fields: _a0, _a1
+func (_m *MockControllerInterface) CreateCampaign(_a0 context.Context, _a1 models.CampaignRequest) (*models.CampaignResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCampaign")
+	}
+
+	var r0 *models.CampaignResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.CampaignRequest) (*models.CampaignResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.CampaignRequest) *models.CampaignResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CampaignResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.CampaignRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockControllerInterface_CreateCampaign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCampaign'
+type MockControllerInterface_CreateCampaign_Call struct {
+	*mock.Call
+}
+
+// CreateCampaign is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 models.CampaignRequest
+func (_e *MockControllerInterface_Expecter) CreateCampaign(_a0 interface{}, _a1 interface{}) *MockControllerInterface_CreateCampaign_Call {
+	return &MockControllerInterface_CreateCampaign_Call{Call: _e.mock.On("CreateCampaign", _a0, _a1)}
+}
+
+func (_c *MockControllerInterface_CreateCampaign_Call) Run(run func(_a0 context.Context, _a1 models.CampaignRequest)) *MockControllerInterface_CreateCampaign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.CampaignRequest))
+	})
+	return _c
+}
+
+func (_c *MockControllerInterface_CreateCampaign_Call) Return(_a0 *models.CampaignResponse, _a1 error) *MockControllerInterface_CreateCampaign_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockControllerInterface_CreateCampaign_Call) RunAndReturn(run func(context.Context, models.CampaignRequest) (*models.CampaignResponse, error)) *MockControllerInterface_CreateCampaign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateShortLink provides a mock function with given fields: _a0, _a1
-func (_m *MockControllerInterface) CreateShortLink(_a0 context.Context, _a1 string) (*models.ShortLinkResponse, error) {
+func (_m *MockControllerInterface) CreateShortLink(_a0 context.Context, _a1 models.ShortLinkRequest) (*models.ShortLinkResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
@@ -33,10 +94,10 @@ func (_m *MockControllerInterface) CreateShortLink(_a0 context.Context, _a1 stri
 
 	var r0 *models.ShortLinkResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.ShortLinkResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, models.ShortLinkRequest) (*models.ShortLinkResponse, error)); ok {
 		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *models.ShortLinkResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, models.ShortLinkRequest) *models.ShortLinkResponse); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
@@ -44,7 +105,7 @@ func (_m *MockControllerInterface) CreateShortLink(_a0 context.Context, _a1 stri
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, models.ShortLinkRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -60,14 +121,14 @@ type MockControllerInterface_CreateShortLink_Call struct {
 
 // CreateShortLink is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 string
+//   - _a1 models.ShortLinkRequest
 func (_e *MockControllerInterface_Expecter) CreateShortLink(_a0 interface{}, _a1 interface{}) *MockControllerInterface_CreateShortLink_Call {
 	return &MockControllerInterface_CreateShortLink_Call{Call: _e.mock.On("CreateShortLink", _a0, _a1)}
 }
 
-func (_c *MockControllerInterface_CreateShortLink_Call) Run(run func(_a0 context.Context, _a1 string)) *MockControllerInterface_CreateShortLink_Call {
+func (_c *MockControllerInterface_CreateShortLink_Call) Run(run func(_a0 context.Context, _a1 models.ShortLinkRequest)) *MockControllerInterface_CreateShortLink_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(models.ShortLinkRequest))
 	})
 	return _c
 }
@@ -77,21 +138,147 @@ func (_c *MockControllerInterface_CreateShortLink_Call) Return(_a0 *models.Short
 	return _c
 }
 
-func (_c *MockControllerInterface_CreateShortLink_Call) RunAndReturn(run func(context.Context, string) (*models.ShortLinkResponse, error)) *MockControllerInterface_CreateShortLink_Call {
+func (_c *MockControllerInterface_CreateShortLink_Call) RunAndReturn(run func(context.Context, models.ShortLinkRequest) (*models.ShortLinkResponse, error)) *MockControllerInterface_CreateShortLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateShortLinkIdempotent provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockControllerInterface) CreateShortLinkIdempotent(_a0 context.Context, _a1 string, _a2 models.ShortLinkRequest) (*models.ShortLinkResponse, bool, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateShortLinkIdempotent")
+	}
+
+	var r0 *models.ShortLinkResponse
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ShortLinkRequest) (*models.ShortLinkResponse, bool, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ShortLinkRequest) *models.ShortLinkResponse); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ShortLinkResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.ShortLinkRequest) bool); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.ShortLinkRequest) error); ok {
+		r2 = rf(_a0, _a1, _a2)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockControllerInterface_CreateShortLinkIdempotent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateShortLinkIdempotent'
+type MockControllerInterface_CreateShortLinkIdempotent_Call struct {
+	*mock.Call
+}
+
+// CreateShortLinkIdempotent is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 models.ShortLinkRequest
+func (_e *MockControllerInterface_Expecter) CreateShortLinkIdempotent(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockControllerInterface_CreateShortLinkIdempotent_Call {
+	return &MockControllerInterface_CreateShortLinkIdempotent_Call{Call: _e.mock.On("CreateShortLinkIdempotent", _a0, _a1, _a2)}
+}
+
+func (_c *MockControllerInterface_CreateShortLinkIdempotent_Call) Run(run func(_a0 context.Context, _a1 string, _a2 models.ShortLinkRequest)) *MockControllerInterface_CreateShortLinkIdempotent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.ShortLinkRequest))
+	})
+	return _c
+}
+
+func (_c *MockControllerInterface_CreateShortLinkIdempotent_Call) Return(_a0 *models.ShortLinkResponse, _a1 bool, _a2 error) *MockControllerInterface_CreateShortLinkIdempotent_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockControllerInterface_CreateShortLinkIdempotent_Call) RunAndReturn(run func(context.Context, string, models.ShortLinkRequest) (*models.ShortLinkResponse, bool, error)) *MockControllerInterface_CreateShortLinkIdempotent_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteShortLink provides a mock function with given fields: _a0, _a1
-func (_m *MockControllerInterface) DeleteShortLink(_a0 context.Context, _a1 string) error {
+// CreateShortLinks provides a mock function with given fields: _a0, _a1
+func (_m *MockControllerInterface) CreateShortLinks(_a0 context.Context, _a1 []models.ShortLinkRequest) (*models.BatchResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteShortLink")
+		panic("no return value specified for CreateShortLinks")
+	}
+
+	var r0 *models.BatchResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.ShortLinkRequest) (*models.BatchResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []models.ShortLinkRequest) *models.BatchResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BatchResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []models.ShortLinkRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockControllerInterface_CreateShortLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateShortLinks'
+type MockControllerInterface_CreateShortLinks_Call struct {
+	*mock.Call
+}
+
+// CreateShortLinks is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 []models.ShortLinkRequest
+func (_e *MockControllerInterface_Expecter) CreateShortLinks(_a0 interface{}, _a1 interface{}) *MockControllerInterface_CreateShortLinks_Call {
+	return &MockControllerInterface_CreateShortLinks_Call{Call: _e.mock.On("CreateShortLinks", _a0, _a1)}
+}
+
+func (_c *MockControllerInterface_CreateShortLinks_Call) Run(run func(_a0 context.Context, _a1 []models.ShortLinkRequest)) *MockControllerInterface_CreateShortLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]models.ShortLinkRequest))
+	})
+	return _c
+}
+
+func (_c *MockControllerInterface_CreateShortLinks_Call) Return(_a0 *models.BatchResponse, _a1 error) *MockControllerInterface_CreateShortLinks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockControllerInterface_CreateShortLinks_Call) RunAndReturn(run func(context.Context, []models.ShortLinkRequest) (*models.BatchResponse, error)) *MockControllerInterface_CreateShortLinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCampaign provides a mock function with given fields: _a0, _a1
+func (_m *MockControllerInterface) DeleteCampaign(_a0 context.Context, _a1 int64) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCampaign")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Error(0)
@@ -100,6 +287,53 @@ func (_m *MockControllerInterface) DeleteShortLink(_a0 context.Context, _a1 stri
 	return r0
 }
 
+// MockControllerInterface_DeleteCampaign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCampaign'
+type MockControllerInterface_DeleteCampaign_Call struct {
+	*mock.Call
+}
+
+// DeleteCampaign is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 int64
+func (_e *MockControllerInterface_Expecter) DeleteCampaign(_a0 interface{}, _a1 interface{}) *MockControllerInterface_DeleteCampaign_Call {
+	return &MockControllerInterface_DeleteCampaign_Call{Call: _e.mock.On("DeleteCampaign", _a0, _a1)}
+}
+
+func (_c *MockControllerInterface_DeleteCampaign_Call) Run(run func(_a0 context.Context, _a1 int64)) *MockControllerInterface_DeleteCampaign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockControllerInterface_DeleteCampaign_Call) Return(_a0 error) *MockControllerInterface_DeleteCampaign_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockControllerInterface_DeleteCampaign_Call) RunAndReturn(run func(context.Context, int64) error) *MockControllerInterface_DeleteCampaign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteShortLink provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockControllerInterface) DeleteShortLink(_a0 context.Context, _a1 string, _a2 int64) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteShortLink")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // MockControllerInterface_DeleteShortLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteShortLink'
 type MockControllerInterface_DeleteShortLink_Call struct {
 	*mock.Call
@@ -108,13 +342,14 @@ type MockControllerInterface_DeleteShortLink_Call struct {
 // DeleteShortLink is a helper method to define mock.On call
 //   - _a0 context.Context
 //   - _a1 string
-func (_e *MockControllerInterface_Expecter) DeleteShortLink(_a0 interface{}, _a1 interface{}) *MockControllerInterface_DeleteShortLink_Call {
-	return &MockControllerInterface_DeleteShortLink_Call{Call: _e.mock.On("DeleteShortLink", _a0, _a1)}
+//   - _a2 int64
+func (_e *MockControllerInterface_Expecter) DeleteShortLink(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockControllerInterface_DeleteShortLink_Call {
+	return &MockControllerInterface_DeleteShortLink_Call{Call: _e.mock.On("DeleteShortLink", _a0, _a1, _a2)}
 }
 
-func (_c *MockControllerInterface_DeleteShortLink_Call) Run(run func(_a0 context.Context, _a1 string)) *MockControllerInterface_DeleteShortLink_Call {
+func (_c *MockControllerInterface_DeleteShortLink_Call) Run(run func(_a0 context.Context, _a1 string, _a2 int64)) *MockControllerInterface_DeleteShortLink_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
 	})
 	return _c
 }
@@ -124,34 +359,82 @@ func (_c *MockControllerInterface_DeleteShortLink_Call) Return(_a0 error) *MockC
 	return _c
 }
 
-func (_c *MockControllerInterface_DeleteShortLink_Call) RunAndReturn(run func(context.Context, string) error) *MockControllerInterface_DeleteShortLink_Call {
+func (_c *MockControllerInterface_DeleteShortLink_Call) RunAndReturn(run func(context.Context, string, int64) error) *MockControllerInterface_DeleteShortLink_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetOriginalLink provides a mock function with given fields: _a0, _a1
-func (_m *MockControllerInterface) GetOriginalLink(_a0 context.Context, _a1 string) (*models.ShortLinkResponse, error) {
-	ret := _m.Called(_a0, _a1)
+// ExportLinks provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockControllerInterface) ExportLinks(_a0 context.Context, _a1 string, _a2 io.Writer) error {
+	ret := _m.Called(_a0, _a1, _a2)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetOriginalLink")
+		panic("no return value specified for ExportLinks")
 	}
 
-	var r0 *models.ShortLinkResponse
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Writer) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockControllerInterface_ExportLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportLinks'
+type MockControllerInterface_ExportLinks_Call struct {
+	*mock.Call
+}
+
+// ExportLinks is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 io.Writer
+func (_e *MockControllerInterface_Expecter) ExportLinks(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockControllerInterface_ExportLinks_Call {
+	return &MockControllerInterface_ExportLinks_Call{Call: _e.mock.On("ExportLinks", _a0, _a1, _a2)}
+}
+
+func (_c *MockControllerInterface_ExportLinks_Call) Run(run func(_a0 context.Context, _a1 string, _a2 io.Writer)) *MockControllerInterface_ExportLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(io.Writer))
+	})
+	return _c
+}
+
+func (_c *MockControllerInterface_ExportLinks_Call) Return(_a0 error) *MockControllerInterface_ExportLinks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockControllerInterface_ExportLinks_Call) RunAndReturn(run func(context.Context, string, io.Writer) error) *MockControllerInterface_ExportLinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBreakdown provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockControllerInterface) GetBreakdown(_a0 context.Context, _a1 string, _a2 models.BreakdownRequest) (*models.BreakdownResponse, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBreakdown")
+	}
+
+	var r0 *models.BreakdownResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.ShortLinkResponse, error)); ok {
-		return rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.BreakdownRequest) (*models.BreakdownResponse, error)); ok {
+		return rf(_a0, _a1, _a2)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *models.ShortLinkResponse); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.BreakdownRequest) *models.BreakdownResponse); ok {
+		r0 = rf(_a0, _a1, _a2)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.ShortLinkResponse)
+			r0 = ret.Get(0).(*models.BreakdownResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(_a0, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.BreakdownRequest) error); ok {
+		r1 = rf(_a0, _a1, _a2)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -159,57 +442,58 @@ func (_m *MockControllerInterface) GetOriginalLink(_a0 context.Context, _a1 stri
 	return r0, r1
 }
 
-// MockControllerInterface_GetOriginalLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOriginalLink'
-type MockControllerInterface_GetOriginalLink_Call struct {
+// MockControllerInterface_GetBreakdown_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBreakdown'
+type MockControllerInterface_GetBreakdown_Call struct {
 	*mock.Call
 }
 
-// GetOriginalLink is a helper method to define mock.On call
+// GetBreakdown is a helper method to define mock.On call
 //   - _a0 context.Context
 //   - _a1 string
-func (_e *MockControllerInterface_Expecter) GetOriginalLink(_a0 interface{}, _a1 interface{}) *MockControllerInterface_GetOriginalLink_Call {
-	return &MockControllerInterface_GetOriginalLink_Call{Call: _e.mock.On("GetOriginalLink", _a0, _a1)}
+//   - _a2 models.BreakdownRequest
+func (_e *MockControllerInterface_Expecter) GetBreakdown(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockControllerInterface_GetBreakdown_Call {
+	return &MockControllerInterface_GetBreakdown_Call{Call: _e.mock.On("GetBreakdown", _a0, _a1, _a2)}
 }
 
-func (_c *MockControllerInterface_GetOriginalLink_Call) Run(run func(_a0 context.Context, _a1 string)) *MockControllerInterface_GetOriginalLink_Call {
+func (_c *MockControllerInterface_GetBreakdown_Call) Run(run func(_a0 context.Context, _a1 string, _a2 models.BreakdownRequest)) *MockControllerInterface_GetBreakdown_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(models.BreakdownRequest))
 	})
 	return _c
 }
 
-func (_c *MockControllerInterface_GetOriginalLink_Call) Return(_a0 *models.ShortLinkResponse, _a1 error) *MockControllerInterface_GetOriginalLink_Call {
+func (_c *MockControllerInterface_GetBreakdown_Call) Return(_a0 *models.BreakdownResponse, _a1 error) *MockControllerInterface_GetBreakdown_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockControllerInterface_GetOriginalLink_Call) RunAndReturn(run func(context.Context, string) (*models.ShortLinkResponse, error)) *MockControllerInterface_GetOriginalLink_Call {
+func (_c *MockControllerInterface_GetBreakdown_Call) RunAndReturn(run func(context.Context, string, models.BreakdownRequest) (*models.BreakdownResponse, error)) *MockControllerInterface_GetBreakdown_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetStatShortLink provides a mock function with given fields: _a0, _a1
-func (_m *MockControllerInterface) GetStatShortLink(_a0 context.Context, _a1 string) (*models.StatShortLinkResponse, error) {
+// GetCampaign provides a mock function with given fields: _a0, _a1
+func (_m *MockControllerInterface) GetCampaign(_a0 context.Context, _a1 int64) (*models.CampaignResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetStatShortLink")
+		panic("no return value specified for GetCampaign")
 	}
 
-	var r0 *models.StatShortLinkResponse
+	var r0 *models.CampaignResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.StatShortLinkResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*models.CampaignResponse, error)); ok {
 		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *models.StatShortLinkResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *models.CampaignResponse); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.StatShortLinkResponse)
+			r0 = ret.Get(0).(*models.CampaignResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -218,58 +502,58 @@ func (_m *MockControllerInterface) GetStatShortLink(_a0 context.Context, _a1 str
 	return r0, r1
 }
 
-// MockControllerInterface_GetStatShortLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStatShortLink'
-type MockControllerInterface_GetStatShortLink_Call struct {
+// MockControllerInterface_GetCampaign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCampaign'
+type MockControllerInterface_GetCampaign_Call struct {
 	*mock.Call
 }
 
-// GetStatShortLink is a helper method to define mock.On call
+// GetCampaign is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 string
-func (_e *MockControllerInterface_Expecter) GetStatShortLink(_a0 interface{}, _a1 interface{}) *MockControllerInterface_GetStatShortLink_Call {
-	return &MockControllerInterface_GetStatShortLink_Call{Call: _e.mock.On("GetStatShortLink", _a0, _a1)}
+//   - _a1 int64
+func (_e *MockControllerInterface_Expecter) GetCampaign(_a0 interface{}, _a1 interface{}) *MockControllerInterface_GetCampaign_Call {
+	return &MockControllerInterface_GetCampaign_Call{Call: _e.mock.On("GetCampaign", _a0, _a1)}
 }
 
-func (_c *MockControllerInterface_GetStatShortLink_Call) Run(run func(_a0 context.Context, _a1 string)) *MockControllerInterface_GetStatShortLink_Call {
+func (_c *MockControllerInterface_GetCampaign_Call) Run(run func(_a0 context.Context, _a1 int64)) *MockControllerInterface_GetCampaign_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
 
-func (_c *MockControllerInterface_GetStatShortLink_Call) Return(_a0 *models.StatShortLinkResponse, _a1 error) *MockControllerInterface_GetStatShortLink_Call {
+func (_c *MockControllerInterface_GetCampaign_Call) Return(_a0 *models.CampaignResponse, _a1 error) *MockControllerInterface_GetCampaign_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockControllerInterface_GetStatShortLink_Call) RunAndReturn(run func(context.Context, string) (*models.StatShortLinkResponse, error)) *MockControllerInterface_GetStatShortLink_Call {
+func (_c *MockControllerInterface_GetCampaign_Call) RunAndReturn(run func(context.Context, int64) (*models.CampaignResponse, error)) *MockControllerInterface_GetCampaign_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateLink provides a mock function with given fields: _a0, _a1, _a2
-func (_m *MockControllerInterface) UpdateLink(_a0 context.Context, _a1 string, _a2 string) (*models.ShortLinkResponse, error) {
-	ret := _m.Called(_a0, _a1, _a2)
+// GetCampaignStats provides a mock function with given fields: _a0, _a1
+func (_m *MockControllerInterface) GetCampaignStats(_a0 context.Context, _a1 int64) (*models.CampaignStatsResponse, error) {
+	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateLink")
+		panic("no return value specified for GetCampaignStats")
 	}
 
-	var r0 *models.ShortLinkResponse
+	var r0 *models.CampaignStatsResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.ShortLinkResponse, error)); ok {
-		return rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*models.CampaignStatsResponse, error)); ok {
+		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.ShortLinkResponse); ok {
-		r0 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *models.CampaignStatsResponse); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.ShortLinkResponse)
+			r0 = ret.Get(0).(*models.CampaignStatsResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -277,22 +561,793 @@ func (_m *MockControllerInterface) UpdateLink(_a0 context.Context, _a1 string, _
 	return r0, r1
 }
 
-// MockControllerInterface_UpdateLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLink'
-type MockControllerInterface_UpdateLink_Call struct {
+// MockControllerInterface_GetCampaignStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCampaignStats'
+type MockControllerInterface_GetCampaignStats_Call struct {
 	*mock.Call
 }
 
-// UpdateLink is a helper method to define mock.On call
+// GetCampaignStats is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 string
-//   - _a2 string
-func (_e *MockControllerInterface_Expecter) UpdateLink(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockControllerInterface_UpdateLink_Call {
-	return &MockControllerInterface_UpdateLink_Call{Call: _e.mock.On("UpdateLink", _a0, _a1, _a2)}
+//   - _a1 int64
+func (_e *MockControllerInterface_Expecter) GetCampaignStats(_a0 interface{}, _a1 interface{}) *MockControllerInterface_GetCampaignStats_Call {
+	return &MockControllerInterface_GetCampaignStats_Call{Call: _e.mock.On("GetCampaignStats", _a0, _a1)}
 }
 
-func (_c *MockControllerInterface_UpdateLink_Call) Run(run func(_a0 context.Context, _a1 string, _a2 string)) *MockControllerInterface_UpdateLink_Call {
+func (_c *MockControllerInterface_GetCampaignStats_Call) Run(run func(_a0 context.Context, _a1 int64)) *MockControllerInterface_GetCampaignStats_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockControllerInterface_GetCampaignStats_Call) Return(_a0 *models.CampaignStatsResponse, _a1 error) *MockControllerInterface_GetCampaignStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockControllerInterface_GetCampaignStats_Call) RunAndReturn(run func(context.Context, int64) (*models.CampaignStatsResponse, error)) *MockControllerInterface_GetCampaignStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLink provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockControllerInterface) GetLink(_a0 context.Context, _a1 string, _a2 string) (*models.ShortLinkResponse, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLink")
+	}
+
+	var r0 *models.ShortLinkResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.ShortLinkResponse, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.ShortLinkResponse); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ShortLinkResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockControllerInterface_GetLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLink'
+type MockControllerInterface_GetLink_Call struct {
+	*mock.Call
+}
+
+// GetLink is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 string
+func (_e *MockControllerInterface_Expecter) GetLink(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockControllerInterface_GetLink_Call {
+	return &MockControllerInterface_GetLink_Call{Call: _e.mock.On("GetLink", _a0, _a1, _a2)}
+}
+
+func (_c *MockControllerInterface_GetLink_Call) Run(run func(_a0 context.Context, _a1 string, _a2 string)) *MockControllerInterface_GetLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockControllerInterface_GetLink_Call) Return(_a0 *models.ShortLinkResponse, _a1 error) *MockControllerInterface_GetLink_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockControllerInterface_GetLink_Call) RunAndReturn(run func(context.Context, string, string) (*models.ShortLinkResponse, error)) *MockControllerInterface_GetLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStatShortLink provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockControllerInterface) GetStatShortLink(_a0 context.Context, _a1 string, _a2 string) (*models.StatShortLinkResponse, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatShortLink")
+	}
+
+	var r0 *models.StatShortLinkResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.StatShortLinkResponse, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.StatShortLinkResponse); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StatShortLinkResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockControllerInterface_GetStatShortLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStatShortLink'
+type MockControllerInterface_GetStatShortLink_Call struct {
+	*mock.Call
+}
+
+// GetStatShortLink is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 string
+func (_e *MockControllerInterface_Expecter) GetStatShortLink(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockControllerInterface_GetStatShortLink_Call {
+	return &MockControllerInterface_GetStatShortLink_Call{Call: _e.mock.On("GetStatShortLink", _a0, _a1, _a2)}
+}
+
+func (_c *MockControllerInterface_GetStatShortLink_Call) Run(run func(_a0 context.Context, _a1 string, _a2 string)) *MockControllerInterface_GetStatShortLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockControllerInterface_GetStatShortLink_Call) Return(_a0 *models.StatShortLinkResponse, _a1 error) *MockControllerInterface_GetStatShortLink_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockControllerInterface_GetStatShortLink_Call) RunAndReturn(run func(context.Context, string, string) (*models.StatShortLinkResponse, error)) *MockControllerInterface_GetStatShortLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTagStats provides a mock function with given fields: _a0, _a1
+func (_m *MockControllerInterface) GetTagStats(_a0 context.Context, _a1 string) (*models.TagStatsResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTagStats")
+	}
+
+	var r0 *models.TagStatsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.TagStatsResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.TagStatsResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TagStatsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockControllerInterface_GetTagStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTagStats'
+type MockControllerInterface_GetTagStats_Call struct {
+	*mock.Call
+}
+
+// GetTagStats is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *MockControllerInterface_Expecter) GetTagStats(_a0 interface{}, _a1 interface{}) *MockControllerInterface_GetTagStats_Call {
+	return &MockControllerInterface_GetTagStats_Call{Call: _e.mock.On("GetTagStats", _a0, _a1)}
+}
+
+func (_c *MockControllerInterface_GetTagStats_Call) Run(run func(_a0 context.Context, _a1 string)) *MockControllerInterface_GetTagStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockControllerInterface_GetTagStats_Call) Return(_a0 *models.TagStatsResponse, _a1 error) *MockControllerInterface_GetTagStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockControllerInterface_GetTagStats_Call) RunAndReturn(run func(context.Context, string) (*models.TagStatsResponse, error)) *MockControllerInterface_GetTagStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTimeSeries provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockControllerInterface) GetTimeSeries(_a0 context.Context, _a1 string, _a2 models.TimeSeriesRequest) (*models.TimeSeriesResponse, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTimeSeries")
+	}
+
+	var r0 *models.TimeSeriesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.TimeSeriesRequest) (*models.TimeSeriesResponse, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.TimeSeriesRequest) *models.TimeSeriesResponse); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TimeSeriesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.TimeSeriesRequest) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockControllerInterface_GetTimeSeries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTimeSeries'
+type MockControllerInterface_GetTimeSeries_Call struct {
+	*mock.Call
+}
+
+// GetTimeSeries is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 models.TimeSeriesRequest
+func (_e *MockControllerInterface_Expecter) GetTimeSeries(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockControllerInterface_GetTimeSeries_Call {
+	return &MockControllerInterface_GetTimeSeries_Call{Call: _e.mock.On("GetTimeSeries", _a0, _a1, _a2)}
+}
+
+func (_c *MockControllerInterface_GetTimeSeries_Call) Run(run func(_a0 context.Context, _a1 string, _a2 models.TimeSeriesRequest)) *MockControllerInterface_GetTimeSeries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.TimeSeriesRequest))
+	})
+	return _c
+}
+
+func (_c *MockControllerInterface_GetTimeSeries_Call) Return(_a0 *models.TimeSeriesResponse, _a1 error) *MockControllerInterface_GetTimeSeries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockControllerInterface_GetTimeSeries_Call) RunAndReturn(run func(context.Context, string, models.TimeSeriesRequest) (*models.TimeSeriesResponse, error)) *MockControllerInterface_GetTimeSeries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportLinks provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockControllerInterface) ImportLinks(_a0 context.Context, _a1 string, _a2 io.Reader) (*models.BatchResponse, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportLinks")
+	}
+
+	var r0 *models.BatchResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader) (*models.BatchResponse, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader) *models.BatchResponse); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BatchResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, io.Reader) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockControllerInterface_ImportLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportLinks'
+type MockControllerInterface_ImportLinks_Call struct {
+	*mock.Call
+}
+
+// ImportLinks is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 io.Reader
+func (_e *MockControllerInterface_Expecter) ImportLinks(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockControllerInterface_ImportLinks_Call {
+	return &MockControllerInterface_ImportLinks_Call{Call: _e.mock.On("ImportLinks", _a0, _a1, _a2)}
+}
+
+func (_c *MockControllerInterface_ImportLinks_Call) Run(run func(_a0 context.Context, _a1 string, _a2 io.Reader)) *MockControllerInterface_ImportLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(io.Reader))
+	})
+	return _c
+}
+
+func (_c *MockControllerInterface_ImportLinks_Call) Return(_a0 *models.BatchResponse, _a1 error) *MockControllerInterface_ImportLinks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockControllerInterface_ImportLinks_Call) RunAndReturn(run func(context.Context, string, io.Reader) (*models.BatchResponse, error)) *MockControllerInterface_ImportLinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCampaigns provides a mock function with given fields: _a0
+func (_m *MockControllerInterface) ListCampaigns(_a0 context.Context) (*models.ListCampaignsResponse, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCampaigns")
+	}
+
+	var r0 *models.ListCampaignsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*models.ListCampaignsResponse, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *models.ListCampaignsResponse); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ListCampaignsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockControllerInterface_ListCampaigns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCampaigns'
+type MockControllerInterface_ListCampaigns_Call struct {
+	*mock.Call
+}
+
+// ListCampaigns is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *MockControllerInterface_Expecter) ListCampaigns(_a0 interface{}) *MockControllerInterface_ListCampaigns_Call {
+	return &MockControllerInterface_ListCampaigns_Call{Call: _e.mock.On("ListCampaigns", _a0)}
+}
+
+func (_c *MockControllerInterface_ListCampaigns_Call) Run(run func(_a0 context.Context)) *MockControllerInterface_ListCampaigns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockControllerInterface_ListCampaigns_Call) Return(_a0 *models.ListCampaignsResponse, _a1 error) *MockControllerInterface_ListCampaigns_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockControllerInterface_ListCampaigns_Call) RunAndReturn(run func(context.Context) (*models.ListCampaignsResponse, error)) *MockControllerInterface_ListCampaigns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListLinks provides a mock function with given fields: _a0, _a1
+func (_m *MockControllerInterface) ListLinks(_a0 context.Context, _a1 models.ListLinksRequest) (*models.ListLinksResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLinks")
+	}
+
+	var r0 *models.ListLinksResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ListLinksRequest) (*models.ListLinksResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.ListLinksRequest) *models.ListLinksResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ListLinksResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.ListLinksRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockControllerInterface_ListLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListLinks'
+type MockControllerInterface_ListLinks_Call struct {
+	*mock.Call
+}
+
+// ListLinks is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 models.ListLinksRequest
+func (_e *MockControllerInterface_Expecter) ListLinks(_a0 interface{}, _a1 interface{}) *MockControllerInterface_ListLinks_Call {
+	return &MockControllerInterface_ListLinks_Call{Call: _e.mock.On("ListLinks", _a0, _a1)}
+}
+
+func (_c *MockControllerInterface_ListLinks_Call) Run(run func(_a0 context.Context, _a1 models.ListLinksRequest)) *MockControllerInterface_ListLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.ListLinksRequest))
+	})
+	return _c
+}
+
+func (_c *MockControllerInterface_ListLinks_Call) Return(_a0 *models.ListLinksResponse, _a1 error) *MockControllerInterface_ListLinks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockControllerInterface_ListLinks_Call) RunAndReturn(run func(context.Context, models.ListLinksRequest) (*models.ListLinksResponse, error)) *MockControllerInterface_ListLinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTags provides a mock function with given fields: _a0
+func (_m *MockControllerInterface) ListTags(_a0 context.Context) (*models.ListTagsResponse, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTags")
+	}
+
+	var r0 *models.ListTagsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*models.ListTagsResponse, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *models.ListTagsResponse); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ListTagsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockControllerInterface_ListTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTags'
+type MockControllerInterface_ListTags_Call struct {
+	*mock.Call
+}
+
+// ListTags is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *MockControllerInterface_Expecter) ListTags(_a0 interface{}) *MockControllerInterface_ListTags_Call {
+	return &MockControllerInterface_ListTags_Call{Call: _e.mock.On("ListTags", _a0)}
+}
+
+func (_c *MockControllerInterface_ListTags_Call) Run(run func(_a0 context.Context)) *MockControllerInterface_ListTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockControllerInterface_ListTags_Call) Return(_a0 *models.ListTagsResponse, _a1 error) *MockControllerInterface_ListTags_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockControllerInterface_ListTags_Call) RunAndReturn(run func(context.Context) (*models.ListTagsResponse, error)) *MockControllerInterface_ListTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NormalizeLinks provides a mock function with given fields: _a0
+func (_m *MockControllerInterface) NormalizeLinks(_a0 context.Context) (int, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NormalizeLinks")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockControllerInterface_NormalizeLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NormalizeLinks'
+type MockControllerInterface_NormalizeLinks_Call struct {
+	*mock.Call
+}
+
+// NormalizeLinks is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *MockControllerInterface_Expecter) NormalizeLinks(_a0 interface{}) *MockControllerInterface_NormalizeLinks_Call {
+	return &MockControllerInterface_NormalizeLinks_Call{Call: _e.mock.On("NormalizeLinks", _a0)}
+}
+
+func (_c *MockControllerInterface_NormalizeLinks_Call) Run(run func(_a0 context.Context)) *MockControllerInterface_NormalizeLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockControllerInterface_NormalizeLinks_Call) Return(_a0 int, _a1 error) *MockControllerInterface_NormalizeLinks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockControllerInterface_NormalizeLinks_Call) RunAndReturn(run func(context.Context) (int, error)) *MockControllerInterface_NormalizeLinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchLink provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *MockControllerInterface) PatchLink(_a0 context.Context, _a1 []byte, _a2 string, _a3 int64) (*models.ShortLinkResponse, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchLink")
+	}
+
+	var r0 *models.ShortLinkResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, string, int64) (*models.ShortLinkResponse, error)); ok {
+		return rf(_a0, _a1, _a2, _a3)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, string, int64) *models.ShortLinkResponse); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ShortLinkResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, string, int64) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockControllerInterface_PatchLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchLink'
+type MockControllerInterface_PatchLink_Call struct {
+	*mock.Call
+}
+
+// PatchLink is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 []byte
+//   - _a2 string
+//   - _a3 int64
+func (_e *MockControllerInterface_Expecter) PatchLink(_a0 interface{}, _a1 interface{}, _a2 interface{}, _a3 interface{}) *MockControllerInterface_PatchLink_Call {
+	return &MockControllerInterface_PatchLink_Call{Call: _e.mock.On("PatchLink", _a0, _a1, _a2, _a3)}
+}
+
+func (_c *MockControllerInterface_PatchLink_Call) Run(run func(_a0 context.Context, _a1 []byte, _a2 string, _a3 int64)) *MockControllerInterface_PatchLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].(string), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockControllerInterface_PatchLink_Call) Return(_a0 *models.ShortLinkResponse, _a1 error) *MockControllerInterface_PatchLink_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockControllerInterface_PatchLink_Call) RunAndReturn(run func(context.Context, []byte, string, int64) (*models.ShortLinkResponse, error)) *MockControllerInterface_PatchLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResolveLink provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockControllerInterface) ResolveLink(_a0 context.Context, _a1 string, _a2 models.VisitRequest) (*models.ShortLinkResponse, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveLink")
+	}
+
+	var r0 *models.ShortLinkResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.VisitRequest) (*models.ShortLinkResponse, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.VisitRequest) *models.ShortLinkResponse); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ShortLinkResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.VisitRequest) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockControllerInterface_ResolveLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveLink'
+type MockControllerInterface_ResolveLink_Call struct {
+	*mock.Call
+}
+
+// ResolveLink is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 models.VisitRequest
+func (_e *MockControllerInterface_Expecter) ResolveLink(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockControllerInterface_ResolveLink_Call {
+	return &MockControllerInterface_ResolveLink_Call{Call: _e.mock.On("ResolveLink", _a0, _a1, _a2)}
+}
+
+func (_c *MockControllerInterface_ResolveLink_Call) Run(run func(_a0 context.Context, _a1 string, _a2 models.VisitRequest)) *MockControllerInterface_ResolveLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.VisitRequest))
+	})
+	return _c
+}
+
+func (_c *MockControllerInterface_ResolveLink_Call) Return(_a0 *models.ShortLinkResponse, _a1 error) *MockControllerInterface_ResolveLink_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockControllerInterface_ResolveLink_Call) RunAndReturn(run func(context.Context, string, models.VisitRequest) (*models.ShortLinkResponse, error)) *MockControllerInterface_ResolveLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCampaign provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockControllerInterface) UpdateCampaign(_a0 context.Context, _a1 models.CampaignRequest, _a2 int64) (*models.CampaignResponse, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCampaign")
+	}
+
+	var r0 *models.CampaignResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.CampaignRequest, int64) (*models.CampaignResponse, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.CampaignRequest, int64) *models.CampaignResponse); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CampaignResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.CampaignRequest, int64) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockControllerInterface_UpdateCampaign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCampaign'
+type MockControllerInterface_UpdateCampaign_Call struct {
+	*mock.Call
+}
+
+// UpdateCampaign is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 models.CampaignRequest
+//   - _a2 int64
+func (_e *MockControllerInterface_Expecter) UpdateCampaign(_a0 interface{}, _a1 interface{}, _a2 interface{}) *MockControllerInterface_UpdateCampaign_Call {
+	return &MockControllerInterface_UpdateCampaign_Call{Call: _e.mock.On("UpdateCampaign", _a0, _a1, _a2)}
+}
+
+func (_c *MockControllerInterface_UpdateCampaign_Call) Run(run func(_a0 context.Context, _a1 models.CampaignRequest, _a2 int64)) *MockControllerInterface_UpdateCampaign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.CampaignRequest), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockControllerInterface_UpdateCampaign_Call) Return(_a0 *models.CampaignResponse, _a1 error) *MockControllerInterface_UpdateCampaign_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockControllerInterface_UpdateCampaign_Call) RunAndReturn(run func(context.Context, models.CampaignRequest, int64) (*models.CampaignResponse, error)) *MockControllerInterface_UpdateCampaign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateLink provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *MockControllerInterface) UpdateLink(_a0 context.Context, _a1 models.ShortLinkRequest, _a2 string, _a3 int64) (*models.ShortLinkResponse, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLink")
+	}
+
+	var r0 *models.ShortLinkResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ShortLinkRequest, string, int64) (*models.ShortLinkResponse, error)); ok {
+		return rf(_a0, _a1, _a2, _a3)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.ShortLinkRequest, string, int64) *models.ShortLinkResponse); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ShortLinkResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.ShortLinkRequest, string, int64) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockControllerInterface_UpdateLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLink'
+type MockControllerInterface_UpdateLink_Call struct {
+	*mock.Call
+}
+
+// UpdateLink is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 models.ShortLinkRequest
+//   - _a2 string
+//   - _a3 int64
+func (_e *MockControllerInterface_Expecter) UpdateLink(_a0 interface{}, _a1 interface{}, _a2 interface{}, _a3 interface{}) *MockControllerInterface_UpdateLink_Call {
+	return &MockControllerInterface_UpdateLink_Call{Call: _e.mock.On("UpdateLink", _a0, _a1, _a2, _a3)}
+}
+
+func (_c *MockControllerInterface_UpdateLink_Call) Run(run func(_a0 context.Context, _a1 models.ShortLinkRequest, _a2 string, _a3 int64)) *MockControllerInterface_UpdateLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.ShortLinkRequest), args[2].(string), args[3].(int64))
 	})
 	return _c
 }
@@ -302,7 +1357,7 @@ func (_c *MockControllerInterface_UpdateLink_Call) Return(_a0 *models.ShortLinkR
 	return _c
 }
 
-func (_c *MockControllerInterface_UpdateLink_Call) RunAndReturn(run func(context.Context, string, string) (*models.ShortLinkResponse, error)) *MockControllerInterface_UpdateLink_Call {
+func (_c *MockControllerInterface_UpdateLink_Call) RunAndReturn(run func(context.Context, models.ShortLinkRequest, string, int64) (*models.ShortLinkResponse, error)) *MockControllerInterface_UpdateLink_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -7,41 +7,2559 @@ import (
 
 	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
 	mock "github.com/stretchr/testify/mock"
+
+	sql "database/sql"
+
+	time "time"
 )
 
-// MockQuerier is an autogenerated mock type for the Querier type
-type MockQuerier struct {
-	mock.Mock
+// MockQuerier is an autogenerated mock type for the Querier type
+type MockQuerier struct {
+	mock.Mock
+}
+
+type MockQuerier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockQuerier) EXPECT() *MockQuerier_Expecter {
+	return &MockQuerier_Expecter{mock: &_m.Mock}
+}
+
+// AddURLCountsByID provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) AddURLCountsByID(ctx context.Context, arg db.AddURLCountsByIDParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddURLCountsByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.AddURLCountsByIDParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_AddURLCountsByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddURLCountsByID'
+type MockQuerier_AddURLCountsByID_Call struct {
+	*mock.Call
+}
+
+// AddURLCountsByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.AddURLCountsByIDParams
+func (_e *MockQuerier_Expecter) AddURLCountsByID(ctx interface{}, arg interface{}) *MockQuerier_AddURLCountsByID_Call {
+	return &MockQuerier_AddURLCountsByID_Call{Call: _e.mock.On("AddURLCountsByID", ctx, arg)}
+}
+
+func (_c *MockQuerier_AddURLCountsByID_Call) Run(run func(ctx context.Context, arg db.AddURLCountsByIDParams)) *MockQuerier_AddURLCountsByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.AddURLCountsByIDParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_AddURLCountsByID_Call) Return(_a0 error) *MockQuerier_AddURLCountsByID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_AddURLCountsByID_Call) RunAndReturn(run func(context.Context, db.AddURLCountsByIDParams) error) *MockQuerier_AddURLCountsByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddURLTag provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) AddURLTag(ctx context.Context, arg db.AddURLTagParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddURLTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.AddURLTagParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_AddURLTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddURLTag'
+type MockQuerier_AddURLTag_Call struct {
+	*mock.Call
+}
+
+// AddURLTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.AddURLTagParams
+func (_e *MockQuerier_Expecter) AddURLTag(ctx interface{}, arg interface{}) *MockQuerier_AddURLTag_Call {
+	return &MockQuerier_AddURLTag_Call{Call: _e.mock.On("AddURLTag", ctx, arg)}
+}
+
+func (_c *MockQuerier_AddURLTag_Call) Run(run func(ctx context.Context, arg db.AddURLTagParams)) *MockQuerier_AddURLTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.AddURLTagParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_AddURLTag_Call) Return(_a0 error) *MockQuerier_AddURLTag_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_AddURLTag_Call) RunAndReturn(run func(context.Context, db.AddURLTagParams) error) *MockQuerier_AddURLTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BumpURLVersionByShortCode provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) BumpURLVersionByShortCode(ctx context.Context, arg db.BumpURLVersionByShortCodeParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BumpURLVersionByShortCode")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.BumpURLVersionByShortCodeParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.BumpURLVersionByShortCodeParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.BumpURLVersionByShortCodeParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_BumpURLVersionByShortCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BumpURLVersionByShortCode'
+type MockQuerier_BumpURLVersionByShortCode_Call struct {
+	*mock.Call
+}
+
+// BumpURLVersionByShortCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.BumpURLVersionByShortCodeParams
+func (_e *MockQuerier_Expecter) BumpURLVersionByShortCode(ctx interface{}, arg interface{}) *MockQuerier_BumpURLVersionByShortCode_Call {
+	return &MockQuerier_BumpURLVersionByShortCode_Call{Call: _e.mock.On("BumpURLVersionByShortCode", ctx, arg)}
+}
+
+func (_c *MockQuerier_BumpURLVersionByShortCode_Call) Run(run func(ctx context.Context, arg db.BumpURLVersionByShortCodeParams)) *MockQuerier_BumpURLVersionByShortCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.BumpURLVersionByShortCodeParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_BumpURLVersionByShortCode_Call) Return(_a0 int64, _a1 error) *MockQuerier_BumpURLVersionByShortCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_BumpURLVersionByShortCode_Call) RunAndReturn(run func(context.Context, db.BumpURLVersionByShortCodeParams) (int64, error)) *MockQuerier_BumpURLVersionByShortCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountClicksByBucket provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CountClicksByBucket(ctx context.Context, arg db.CountClicksByBucketParams) ([]db.CountClicksByBucketRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountClicksByBucket")
+	}
+
+	var r0 []db.CountClicksByBucketRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CountClicksByBucketParams) ([]db.CountClicksByBucketRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CountClicksByBucketParams) []db.CountClicksByBucketRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.CountClicksByBucketRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CountClicksByBucketParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CountClicksByBucket_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountClicksByBucket'
+type MockQuerier_CountClicksByBucket_Call struct {
+	*mock.Call
+}
+
+// CountClicksByBucket is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CountClicksByBucketParams
+func (_e *MockQuerier_Expecter) CountClicksByBucket(ctx interface{}, arg interface{}) *MockQuerier_CountClicksByBucket_Call {
+	return &MockQuerier_CountClicksByBucket_Call{Call: _e.mock.On("CountClicksByBucket", ctx, arg)}
+}
+
+func (_c *MockQuerier_CountClicksByBucket_Call) Run(run func(ctx context.Context, arg db.CountClicksByBucketParams)) *MockQuerier_CountClicksByBucket_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CountClicksByBucketParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CountClicksByBucket_Call) Return(_a0 []db.CountClicksByBucketRow, _a1 error) *MockQuerier_CountClicksByBucket_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CountClicksByBucket_Call) RunAndReturn(run func(context.Context, db.CountClicksByBucketParams) ([]db.CountClicksByBucketRow, error)) *MockQuerier_CountClicksByBucket_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountClicksByURLID provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CountClicksByURLID(ctx context.Context, arg db.CountClicksByURLIDParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountClicksByURLID")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CountClicksByURLIDParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CountClicksByURLIDParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CountClicksByURLIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CountClicksByURLID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountClicksByURLID'
+type MockQuerier_CountClicksByURLID_Call struct {
+	*mock.Call
+}
+
+// CountClicksByURLID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CountClicksByURLIDParams
+func (_e *MockQuerier_Expecter) CountClicksByURLID(ctx interface{}, arg interface{}) *MockQuerier_CountClicksByURLID_Call {
+	return &MockQuerier_CountClicksByURLID_Call{Call: _e.mock.On("CountClicksByURLID", ctx, arg)}
+}
+
+func (_c *MockQuerier_CountClicksByURLID_Call) Run(run func(ctx context.Context, arg db.CountClicksByURLIDParams)) *MockQuerier_CountClicksByURLID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CountClicksByURLIDParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CountClicksByURLID_Call) Return(_a0 int64, _a1 error) *MockQuerier_CountClicksByURLID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CountClicksByURLID_Call) RunAndReturn(run func(context.Context, db.CountClicksByURLIDParams) (int64, error)) *MockQuerier_CountClicksByURLID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountUniqueVisitorsByURLID provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CountUniqueVisitorsByURLID(ctx context.Context, arg db.CountUniqueVisitorsByURLIDParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUniqueVisitorsByURLID")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CountUniqueVisitorsByURLIDParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CountUniqueVisitorsByURLIDParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CountUniqueVisitorsByURLIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CountUniqueVisitorsByURLID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountUniqueVisitorsByURLID'
+type MockQuerier_CountUniqueVisitorsByURLID_Call struct {
+	*mock.Call
+}
+
+// CountUniqueVisitorsByURLID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CountUniqueVisitorsByURLIDParams
+func (_e *MockQuerier_Expecter) CountUniqueVisitorsByURLID(ctx interface{}, arg interface{}) *MockQuerier_CountUniqueVisitorsByURLID_Call {
+	return &MockQuerier_CountUniqueVisitorsByURLID_Call{Call: _e.mock.On("CountUniqueVisitorsByURLID", ctx, arg)}
+}
+
+func (_c *MockQuerier_CountUniqueVisitorsByURLID_Call) Run(run func(ctx context.Context, arg db.CountUniqueVisitorsByURLIDParams)) *MockQuerier_CountUniqueVisitorsByURLID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CountUniqueVisitorsByURLIDParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CountUniqueVisitorsByURLID_Call) Return(_a0 int64, _a1 error) *MockQuerier_CountUniqueVisitorsByURLID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CountUniqueVisitorsByURLID_Call) RunAndReturn(run func(context.Context, db.CountUniqueVisitorsByURLIDParams) (int64, error)) *MockQuerier_CountUniqueVisitorsByURLID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateCampaign provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateCampaign(ctx context.Context, arg db.CreateCampaignParams) (db.Campaign, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCampaign")
+	}
+
+	var r0 db.Campaign
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateCampaignParams) (db.Campaign, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateCampaignParams) db.Campaign); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Campaign)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateCampaignParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CreateCampaign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCampaign'
+type MockQuerier_CreateCampaign_Call struct {
+	*mock.Call
+}
+
+// CreateCampaign is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateCampaignParams
+func (_e *MockQuerier_Expecter) CreateCampaign(ctx interface{}, arg interface{}) *MockQuerier_CreateCampaign_Call {
+	return &MockQuerier_CreateCampaign_Call{Call: _e.mock.On("CreateCampaign", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateCampaign_Call) Run(run func(ctx context.Context, arg db.CreateCampaignParams)) *MockQuerier_CreateCampaign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CreateCampaignParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateCampaign_Call) Return(_a0 db.Campaign, _a1 error) *MockQuerier_CreateCampaign_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CreateCampaign_Call) RunAndReturn(run func(context.Context, db.CreateCampaignParams) (db.Campaign, error)) *MockQuerier_CreateCampaign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateClick provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateClick(ctx context.Context, arg db.CreateClickParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateClick")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateClickParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_CreateClick_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateClick'
+type MockQuerier_CreateClick_Call struct {
+	*mock.Call
+}
+
+// CreateClick is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateClickParams
+func (_e *MockQuerier_Expecter) CreateClick(ctx interface{}, arg interface{}) *MockQuerier_CreateClick_Call {
+	return &MockQuerier_CreateClick_Call{Call: _e.mock.On("CreateClick", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateClick_Call) Run(run func(ctx context.Context, arg db.CreateClickParams)) *MockQuerier_CreateClick_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CreateClickParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateClick_Call) Return(_a0 error) *MockQuerier_CreateClick_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_CreateClick_Call) RunAndReturn(run func(context.Context, db.CreateClickParams) error) *MockQuerier_CreateClick_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateIdempotencyKey provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateIdempotencyKey(ctx context.Context, arg db.CreateIdempotencyKeyParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateIdempotencyKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateIdempotencyKeyParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_CreateIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateIdempotencyKey'
+type MockQuerier_CreateIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// CreateIdempotencyKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateIdempotencyKeyParams
+func (_e *MockQuerier_Expecter) CreateIdempotencyKey(ctx interface{}, arg interface{}) *MockQuerier_CreateIdempotencyKey_Call {
+	return &MockQuerier_CreateIdempotencyKey_Call{Call: _e.mock.On("CreateIdempotencyKey", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateIdempotencyKey_Call) Run(run func(ctx context.Context, arg db.CreateIdempotencyKeyParams)) *MockQuerier_CreateIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CreateIdempotencyKeyParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateIdempotencyKey_Call) Return(_a0 error) *MockQuerier_CreateIdempotencyKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_CreateIdempotencyKey_Call) RunAndReturn(run func(context.Context, db.CreateIdempotencyKeyParams) error) *MockQuerier_CreateIdempotencyKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateURL provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateURL(ctx context.Context, arg db.CreateURLParams) (db.CreateURLRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateURL")
+	}
+
+	var r0 db.CreateURLRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateURLParams) (db.CreateURLRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateURLParams) db.CreateURLRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.CreateURLRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateURLParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CreateURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateURL'
+type MockQuerier_CreateURL_Call struct {
+	*mock.Call
+}
+
+// CreateURL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateURLParams
+func (_e *MockQuerier_Expecter) CreateURL(ctx interface{}, arg interface{}) *MockQuerier_CreateURL_Call {
+	return &MockQuerier_CreateURL_Call{Call: _e.mock.On("CreateURL", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateURL_Call) Run(run func(ctx context.Context, arg db.CreateURLParams)) *MockQuerier_CreateURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CreateURLParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateURL_Call) Return(_a0 db.CreateURLRow, _a1 error) *MockQuerier_CreateURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CreateURL_Call) RunAndReturn(run func(context.Context, db.CreateURLParams) (db.CreateURLRow, error)) *MockQuerier_CreateURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateVisitorSalt provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateVisitorSalt(ctx context.Context, arg db.CreateVisitorSaltParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateVisitorSalt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateVisitorSaltParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_CreateVisitorSalt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateVisitorSalt'
+type MockQuerier_CreateVisitorSalt_Call struct {
+	*mock.Call
+}
+
+// CreateVisitorSalt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateVisitorSaltParams
+func (_e *MockQuerier_Expecter) CreateVisitorSalt(ctx interface{}, arg interface{}) *MockQuerier_CreateVisitorSalt_Call {
+	return &MockQuerier_CreateVisitorSalt_Call{Call: _e.mock.On("CreateVisitorSalt", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateVisitorSalt_Call) Run(run func(ctx context.Context, arg db.CreateVisitorSaltParams)) *MockQuerier_CreateVisitorSalt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CreateVisitorSaltParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateVisitorSalt_Call) Return(_a0 error) *MockQuerier_CreateVisitorSalt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_CreateVisitorSalt_Call) RunAndReturn(run func(context.Context, db.CreateVisitorSaltParams) error) *MockQuerier_CreateVisitorSalt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCampaignByID provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) DeleteCampaignByID(ctx context.Context, id int64) (int64, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCampaignByID")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_DeleteCampaignByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCampaignByID'
+type MockQuerier_DeleteCampaignByID_Call struct {
+	*mock.Call
+}
+
+// DeleteCampaignByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockQuerier_Expecter) DeleteCampaignByID(ctx interface{}, id interface{}) *MockQuerier_DeleteCampaignByID_Call {
+	return &MockQuerier_DeleteCampaignByID_Call{Call: _e.mock.On("DeleteCampaignByID", ctx, id)}
+}
+
+func (_c *MockQuerier_DeleteCampaignByID_Call) Run(run func(ctx context.Context, id int64)) *MockQuerier_DeleteCampaignByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteCampaignByID_Call) Return(_a0 int64, _a1 error) *MockQuerier_DeleteCampaignByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteCampaignByID_Call) RunAndReturn(run func(context.Context, int64) (int64, error)) *MockQuerier_DeleteCampaignByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteIdempotencyKeysBefore provides a mock function with given fields: ctx, createdat
+func (_m *MockQuerier) DeleteIdempotencyKeysBefore(ctx context.Context, createdat time.Time) error {
+	ret := _m.Called(ctx, createdat)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIdempotencyKeysBefore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, createdat)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_DeleteIdempotencyKeysBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIdempotencyKeysBefore'
+type MockQuerier_DeleteIdempotencyKeysBefore_Call struct {
+	*mock.Call
+}
+
+// DeleteIdempotencyKeysBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - createdat time.Time
+func (_e *MockQuerier_Expecter) DeleteIdempotencyKeysBefore(ctx interface{}, createdat interface{}) *MockQuerier_DeleteIdempotencyKeysBefore_Call {
+	return &MockQuerier_DeleteIdempotencyKeysBefore_Call{Call: _e.mock.On("DeleteIdempotencyKeysBefore", ctx, createdat)}
+}
+
+func (_c *MockQuerier_DeleteIdempotencyKeysBefore_Call) Run(run func(ctx context.Context, createdat time.Time)) *MockQuerier_DeleteIdempotencyKeysBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteIdempotencyKeysBefore_Call) Return(_a0 error) *MockQuerier_DeleteIdempotencyKeysBefore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteIdempotencyKeysBefore_Call) RunAndReturn(run func(context.Context, time.Time) error) *MockQuerier_DeleteIdempotencyKeysBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteURLByShortCode provides a mock function with given fields: ctx, shortcode
+func (_m *MockQuerier) DeleteURLByShortCode(ctx context.Context, shortcode string) error {
+	ret := _m.Called(ctx, shortcode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteURLByShortCode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, shortcode)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_DeleteURLByShortCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteURLByShortCode'
+type MockQuerier_DeleteURLByShortCode_Call struct {
+	*mock.Call
+}
+
+// DeleteURLByShortCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortcode string
+func (_e *MockQuerier_Expecter) DeleteURLByShortCode(ctx interface{}, shortcode interface{}) *MockQuerier_DeleteURLByShortCode_Call {
+	return &MockQuerier_DeleteURLByShortCode_Call{Call: _e.mock.On("DeleteURLByShortCode", ctx, shortcode)}
+}
+
+func (_c *MockQuerier_DeleteURLByShortCode_Call) Run(run func(ctx context.Context, shortcode string)) *MockQuerier_DeleteURLByShortCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteURLByShortCode_Call) Return(_a0 error) *MockQuerier_DeleteURLByShortCode_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteURLByShortCode_Call) RunAndReturn(run func(context.Context, string) error) *MockQuerier_DeleteURLByShortCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteURLTagsByURLID provides a mock function with given fields: ctx, urlid
+func (_m *MockQuerier) DeleteURLTagsByURLID(ctx context.Context, urlid int64) error {
+	ret := _m.Called(ctx, urlid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteURLTagsByURLID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, urlid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_DeleteURLTagsByURLID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteURLTagsByURLID'
+type MockQuerier_DeleteURLTagsByURLID_Call struct {
+	*mock.Call
+}
+
+// DeleteURLTagsByURLID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - urlid int64
+func (_e *MockQuerier_Expecter) DeleteURLTagsByURLID(ctx interface{}, urlid interface{}) *MockQuerier_DeleteURLTagsByURLID_Call {
+	return &MockQuerier_DeleteURLTagsByURLID_Call{Call: _e.mock.On("DeleteURLTagsByURLID", ctx, urlid)}
+}
+
+func (_c *MockQuerier_DeleteURLTagsByURLID_Call) Run(run func(ctx context.Context, urlid int64)) *MockQuerier_DeleteURLTagsByURLID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteURLTagsByURLID_Call) Return(_a0 error) *MockQuerier_DeleteURLTagsByURLID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteURLTagsByURLID_Call) RunAndReturn(run func(context.Context, int64) error) *MockQuerier_DeleteURLTagsByURLID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteVisitorSaltsBefore provides a mock function with given fields: ctx, day
+func (_m *MockQuerier) DeleteVisitorSaltsBefore(ctx context.Context, day string) error {
+	ret := _m.Called(ctx, day)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteVisitorSaltsBefore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, day)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_DeleteVisitorSaltsBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteVisitorSaltsBefore'
+type MockQuerier_DeleteVisitorSaltsBefore_Call struct {
+	*mock.Call
+}
+
+// DeleteVisitorSaltsBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - day string
+func (_e *MockQuerier_Expecter) DeleteVisitorSaltsBefore(ctx interface{}, day interface{}) *MockQuerier_DeleteVisitorSaltsBefore_Call {
+	return &MockQuerier_DeleteVisitorSaltsBefore_Call{Call: _e.mock.On("DeleteVisitorSaltsBefore", ctx, day)}
+}
+
+func (_c *MockQuerier_DeleteVisitorSaltsBefore_Call) Run(run func(ctx context.Context, day string)) *MockQuerier_DeleteVisitorSaltsBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteVisitorSaltsBefore_Call) Return(_a0 error) *MockQuerier_DeleteVisitorSaltsBefore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteVisitorSaltsBefore_Call) RunAndReturn(run func(context.Context, string) error) *MockQuerier_DeleteVisitorSaltsBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCampaignByID provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) GetCampaignByID(ctx context.Context, id int64) (db.Campaign, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCampaignByID")
+	}
+
+	var r0 db.Campaign
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Campaign, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Campaign); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.Campaign)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetCampaignByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCampaignByID'
+type MockQuerier_GetCampaignByID_Call struct {
+	*mock.Call
+}
+
+// GetCampaignByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockQuerier_Expecter) GetCampaignByID(ctx interface{}, id interface{}) *MockQuerier_GetCampaignByID_Call {
+	return &MockQuerier_GetCampaignByID_Call{Call: _e.mock.On("GetCampaignByID", ctx, id)}
+}
+
+func (_c *MockQuerier_GetCampaignByID_Call) Run(run func(ctx context.Context, id int64)) *MockQuerier_GetCampaignByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetCampaignByID_Call) Return(_a0 db.Campaign, _a1 error) *MockQuerier_GetCampaignByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetCampaignByID_Call) RunAndReturn(run func(context.Context, int64) (db.Campaign, error)) *MockQuerier_GetCampaignByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCampaignStats provides a mock function with given fields: ctx, campaignid
+func (_m *MockQuerier) GetCampaignStats(ctx context.Context, campaignid sql.NullInt64) (db.GetCampaignStatsRow, error) {
+	ret := _m.Called(ctx, campaignid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCampaignStats")
+	}
+
+	var r0 db.GetCampaignStatsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullInt64) (db.GetCampaignStatsRow, error)); ok {
+		return rf(ctx, campaignid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullInt64) db.GetCampaignStatsRow); ok {
+		r0 = rf(ctx, campaignid)
+	} else {
+		r0 = ret.Get(0).(db.GetCampaignStatsRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, sql.NullInt64) error); ok {
+		r1 = rf(ctx, campaignid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetCampaignStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCampaignStats'
+type MockQuerier_GetCampaignStats_Call struct {
+	*mock.Call
+}
+
+// GetCampaignStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - campaignid sql.NullInt64
+func (_e *MockQuerier_Expecter) GetCampaignStats(ctx interface{}, campaignid interface{}) *MockQuerier_GetCampaignStats_Call {
+	return &MockQuerier_GetCampaignStats_Call{Call: _e.mock.On("GetCampaignStats", ctx, campaignid)}
+}
+
+func (_c *MockQuerier_GetCampaignStats_Call) Run(run func(ctx context.Context, campaignid sql.NullInt64)) *MockQuerier_GetCampaignStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sql.NullInt64))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetCampaignStats_Call) Return(_a0 db.GetCampaignStatsRow, _a1 error) *MockQuerier_GetCampaignStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetCampaignStats_Call) RunAndReturn(run func(context.Context, sql.NullInt64) (db.GetCampaignStatsRow, error)) *MockQuerier_GetCampaignStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIdempotencyKey provides a mock function with given fields: ctx, idempotencykey
+func (_m *MockQuerier) GetIdempotencyKey(ctx context.Context, idempotencykey string) (db.GetIdempotencyKeyRow, error) {
+	ret := _m.Called(ctx, idempotencykey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIdempotencyKey")
+	}
+
+	var r0 db.GetIdempotencyKeyRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.GetIdempotencyKeyRow, error)); ok {
+		return rf(ctx, idempotencykey)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.GetIdempotencyKeyRow); ok {
+		r0 = rf(ctx, idempotencykey)
+	} else {
+		r0 = ret.Get(0).(db.GetIdempotencyKeyRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, idempotencykey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIdempotencyKey'
+type MockQuerier_GetIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// GetIdempotencyKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - idempotencykey string
+func (_e *MockQuerier_Expecter) GetIdempotencyKey(ctx interface{}, idempotencykey interface{}) *MockQuerier_GetIdempotencyKey_Call {
+	return &MockQuerier_GetIdempotencyKey_Call{Call: _e.mock.On("GetIdempotencyKey", ctx, idempotencykey)}
+}
+
+func (_c *MockQuerier_GetIdempotencyKey_Call) Run(run func(ctx context.Context, idempotencykey string)) *MockQuerier_GetIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetIdempotencyKey_Call) Return(_a0 db.GetIdempotencyKeyRow, _a1 error) *MockQuerier_GetIdempotencyKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetIdempotencyKey_Call) RunAndReturn(run func(context.Context, string) (db.GetIdempotencyKeyRow, error)) *MockQuerier_GetIdempotencyKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLastURLID provides a mock function with given fields: ctx
+func (_m *MockQuerier) GetLastURLID(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastURLID")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetLastURLID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastURLID'
+type MockQuerier_GetLastURLID_Call struct {
+	*mock.Call
+}
+
+// GetLastURLID is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) GetLastURLID(ctx interface{}) *MockQuerier_GetLastURLID_Call {
+	return &MockQuerier_GetLastURLID_Call{Call: _e.mock.On("GetLastURLID", ctx)}
+}
+
+func (_c *MockQuerier_GetLastURLID_Call) Run(run func(ctx context.Context)) *MockQuerier_GetLastURLID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetLastURLID_Call) Return(_a0 int64, _a1 error) *MockQuerier_GetLastURLID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetLastURLID_Call) RunAndReturn(run func(context.Context) (int64, error)) *MockQuerier_GetLastURLID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPlainURLByURL provides a mock function with given fields: ctx, url
+func (_m *MockQuerier) GetPlainURLByURL(ctx context.Context, url string) (string, error) {
+	ret := _m.Called(ctx, url)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPlainURLByURL")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, url)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, url)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, url)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetPlainURLByURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPlainURLByURL'
+type MockQuerier_GetPlainURLByURL_Call struct {
+	*mock.Call
+}
+
+// GetPlainURLByURL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - url string
+func (_e *MockQuerier_Expecter) GetPlainURLByURL(ctx interface{}, url interface{}) *MockQuerier_GetPlainURLByURL_Call {
+	return &MockQuerier_GetPlainURLByURL_Call{Call: _e.mock.On("GetPlainURLByURL", ctx, url)}
+}
+
+func (_c *MockQuerier_GetPlainURLByURL_Call) Run(run func(ctx context.Context, url string)) *MockQuerier_GetPlainURLByURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetPlainURLByURL_Call) Return(_a0 string, _a1 error) *MockQuerier_GetPlainURLByURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetPlainURLByURL_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockQuerier_GetPlainURLByURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTagStats provides a mock function with given fields: ctx, name
+func (_m *MockQuerier) GetTagStats(ctx context.Context, name string) (db.GetTagStatsRow, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTagStats")
+	}
+
+	var r0 db.GetTagStatsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.GetTagStatsRow, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.GetTagStatsRow); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(db.GetTagStatsRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetTagStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTagStats'
+type MockQuerier_GetTagStats_Call struct {
+	*mock.Call
+}
+
+// GetTagStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockQuerier_Expecter) GetTagStats(ctx interface{}, name interface{}) *MockQuerier_GetTagStats_Call {
+	return &MockQuerier_GetTagStats_Call{Call: _e.mock.On("GetTagStats", ctx, name)}
+}
+
+func (_c *MockQuerier_GetTagStats_Call) Run(run func(ctx context.Context, name string)) *MockQuerier_GetTagStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetTagStats_Call) Return(_a0 db.GetTagStatsRow, _a1 error) *MockQuerier_GetTagStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetTagStats_Call) RunAndReturn(run func(context.Context, string) (db.GetTagStatsRow, error)) *MockQuerier_GetTagStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetURLByShortCode provides a mock function with given fields: ctx, shortcode
+func (_m *MockQuerier) GetURLByShortCode(ctx context.Context, shortcode string) (db.GetURLByShortCodeRow, error) {
+	ret := _m.Called(ctx, shortcode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetURLByShortCode")
+	}
+
+	var r0 db.GetURLByShortCodeRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.GetURLByShortCodeRow, error)); ok {
+		return rf(ctx, shortcode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.GetURLByShortCodeRow); ok {
+		r0 = rf(ctx, shortcode)
+	} else {
+		r0 = ret.Get(0).(db.GetURLByShortCodeRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, shortcode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetURLByShortCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetURLByShortCode'
+type MockQuerier_GetURLByShortCode_Call struct {
+	*mock.Call
+}
+
+// GetURLByShortCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortcode string
+func (_e *MockQuerier_Expecter) GetURLByShortCode(ctx interface{}, shortcode interface{}) *MockQuerier_GetURLByShortCode_Call {
+	return &MockQuerier_GetURLByShortCode_Call{Call: _e.mock.On("GetURLByShortCode", ctx, shortcode)}
+}
+
+func (_c *MockQuerier_GetURLByShortCode_Call) Run(run func(ctx context.Context, shortcode string)) *MockQuerier_GetURLByShortCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetURLByShortCode_Call) Return(_a0 db.GetURLByShortCodeRow, _a1 error) *MockQuerier_GetURLByShortCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetURLByShortCode_Call) RunAndReturn(run func(context.Context, string) (db.GetURLByShortCodeRow, error)) *MockQuerier_GetURLByShortCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetURLStatsByShortCode provides a mock function with given fields: ctx, shortcode
+func (_m *MockQuerier) GetURLStatsByShortCode(ctx context.Context, shortcode string) (db.Url, error) {
+	ret := _m.Called(ctx, shortcode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetURLStatsByShortCode")
+	}
+
+	var r0 db.Url
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.Url, error)); ok {
+		return rf(ctx, shortcode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.Url); ok {
+		r0 = rf(ctx, shortcode)
+	} else {
+		r0 = ret.Get(0).(db.Url)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, shortcode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetURLStatsByShortCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetURLStatsByShortCode'
+type MockQuerier_GetURLStatsByShortCode_Call struct {
+	*mock.Call
+}
+
+// GetURLStatsByShortCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortcode string
+func (_e *MockQuerier_Expecter) GetURLStatsByShortCode(ctx interface{}, shortcode interface{}) *MockQuerier_GetURLStatsByShortCode_Call {
+	return &MockQuerier_GetURLStatsByShortCode_Call{Call: _e.mock.On("GetURLStatsByShortCode", ctx, shortcode)}
+}
+
+func (_c *MockQuerier_GetURLStatsByShortCode_Call) Run(run func(ctx context.Context, shortcode string)) *MockQuerier_GetURLStatsByShortCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetURLStatsByShortCode_Call) Return(_a0 db.Url, _a1 error) *MockQuerier_GetURLStatsByShortCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetURLStatsByShortCode_Call) RunAndReturn(run func(context.Context, string) (db.Url, error)) *MockQuerier_GetURLStatsByShortCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetVisitorSalt provides a mock function with given fields: ctx, day
+func (_m *MockQuerier) GetVisitorSalt(ctx context.Context, day string) ([]byte, error) {
+	ret := _m.Called(ctx, day)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVisitorSalt")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]byte, error)); ok {
+		return rf(ctx, day)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []byte); ok {
+		r0 = rf(ctx, day)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, day)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetVisitorSalt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVisitorSalt'
+type MockQuerier_GetVisitorSalt_Call struct {
+	*mock.Call
+}
+
+// GetVisitorSalt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - day string
+func (_e *MockQuerier_Expecter) GetVisitorSalt(ctx interface{}, day interface{}) *MockQuerier_GetVisitorSalt_Call {
+	return &MockQuerier_GetVisitorSalt_Call{Call: _e.mock.On("GetVisitorSalt", ctx, day)}
+}
+
+func (_c *MockQuerier_GetVisitorSalt_Call) Run(run func(ctx context.Context, day string)) *MockQuerier_GetVisitorSalt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetVisitorSalt_Call) Return(_a0 []byte, _a1 error) *MockQuerier_GetVisitorSalt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetVisitorSalt_Call) RunAndReturn(run func(context.Context, string) ([]byte, error)) *MockQuerier_GetVisitorSalt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrementURLAccessCountByShortCode provides a mock function with given fields: ctx, shortcode
+func (_m *MockQuerier) IncrementURLAccessCountByShortCode(ctx context.Context, shortcode string) (int64, error) {
+	ret := _m.Called(ctx, shortcode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementURLAccessCountByShortCode")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, shortcode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, shortcode)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, shortcode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_IncrementURLAccessCountByShortCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementURLAccessCountByShortCode'
+type MockQuerier_IncrementURLAccessCountByShortCode_Call struct {
+	*mock.Call
+}
+
+// IncrementURLAccessCountByShortCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortcode string
+func (_e *MockQuerier_Expecter) IncrementURLAccessCountByShortCode(ctx interface{}, shortcode interface{}) *MockQuerier_IncrementURLAccessCountByShortCode_Call {
+	return &MockQuerier_IncrementURLAccessCountByShortCode_Call{Call: _e.mock.On("IncrementURLAccessCountByShortCode", ctx, shortcode)}
+}
+
+func (_c *MockQuerier_IncrementURLAccessCountByShortCode_Call) Run(run func(ctx context.Context, shortcode string)) *MockQuerier_IncrementURLAccessCountByShortCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_IncrementURLAccessCountByShortCode_Call) Return(_a0 int64, _a1 error) *MockQuerier_IncrementURLAccessCountByShortCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_IncrementURLAccessCountByShortCode_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *MockQuerier_IncrementURLAccessCountByShortCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrementURLBotCountByShortCode provides a mock function with given fields: ctx, shortcode
+func (_m *MockQuerier) IncrementURLBotCountByShortCode(ctx context.Context, shortcode string) (int64, error) {
+	ret := _m.Called(ctx, shortcode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementURLBotCountByShortCode")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, shortcode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, shortcode)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, shortcode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_IncrementURLBotCountByShortCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementURLBotCountByShortCode'
+type MockQuerier_IncrementURLBotCountByShortCode_Call struct {
+	*mock.Call
+}
+
+// IncrementURLBotCountByShortCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortcode string
+func (_e *MockQuerier_Expecter) IncrementURLBotCountByShortCode(ctx interface{}, shortcode interface{}) *MockQuerier_IncrementURLBotCountByShortCode_Call {
+	return &MockQuerier_IncrementURLBotCountByShortCode_Call{Call: _e.mock.On("IncrementURLBotCountByShortCode", ctx, shortcode)}
+}
+
+func (_c *MockQuerier_IncrementURLBotCountByShortCode_Call) Run(run func(ctx context.Context, shortcode string)) *MockQuerier_IncrementURLBotCountByShortCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_IncrementURLBotCountByShortCode_Call) Return(_a0 int64, _a1 error) *MockQuerier_IncrementURLBotCountByShortCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_IncrementURLBotCountByShortCode_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *MockQuerier_IncrementURLBotCountByShortCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCampaigns provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListCampaigns(ctx context.Context) ([]db.Campaign, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCampaigns")
+	}
+
+	var r0 []db.Campaign
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.Campaign, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.Campaign); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Campaign)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListCampaigns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCampaigns'
+type MockQuerier_ListCampaigns_Call struct {
+	*mock.Call
+}
+
+// ListCampaigns is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListCampaigns(ctx interface{}) *MockQuerier_ListCampaigns_Call {
+	return &MockQuerier_ListCampaigns_Call{Call: _e.mock.On("ListCampaigns", ctx)}
+}
+
+func (_c *MockQuerier_ListCampaigns_Call) Run(run func(ctx context.Context)) *MockQuerier_ListCampaigns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListCampaigns_Call) Return(_a0 []db.Campaign, _a1 error) *MockQuerier_ListCampaigns_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListCampaigns_Call) RunAndReturn(run func(context.Context) ([]db.Campaign, error)) *MockQuerier_ListCampaigns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTags provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListTags(ctx context.Context) ([]db.ListTagsRow, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTags")
+	}
+
+	var r0 []db.ListTagsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.ListTagsRow, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.ListTagsRow); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListTagsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTags'
+type MockQuerier_ListTags_Call struct {
+	*mock.Call
+}
+
+// ListTags is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListTags(ctx interface{}) *MockQuerier_ListTags_Call {
+	return &MockQuerier_ListTags_Call{Call: _e.mock.On("ListTags", ctx)}
+}
+
+func (_c *MockQuerier_ListTags_Call) Run(run func(ctx context.Context)) *MockQuerier_ListTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListTags_Call) Return(_a0 []db.ListTagsRow, _a1 error) *MockQuerier_ListTags_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListTags_Call) RunAndReturn(run func(context.Context) ([]db.ListTagsRow, error)) *MockQuerier_ListTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTagsByURLID provides a mock function with given fields: ctx, urlid
+func (_m *MockQuerier) ListTagsByURLID(ctx context.Context, urlid int64) ([]string, error) {
+	ret := _m.Called(ctx, urlid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTagsByURLID")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]string, error)); ok {
+		return rf(ctx, urlid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []string); ok {
+		r0 = rf(ctx, urlid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, urlid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListTagsByURLID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTagsByURLID'
+type MockQuerier_ListTagsByURLID_Call struct {
+	*mock.Call
+}
+
+// ListTagsByURLID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - urlid int64
+func (_e *MockQuerier_Expecter) ListTagsByURLID(ctx interface{}, urlid interface{}) *MockQuerier_ListTagsByURLID_Call {
+	return &MockQuerier_ListTagsByURLID_Call{Call: _e.mock.On("ListTagsByURLID", ctx, urlid)}
+}
+
+func (_c *MockQuerier_ListTagsByURLID_Call) Run(run func(ctx context.Context, urlid int64)) *MockQuerier_ListTagsByURLID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListTagsByURLID_Call) Return(_a0 []string, _a1 error) *MockQuerier_ListTagsByURLID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListTagsByURLID_Call) RunAndReturn(run func(context.Context, int64) ([]string, error)) *MockQuerier_ListTagsByURLID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTagsByURLIDs provides a mock function with given fields: ctx, url_ids
+func (_m *MockQuerier) ListTagsByURLIDs(ctx context.Context, url_ids string) ([]db.ListTagsByURLIDsRow, error) {
+	ret := _m.Called(ctx, url_ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTagsByURLIDs")
+	}
+
+	var r0 []db.ListTagsByURLIDsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]db.ListTagsByURLIDsRow, error)); ok {
+		return rf(ctx, url_ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []db.ListTagsByURLIDsRow); ok {
+		r0 = rf(ctx, url_ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListTagsByURLIDsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, url_ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListTagsByURLIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTagsByURLIDs'
+type MockQuerier_ListTagsByURLIDs_Call struct {
+	*mock.Call
+}
+
+// ListTagsByURLIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - url_ids string
+func (_e *MockQuerier_Expecter) ListTagsByURLIDs(ctx interface{}, url_ids interface{}) *MockQuerier_ListTagsByURLIDs_Call {
+	return &MockQuerier_ListTagsByURLIDs_Call{Call: _e.mock.On("ListTagsByURLIDs", ctx, url_ids)}
+}
+
+func (_c *MockQuerier_ListTagsByURLIDs_Call) Run(run func(ctx context.Context, url_ids string)) *MockQuerier_ListTagsByURLIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListTagsByURLIDs_Call) Return(_a0 []db.ListTagsByURLIDsRow, _a1 error) *MockQuerier_ListTagsByURLIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListTagsByURLIDs_Call) RunAndReturn(run func(context.Context, string) ([]db.ListTagsByURLIDsRow, error)) *MockQuerier_ListTagsByURLIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTopBrowsersByURLID provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListTopBrowsersByURLID(ctx context.Context, arg db.ListTopBrowsersByURLIDParams) ([]db.ListTopBrowsersByURLIDRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTopBrowsersByURLID")
+	}
+
+	var r0 []db.ListTopBrowsersByURLIDRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListTopBrowsersByURLIDParams) ([]db.ListTopBrowsersByURLIDRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListTopBrowsersByURLIDParams) []db.ListTopBrowsersByURLIDRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListTopBrowsersByURLIDRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListTopBrowsersByURLIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListTopBrowsersByURLID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTopBrowsersByURLID'
+type MockQuerier_ListTopBrowsersByURLID_Call struct {
+	*mock.Call
+}
+
+// ListTopBrowsersByURLID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListTopBrowsersByURLIDParams
+func (_e *MockQuerier_Expecter) ListTopBrowsersByURLID(ctx interface{}, arg interface{}) *MockQuerier_ListTopBrowsersByURLID_Call {
+	return &MockQuerier_ListTopBrowsersByURLID_Call{Call: _e.mock.On("ListTopBrowsersByURLID", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListTopBrowsersByURLID_Call) Run(run func(ctx context.Context, arg db.ListTopBrowsersByURLIDParams)) *MockQuerier_ListTopBrowsersByURLID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListTopBrowsersByURLIDParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListTopBrowsersByURLID_Call) Return(_a0 []db.ListTopBrowsersByURLIDRow, _a1 error) *MockQuerier_ListTopBrowsersByURLID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListTopBrowsersByURLID_Call) RunAndReturn(run func(context.Context, db.ListTopBrowsersByURLIDParams) ([]db.ListTopBrowsersByURLIDRow, error)) *MockQuerier_ListTopBrowsersByURLID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTopDevicesByURLID provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListTopDevicesByURLID(ctx context.Context, arg db.ListTopDevicesByURLIDParams) ([]db.ListTopDevicesByURLIDRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTopDevicesByURLID")
+	}
+
+	var r0 []db.ListTopDevicesByURLIDRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListTopDevicesByURLIDParams) ([]db.ListTopDevicesByURLIDRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListTopDevicesByURLIDParams) []db.ListTopDevicesByURLIDRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListTopDevicesByURLIDRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListTopDevicesByURLIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListTopDevicesByURLID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTopDevicesByURLID'
+type MockQuerier_ListTopDevicesByURLID_Call struct {
+	*mock.Call
+}
+
+// ListTopDevicesByURLID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListTopDevicesByURLIDParams
+func (_e *MockQuerier_Expecter) ListTopDevicesByURLID(ctx interface{}, arg interface{}) *MockQuerier_ListTopDevicesByURLID_Call {
+	return &MockQuerier_ListTopDevicesByURLID_Call{Call: _e.mock.On("ListTopDevicesByURLID", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListTopDevicesByURLID_Call) Run(run func(ctx context.Context, arg db.ListTopDevicesByURLIDParams)) *MockQuerier_ListTopDevicesByURLID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListTopDevicesByURLIDParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListTopDevicesByURLID_Call) Return(_a0 []db.ListTopDevicesByURLIDRow, _a1 error) *MockQuerier_ListTopDevicesByURLID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListTopDevicesByURLID_Call) RunAndReturn(run func(context.Context, db.ListTopDevicesByURLIDParams) ([]db.ListTopDevicesByURLIDRow, error)) *MockQuerier_ListTopDevicesByURLID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTopOSByURLID provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListTopOSByURLID(ctx context.Context, arg db.ListTopOSByURLIDParams) ([]db.ListTopOSByURLIDRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTopOSByURLID")
+	}
+
+	var r0 []db.ListTopOSByURLIDRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListTopOSByURLIDParams) ([]db.ListTopOSByURLIDRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListTopOSByURLIDParams) []db.ListTopOSByURLIDRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListTopOSByURLIDRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListTopOSByURLIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListTopOSByURLID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTopOSByURLID'
+type MockQuerier_ListTopOSByURLID_Call struct {
+	*mock.Call
+}
+
+// ListTopOSByURLID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListTopOSByURLIDParams
+func (_e *MockQuerier_Expecter) ListTopOSByURLID(ctx interface{}, arg interface{}) *MockQuerier_ListTopOSByURLID_Call {
+	return &MockQuerier_ListTopOSByURLID_Call{Call: _e.mock.On("ListTopOSByURLID", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListTopOSByURLID_Call) Run(run func(ctx context.Context, arg db.ListTopOSByURLIDParams)) *MockQuerier_ListTopOSByURLID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListTopOSByURLIDParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListTopOSByURLID_Call) Return(_a0 []db.ListTopOSByURLIDRow, _a1 error) *MockQuerier_ListTopOSByURLID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListTopOSByURLID_Call) RunAndReturn(run func(context.Context, db.ListTopOSByURLIDParams) ([]db.ListTopOSByURLIDRow, error)) *MockQuerier_ListTopOSByURLID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTopReferrersByURLID provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListTopReferrersByURLID(ctx context.Context, arg db.ListTopReferrersByURLIDParams) ([]db.ListTopReferrersByURLIDRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTopReferrersByURLID")
+	}
+
+	var r0 []db.ListTopReferrersByURLIDRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListTopReferrersByURLIDParams) ([]db.ListTopReferrersByURLIDRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListTopReferrersByURLIDParams) []db.ListTopReferrersByURLIDRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListTopReferrersByURLIDRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListTopReferrersByURLIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListTopReferrersByURLID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTopReferrersByURLID'
+type MockQuerier_ListTopReferrersByURLID_Call struct {
+	*mock.Call
+}
+
+// ListTopReferrersByURLID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListTopReferrersByURLIDParams
+func (_e *MockQuerier_Expecter) ListTopReferrersByURLID(ctx interface{}, arg interface{}) *MockQuerier_ListTopReferrersByURLID_Call {
+	return &MockQuerier_ListTopReferrersByURLID_Call{Call: _e.mock.On("ListTopReferrersByURLID", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListTopReferrersByURLID_Call) Run(run func(ctx context.Context, arg db.ListTopReferrersByURLIDParams)) *MockQuerier_ListTopReferrersByURLID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListTopReferrersByURLIDParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListTopReferrersByURLID_Call) Return(_a0 []db.ListTopReferrersByURLIDRow, _a1 error) *MockQuerier_ListTopReferrersByURLID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListTopReferrersByURLID_Call) RunAndReturn(run func(context.Context, db.ListTopReferrersByURLIDParams) ([]db.ListTopReferrersByURLIDRow, error)) *MockQuerier_ListTopReferrersByURLID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListURLsAfterID provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListURLsAfterID(ctx context.Context, arg db.ListURLsAfterIDParams) ([]db.Url, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListURLsAfterID")
+	}
+
+	var r0 []db.Url
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsAfterIDParams) ([]db.Url, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsAfterIDParams) []db.Url); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Url)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListURLsAfterIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListURLsAfterID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListURLsAfterID'
+type MockQuerier_ListURLsAfterID_Call struct {
+	*mock.Call
+}
+
+// ListURLsAfterID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListURLsAfterIDParams
+func (_e *MockQuerier_Expecter) ListURLsAfterID(ctx interface{}, arg interface{}) *MockQuerier_ListURLsAfterID_Call {
+	return &MockQuerier_ListURLsAfterID_Call{Call: _e.mock.On("ListURLsAfterID", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListURLsAfterID_Call) Run(run func(ctx context.Context, arg db.ListURLsAfterIDParams)) *MockQuerier_ListURLsAfterID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListURLsAfterIDParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListURLsAfterID_Call) Return(_a0 []db.Url, _a1 error) *MockQuerier_ListURLsAfterID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListURLsAfterID_Call) RunAndReturn(run func(context.Context, db.ListURLsAfterIDParams) ([]db.Url, error)) *MockQuerier_ListURLsAfterID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListURLsByAccessCount provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListURLsByAccessCount(ctx context.Context, arg db.ListURLsByAccessCountParams) ([]db.Url, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListURLsByAccessCount")
+	}
+
+	var r0 []db.Url
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsByAccessCountParams) ([]db.Url, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsByAccessCountParams) []db.Url); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Url)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListURLsByAccessCountParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListURLsByAccessCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListURLsByAccessCount'
+type MockQuerier_ListURLsByAccessCount_Call struct {
+	*mock.Call
+}
+
+// ListURLsByAccessCount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListURLsByAccessCountParams
+func (_e *MockQuerier_Expecter) ListURLsByAccessCount(ctx interface{}, arg interface{}) *MockQuerier_ListURLsByAccessCount_Call {
+	return &MockQuerier_ListURLsByAccessCount_Call{Call: _e.mock.On("ListURLsByAccessCount", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListURLsByAccessCount_Call) Run(run func(ctx context.Context, arg db.ListURLsByAccessCountParams)) *MockQuerier_ListURLsByAccessCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListURLsByAccessCountParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListURLsByAccessCount_Call) Return(_a0 []db.Url, _a1 error) *MockQuerier_ListURLsByAccessCount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListURLsByAccessCount_Call) RunAndReturn(run func(context.Context, db.ListURLsByAccessCountParams) ([]db.Url, error)) *MockQuerier_ListURLsByAccessCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListURLsByAccessCountDesc provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListURLsByAccessCountDesc(ctx context.Context, arg db.ListURLsByAccessCountDescParams) ([]db.Url, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListURLsByAccessCountDesc")
+	}
+
+	var r0 []db.Url
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsByAccessCountDescParams) ([]db.Url, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsByAccessCountDescParams) []db.Url); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Url)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListURLsByAccessCountDescParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListURLsByAccessCountDesc_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListURLsByAccessCountDesc'
+type MockQuerier_ListURLsByAccessCountDesc_Call struct {
+	*mock.Call
+}
+
+// ListURLsByAccessCountDesc is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListURLsByAccessCountDescParams
+func (_e *MockQuerier_Expecter) ListURLsByAccessCountDesc(ctx interface{}, arg interface{}) *MockQuerier_ListURLsByAccessCountDesc_Call {
+	return &MockQuerier_ListURLsByAccessCountDesc_Call{Call: _e.mock.On("ListURLsByAccessCountDesc", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListURLsByAccessCountDesc_Call) Run(run func(ctx context.Context, arg db.ListURLsByAccessCountDescParams)) *MockQuerier_ListURLsByAccessCountDesc_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListURLsByAccessCountDescParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListURLsByAccessCountDesc_Call) Return(_a0 []db.Url, _a1 error) *MockQuerier_ListURLsByAccessCountDesc_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListURLsByAccessCountDesc_Call) RunAndReturn(run func(context.Context, db.ListURLsByAccessCountDescParams) ([]db.Url, error)) *MockQuerier_ListURLsByAccessCountDesc_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListURLsByCreatedAt provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListURLsByCreatedAt(ctx context.Context, arg db.ListURLsByCreatedAtParams) ([]db.Url, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListURLsByCreatedAt")
+	}
+
+	var r0 []db.Url
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsByCreatedAtParams) ([]db.Url, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsByCreatedAtParams) []db.Url); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Url)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListURLsByCreatedAtParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListURLsByCreatedAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListURLsByCreatedAt'
+type MockQuerier_ListURLsByCreatedAt_Call struct {
+	*mock.Call
+}
+
+// ListURLsByCreatedAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListURLsByCreatedAtParams
+func (_e *MockQuerier_Expecter) ListURLsByCreatedAt(ctx interface{}, arg interface{}) *MockQuerier_ListURLsByCreatedAt_Call {
+	return &MockQuerier_ListURLsByCreatedAt_Call{Call: _e.mock.On("ListURLsByCreatedAt", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListURLsByCreatedAt_Call) Run(run func(ctx context.Context, arg db.ListURLsByCreatedAtParams)) *MockQuerier_ListURLsByCreatedAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListURLsByCreatedAtParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListURLsByCreatedAt_Call) Return(_a0 []db.Url, _a1 error) *MockQuerier_ListURLsByCreatedAt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListURLsByCreatedAt_Call) RunAndReturn(run func(context.Context, db.ListURLsByCreatedAtParams) ([]db.Url, error)) *MockQuerier_ListURLsByCreatedAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListURLsByCreatedAtDesc provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListURLsByCreatedAtDesc(ctx context.Context, arg db.ListURLsByCreatedAtDescParams) ([]db.Url, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListURLsByCreatedAtDesc")
+	}
+
+	var r0 []db.Url
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsByCreatedAtDescParams) ([]db.Url, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsByCreatedAtDescParams) []db.Url); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Url)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListURLsByCreatedAtDescParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListURLsByCreatedAtDesc_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListURLsByCreatedAtDesc'
+type MockQuerier_ListURLsByCreatedAtDesc_Call struct {
+	*mock.Call
+}
+
+// ListURLsByCreatedAtDesc is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListURLsByCreatedAtDescParams
+func (_e *MockQuerier_Expecter) ListURLsByCreatedAtDesc(ctx interface{}, arg interface{}) *MockQuerier_ListURLsByCreatedAtDesc_Call {
+	return &MockQuerier_ListURLsByCreatedAtDesc_Call{Call: _e.mock.On("ListURLsByCreatedAtDesc", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListURLsByCreatedAtDesc_Call) Run(run func(ctx context.Context, arg db.ListURLsByCreatedAtDescParams)) *MockQuerier_ListURLsByCreatedAtDesc_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListURLsByCreatedAtDescParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListURLsByCreatedAtDesc_Call) Return(_a0 []db.Url, _a1 error) *MockQuerier_ListURLsByCreatedAtDesc_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListURLsByCreatedAtDesc_Call) RunAndReturn(run func(context.Context, db.ListURLsByCreatedAtDescParams) ([]db.Url, error)) *MockQuerier_ListURLsByCreatedAtDesc_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListURLsByUpdatedAt provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListURLsByUpdatedAt(ctx context.Context, arg db.ListURLsByUpdatedAtParams) ([]db.Url, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListURLsByUpdatedAt")
+	}
+
+	var r0 []db.Url
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsByUpdatedAtParams) ([]db.Url, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsByUpdatedAtParams) []db.Url); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Url)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListURLsByUpdatedAtParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListURLsByUpdatedAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListURLsByUpdatedAt'
+type MockQuerier_ListURLsByUpdatedAt_Call struct {
+	*mock.Call
+}
+
+// ListURLsByUpdatedAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListURLsByUpdatedAtParams
+func (_e *MockQuerier_Expecter) ListURLsByUpdatedAt(ctx interface{}, arg interface{}) *MockQuerier_ListURLsByUpdatedAt_Call {
+	return &MockQuerier_ListURLsByUpdatedAt_Call{Call: _e.mock.On("ListURLsByUpdatedAt", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListURLsByUpdatedAt_Call) Run(run func(ctx context.Context, arg db.ListURLsByUpdatedAtParams)) *MockQuerier_ListURLsByUpdatedAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListURLsByUpdatedAtParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListURLsByUpdatedAt_Call) Return(_a0 []db.Url, _a1 error) *MockQuerier_ListURLsByUpdatedAt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListURLsByUpdatedAt_Call) RunAndReturn(run func(context.Context, db.ListURLsByUpdatedAtParams) ([]db.Url, error)) *MockQuerier_ListURLsByUpdatedAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListURLsByUpdatedAtDesc provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListURLsByUpdatedAtDesc(ctx context.Context, arg db.ListURLsByUpdatedAtDescParams) ([]db.Url, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListURLsByUpdatedAtDesc")
+	}
+
+	var r0 []db.Url
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsByUpdatedAtDescParams) ([]db.Url, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsByUpdatedAtDescParams) []db.Url); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Url)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListURLsByUpdatedAtDescParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListURLsByUpdatedAtDesc_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListURLsByUpdatedAtDesc'
+type MockQuerier_ListURLsByUpdatedAtDesc_Call struct {
+	*mock.Call
+}
+
+// ListURLsByUpdatedAtDesc is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListURLsByUpdatedAtDescParams
+func (_e *MockQuerier_Expecter) ListURLsByUpdatedAtDesc(ctx interface{}, arg interface{}) *MockQuerier_ListURLsByUpdatedAtDesc_Call {
+	return &MockQuerier_ListURLsByUpdatedAtDesc_Call{Call: _e.mock.On("ListURLsByUpdatedAtDesc", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListURLsByUpdatedAtDesc_Call) Run(run func(ctx context.Context, arg db.ListURLsByUpdatedAtDescParams)) *MockQuerier_ListURLsByUpdatedAtDesc_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListURLsByUpdatedAtDescParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListURLsByUpdatedAtDesc_Call) Return(_a0 []db.Url, _a1 error) *MockQuerier_ListURLsByUpdatedAtDesc_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListURLsByUpdatedAtDesc_Call) RunAndReturn(run func(context.Context, db.ListURLsByUpdatedAtDescParams) ([]db.Url, error)) *MockQuerier_ListURLsByUpdatedAtDesc_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListVisitorSketchByCampaignID provides a mock function with given fields: ctx, campaignid
+func (_m *MockQuerier) ListVisitorSketchByCampaignID(ctx context.Context, campaignid sql.NullInt64) ([]db.ListVisitorSketchByCampaignIDRow, error) {
+	ret := _m.Called(ctx, campaignid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListVisitorSketchByCampaignID")
+	}
+
+	var r0 []db.ListVisitorSketchByCampaignIDRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullInt64) ([]db.ListVisitorSketchByCampaignIDRow, error)); ok {
+		return rf(ctx, campaignid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullInt64) []db.ListVisitorSketchByCampaignIDRow); ok {
+		r0 = rf(ctx, campaignid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListVisitorSketchByCampaignIDRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, sql.NullInt64) error); ok {
+		r1 = rf(ctx, campaignid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListVisitorSketchByCampaignID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListVisitorSketchByCampaignID'
+type MockQuerier_ListVisitorSketchByCampaignID_Call struct {
+	*mock.Call
+}
+
+// ListVisitorSketchByCampaignID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - campaignid sql.NullInt64
+func (_e *MockQuerier_Expecter) ListVisitorSketchByCampaignID(ctx interface{}, campaignid interface{}) *MockQuerier_ListVisitorSketchByCampaignID_Call {
+	return &MockQuerier_ListVisitorSketchByCampaignID_Call{Call: _e.mock.On("ListVisitorSketchByCampaignID", ctx, campaignid)}
+}
+
+func (_c *MockQuerier_ListVisitorSketchByCampaignID_Call) Run(run func(ctx context.Context, campaignid sql.NullInt64)) *MockQuerier_ListVisitorSketchByCampaignID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sql.NullInt64))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListVisitorSketchByCampaignID_Call) Return(_a0 []db.ListVisitorSketchByCampaignIDRow, _a1 error) *MockQuerier_ListVisitorSketchByCampaignID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListVisitorSketchByCampaignID_Call) RunAndReturn(run func(context.Context, sql.NullInt64) ([]db.ListVisitorSketchByCampaignIDRow, error)) *MockQuerier_ListVisitorSketchByCampaignID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListVisitorSketchByTag provides a mock function with given fields: ctx, name
+func (_m *MockQuerier) ListVisitorSketchByTag(ctx context.Context, name string) ([]db.ListVisitorSketchByTagRow, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListVisitorSketchByTag")
+	}
+
+	var r0 []db.ListVisitorSketchByTagRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]db.ListVisitorSketchByTagRow, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []db.ListVisitorSketchByTagRow); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListVisitorSketchByTagRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListVisitorSketchByTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListVisitorSketchByTag'
+type MockQuerier_ListVisitorSketchByTag_Call struct {
+	*mock.Call
+}
+
+// ListVisitorSketchByTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockQuerier_Expecter) ListVisitorSketchByTag(ctx interface{}, name interface{}) *MockQuerier_ListVisitorSketchByTag_Call {
+	return &MockQuerier_ListVisitorSketchByTag_Call{Call: _e.mock.On("ListVisitorSketchByTag", ctx, name)}
+}
+
+func (_c *MockQuerier_ListVisitorSketchByTag_Call) Run(run func(ctx context.Context, name string)) *MockQuerier_ListVisitorSketchByTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListVisitorSketchByTag_Call) Return(_a0 []db.ListVisitorSketchByTagRow, _a1 error) *MockQuerier_ListVisitorSketchByTag_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListVisitorSketchByTag_Call) RunAndReturn(run func(context.Context, string) ([]db.ListVisitorSketchByTagRow, error)) *MockQuerier_ListVisitorSketchByTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListVisitorSketchByURLID provides a mock function with given fields: ctx, urlid
+func (_m *MockQuerier) ListVisitorSketchByURLID(ctx context.Context, urlid int64) ([]db.ListVisitorSketchByURLIDRow, error) {
+	ret := _m.Called(ctx, urlid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListVisitorSketchByURLID")
+	}
+
+	var r0 []db.ListVisitorSketchByURLIDRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.ListVisitorSketchByURLIDRow, error)); ok {
+		return rf(ctx, urlid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.ListVisitorSketchByURLIDRow); ok {
+		r0 = rf(ctx, urlid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListVisitorSketchByURLIDRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, urlid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListVisitorSketchByURLID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListVisitorSketchByURLID'
+type MockQuerier_ListVisitorSketchByURLID_Call struct {
+	*mock.Call
+}
+
+// ListVisitorSketchByURLID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - urlid int64
+func (_e *MockQuerier_Expecter) ListVisitorSketchByURLID(ctx interface{}, urlid interface{}) *MockQuerier_ListVisitorSketchByURLID_Call {
+	return &MockQuerier_ListVisitorSketchByURLID_Call{Call: _e.mock.On("ListVisitorSketchByURLID", ctx, urlid)}
+}
+
+func (_c *MockQuerier_ListVisitorSketchByURLID_Call) Run(run func(ctx context.Context, urlid int64)) *MockQuerier_ListVisitorSketchByURLID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
 }
 
-type MockQuerier_Expecter struct {
-	mock *mock.Mock
+func (_c *MockQuerier_ListVisitorSketchByURLID_Call) Return(_a0 []db.ListVisitorSketchByURLIDRow, _a1 error) *MockQuerier_ListVisitorSketchByURLID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
 }
 
-func (_m *MockQuerier) EXPECT() *MockQuerier_Expecter {
-	return &MockQuerier_Expecter{mock: &_m.Mock}
+func (_c *MockQuerier_ListVisitorSketchByURLID_Call) RunAndReturn(run func(context.Context, int64) ([]db.ListVisitorSketchByURLIDRow, error)) *MockQuerier_ListVisitorSketchByURLID_Call {
+	_c.Call.Return(run)
+	return _c
 }
 
-// CreateURL provides a mock function with given fields: ctx, arg
-func (_m *MockQuerier) CreateURL(ctx context.Context, arg db.CreateURLParams) (db.CreateURLRow, error) {
+// ListVisitorSketchesByURLIDRange provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListVisitorSketchesByURLIDRange(ctx context.Context, arg db.ListVisitorSketchesByURLIDRangeParams) ([]db.ListVisitorSketchesByURLIDRangeRow, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateURL")
+		panic("no return value specified for ListVisitorSketchesByURLIDRange")
 	}
 
-	var r0 db.CreateURLRow
+	var r0 []db.ListVisitorSketchesByURLIDRangeRow
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateURLParams) (db.CreateURLRow, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListVisitorSketchesByURLIDRangeParams) ([]db.ListVisitorSketchesByURLIDRangeRow, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateURLParams) db.CreateURLRow); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListVisitorSketchesByURLIDRangeParams) []db.ListVisitorSketchesByURLIDRangeRow); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.CreateURLRow)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListVisitorSketchesByURLIDRangeRow)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.CreateURLParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListVisitorSketchesByURLIDRangeParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -50,46 +2568,46 @@ func (_m *MockQuerier) CreateURL(ctx context.Context, arg db.CreateURLParams) (d
 	return r0, r1
 }
 
-// MockQuerier_CreateURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateURL'
-type MockQuerier_CreateURL_Call struct {
+// MockQuerier_ListVisitorSketchesByURLIDRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListVisitorSketchesByURLIDRange'
+type MockQuerier_ListVisitorSketchesByURLIDRange_Call struct {
 	*mock.Call
 }
 
-// CreateURL is a helper method to define mock.On call
+// ListVisitorSketchesByURLIDRange is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.CreateURLParams
-func (_e *MockQuerier_Expecter) CreateURL(ctx interface{}, arg interface{}) *MockQuerier_CreateURL_Call {
-	return &MockQuerier_CreateURL_Call{Call: _e.mock.On("CreateURL", ctx, arg)}
+//   - arg db.ListVisitorSketchesByURLIDRangeParams
+func (_e *MockQuerier_Expecter) ListVisitorSketchesByURLIDRange(ctx interface{}, arg interface{}) *MockQuerier_ListVisitorSketchesByURLIDRange_Call {
+	return &MockQuerier_ListVisitorSketchesByURLIDRange_Call{Call: _e.mock.On("ListVisitorSketchesByURLIDRange", ctx, arg)}
 }
 
-func (_c *MockQuerier_CreateURL_Call) Run(run func(ctx context.Context, arg db.CreateURLParams)) *MockQuerier_CreateURL_Call {
+func (_c *MockQuerier_ListVisitorSketchesByURLIDRange_Call) Run(run func(ctx context.Context, arg db.ListVisitorSketchesByURLIDRangeParams)) *MockQuerier_ListVisitorSketchesByURLIDRange_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.CreateURLParams))
+		run(args[0].(context.Context), args[1].(db.ListVisitorSketchesByURLIDRangeParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_CreateURL_Call) Return(_a0 db.CreateURLRow, _a1 error) *MockQuerier_CreateURL_Call {
+func (_c *MockQuerier_ListVisitorSketchesByURLIDRange_Call) Return(_a0 []db.ListVisitorSketchesByURLIDRangeRow, _a1 error) *MockQuerier_ListVisitorSketchesByURLIDRange_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockQuerier_CreateURL_Call) RunAndReturn(run func(context.Context, db.CreateURLParams) (db.CreateURLRow, error)) *MockQuerier_CreateURL_Call {
+func (_c *MockQuerier_ListVisitorSketchesByURLIDRange_Call) RunAndReturn(run func(context.Context, db.ListVisitorSketchesByURLIDRangeParams) ([]db.ListVisitorSketchesByURLIDRangeRow, error)) *MockQuerier_ListVisitorSketchesByURLIDRange_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteURLByShortCode provides a mock function with given fields: ctx, shortcode
-func (_m *MockQuerier) DeleteURLByShortCode(ctx context.Context, shortcode string) error {
-	ret := _m.Called(ctx, shortcode)
+// NormalizeURLByID provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) NormalizeURLByID(ctx context.Context, arg db.NormalizeURLByIDParams) error {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteURLByShortCode")
+		panic("no return value specified for NormalizeURLByID")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, shortcode)
+	if rf, ok := ret.Get(0).(func(context.Context, db.NormalizeURLByIDParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -97,56 +2615,103 @@ func (_m *MockQuerier) DeleteURLByShortCode(ctx context.Context, shortcode strin
 	return r0
 }
 
-// MockQuerier_DeleteURLByShortCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteURLByShortCode'
-type MockQuerier_DeleteURLByShortCode_Call struct {
+// MockQuerier_NormalizeURLByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NormalizeURLByID'
+type MockQuerier_NormalizeURLByID_Call struct {
 	*mock.Call
 }
 
-// DeleteURLByShortCode is a helper method to define mock.On call
+// NormalizeURLByID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - shortcode string
-func (_e *MockQuerier_Expecter) DeleteURLByShortCode(ctx interface{}, shortcode interface{}) *MockQuerier_DeleteURLByShortCode_Call {
-	return &MockQuerier_DeleteURLByShortCode_Call{Call: _e.mock.On("DeleteURLByShortCode", ctx, shortcode)}
+//   - arg db.NormalizeURLByIDParams
+func (_e *MockQuerier_Expecter) NormalizeURLByID(ctx interface{}, arg interface{}) *MockQuerier_NormalizeURLByID_Call {
+	return &MockQuerier_NormalizeURLByID_Call{Call: _e.mock.On("NormalizeURLByID", ctx, arg)}
 }
 
-func (_c *MockQuerier_DeleteURLByShortCode_Call) Run(run func(ctx context.Context, shortcode string)) *MockQuerier_DeleteURLByShortCode_Call {
+func (_c *MockQuerier_NormalizeURLByID_Call) Run(run func(ctx context.Context, arg db.NormalizeURLByIDParams)) *MockQuerier_NormalizeURLByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(db.NormalizeURLByIDParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_DeleteURLByShortCode_Call) Return(_a0 error) *MockQuerier_DeleteURLByShortCode_Call {
+func (_c *MockQuerier_NormalizeURLByID_Call) Return(_a0 error) *MockQuerier_NormalizeURLByID_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockQuerier_DeleteURLByShortCode_Call) RunAndReturn(run func(context.Context, string) error) *MockQuerier_DeleteURLByShortCode_Call {
+func (_c *MockQuerier_NormalizeURLByID_Call) RunAndReturn(run func(context.Context, db.NormalizeURLByIDParams) error) *MockQuerier_NormalizeURLByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetURLByShortCode provides a mock function with given fields: ctx, shortcode
-func (_m *MockQuerier) GetURLByShortCode(ctx context.Context, shortcode string) (db.GetURLByShortCodeRow, error) {
-	ret := _m.Called(ctx, shortcode)
+// RestoreURLStatsByID provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RestoreURLStatsByID(ctx context.Context, arg db.RestoreURLStatsByIDParams) error {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetURLByShortCode")
+		panic("no return value specified for RestoreURLStatsByID")
 	}
 
-	var r0 db.GetURLByShortCodeRow
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreURLStatsByIDParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_RestoreURLStatsByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreURLStatsByID'
+type MockQuerier_RestoreURLStatsByID_Call struct {
+	*mock.Call
+}
+
+// RestoreURLStatsByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RestoreURLStatsByIDParams
+func (_e *MockQuerier_Expecter) RestoreURLStatsByID(ctx interface{}, arg interface{}) *MockQuerier_RestoreURLStatsByID_Call {
+	return &MockQuerier_RestoreURLStatsByID_Call{Call: _e.mock.On("RestoreURLStatsByID", ctx, arg)}
+}
+
+func (_c *MockQuerier_RestoreURLStatsByID_Call) Run(run func(ctx context.Context, arg db.RestoreURLStatsByIDParams)) *MockQuerier_RestoreURLStatsByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RestoreURLStatsByIDParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RestoreURLStatsByID_Call) Return(_a0 error) *MockQuerier_RestoreURLStatsByID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_RestoreURLStatsByID_Call) RunAndReturn(run func(context.Context, db.RestoreURLStatsByIDParams) error) *MockQuerier_RestoreURLStatsByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCampaignByID provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpdateCampaignByID(ctx context.Context, arg db.UpdateCampaignByIDParams) (db.Campaign, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCampaignByID")
+	}
+
+	var r0 db.Campaign
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (db.GetURLByShortCodeRow, error)); ok {
-		return rf(ctx, shortcode)
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateCampaignByIDParams) (db.Campaign, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) db.GetURLByShortCodeRow); ok {
-		r0 = rf(ctx, shortcode)
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateCampaignByIDParams) db.Campaign); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.GetURLByShortCodeRow)
+		r0 = ret.Get(0).(db.Campaign)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, shortcode)
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateCampaignByIDParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -154,56 +2719,56 @@ func (_m *MockQuerier) GetURLByShortCode(ctx context.Context, shortcode string)
 	return r0, r1
 }
 
-// MockQuerier_GetURLByShortCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetURLByShortCode'
-type MockQuerier_GetURLByShortCode_Call struct {
+// MockQuerier_UpdateCampaignByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCampaignByID'
+type MockQuerier_UpdateCampaignByID_Call struct {
 	*mock.Call
 }
 
-// GetURLByShortCode is a helper method to define mock.On call
+// UpdateCampaignByID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - shortcode string
-func (_e *MockQuerier_Expecter) GetURLByShortCode(ctx interface{}, shortcode interface{}) *MockQuerier_GetURLByShortCode_Call {
-	return &MockQuerier_GetURLByShortCode_Call{Call: _e.mock.On("GetURLByShortCode", ctx, shortcode)}
+//   - arg db.UpdateCampaignByIDParams
+func (_e *MockQuerier_Expecter) UpdateCampaignByID(ctx interface{}, arg interface{}) *MockQuerier_UpdateCampaignByID_Call {
+	return &MockQuerier_UpdateCampaignByID_Call{Call: _e.mock.On("UpdateCampaignByID", ctx, arg)}
 }
 
-func (_c *MockQuerier_GetURLByShortCode_Call) Run(run func(ctx context.Context, shortcode string)) *MockQuerier_GetURLByShortCode_Call {
+func (_c *MockQuerier_UpdateCampaignByID_Call) Run(run func(ctx context.Context, arg db.UpdateCampaignByIDParams)) *MockQuerier_UpdateCampaignByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(db.UpdateCampaignByIDParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_GetURLByShortCode_Call) Return(_a0 db.GetURLByShortCodeRow, _a1 error) *MockQuerier_GetURLByShortCode_Call {
+func (_c *MockQuerier_UpdateCampaignByID_Call) Return(_a0 db.Campaign, _a1 error) *MockQuerier_UpdateCampaignByID_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockQuerier_GetURLByShortCode_Call) RunAndReturn(run func(context.Context, string) (db.GetURLByShortCodeRow, error)) *MockQuerier_GetURLByShortCode_Call {
+func (_c *MockQuerier_UpdateCampaignByID_Call) RunAndReturn(run func(context.Context, db.UpdateCampaignByIDParams) (db.Campaign, error)) *MockQuerier_UpdateCampaignByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetURLStatsByShortCode provides a mock function with given fields: ctx, shortcode
-func (_m *MockQuerier) GetURLStatsByShortCode(ctx context.Context, shortcode string) (db.Url, error) {
-	ret := _m.Called(ctx, shortcode)
+// UpdateURLByShortCode provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpdateURLByShortCode(ctx context.Context, arg db.UpdateURLByShortCodeParams) (db.UpdateURLByShortCodeRow, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetURLStatsByShortCode")
+		panic("no return value specified for UpdateURLByShortCode")
 	}
 
-	var r0 db.Url
+	var r0 db.UpdateURLByShortCodeRow
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (db.Url, error)); ok {
-		return rf(ctx, shortcode)
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateURLByShortCodeParams) (db.UpdateURLByShortCodeRow, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) db.Url); ok {
-		r0 = rf(ctx, shortcode)
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateURLByShortCodeParams) db.UpdateURLByShortCodeRow); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Url)
+		r0 = ret.Get(0).(db.UpdateURLByShortCodeRow)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, shortcode)
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateURLByShortCodeParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -211,46 +2776,46 @@ func (_m *MockQuerier) GetURLStatsByShortCode(ctx context.Context, shortcode str
 	return r0, r1
 }
 
-// MockQuerier_GetURLStatsByShortCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetURLStatsByShortCode'
-type MockQuerier_GetURLStatsByShortCode_Call struct {
+// MockQuerier_UpdateURLByShortCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateURLByShortCode'
+type MockQuerier_UpdateURLByShortCode_Call struct {
 	*mock.Call
 }
 
-// GetURLStatsByShortCode is a helper method to define mock.On call
+// UpdateURLByShortCode is a helper method to define mock.On call
 //   - ctx context.Context
-//   - shortcode string
-func (_e *MockQuerier_Expecter) GetURLStatsByShortCode(ctx interface{}, shortcode interface{}) *MockQuerier_GetURLStatsByShortCode_Call {
-	return &MockQuerier_GetURLStatsByShortCode_Call{Call: _e.mock.On("GetURLStatsByShortCode", ctx, shortcode)}
+//   - arg db.UpdateURLByShortCodeParams
+func (_e *MockQuerier_Expecter) UpdateURLByShortCode(ctx interface{}, arg interface{}) *MockQuerier_UpdateURLByShortCode_Call {
+	return &MockQuerier_UpdateURLByShortCode_Call{Call: _e.mock.On("UpdateURLByShortCode", ctx, arg)}
 }
 
-func (_c *MockQuerier_GetURLStatsByShortCode_Call) Run(run func(ctx context.Context, shortcode string)) *MockQuerier_GetURLStatsByShortCode_Call {
+func (_c *MockQuerier_UpdateURLByShortCode_Call) Run(run func(ctx context.Context, arg db.UpdateURLByShortCodeParams)) *MockQuerier_UpdateURLByShortCode_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(db.UpdateURLByShortCodeParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_GetURLStatsByShortCode_Call) Return(_a0 db.Url, _a1 error) *MockQuerier_GetURLStatsByShortCode_Call {
+func (_c *MockQuerier_UpdateURLByShortCode_Call) Return(_a0 db.UpdateURLByShortCodeRow, _a1 error) *MockQuerier_UpdateURLByShortCode_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockQuerier_GetURLStatsByShortCode_Call) RunAndReturn(run func(context.Context, string) (db.Url, error)) *MockQuerier_GetURLStatsByShortCode_Call {
+func (_c *MockQuerier_UpdateURLByShortCode_Call) RunAndReturn(run func(context.Context, db.UpdateURLByShortCodeParams) (db.UpdateURLByShortCodeRow, error)) *MockQuerier_UpdateURLByShortCode_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// IncrementURLAccessCountByShortCode provides a mock function with given fields: ctx, shortcode
-func (_m *MockQuerier) IncrementURLAccessCountByShortCode(ctx context.Context, shortcode string) error {
-	ret := _m.Called(ctx, shortcode)
+// UpdateURLCampaignByShortCode provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpdateURLCampaignByShortCode(ctx context.Context, arg db.UpdateURLCampaignByShortCodeParams) error {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IncrementURLAccessCountByShortCode")
+		panic("no return value specified for UpdateURLCampaignByShortCode")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, shortcode)
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateURLCampaignByShortCodeParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -258,56 +2823,103 @@ func (_m *MockQuerier) IncrementURLAccessCountByShortCode(ctx context.Context, s
 	return r0
 }
 
-// MockQuerier_IncrementURLAccessCountByShortCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementURLAccessCountByShortCode'
-type MockQuerier_IncrementURLAccessCountByShortCode_Call struct {
+// MockQuerier_UpdateURLCampaignByShortCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateURLCampaignByShortCode'
+type MockQuerier_UpdateURLCampaignByShortCode_Call struct {
 	*mock.Call
 }
 
-// IncrementURLAccessCountByShortCode is a helper method to define mock.On call
+// UpdateURLCampaignByShortCode is a helper method to define mock.On call
 //   - ctx context.Context
-//   - shortcode string
-func (_e *MockQuerier_Expecter) IncrementURLAccessCountByShortCode(ctx interface{}, shortcode interface{}) *MockQuerier_IncrementURLAccessCountByShortCode_Call {
-	return &MockQuerier_IncrementURLAccessCountByShortCode_Call{Call: _e.mock.On("IncrementURLAccessCountByShortCode", ctx, shortcode)}
+//   - arg db.UpdateURLCampaignByShortCodeParams
+func (_e *MockQuerier_Expecter) UpdateURLCampaignByShortCode(ctx interface{}, arg interface{}) *MockQuerier_UpdateURLCampaignByShortCode_Call {
+	return &MockQuerier_UpdateURLCampaignByShortCode_Call{Call: _e.mock.On("UpdateURLCampaignByShortCode", ctx, arg)}
 }
 
-func (_c *MockQuerier_IncrementURLAccessCountByShortCode_Call) Run(run func(ctx context.Context, shortcode string)) *MockQuerier_IncrementURLAccessCountByShortCode_Call {
+func (_c *MockQuerier_UpdateURLCampaignByShortCode_Call) Run(run func(ctx context.Context, arg db.UpdateURLCampaignByShortCodeParams)) *MockQuerier_UpdateURLCampaignByShortCode_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(db.UpdateURLCampaignByShortCodeParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_IncrementURLAccessCountByShortCode_Call) Return(_a0 error) *MockQuerier_IncrementURLAccessCountByShortCode_Call {
+func (_c *MockQuerier_UpdateURLCampaignByShortCode_Call) Return(_a0 error) *MockQuerier_UpdateURLCampaignByShortCode_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockQuerier_IncrementURLAccessCountByShortCode_Call) RunAndReturn(run func(context.Context, string) error) *MockQuerier_IncrementURLAccessCountByShortCode_Call {
+func (_c *MockQuerier_UpdateURLCampaignByShortCode_Call) RunAndReturn(run func(context.Context, db.UpdateURLCampaignByShortCodeParams) error) *MockQuerier_UpdateURLCampaignByShortCode_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateURLByShortCode provides a mock function with given fields: ctx, arg
-func (_m *MockQuerier) UpdateURLByShortCode(ctx context.Context, arg db.UpdateURLByShortCodeParams) (db.UpdateURLByShortCodeRow, error) {
+// UpdateURLPasswordByShortCode provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpdateURLPasswordByShortCode(ctx context.Context, arg db.UpdateURLPasswordByShortCodeParams) error {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateURLByShortCode")
+		panic("no return value specified for UpdateURLPasswordByShortCode")
 	}
 
-	var r0 db.UpdateURLByShortCodeRow
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateURLPasswordByShortCodeParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_UpdateURLPasswordByShortCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateURLPasswordByShortCode'
+type MockQuerier_UpdateURLPasswordByShortCode_Call struct {
+	*mock.Call
+}
+
+// UpdateURLPasswordByShortCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpdateURLPasswordByShortCodeParams
+func (_e *MockQuerier_Expecter) UpdateURLPasswordByShortCode(ctx interface{}, arg interface{}) *MockQuerier_UpdateURLPasswordByShortCode_Call {
+	return &MockQuerier_UpdateURLPasswordByShortCode_Call{Call: _e.mock.On("UpdateURLPasswordByShortCode", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpdateURLPasswordByShortCode_Call) Run(run func(ctx context.Context, arg db.UpdateURLPasswordByShortCodeParams)) *MockQuerier_UpdateURLPasswordByShortCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpdateURLPasswordByShortCodeParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpdateURLPasswordByShortCode_Call) Return(_a0 error) *MockQuerier_UpdateURLPasswordByShortCode_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_UpdateURLPasswordByShortCode_Call) RunAndReturn(run func(context.Context, db.UpdateURLPasswordByShortCodeParams) error) *MockQuerier_UpdateURLPasswordByShortCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertTag provides a mock function with given fields: ctx, name
+func (_m *MockQuerier) UpsertTag(ctx context.Context, name string) (int64, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertTag")
+	}
+
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateURLByShortCodeParams) (db.UpdateURLByShortCodeRow, error)); ok {
-		return rf(ctx, arg)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, name)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateURLByShortCodeParams) db.UpdateURLByShortCodeRow); ok {
-		r0 = rf(ctx, arg)
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, name)
 	} else {
-		r0 = ret.Get(0).(db.UpdateURLByShortCodeRow)
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateURLByShortCodeParams) error); ok {
-		r1 = rf(ctx, arg)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -315,31 +2927,78 @@ func (_m *MockQuerier) UpdateURLByShortCode(ctx context.Context, arg db.UpdateUR
 	return r0, r1
 }
 
-// MockQuerier_UpdateURLByShortCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateURLByShortCode'
-type MockQuerier_UpdateURLByShortCode_Call struct {
+// MockQuerier_UpsertTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertTag'
+type MockQuerier_UpsertTag_Call struct {
 	*mock.Call
 }
 
-// UpdateURLByShortCode is a helper method to define mock.On call
+// UpsertTag is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.UpdateURLByShortCodeParams
-func (_e *MockQuerier_Expecter) UpdateURLByShortCode(ctx interface{}, arg interface{}) *MockQuerier_UpdateURLByShortCode_Call {
-	return &MockQuerier_UpdateURLByShortCode_Call{Call: _e.mock.On("UpdateURLByShortCode", ctx, arg)}
+//   - name string
+func (_e *MockQuerier_Expecter) UpsertTag(ctx interface{}, name interface{}) *MockQuerier_UpsertTag_Call {
+	return &MockQuerier_UpsertTag_Call{Call: _e.mock.On("UpsertTag", ctx, name)}
 }
 
-func (_c *MockQuerier_UpdateURLByShortCode_Call) Run(run func(ctx context.Context, arg db.UpdateURLByShortCodeParams)) *MockQuerier_UpdateURLByShortCode_Call {
+func (_c *MockQuerier_UpsertTag_Call) Run(run func(ctx context.Context, name string)) *MockQuerier_UpsertTag_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.UpdateURLByShortCodeParams))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_UpdateURLByShortCode_Call) Return(_a0 db.UpdateURLByShortCodeRow, _a1 error) *MockQuerier_UpdateURLByShortCode_Call {
+func (_c *MockQuerier_UpsertTag_Call) Return(_a0 int64, _a1 error) *MockQuerier_UpsertTag_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockQuerier_UpdateURLByShortCode_Call) RunAndReturn(run func(context.Context, db.UpdateURLByShortCodeParams) (db.UpdateURLByShortCodeRow, error)) *MockQuerier_UpdateURLByShortCode_Call {
+func (_c *MockQuerier_UpsertTag_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *MockQuerier_UpsertTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertVisitorSketch provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpsertVisitorSketch(ctx context.Context, arg db.UpsertVisitorSketchParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertVisitorSketch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertVisitorSketchParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_UpsertVisitorSketch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertVisitorSketch'
+type MockQuerier_UpsertVisitorSketch_Call struct {
+	*mock.Call
+}
+
+// UpsertVisitorSketch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpsertVisitorSketchParams
+func (_e *MockQuerier_Expecter) UpsertVisitorSketch(ctx interface{}, arg interface{}) *MockQuerier_UpsertVisitorSketch_Call {
+	return &MockQuerier_UpsertVisitorSketch_Call{Call: _e.mock.On("UpsertVisitorSketch", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpsertVisitorSketch_Call) Run(run func(ctx context.Context, arg db.UpsertVisitorSketchParams)) *MockQuerier_UpsertVisitorSketch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpsertVisitorSketchParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpsertVisitorSketch_Call) Return(_a0 error) *MockQuerier_UpsertVisitorSketch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_UpsertVisitorSketch_Call) RunAndReturn(run func(context.Context, db.UpsertVisitorSketchParams) error) *MockQuerier_UpsertVisitorSketch_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,68 @@
+// Code generated by mockery v2.50.4. DO NOT EDIT.
+
+package recorder
+
+import (
+	recorder "github.com/DarcoProgramador/shortener-go-backend/internal/recorder"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRecorder is an autogenerated mock type for the Recorder type
+type MockRecorder struct {
+	mock.Mock
+}
+
+type MockRecorder_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRecorder) EXPECT() *MockRecorder_Expecter {
+	return &MockRecorder_Expecter{mock: &_m.Mock}
+}
+
+// Record provides a mock function with given fields: _a0
+func (_m *MockRecorder) Record(_a0 recorder.Visit) {
+	_m.Called(_a0)
+}
+
+// MockRecorder_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type MockRecorder_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - _a0 recorder.Visit
+func (_e *MockRecorder_Expecter) Record(_a0 interface{}) *MockRecorder_Record_Call {
+	return &MockRecorder_Record_Call{Call: _e.mock.On("Record", _a0)}
+}
+
+func (_c *MockRecorder_Record_Call) Run(run func(_a0 recorder.Visit)) *MockRecorder_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(recorder.Visit))
+	})
+	return _c
+}
+
+func (_c *MockRecorder_Record_Call) Return() *MockRecorder_Record_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockRecorder_Record_Call) RunAndReturn(run func(recorder.Visit)) *MockRecorder_Record_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockRecorder creates a new instance of MockRecorder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRecorder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRecorder {
+	mock := &MockRecorder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
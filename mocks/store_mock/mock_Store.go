@@ -0,0 +1,3066 @@
+// Code generated by mockery v2.50.4. DO NOT EDIT.
+
+package database
+
+import (
+	context "context"
+
+	db "github.com/DarcoProgramador/shortener-go-backend/internal/database/sqlc"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sql "database/sql"
+
+	time "time"
+)
+
+// MockStore is an autogenerated mock type for the Store type
+type MockStore struct {
+	mock.Mock
+}
+
+type MockStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStore) EXPECT() *MockStore_Expecter {
+	return &MockStore_Expecter{mock: &_m.Mock}
+}
+
+// AddURLCountsByID provides a mock function with given fields: ctx, arg
+func (_m *MockStore) AddURLCountsByID(ctx context.Context, arg db.AddURLCountsByIDParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddURLCountsByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.AddURLCountsByIDParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_AddURLCountsByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddURLCountsByID'
+type MockStore_AddURLCountsByID_Call struct {
+	*mock.Call
+}
+
+// AddURLCountsByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.AddURLCountsByIDParams
+func (_e *MockStore_Expecter) AddURLCountsByID(ctx interface{}, arg interface{}) *MockStore_AddURLCountsByID_Call {
+	return &MockStore_AddURLCountsByID_Call{Call: _e.mock.On("AddURLCountsByID", ctx, arg)}
+}
+
+func (_c *MockStore_AddURLCountsByID_Call) Run(run func(ctx context.Context, arg db.AddURLCountsByIDParams)) *MockStore_AddURLCountsByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.AddURLCountsByIDParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_AddURLCountsByID_Call) Return(_a0 error) *MockStore_AddURLCountsByID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_AddURLCountsByID_Call) RunAndReturn(run func(context.Context, db.AddURLCountsByIDParams) error) *MockStore_AddURLCountsByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddURLTag provides a mock function with given fields: ctx, arg
+func (_m *MockStore) AddURLTag(ctx context.Context, arg db.AddURLTagParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddURLTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.AddURLTagParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_AddURLTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddURLTag'
+type MockStore_AddURLTag_Call struct {
+	*mock.Call
+}
+
+// AddURLTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.AddURLTagParams
+func (_e *MockStore_Expecter) AddURLTag(ctx interface{}, arg interface{}) *MockStore_AddURLTag_Call {
+	return &MockStore_AddURLTag_Call{Call: _e.mock.On("AddURLTag", ctx, arg)}
+}
+
+func (_c *MockStore_AddURLTag_Call) Run(run func(ctx context.Context, arg db.AddURLTagParams)) *MockStore_AddURLTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.AddURLTagParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_AddURLTag_Call) Return(_a0 error) *MockStore_AddURLTag_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_AddURLTag_Call) RunAndReturn(run func(context.Context, db.AddURLTagParams) error) *MockStore_AddURLTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BumpURLVersionByShortCode provides a mock function with given fields: ctx, arg
+func (_m *MockStore) BumpURLVersionByShortCode(ctx context.Context, arg db.BumpURLVersionByShortCodeParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BumpURLVersionByShortCode")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.BumpURLVersionByShortCodeParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.BumpURLVersionByShortCodeParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.BumpURLVersionByShortCodeParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_BumpURLVersionByShortCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BumpURLVersionByShortCode'
+type MockStore_BumpURLVersionByShortCode_Call struct {
+	*mock.Call
+}
+
+// BumpURLVersionByShortCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.BumpURLVersionByShortCodeParams
+func (_e *MockStore_Expecter) BumpURLVersionByShortCode(ctx interface{}, arg interface{}) *MockStore_BumpURLVersionByShortCode_Call {
+	return &MockStore_BumpURLVersionByShortCode_Call{Call: _e.mock.On("BumpURLVersionByShortCode", ctx, arg)}
+}
+
+func (_c *MockStore_BumpURLVersionByShortCode_Call) Run(run func(ctx context.Context, arg db.BumpURLVersionByShortCodeParams)) *MockStore_BumpURLVersionByShortCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.BumpURLVersionByShortCodeParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_BumpURLVersionByShortCode_Call) Return(_a0 int64, _a1 error) *MockStore_BumpURLVersionByShortCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_BumpURLVersionByShortCode_Call) RunAndReturn(run func(context.Context, db.BumpURLVersionByShortCodeParams) (int64, error)) *MockStore_BumpURLVersionByShortCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountClicksByBucket provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CountClicksByBucket(ctx context.Context, arg db.CountClicksByBucketParams) ([]db.CountClicksByBucketRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountClicksByBucket")
+	}
+
+	var r0 []db.CountClicksByBucketRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CountClicksByBucketParams) ([]db.CountClicksByBucketRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CountClicksByBucketParams) []db.CountClicksByBucketRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.CountClicksByBucketRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CountClicksByBucketParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_CountClicksByBucket_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountClicksByBucket'
+type MockStore_CountClicksByBucket_Call struct {
+	*mock.Call
+}
+
+// CountClicksByBucket is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CountClicksByBucketParams
+func (_e *MockStore_Expecter) CountClicksByBucket(ctx interface{}, arg interface{}) *MockStore_CountClicksByBucket_Call {
+	return &MockStore_CountClicksByBucket_Call{Call: _e.mock.On("CountClicksByBucket", ctx, arg)}
+}
+
+func (_c *MockStore_CountClicksByBucket_Call) Run(run func(ctx context.Context, arg db.CountClicksByBucketParams)) *MockStore_CountClicksByBucket_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CountClicksByBucketParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_CountClicksByBucket_Call) Return(_a0 []db.CountClicksByBucketRow, _a1 error) *MockStore_CountClicksByBucket_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_CountClicksByBucket_Call) RunAndReturn(run func(context.Context, db.CountClicksByBucketParams) ([]db.CountClicksByBucketRow, error)) *MockStore_CountClicksByBucket_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountClicksByURLID provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CountClicksByURLID(ctx context.Context, arg db.CountClicksByURLIDParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountClicksByURLID")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CountClicksByURLIDParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CountClicksByURLIDParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CountClicksByURLIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_CountClicksByURLID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountClicksByURLID'
+type MockStore_CountClicksByURLID_Call struct {
+	*mock.Call
+}
+
+// CountClicksByURLID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CountClicksByURLIDParams
+func (_e *MockStore_Expecter) CountClicksByURLID(ctx interface{}, arg interface{}) *MockStore_CountClicksByURLID_Call {
+	return &MockStore_CountClicksByURLID_Call{Call: _e.mock.On("CountClicksByURLID", ctx, arg)}
+}
+
+func (_c *MockStore_CountClicksByURLID_Call) Run(run func(ctx context.Context, arg db.CountClicksByURLIDParams)) *MockStore_CountClicksByURLID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CountClicksByURLIDParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_CountClicksByURLID_Call) Return(_a0 int64, _a1 error) *MockStore_CountClicksByURLID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_CountClicksByURLID_Call) RunAndReturn(run func(context.Context, db.CountClicksByURLIDParams) (int64, error)) *MockStore_CountClicksByURLID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountUniqueVisitorsByURLID provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CountUniqueVisitorsByURLID(ctx context.Context, arg db.CountUniqueVisitorsByURLIDParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUniqueVisitorsByURLID")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CountUniqueVisitorsByURLIDParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CountUniqueVisitorsByURLIDParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CountUniqueVisitorsByURLIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_CountUniqueVisitorsByURLID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountUniqueVisitorsByURLID'
+type MockStore_CountUniqueVisitorsByURLID_Call struct {
+	*mock.Call
+}
+
+// CountUniqueVisitorsByURLID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CountUniqueVisitorsByURLIDParams
+func (_e *MockStore_Expecter) CountUniqueVisitorsByURLID(ctx interface{}, arg interface{}) *MockStore_CountUniqueVisitorsByURLID_Call {
+	return &MockStore_CountUniqueVisitorsByURLID_Call{Call: _e.mock.On("CountUniqueVisitorsByURLID", ctx, arg)}
+}
+
+func (_c *MockStore_CountUniqueVisitorsByURLID_Call) Run(run func(ctx context.Context, arg db.CountUniqueVisitorsByURLIDParams)) *MockStore_CountUniqueVisitorsByURLID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CountUniqueVisitorsByURLIDParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_CountUniqueVisitorsByURLID_Call) Return(_a0 int64, _a1 error) *MockStore_CountUniqueVisitorsByURLID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_CountUniqueVisitorsByURLID_Call) RunAndReturn(run func(context.Context, db.CountUniqueVisitorsByURLIDParams) (int64, error)) *MockStore_CountUniqueVisitorsByURLID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateCampaign provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateCampaign(ctx context.Context, arg db.CreateCampaignParams) (db.Campaign, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCampaign")
+	}
+
+	var r0 db.Campaign
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateCampaignParams) (db.Campaign, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateCampaignParams) db.Campaign); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Campaign)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateCampaignParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_CreateCampaign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCampaign'
+type MockStore_CreateCampaign_Call struct {
+	*mock.Call
+}
+
+// CreateCampaign is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateCampaignParams
+func (_e *MockStore_Expecter) CreateCampaign(ctx interface{}, arg interface{}) *MockStore_CreateCampaign_Call {
+	return &MockStore_CreateCampaign_Call{Call: _e.mock.On("CreateCampaign", ctx, arg)}
+}
+
+func (_c *MockStore_CreateCampaign_Call) Run(run func(ctx context.Context, arg db.CreateCampaignParams)) *MockStore_CreateCampaign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CreateCampaignParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_CreateCampaign_Call) Return(_a0 db.Campaign, _a1 error) *MockStore_CreateCampaign_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_CreateCampaign_Call) RunAndReturn(run func(context.Context, db.CreateCampaignParams) (db.Campaign, error)) *MockStore_CreateCampaign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateClick provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateClick(ctx context.Context, arg db.CreateClickParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateClick")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateClickParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_CreateClick_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateClick'
+type MockStore_CreateClick_Call struct {
+	*mock.Call
+}
+
+// CreateClick is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateClickParams
+func (_e *MockStore_Expecter) CreateClick(ctx interface{}, arg interface{}) *MockStore_CreateClick_Call {
+	return &MockStore_CreateClick_Call{Call: _e.mock.On("CreateClick", ctx, arg)}
+}
+
+func (_c *MockStore_CreateClick_Call) Run(run func(ctx context.Context, arg db.CreateClickParams)) *MockStore_CreateClick_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CreateClickParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_CreateClick_Call) Return(_a0 error) *MockStore_CreateClick_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_CreateClick_Call) RunAndReturn(run func(context.Context, db.CreateClickParams) error) *MockStore_CreateClick_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateIdempotencyKey provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateIdempotencyKey(ctx context.Context, arg db.CreateIdempotencyKeyParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateIdempotencyKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateIdempotencyKeyParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_CreateIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateIdempotencyKey'
+type MockStore_CreateIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// CreateIdempotencyKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateIdempotencyKeyParams
+func (_e *MockStore_Expecter) CreateIdempotencyKey(ctx interface{}, arg interface{}) *MockStore_CreateIdempotencyKey_Call {
+	return &MockStore_CreateIdempotencyKey_Call{Call: _e.mock.On("CreateIdempotencyKey", ctx, arg)}
+}
+
+func (_c *MockStore_CreateIdempotencyKey_Call) Run(run func(ctx context.Context, arg db.CreateIdempotencyKeyParams)) *MockStore_CreateIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CreateIdempotencyKeyParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_CreateIdempotencyKey_Call) Return(_a0 error) *MockStore_CreateIdempotencyKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_CreateIdempotencyKey_Call) RunAndReturn(run func(context.Context, db.CreateIdempotencyKeyParams) error) *MockStore_CreateIdempotencyKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateURL provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateURL(ctx context.Context, arg db.CreateURLParams) (db.CreateURLRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateURL")
+	}
+
+	var r0 db.CreateURLRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateURLParams) (db.CreateURLRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateURLParams) db.CreateURLRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.CreateURLRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateURLParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_CreateURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateURL'
+type MockStore_CreateURL_Call struct {
+	*mock.Call
+}
+
+// CreateURL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateURLParams
+func (_e *MockStore_Expecter) CreateURL(ctx interface{}, arg interface{}) *MockStore_CreateURL_Call {
+	return &MockStore_CreateURL_Call{Call: _e.mock.On("CreateURL", ctx, arg)}
+}
+
+func (_c *MockStore_CreateURL_Call) Run(run func(ctx context.Context, arg db.CreateURLParams)) *MockStore_CreateURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CreateURLParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_CreateURL_Call) Return(_a0 db.CreateURLRow, _a1 error) *MockStore_CreateURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_CreateURL_Call) RunAndReturn(run func(context.Context, db.CreateURLParams) (db.CreateURLRow, error)) *MockStore_CreateURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateVisitorSalt provides a mock function with given fields: ctx, arg
+func (_m *MockStore) CreateVisitorSalt(ctx context.Context, arg db.CreateVisitorSaltParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateVisitorSalt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateVisitorSaltParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_CreateVisitorSalt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateVisitorSalt'
+type MockStore_CreateVisitorSalt_Call struct {
+	*mock.Call
+}
+
+// CreateVisitorSalt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateVisitorSaltParams
+func (_e *MockStore_Expecter) CreateVisitorSalt(ctx interface{}, arg interface{}) *MockStore_CreateVisitorSalt_Call {
+	return &MockStore_CreateVisitorSalt_Call{Call: _e.mock.On("CreateVisitorSalt", ctx, arg)}
+}
+
+func (_c *MockStore_CreateVisitorSalt_Call) Run(run func(ctx context.Context, arg db.CreateVisitorSaltParams)) *MockStore_CreateVisitorSalt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CreateVisitorSaltParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_CreateVisitorSalt_Call) Return(_a0 error) *MockStore_CreateVisitorSalt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_CreateVisitorSalt_Call) RunAndReturn(run func(context.Context, db.CreateVisitorSaltParams) error) *MockStore_CreateVisitorSalt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCampaignByID provides a mock function with given fields: ctx, id
+func (_m *MockStore) DeleteCampaignByID(ctx context.Context, id int64) (int64, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCampaignByID")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_DeleteCampaignByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCampaignByID'
+type MockStore_DeleteCampaignByID_Call struct {
+	*mock.Call
+}
+
+// DeleteCampaignByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockStore_Expecter) DeleteCampaignByID(ctx interface{}, id interface{}) *MockStore_DeleteCampaignByID_Call {
+	return &MockStore_DeleteCampaignByID_Call{Call: _e.mock.On("DeleteCampaignByID", ctx, id)}
+}
+
+func (_c *MockStore_DeleteCampaignByID_Call) Run(run func(ctx context.Context, id int64)) *MockStore_DeleteCampaignByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockStore_DeleteCampaignByID_Call) Return(_a0 int64, _a1 error) *MockStore_DeleteCampaignByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_DeleteCampaignByID_Call) RunAndReturn(run func(context.Context, int64) (int64, error)) *MockStore_DeleteCampaignByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteIdempotencyKeysBefore provides a mock function with given fields: ctx, createdat
+func (_m *MockStore) DeleteIdempotencyKeysBefore(ctx context.Context, createdat time.Time) error {
+	ret := _m.Called(ctx, createdat)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIdempotencyKeysBefore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, createdat)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_DeleteIdempotencyKeysBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIdempotencyKeysBefore'
+type MockStore_DeleteIdempotencyKeysBefore_Call struct {
+	*mock.Call
+}
+
+// DeleteIdempotencyKeysBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - createdat time.Time
+func (_e *MockStore_Expecter) DeleteIdempotencyKeysBefore(ctx interface{}, createdat interface{}) *MockStore_DeleteIdempotencyKeysBefore_Call {
+	return &MockStore_DeleteIdempotencyKeysBefore_Call{Call: _e.mock.On("DeleteIdempotencyKeysBefore", ctx, createdat)}
+}
+
+func (_c *MockStore_DeleteIdempotencyKeysBefore_Call) Run(run func(ctx context.Context, createdat time.Time)) *MockStore_DeleteIdempotencyKeysBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockStore_DeleteIdempotencyKeysBefore_Call) Return(_a0 error) *MockStore_DeleteIdempotencyKeysBefore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_DeleteIdempotencyKeysBefore_Call) RunAndReturn(run func(context.Context, time.Time) error) *MockStore_DeleteIdempotencyKeysBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteURLByShortCode provides a mock function with given fields: ctx, shortcode
+func (_m *MockStore) DeleteURLByShortCode(ctx context.Context, shortcode string) error {
+	ret := _m.Called(ctx, shortcode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteURLByShortCode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, shortcode)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_DeleteURLByShortCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteURLByShortCode'
+type MockStore_DeleteURLByShortCode_Call struct {
+	*mock.Call
+}
+
+// DeleteURLByShortCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortcode string
+func (_e *MockStore_Expecter) DeleteURLByShortCode(ctx interface{}, shortcode interface{}) *MockStore_DeleteURLByShortCode_Call {
+	return &MockStore_DeleteURLByShortCode_Call{Call: _e.mock.On("DeleteURLByShortCode", ctx, shortcode)}
+}
+
+func (_c *MockStore_DeleteURLByShortCode_Call) Run(run func(ctx context.Context, shortcode string)) *MockStore_DeleteURLByShortCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_DeleteURLByShortCode_Call) Return(_a0 error) *MockStore_DeleteURLByShortCode_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_DeleteURLByShortCode_Call) RunAndReturn(run func(context.Context, string) error) *MockStore_DeleteURLByShortCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteURLTagsByURLID provides a mock function with given fields: ctx, urlid
+func (_m *MockStore) DeleteURLTagsByURLID(ctx context.Context, urlid int64) error {
+	ret := _m.Called(ctx, urlid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteURLTagsByURLID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, urlid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_DeleteURLTagsByURLID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteURLTagsByURLID'
+type MockStore_DeleteURLTagsByURLID_Call struct {
+	*mock.Call
+}
+
+// DeleteURLTagsByURLID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - urlid int64
+func (_e *MockStore_Expecter) DeleteURLTagsByURLID(ctx interface{}, urlid interface{}) *MockStore_DeleteURLTagsByURLID_Call {
+	return &MockStore_DeleteURLTagsByURLID_Call{Call: _e.mock.On("DeleteURLTagsByURLID", ctx, urlid)}
+}
+
+func (_c *MockStore_DeleteURLTagsByURLID_Call) Run(run func(ctx context.Context, urlid int64)) *MockStore_DeleteURLTagsByURLID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockStore_DeleteURLTagsByURLID_Call) Return(_a0 error) *MockStore_DeleteURLTagsByURLID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_DeleteURLTagsByURLID_Call) RunAndReturn(run func(context.Context, int64) error) *MockStore_DeleteURLTagsByURLID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteVisitorSaltsBefore provides a mock function with given fields: ctx, day
+func (_m *MockStore) DeleteVisitorSaltsBefore(ctx context.Context, day string) error {
+	ret := _m.Called(ctx, day)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteVisitorSaltsBefore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, day)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_DeleteVisitorSaltsBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteVisitorSaltsBefore'
+type MockStore_DeleteVisitorSaltsBefore_Call struct {
+	*mock.Call
+}
+
+// DeleteVisitorSaltsBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - day string
+func (_e *MockStore_Expecter) DeleteVisitorSaltsBefore(ctx interface{}, day interface{}) *MockStore_DeleteVisitorSaltsBefore_Call {
+	return &MockStore_DeleteVisitorSaltsBefore_Call{Call: _e.mock.On("DeleteVisitorSaltsBefore", ctx, day)}
+}
+
+func (_c *MockStore_DeleteVisitorSaltsBefore_Call) Run(run func(ctx context.Context, day string)) *MockStore_DeleteVisitorSaltsBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_DeleteVisitorSaltsBefore_Call) Return(_a0 error) *MockStore_DeleteVisitorSaltsBefore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_DeleteVisitorSaltsBefore_Call) RunAndReturn(run func(context.Context, string) error) *MockStore_DeleteVisitorSaltsBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExecTx provides a mock function with given fields: ctx, fn
+func (_m *MockStore) ExecTx(ctx context.Context, fn func(db.Querier) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(db.Querier) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_ExecTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecTx'
+type MockStore_ExecTx_Call struct {
+	*mock.Call
+}
+
+// ExecTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(db.Querier) error
+func (_e *MockStore_Expecter) ExecTx(ctx interface{}, fn interface{}) *MockStore_ExecTx_Call {
+	return &MockStore_ExecTx_Call{Call: _e.mock.On("ExecTx", ctx, fn)}
+}
+
+func (_c *MockStore_ExecTx_Call) Run(run func(ctx context.Context, fn func(db.Querier) error)) *MockStore_ExecTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(db.Querier) error))
+	})
+	return _c
+}
+
+func (_c *MockStore_ExecTx_Call) Return(_a0 error) *MockStore_ExecTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_ExecTx_Call) RunAndReturn(run func(context.Context, func(db.Querier) error) error) *MockStore_ExecTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCampaignByID provides a mock function with given fields: ctx, id
+func (_m *MockStore) GetCampaignByID(ctx context.Context, id int64) (db.Campaign, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCampaignByID")
+	}
+
+	var r0 db.Campaign
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Campaign, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Campaign); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.Campaign)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GetCampaignByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCampaignByID'
+type MockStore_GetCampaignByID_Call struct {
+	*mock.Call
+}
+
+// GetCampaignByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockStore_Expecter) GetCampaignByID(ctx interface{}, id interface{}) *MockStore_GetCampaignByID_Call {
+	return &MockStore_GetCampaignByID_Call{Call: _e.mock.On("GetCampaignByID", ctx, id)}
+}
+
+func (_c *MockStore_GetCampaignByID_Call) Run(run func(ctx context.Context, id int64)) *MockStore_GetCampaignByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockStore_GetCampaignByID_Call) Return(_a0 db.Campaign, _a1 error) *MockStore_GetCampaignByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_GetCampaignByID_Call) RunAndReturn(run func(context.Context, int64) (db.Campaign, error)) *MockStore_GetCampaignByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCampaignStats provides a mock function with given fields: ctx, campaignid
+func (_m *MockStore) GetCampaignStats(ctx context.Context, campaignid sql.NullInt64) (db.GetCampaignStatsRow, error) {
+	ret := _m.Called(ctx, campaignid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCampaignStats")
+	}
+
+	var r0 db.GetCampaignStatsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullInt64) (db.GetCampaignStatsRow, error)); ok {
+		return rf(ctx, campaignid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullInt64) db.GetCampaignStatsRow); ok {
+		r0 = rf(ctx, campaignid)
+	} else {
+		r0 = ret.Get(0).(db.GetCampaignStatsRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, sql.NullInt64) error); ok {
+		r1 = rf(ctx, campaignid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GetCampaignStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCampaignStats'
+type MockStore_GetCampaignStats_Call struct {
+	*mock.Call
+}
+
+// GetCampaignStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - campaignid sql.NullInt64
+func (_e *MockStore_Expecter) GetCampaignStats(ctx interface{}, campaignid interface{}) *MockStore_GetCampaignStats_Call {
+	return &MockStore_GetCampaignStats_Call{Call: _e.mock.On("GetCampaignStats", ctx, campaignid)}
+}
+
+func (_c *MockStore_GetCampaignStats_Call) Run(run func(ctx context.Context, campaignid sql.NullInt64)) *MockStore_GetCampaignStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sql.NullInt64))
+	})
+	return _c
+}
+
+func (_c *MockStore_GetCampaignStats_Call) Return(_a0 db.GetCampaignStatsRow, _a1 error) *MockStore_GetCampaignStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_GetCampaignStats_Call) RunAndReturn(run func(context.Context, sql.NullInt64) (db.GetCampaignStatsRow, error)) *MockStore_GetCampaignStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIdempotencyKey provides a mock function with given fields: ctx, idempotencykey
+func (_m *MockStore) GetIdempotencyKey(ctx context.Context, idempotencykey string) (db.GetIdempotencyKeyRow, error) {
+	ret := _m.Called(ctx, idempotencykey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIdempotencyKey")
+	}
+
+	var r0 db.GetIdempotencyKeyRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.GetIdempotencyKeyRow, error)); ok {
+		return rf(ctx, idempotencykey)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.GetIdempotencyKeyRow); ok {
+		r0 = rf(ctx, idempotencykey)
+	} else {
+		r0 = ret.Get(0).(db.GetIdempotencyKeyRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, idempotencykey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GetIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIdempotencyKey'
+type MockStore_GetIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// GetIdempotencyKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - idempotencykey string
+func (_e *MockStore_Expecter) GetIdempotencyKey(ctx interface{}, idempotencykey interface{}) *MockStore_GetIdempotencyKey_Call {
+	return &MockStore_GetIdempotencyKey_Call{Call: _e.mock.On("GetIdempotencyKey", ctx, idempotencykey)}
+}
+
+func (_c *MockStore_GetIdempotencyKey_Call) Run(run func(ctx context.Context, idempotencykey string)) *MockStore_GetIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_GetIdempotencyKey_Call) Return(_a0 db.GetIdempotencyKeyRow, _a1 error) *MockStore_GetIdempotencyKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_GetIdempotencyKey_Call) RunAndReturn(run func(context.Context, string) (db.GetIdempotencyKeyRow, error)) *MockStore_GetIdempotencyKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLastURLID provides a mock function with given fields: ctx
+func (_m *MockStore) GetLastURLID(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastURLID")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GetLastURLID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastURLID'
+type MockStore_GetLastURLID_Call struct {
+	*mock.Call
+}
+
+// GetLastURLID is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStore_Expecter) GetLastURLID(ctx interface{}) *MockStore_GetLastURLID_Call {
+	return &MockStore_GetLastURLID_Call{Call: _e.mock.On("GetLastURLID", ctx)}
+}
+
+func (_c *MockStore_GetLastURLID_Call) Run(run func(ctx context.Context)) *MockStore_GetLastURLID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockStore_GetLastURLID_Call) Return(_a0 int64, _a1 error) *MockStore_GetLastURLID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_GetLastURLID_Call) RunAndReturn(run func(context.Context) (int64, error)) *MockStore_GetLastURLID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPlainURLByURL provides a mock function with given fields: ctx, url
+func (_m *MockStore) GetPlainURLByURL(ctx context.Context, url string) (string, error) {
+	ret := _m.Called(ctx, url)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPlainURLByURL")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, url)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, url)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, url)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GetPlainURLByURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPlainURLByURL'
+type MockStore_GetPlainURLByURL_Call struct {
+	*mock.Call
+}
+
+// GetPlainURLByURL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - url string
+func (_e *MockStore_Expecter) GetPlainURLByURL(ctx interface{}, url interface{}) *MockStore_GetPlainURLByURL_Call {
+	return &MockStore_GetPlainURLByURL_Call{Call: _e.mock.On("GetPlainURLByURL", ctx, url)}
+}
+
+func (_c *MockStore_GetPlainURLByURL_Call) Run(run func(ctx context.Context, url string)) *MockStore_GetPlainURLByURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_GetPlainURLByURL_Call) Return(_a0 string, _a1 error) *MockStore_GetPlainURLByURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_GetPlainURLByURL_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockStore_GetPlainURLByURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTagStats provides a mock function with given fields: ctx, name
+func (_m *MockStore) GetTagStats(ctx context.Context, name string) (db.GetTagStatsRow, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTagStats")
+	}
+
+	var r0 db.GetTagStatsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.GetTagStatsRow, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.GetTagStatsRow); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(db.GetTagStatsRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GetTagStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTagStats'
+type MockStore_GetTagStats_Call struct {
+	*mock.Call
+}
+
+// GetTagStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockStore_Expecter) GetTagStats(ctx interface{}, name interface{}) *MockStore_GetTagStats_Call {
+	return &MockStore_GetTagStats_Call{Call: _e.mock.On("GetTagStats", ctx, name)}
+}
+
+func (_c *MockStore_GetTagStats_Call) Run(run func(ctx context.Context, name string)) *MockStore_GetTagStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_GetTagStats_Call) Return(_a0 db.GetTagStatsRow, _a1 error) *MockStore_GetTagStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_GetTagStats_Call) RunAndReturn(run func(context.Context, string) (db.GetTagStatsRow, error)) *MockStore_GetTagStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetURLByShortCode provides a mock function with given fields: ctx, shortcode
+func (_m *MockStore) GetURLByShortCode(ctx context.Context, shortcode string) (db.GetURLByShortCodeRow, error) {
+	ret := _m.Called(ctx, shortcode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetURLByShortCode")
+	}
+
+	var r0 db.GetURLByShortCodeRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.GetURLByShortCodeRow, error)); ok {
+		return rf(ctx, shortcode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.GetURLByShortCodeRow); ok {
+		r0 = rf(ctx, shortcode)
+	} else {
+		r0 = ret.Get(0).(db.GetURLByShortCodeRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, shortcode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GetURLByShortCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetURLByShortCode'
+type MockStore_GetURLByShortCode_Call struct {
+	*mock.Call
+}
+
+// GetURLByShortCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortcode string
+func (_e *MockStore_Expecter) GetURLByShortCode(ctx interface{}, shortcode interface{}) *MockStore_GetURLByShortCode_Call {
+	return &MockStore_GetURLByShortCode_Call{Call: _e.mock.On("GetURLByShortCode", ctx, shortcode)}
+}
+
+func (_c *MockStore_GetURLByShortCode_Call) Run(run func(ctx context.Context, shortcode string)) *MockStore_GetURLByShortCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_GetURLByShortCode_Call) Return(_a0 db.GetURLByShortCodeRow, _a1 error) *MockStore_GetURLByShortCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_GetURLByShortCode_Call) RunAndReturn(run func(context.Context, string) (db.GetURLByShortCodeRow, error)) *MockStore_GetURLByShortCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetURLStatsByShortCode provides a mock function with given fields: ctx, shortcode
+func (_m *MockStore) GetURLStatsByShortCode(ctx context.Context, shortcode string) (db.Url, error) {
+	ret := _m.Called(ctx, shortcode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetURLStatsByShortCode")
+	}
+
+	var r0 db.Url
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.Url, error)); ok {
+		return rf(ctx, shortcode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.Url); ok {
+		r0 = rf(ctx, shortcode)
+	} else {
+		r0 = ret.Get(0).(db.Url)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, shortcode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GetURLStatsByShortCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetURLStatsByShortCode'
+type MockStore_GetURLStatsByShortCode_Call struct {
+	*mock.Call
+}
+
+// GetURLStatsByShortCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortcode string
+func (_e *MockStore_Expecter) GetURLStatsByShortCode(ctx interface{}, shortcode interface{}) *MockStore_GetURLStatsByShortCode_Call {
+	return &MockStore_GetURLStatsByShortCode_Call{Call: _e.mock.On("GetURLStatsByShortCode", ctx, shortcode)}
+}
+
+func (_c *MockStore_GetURLStatsByShortCode_Call) Run(run func(ctx context.Context, shortcode string)) *MockStore_GetURLStatsByShortCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_GetURLStatsByShortCode_Call) Return(_a0 db.Url, _a1 error) *MockStore_GetURLStatsByShortCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_GetURLStatsByShortCode_Call) RunAndReturn(run func(context.Context, string) (db.Url, error)) *MockStore_GetURLStatsByShortCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetVisitorSalt provides a mock function with given fields: ctx, day
+func (_m *MockStore) GetVisitorSalt(ctx context.Context, day string) ([]byte, error) {
+	ret := _m.Called(ctx, day)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVisitorSalt")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]byte, error)); ok {
+		return rf(ctx, day)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []byte); ok {
+		r0 = rf(ctx, day)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, day)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GetVisitorSalt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVisitorSalt'
+type MockStore_GetVisitorSalt_Call struct {
+	*mock.Call
+}
+
+// GetVisitorSalt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - day string
+func (_e *MockStore_Expecter) GetVisitorSalt(ctx interface{}, day interface{}) *MockStore_GetVisitorSalt_Call {
+	return &MockStore_GetVisitorSalt_Call{Call: _e.mock.On("GetVisitorSalt", ctx, day)}
+}
+
+func (_c *MockStore_GetVisitorSalt_Call) Run(run func(ctx context.Context, day string)) *MockStore_GetVisitorSalt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_GetVisitorSalt_Call) Return(_a0 []byte, _a1 error) *MockStore_GetVisitorSalt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_GetVisitorSalt_Call) RunAndReturn(run func(context.Context, string) ([]byte, error)) *MockStore_GetVisitorSalt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrementURLAccessCountByShortCode provides a mock function with given fields: ctx, shortcode
+func (_m *MockStore) IncrementURLAccessCountByShortCode(ctx context.Context, shortcode string) (int64, error) {
+	ret := _m.Called(ctx, shortcode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementURLAccessCountByShortCode")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, shortcode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, shortcode)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, shortcode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_IncrementURLAccessCountByShortCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementURLAccessCountByShortCode'
+type MockStore_IncrementURLAccessCountByShortCode_Call struct {
+	*mock.Call
+}
+
+// IncrementURLAccessCountByShortCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortcode string
+func (_e *MockStore_Expecter) IncrementURLAccessCountByShortCode(ctx interface{}, shortcode interface{}) *MockStore_IncrementURLAccessCountByShortCode_Call {
+	return &MockStore_IncrementURLAccessCountByShortCode_Call{Call: _e.mock.On("IncrementURLAccessCountByShortCode", ctx, shortcode)}
+}
+
+func (_c *MockStore_IncrementURLAccessCountByShortCode_Call) Run(run func(ctx context.Context, shortcode string)) *MockStore_IncrementURLAccessCountByShortCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_IncrementURLAccessCountByShortCode_Call) Return(_a0 int64, _a1 error) *MockStore_IncrementURLAccessCountByShortCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_IncrementURLAccessCountByShortCode_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *MockStore_IncrementURLAccessCountByShortCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrementURLBotCountByShortCode provides a mock function with given fields: ctx, shortcode
+func (_m *MockStore) IncrementURLBotCountByShortCode(ctx context.Context, shortcode string) (int64, error) {
+	ret := _m.Called(ctx, shortcode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementURLBotCountByShortCode")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, shortcode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, shortcode)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, shortcode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_IncrementURLBotCountByShortCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementURLBotCountByShortCode'
+type MockStore_IncrementURLBotCountByShortCode_Call struct {
+	*mock.Call
+}
+
+// IncrementURLBotCountByShortCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortcode string
+func (_e *MockStore_Expecter) IncrementURLBotCountByShortCode(ctx interface{}, shortcode interface{}) *MockStore_IncrementURLBotCountByShortCode_Call {
+	return &MockStore_IncrementURLBotCountByShortCode_Call{Call: _e.mock.On("IncrementURLBotCountByShortCode", ctx, shortcode)}
+}
+
+func (_c *MockStore_IncrementURLBotCountByShortCode_Call) Run(run func(ctx context.Context, shortcode string)) *MockStore_IncrementURLBotCountByShortCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_IncrementURLBotCountByShortCode_Call) Return(_a0 int64, _a1 error) *MockStore_IncrementURLBotCountByShortCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_IncrementURLBotCountByShortCode_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *MockStore_IncrementURLBotCountByShortCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCampaigns provides a mock function with given fields: ctx
+func (_m *MockStore) ListCampaigns(ctx context.Context) ([]db.Campaign, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCampaigns")
+	}
+
+	var r0 []db.Campaign
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.Campaign, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.Campaign); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Campaign)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListCampaigns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCampaigns'
+type MockStore_ListCampaigns_Call struct {
+	*mock.Call
+}
+
+// ListCampaigns is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStore_Expecter) ListCampaigns(ctx interface{}) *MockStore_ListCampaigns_Call {
+	return &MockStore_ListCampaigns_Call{Call: _e.mock.On("ListCampaigns", ctx)}
+}
+
+func (_c *MockStore_ListCampaigns_Call) Run(run func(ctx context.Context)) *MockStore_ListCampaigns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListCampaigns_Call) Return(_a0 []db.Campaign, _a1 error) *MockStore_ListCampaigns_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListCampaigns_Call) RunAndReturn(run func(context.Context) ([]db.Campaign, error)) *MockStore_ListCampaigns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTags provides a mock function with given fields: ctx
+func (_m *MockStore) ListTags(ctx context.Context) ([]db.ListTagsRow, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTags")
+	}
+
+	var r0 []db.ListTagsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.ListTagsRow, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.ListTagsRow); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListTagsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTags'
+type MockStore_ListTags_Call struct {
+	*mock.Call
+}
+
+// ListTags is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStore_Expecter) ListTags(ctx interface{}) *MockStore_ListTags_Call {
+	return &MockStore_ListTags_Call{Call: _e.mock.On("ListTags", ctx)}
+}
+
+func (_c *MockStore_ListTags_Call) Run(run func(ctx context.Context)) *MockStore_ListTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListTags_Call) Return(_a0 []db.ListTagsRow, _a1 error) *MockStore_ListTags_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListTags_Call) RunAndReturn(run func(context.Context) ([]db.ListTagsRow, error)) *MockStore_ListTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTagsByURLID provides a mock function with given fields: ctx, urlid
+func (_m *MockStore) ListTagsByURLID(ctx context.Context, urlid int64) ([]string, error) {
+	ret := _m.Called(ctx, urlid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTagsByURLID")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]string, error)); ok {
+		return rf(ctx, urlid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []string); ok {
+		r0 = rf(ctx, urlid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, urlid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListTagsByURLID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTagsByURLID'
+type MockStore_ListTagsByURLID_Call struct {
+	*mock.Call
+}
+
+// ListTagsByURLID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - urlid int64
+func (_e *MockStore_Expecter) ListTagsByURLID(ctx interface{}, urlid interface{}) *MockStore_ListTagsByURLID_Call {
+	return &MockStore_ListTagsByURLID_Call{Call: _e.mock.On("ListTagsByURLID", ctx, urlid)}
+}
+
+func (_c *MockStore_ListTagsByURLID_Call) Run(run func(ctx context.Context, urlid int64)) *MockStore_ListTagsByURLID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListTagsByURLID_Call) Return(_a0 []string, _a1 error) *MockStore_ListTagsByURLID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListTagsByURLID_Call) RunAndReturn(run func(context.Context, int64) ([]string, error)) *MockStore_ListTagsByURLID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTagsByURLIDs provides a mock function with given fields: ctx, url_ids
+func (_m *MockStore) ListTagsByURLIDs(ctx context.Context, url_ids string) ([]db.ListTagsByURLIDsRow, error) {
+	ret := _m.Called(ctx, url_ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTagsByURLIDs")
+	}
+
+	var r0 []db.ListTagsByURLIDsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]db.ListTagsByURLIDsRow, error)); ok {
+		return rf(ctx, url_ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []db.ListTagsByURLIDsRow); ok {
+		r0 = rf(ctx, url_ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListTagsByURLIDsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, url_ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListTagsByURLIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTagsByURLIDs'
+type MockStore_ListTagsByURLIDs_Call struct {
+	*mock.Call
+}
+
+// ListTagsByURLIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - url_ids string
+func (_e *MockStore_Expecter) ListTagsByURLIDs(ctx interface{}, url_ids interface{}) *MockStore_ListTagsByURLIDs_Call {
+	return &MockStore_ListTagsByURLIDs_Call{Call: _e.mock.On("ListTagsByURLIDs", ctx, url_ids)}
+}
+
+func (_c *MockStore_ListTagsByURLIDs_Call) Run(run func(ctx context.Context, url_ids string)) *MockStore_ListTagsByURLIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListTagsByURLIDs_Call) Return(_a0 []db.ListTagsByURLIDsRow, _a1 error) *MockStore_ListTagsByURLIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListTagsByURLIDs_Call) RunAndReturn(run func(context.Context, string) ([]db.ListTagsByURLIDsRow, error)) *MockStore_ListTagsByURLIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTopBrowsersByURLID provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListTopBrowsersByURLID(ctx context.Context, arg db.ListTopBrowsersByURLIDParams) ([]db.ListTopBrowsersByURLIDRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTopBrowsersByURLID")
+	}
+
+	var r0 []db.ListTopBrowsersByURLIDRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListTopBrowsersByURLIDParams) ([]db.ListTopBrowsersByURLIDRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListTopBrowsersByURLIDParams) []db.ListTopBrowsersByURLIDRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListTopBrowsersByURLIDRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListTopBrowsersByURLIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListTopBrowsersByURLID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTopBrowsersByURLID'
+type MockStore_ListTopBrowsersByURLID_Call struct {
+	*mock.Call
+}
+
+// ListTopBrowsersByURLID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListTopBrowsersByURLIDParams
+func (_e *MockStore_Expecter) ListTopBrowsersByURLID(ctx interface{}, arg interface{}) *MockStore_ListTopBrowsersByURLID_Call {
+	return &MockStore_ListTopBrowsersByURLID_Call{Call: _e.mock.On("ListTopBrowsersByURLID", ctx, arg)}
+}
+
+func (_c *MockStore_ListTopBrowsersByURLID_Call) Run(run func(ctx context.Context, arg db.ListTopBrowsersByURLIDParams)) *MockStore_ListTopBrowsersByURLID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListTopBrowsersByURLIDParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListTopBrowsersByURLID_Call) Return(_a0 []db.ListTopBrowsersByURLIDRow, _a1 error) *MockStore_ListTopBrowsersByURLID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListTopBrowsersByURLID_Call) RunAndReturn(run func(context.Context, db.ListTopBrowsersByURLIDParams) ([]db.ListTopBrowsersByURLIDRow, error)) *MockStore_ListTopBrowsersByURLID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTopDevicesByURLID provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListTopDevicesByURLID(ctx context.Context, arg db.ListTopDevicesByURLIDParams) ([]db.ListTopDevicesByURLIDRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTopDevicesByURLID")
+	}
+
+	var r0 []db.ListTopDevicesByURLIDRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListTopDevicesByURLIDParams) ([]db.ListTopDevicesByURLIDRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListTopDevicesByURLIDParams) []db.ListTopDevicesByURLIDRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListTopDevicesByURLIDRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListTopDevicesByURLIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListTopDevicesByURLID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTopDevicesByURLID'
+type MockStore_ListTopDevicesByURLID_Call struct {
+	*mock.Call
+}
+
+// ListTopDevicesByURLID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListTopDevicesByURLIDParams
+func (_e *MockStore_Expecter) ListTopDevicesByURLID(ctx interface{}, arg interface{}) *MockStore_ListTopDevicesByURLID_Call {
+	return &MockStore_ListTopDevicesByURLID_Call{Call: _e.mock.On("ListTopDevicesByURLID", ctx, arg)}
+}
+
+func (_c *MockStore_ListTopDevicesByURLID_Call) Run(run func(ctx context.Context, arg db.ListTopDevicesByURLIDParams)) *MockStore_ListTopDevicesByURLID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListTopDevicesByURLIDParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListTopDevicesByURLID_Call) Return(_a0 []db.ListTopDevicesByURLIDRow, _a1 error) *MockStore_ListTopDevicesByURLID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListTopDevicesByURLID_Call) RunAndReturn(run func(context.Context, db.ListTopDevicesByURLIDParams) ([]db.ListTopDevicesByURLIDRow, error)) *MockStore_ListTopDevicesByURLID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTopOSByURLID provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListTopOSByURLID(ctx context.Context, arg db.ListTopOSByURLIDParams) ([]db.ListTopOSByURLIDRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTopOSByURLID")
+	}
+
+	var r0 []db.ListTopOSByURLIDRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListTopOSByURLIDParams) ([]db.ListTopOSByURLIDRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListTopOSByURLIDParams) []db.ListTopOSByURLIDRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListTopOSByURLIDRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListTopOSByURLIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListTopOSByURLID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTopOSByURLID'
+type MockStore_ListTopOSByURLID_Call struct {
+	*mock.Call
+}
+
+// ListTopOSByURLID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListTopOSByURLIDParams
+func (_e *MockStore_Expecter) ListTopOSByURLID(ctx interface{}, arg interface{}) *MockStore_ListTopOSByURLID_Call {
+	return &MockStore_ListTopOSByURLID_Call{Call: _e.mock.On("ListTopOSByURLID", ctx, arg)}
+}
+
+func (_c *MockStore_ListTopOSByURLID_Call) Run(run func(ctx context.Context, arg db.ListTopOSByURLIDParams)) *MockStore_ListTopOSByURLID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListTopOSByURLIDParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListTopOSByURLID_Call) Return(_a0 []db.ListTopOSByURLIDRow, _a1 error) *MockStore_ListTopOSByURLID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListTopOSByURLID_Call) RunAndReturn(run func(context.Context, db.ListTopOSByURLIDParams) ([]db.ListTopOSByURLIDRow, error)) *MockStore_ListTopOSByURLID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTopReferrersByURLID provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListTopReferrersByURLID(ctx context.Context, arg db.ListTopReferrersByURLIDParams) ([]db.ListTopReferrersByURLIDRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTopReferrersByURLID")
+	}
+
+	var r0 []db.ListTopReferrersByURLIDRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListTopReferrersByURLIDParams) ([]db.ListTopReferrersByURLIDRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListTopReferrersByURLIDParams) []db.ListTopReferrersByURLIDRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListTopReferrersByURLIDRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListTopReferrersByURLIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListTopReferrersByURLID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTopReferrersByURLID'
+type MockStore_ListTopReferrersByURLID_Call struct {
+	*mock.Call
+}
+
+// ListTopReferrersByURLID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListTopReferrersByURLIDParams
+func (_e *MockStore_Expecter) ListTopReferrersByURLID(ctx interface{}, arg interface{}) *MockStore_ListTopReferrersByURLID_Call {
+	return &MockStore_ListTopReferrersByURLID_Call{Call: _e.mock.On("ListTopReferrersByURLID", ctx, arg)}
+}
+
+func (_c *MockStore_ListTopReferrersByURLID_Call) Run(run func(ctx context.Context, arg db.ListTopReferrersByURLIDParams)) *MockStore_ListTopReferrersByURLID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListTopReferrersByURLIDParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListTopReferrersByURLID_Call) Return(_a0 []db.ListTopReferrersByURLIDRow, _a1 error) *MockStore_ListTopReferrersByURLID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListTopReferrersByURLID_Call) RunAndReturn(run func(context.Context, db.ListTopReferrersByURLIDParams) ([]db.ListTopReferrersByURLIDRow, error)) *MockStore_ListTopReferrersByURLID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListURLsAfterID provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListURLsAfterID(ctx context.Context, arg db.ListURLsAfterIDParams) ([]db.Url, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListURLsAfterID")
+	}
+
+	var r0 []db.Url
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsAfterIDParams) ([]db.Url, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsAfterIDParams) []db.Url); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Url)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListURLsAfterIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListURLsAfterID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListURLsAfterID'
+type MockStore_ListURLsAfterID_Call struct {
+	*mock.Call
+}
+
+// ListURLsAfterID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListURLsAfterIDParams
+func (_e *MockStore_Expecter) ListURLsAfterID(ctx interface{}, arg interface{}) *MockStore_ListURLsAfterID_Call {
+	return &MockStore_ListURLsAfterID_Call{Call: _e.mock.On("ListURLsAfterID", ctx, arg)}
+}
+
+func (_c *MockStore_ListURLsAfterID_Call) Run(run func(ctx context.Context, arg db.ListURLsAfterIDParams)) *MockStore_ListURLsAfterID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListURLsAfterIDParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListURLsAfterID_Call) Return(_a0 []db.Url, _a1 error) *MockStore_ListURLsAfterID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListURLsAfterID_Call) RunAndReturn(run func(context.Context, db.ListURLsAfterIDParams) ([]db.Url, error)) *MockStore_ListURLsAfterID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListURLsByAccessCount provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListURLsByAccessCount(ctx context.Context, arg db.ListURLsByAccessCountParams) ([]db.Url, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListURLsByAccessCount")
+	}
+
+	var r0 []db.Url
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsByAccessCountParams) ([]db.Url, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsByAccessCountParams) []db.Url); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Url)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListURLsByAccessCountParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListURLsByAccessCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListURLsByAccessCount'
+type MockStore_ListURLsByAccessCount_Call struct {
+	*mock.Call
+}
+
+// ListURLsByAccessCount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListURLsByAccessCountParams
+func (_e *MockStore_Expecter) ListURLsByAccessCount(ctx interface{}, arg interface{}) *MockStore_ListURLsByAccessCount_Call {
+	return &MockStore_ListURLsByAccessCount_Call{Call: _e.mock.On("ListURLsByAccessCount", ctx, arg)}
+}
+
+func (_c *MockStore_ListURLsByAccessCount_Call) Run(run func(ctx context.Context, arg db.ListURLsByAccessCountParams)) *MockStore_ListURLsByAccessCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListURLsByAccessCountParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListURLsByAccessCount_Call) Return(_a0 []db.Url, _a1 error) *MockStore_ListURLsByAccessCount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListURLsByAccessCount_Call) RunAndReturn(run func(context.Context, db.ListURLsByAccessCountParams) ([]db.Url, error)) *MockStore_ListURLsByAccessCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListURLsByAccessCountDesc provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListURLsByAccessCountDesc(ctx context.Context, arg db.ListURLsByAccessCountDescParams) ([]db.Url, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListURLsByAccessCountDesc")
+	}
+
+	var r0 []db.Url
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsByAccessCountDescParams) ([]db.Url, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsByAccessCountDescParams) []db.Url); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Url)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListURLsByAccessCountDescParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListURLsByAccessCountDesc_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListURLsByAccessCountDesc'
+type MockStore_ListURLsByAccessCountDesc_Call struct {
+	*mock.Call
+}
+
+// ListURLsByAccessCountDesc is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListURLsByAccessCountDescParams
+func (_e *MockStore_Expecter) ListURLsByAccessCountDesc(ctx interface{}, arg interface{}) *MockStore_ListURLsByAccessCountDesc_Call {
+	return &MockStore_ListURLsByAccessCountDesc_Call{Call: _e.mock.On("ListURLsByAccessCountDesc", ctx, arg)}
+}
+
+func (_c *MockStore_ListURLsByAccessCountDesc_Call) Run(run func(ctx context.Context, arg db.ListURLsByAccessCountDescParams)) *MockStore_ListURLsByAccessCountDesc_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListURLsByAccessCountDescParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListURLsByAccessCountDesc_Call) Return(_a0 []db.Url, _a1 error) *MockStore_ListURLsByAccessCountDesc_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListURLsByAccessCountDesc_Call) RunAndReturn(run func(context.Context, db.ListURLsByAccessCountDescParams) ([]db.Url, error)) *MockStore_ListURLsByAccessCountDesc_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListURLsByCreatedAt provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListURLsByCreatedAt(ctx context.Context, arg db.ListURLsByCreatedAtParams) ([]db.Url, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListURLsByCreatedAt")
+	}
+
+	var r0 []db.Url
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsByCreatedAtParams) ([]db.Url, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsByCreatedAtParams) []db.Url); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Url)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListURLsByCreatedAtParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListURLsByCreatedAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListURLsByCreatedAt'
+type MockStore_ListURLsByCreatedAt_Call struct {
+	*mock.Call
+}
+
+// ListURLsByCreatedAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListURLsByCreatedAtParams
+func (_e *MockStore_Expecter) ListURLsByCreatedAt(ctx interface{}, arg interface{}) *MockStore_ListURLsByCreatedAt_Call {
+	return &MockStore_ListURLsByCreatedAt_Call{Call: _e.mock.On("ListURLsByCreatedAt", ctx, arg)}
+}
+
+func (_c *MockStore_ListURLsByCreatedAt_Call) Run(run func(ctx context.Context, arg db.ListURLsByCreatedAtParams)) *MockStore_ListURLsByCreatedAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListURLsByCreatedAtParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListURLsByCreatedAt_Call) Return(_a0 []db.Url, _a1 error) *MockStore_ListURLsByCreatedAt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListURLsByCreatedAt_Call) RunAndReturn(run func(context.Context, db.ListURLsByCreatedAtParams) ([]db.Url, error)) *MockStore_ListURLsByCreatedAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListURLsByCreatedAtDesc provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListURLsByCreatedAtDesc(ctx context.Context, arg db.ListURLsByCreatedAtDescParams) ([]db.Url, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListURLsByCreatedAtDesc")
+	}
+
+	var r0 []db.Url
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsByCreatedAtDescParams) ([]db.Url, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsByCreatedAtDescParams) []db.Url); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Url)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListURLsByCreatedAtDescParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListURLsByCreatedAtDesc_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListURLsByCreatedAtDesc'
+type MockStore_ListURLsByCreatedAtDesc_Call struct {
+	*mock.Call
+}
+
+// ListURLsByCreatedAtDesc is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListURLsByCreatedAtDescParams
+func (_e *MockStore_Expecter) ListURLsByCreatedAtDesc(ctx interface{}, arg interface{}) *MockStore_ListURLsByCreatedAtDesc_Call {
+	return &MockStore_ListURLsByCreatedAtDesc_Call{Call: _e.mock.On("ListURLsByCreatedAtDesc", ctx, arg)}
+}
+
+func (_c *MockStore_ListURLsByCreatedAtDesc_Call) Run(run func(ctx context.Context, arg db.ListURLsByCreatedAtDescParams)) *MockStore_ListURLsByCreatedAtDesc_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListURLsByCreatedAtDescParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListURLsByCreatedAtDesc_Call) Return(_a0 []db.Url, _a1 error) *MockStore_ListURLsByCreatedAtDesc_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListURLsByCreatedAtDesc_Call) RunAndReturn(run func(context.Context, db.ListURLsByCreatedAtDescParams) ([]db.Url, error)) *MockStore_ListURLsByCreatedAtDesc_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListURLsByUpdatedAt provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListURLsByUpdatedAt(ctx context.Context, arg db.ListURLsByUpdatedAtParams) ([]db.Url, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListURLsByUpdatedAt")
+	}
+
+	var r0 []db.Url
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsByUpdatedAtParams) ([]db.Url, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsByUpdatedAtParams) []db.Url); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Url)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListURLsByUpdatedAtParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListURLsByUpdatedAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListURLsByUpdatedAt'
+type MockStore_ListURLsByUpdatedAt_Call struct {
+	*mock.Call
+}
+
+// ListURLsByUpdatedAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListURLsByUpdatedAtParams
+func (_e *MockStore_Expecter) ListURLsByUpdatedAt(ctx interface{}, arg interface{}) *MockStore_ListURLsByUpdatedAt_Call {
+	return &MockStore_ListURLsByUpdatedAt_Call{Call: _e.mock.On("ListURLsByUpdatedAt", ctx, arg)}
+}
+
+func (_c *MockStore_ListURLsByUpdatedAt_Call) Run(run func(ctx context.Context, arg db.ListURLsByUpdatedAtParams)) *MockStore_ListURLsByUpdatedAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListURLsByUpdatedAtParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListURLsByUpdatedAt_Call) Return(_a0 []db.Url, _a1 error) *MockStore_ListURLsByUpdatedAt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListURLsByUpdatedAt_Call) RunAndReturn(run func(context.Context, db.ListURLsByUpdatedAtParams) ([]db.Url, error)) *MockStore_ListURLsByUpdatedAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListURLsByUpdatedAtDesc provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListURLsByUpdatedAtDesc(ctx context.Context, arg db.ListURLsByUpdatedAtDescParams) ([]db.Url, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListURLsByUpdatedAtDesc")
+	}
+
+	var r0 []db.Url
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsByUpdatedAtDescParams) ([]db.Url, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListURLsByUpdatedAtDescParams) []db.Url); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Url)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListURLsByUpdatedAtDescParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListURLsByUpdatedAtDesc_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListURLsByUpdatedAtDesc'
+type MockStore_ListURLsByUpdatedAtDesc_Call struct {
+	*mock.Call
+}
+
+// ListURLsByUpdatedAtDesc is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListURLsByUpdatedAtDescParams
+func (_e *MockStore_Expecter) ListURLsByUpdatedAtDesc(ctx interface{}, arg interface{}) *MockStore_ListURLsByUpdatedAtDesc_Call {
+	return &MockStore_ListURLsByUpdatedAtDesc_Call{Call: _e.mock.On("ListURLsByUpdatedAtDesc", ctx, arg)}
+}
+
+func (_c *MockStore_ListURLsByUpdatedAtDesc_Call) Run(run func(ctx context.Context, arg db.ListURLsByUpdatedAtDescParams)) *MockStore_ListURLsByUpdatedAtDesc_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListURLsByUpdatedAtDescParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListURLsByUpdatedAtDesc_Call) Return(_a0 []db.Url, _a1 error) *MockStore_ListURLsByUpdatedAtDesc_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListURLsByUpdatedAtDesc_Call) RunAndReturn(run func(context.Context, db.ListURLsByUpdatedAtDescParams) ([]db.Url, error)) *MockStore_ListURLsByUpdatedAtDesc_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListVisitorSketchByCampaignID provides a mock function with given fields: ctx, campaignid
+func (_m *MockStore) ListVisitorSketchByCampaignID(ctx context.Context, campaignid sql.NullInt64) ([]db.ListVisitorSketchByCampaignIDRow, error) {
+	ret := _m.Called(ctx, campaignid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListVisitorSketchByCampaignID")
+	}
+
+	var r0 []db.ListVisitorSketchByCampaignIDRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullInt64) ([]db.ListVisitorSketchByCampaignIDRow, error)); ok {
+		return rf(ctx, campaignid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullInt64) []db.ListVisitorSketchByCampaignIDRow); ok {
+		r0 = rf(ctx, campaignid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListVisitorSketchByCampaignIDRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, sql.NullInt64) error); ok {
+		r1 = rf(ctx, campaignid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListVisitorSketchByCampaignID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListVisitorSketchByCampaignID'
+type MockStore_ListVisitorSketchByCampaignID_Call struct {
+	*mock.Call
+}
+
+// ListVisitorSketchByCampaignID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - campaignid sql.NullInt64
+func (_e *MockStore_Expecter) ListVisitorSketchByCampaignID(ctx interface{}, campaignid interface{}) *MockStore_ListVisitorSketchByCampaignID_Call {
+	return &MockStore_ListVisitorSketchByCampaignID_Call{Call: _e.mock.On("ListVisitorSketchByCampaignID", ctx, campaignid)}
+}
+
+func (_c *MockStore_ListVisitorSketchByCampaignID_Call) Run(run func(ctx context.Context, campaignid sql.NullInt64)) *MockStore_ListVisitorSketchByCampaignID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sql.NullInt64))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListVisitorSketchByCampaignID_Call) Return(_a0 []db.ListVisitorSketchByCampaignIDRow, _a1 error) *MockStore_ListVisitorSketchByCampaignID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListVisitorSketchByCampaignID_Call) RunAndReturn(run func(context.Context, sql.NullInt64) ([]db.ListVisitorSketchByCampaignIDRow, error)) *MockStore_ListVisitorSketchByCampaignID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListVisitorSketchByTag provides a mock function with given fields: ctx, name
+func (_m *MockStore) ListVisitorSketchByTag(ctx context.Context, name string) ([]db.ListVisitorSketchByTagRow, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListVisitorSketchByTag")
+	}
+
+	var r0 []db.ListVisitorSketchByTagRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]db.ListVisitorSketchByTagRow, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []db.ListVisitorSketchByTagRow); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListVisitorSketchByTagRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListVisitorSketchByTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListVisitorSketchByTag'
+type MockStore_ListVisitorSketchByTag_Call struct {
+	*mock.Call
+}
+
+// ListVisitorSketchByTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockStore_Expecter) ListVisitorSketchByTag(ctx interface{}, name interface{}) *MockStore_ListVisitorSketchByTag_Call {
+	return &MockStore_ListVisitorSketchByTag_Call{Call: _e.mock.On("ListVisitorSketchByTag", ctx, name)}
+}
+
+func (_c *MockStore_ListVisitorSketchByTag_Call) Run(run func(ctx context.Context, name string)) *MockStore_ListVisitorSketchByTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListVisitorSketchByTag_Call) Return(_a0 []db.ListVisitorSketchByTagRow, _a1 error) *MockStore_ListVisitorSketchByTag_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListVisitorSketchByTag_Call) RunAndReturn(run func(context.Context, string) ([]db.ListVisitorSketchByTagRow, error)) *MockStore_ListVisitorSketchByTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListVisitorSketchByURLID provides a mock function with given fields: ctx, urlid
+func (_m *MockStore) ListVisitorSketchByURLID(ctx context.Context, urlid int64) ([]db.ListVisitorSketchByURLIDRow, error) {
+	ret := _m.Called(ctx, urlid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListVisitorSketchByURLID")
+	}
+
+	var r0 []db.ListVisitorSketchByURLIDRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.ListVisitorSketchByURLIDRow, error)); ok {
+		return rf(ctx, urlid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.ListVisitorSketchByURLIDRow); ok {
+		r0 = rf(ctx, urlid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListVisitorSketchByURLIDRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, urlid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListVisitorSketchByURLID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListVisitorSketchByURLID'
+type MockStore_ListVisitorSketchByURLID_Call struct {
+	*mock.Call
+}
+
+// ListVisitorSketchByURLID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - urlid int64
+func (_e *MockStore_Expecter) ListVisitorSketchByURLID(ctx interface{}, urlid interface{}) *MockStore_ListVisitorSketchByURLID_Call {
+	return &MockStore_ListVisitorSketchByURLID_Call{Call: _e.mock.On("ListVisitorSketchByURLID", ctx, urlid)}
+}
+
+func (_c *MockStore_ListVisitorSketchByURLID_Call) Run(run func(ctx context.Context, urlid int64)) *MockStore_ListVisitorSketchByURLID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListVisitorSketchByURLID_Call) Return(_a0 []db.ListVisitorSketchByURLIDRow, _a1 error) *MockStore_ListVisitorSketchByURLID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListVisitorSketchByURLID_Call) RunAndReturn(run func(context.Context, int64) ([]db.ListVisitorSketchByURLIDRow, error)) *MockStore_ListVisitorSketchByURLID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListVisitorSketchesByURLIDRange provides a mock function with given fields: ctx, arg
+func (_m *MockStore) ListVisitorSketchesByURLIDRange(ctx context.Context, arg db.ListVisitorSketchesByURLIDRangeParams) ([]db.ListVisitorSketchesByURLIDRangeRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListVisitorSketchesByURLIDRange")
+	}
+
+	var r0 []db.ListVisitorSketchesByURLIDRangeRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListVisitorSketchesByURLIDRangeParams) ([]db.ListVisitorSketchesByURLIDRangeRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListVisitorSketchesByURLIDRangeParams) []db.ListVisitorSketchesByURLIDRangeRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListVisitorSketchesByURLIDRangeRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListVisitorSketchesByURLIDRangeParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListVisitorSketchesByURLIDRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListVisitorSketchesByURLIDRange'
+type MockStore_ListVisitorSketchesByURLIDRange_Call struct {
+	*mock.Call
+}
+
+// ListVisitorSketchesByURLIDRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListVisitorSketchesByURLIDRangeParams
+func (_e *MockStore_Expecter) ListVisitorSketchesByURLIDRange(ctx interface{}, arg interface{}) *MockStore_ListVisitorSketchesByURLIDRange_Call {
+	return &MockStore_ListVisitorSketchesByURLIDRange_Call{Call: _e.mock.On("ListVisitorSketchesByURLIDRange", ctx, arg)}
+}
+
+func (_c *MockStore_ListVisitorSketchesByURLIDRange_Call) Run(run func(ctx context.Context, arg db.ListVisitorSketchesByURLIDRangeParams)) *MockStore_ListVisitorSketchesByURLIDRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListVisitorSketchesByURLIDRangeParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListVisitorSketchesByURLIDRange_Call) Return(_a0 []db.ListVisitorSketchesByURLIDRangeRow, _a1 error) *MockStore_ListVisitorSketchesByURLIDRange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListVisitorSketchesByURLIDRange_Call) RunAndReturn(run func(context.Context, db.ListVisitorSketchesByURLIDRangeParams) ([]db.ListVisitorSketchesByURLIDRangeRow, error)) *MockStore_ListVisitorSketchesByURLIDRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NormalizeURLByID provides a mock function with given fields: ctx, arg
+func (_m *MockStore) NormalizeURLByID(ctx context.Context, arg db.NormalizeURLByIDParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NormalizeURLByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.NormalizeURLByIDParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_NormalizeURLByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NormalizeURLByID'
+type MockStore_NormalizeURLByID_Call struct {
+	*mock.Call
+}
+
+// NormalizeURLByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.NormalizeURLByIDParams
+func (_e *MockStore_Expecter) NormalizeURLByID(ctx interface{}, arg interface{}) *MockStore_NormalizeURLByID_Call {
+	return &MockStore_NormalizeURLByID_Call{Call: _e.mock.On("NormalizeURLByID", ctx, arg)}
+}
+
+func (_c *MockStore_NormalizeURLByID_Call) Run(run func(ctx context.Context, arg db.NormalizeURLByIDParams)) *MockStore_NormalizeURLByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.NormalizeURLByIDParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_NormalizeURLByID_Call) Return(_a0 error) *MockStore_NormalizeURLByID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_NormalizeURLByID_Call) RunAndReturn(run func(context.Context, db.NormalizeURLByIDParams) error) *MockStore_NormalizeURLByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreURLStatsByID provides a mock function with given fields: ctx, arg
+func (_m *MockStore) RestoreURLStatsByID(ctx context.Context, arg db.RestoreURLStatsByIDParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreURLStatsByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RestoreURLStatsByIDParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_RestoreURLStatsByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreURLStatsByID'
+type MockStore_RestoreURLStatsByID_Call struct {
+	*mock.Call
+}
+
+// RestoreURLStatsByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RestoreURLStatsByIDParams
+func (_e *MockStore_Expecter) RestoreURLStatsByID(ctx interface{}, arg interface{}) *MockStore_RestoreURLStatsByID_Call {
+	return &MockStore_RestoreURLStatsByID_Call{Call: _e.mock.On("RestoreURLStatsByID", ctx, arg)}
+}
+
+func (_c *MockStore_RestoreURLStatsByID_Call) Run(run func(ctx context.Context, arg db.RestoreURLStatsByIDParams)) *MockStore_RestoreURLStatsByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RestoreURLStatsByIDParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_RestoreURLStatsByID_Call) Return(_a0 error) *MockStore_RestoreURLStatsByID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_RestoreURLStatsByID_Call) RunAndReturn(run func(context.Context, db.RestoreURLStatsByIDParams) error) *MockStore_RestoreURLStatsByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCampaignByID provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateCampaignByID(ctx context.Context, arg db.UpdateCampaignByIDParams) (db.Campaign, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCampaignByID")
+	}
+
+	var r0 db.Campaign
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateCampaignByIDParams) (db.Campaign, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateCampaignByIDParams) db.Campaign); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Campaign)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateCampaignByIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_UpdateCampaignByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCampaignByID'
+type MockStore_UpdateCampaignByID_Call struct {
+	*mock.Call
+}
+
+// UpdateCampaignByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpdateCampaignByIDParams
+func (_e *MockStore_Expecter) UpdateCampaignByID(ctx interface{}, arg interface{}) *MockStore_UpdateCampaignByID_Call {
+	return &MockStore_UpdateCampaignByID_Call{Call: _e.mock.On("UpdateCampaignByID", ctx, arg)}
+}
+
+func (_c *MockStore_UpdateCampaignByID_Call) Run(run func(ctx context.Context, arg db.UpdateCampaignByIDParams)) *MockStore_UpdateCampaignByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpdateCampaignByIDParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_UpdateCampaignByID_Call) Return(_a0 db.Campaign, _a1 error) *MockStore_UpdateCampaignByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_UpdateCampaignByID_Call) RunAndReturn(run func(context.Context, db.UpdateCampaignByIDParams) (db.Campaign, error)) *MockStore_UpdateCampaignByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateURLByShortCode provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateURLByShortCode(ctx context.Context, arg db.UpdateURLByShortCodeParams) (db.UpdateURLByShortCodeRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateURLByShortCode")
+	}
+
+	var r0 db.UpdateURLByShortCodeRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateURLByShortCodeParams) (db.UpdateURLByShortCodeRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateURLByShortCodeParams) db.UpdateURLByShortCodeRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.UpdateURLByShortCodeRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateURLByShortCodeParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_UpdateURLByShortCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateURLByShortCode'
+type MockStore_UpdateURLByShortCode_Call struct {
+	*mock.Call
+}
+
+// UpdateURLByShortCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpdateURLByShortCodeParams
+func (_e *MockStore_Expecter) UpdateURLByShortCode(ctx interface{}, arg interface{}) *MockStore_UpdateURLByShortCode_Call {
+	return &MockStore_UpdateURLByShortCode_Call{Call: _e.mock.On("UpdateURLByShortCode", ctx, arg)}
+}
+
+func (_c *MockStore_UpdateURLByShortCode_Call) Run(run func(ctx context.Context, arg db.UpdateURLByShortCodeParams)) *MockStore_UpdateURLByShortCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpdateURLByShortCodeParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_UpdateURLByShortCode_Call) Return(_a0 db.UpdateURLByShortCodeRow, _a1 error) *MockStore_UpdateURLByShortCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_UpdateURLByShortCode_Call) RunAndReturn(run func(context.Context, db.UpdateURLByShortCodeParams) (db.UpdateURLByShortCodeRow, error)) *MockStore_UpdateURLByShortCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateURLCampaignByShortCode provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateURLCampaignByShortCode(ctx context.Context, arg db.UpdateURLCampaignByShortCodeParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateURLCampaignByShortCode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateURLCampaignByShortCodeParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_UpdateURLCampaignByShortCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateURLCampaignByShortCode'
+type MockStore_UpdateURLCampaignByShortCode_Call struct {
+	*mock.Call
+}
+
+// UpdateURLCampaignByShortCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpdateURLCampaignByShortCodeParams
+func (_e *MockStore_Expecter) UpdateURLCampaignByShortCode(ctx interface{}, arg interface{}) *MockStore_UpdateURLCampaignByShortCode_Call {
+	return &MockStore_UpdateURLCampaignByShortCode_Call{Call: _e.mock.On("UpdateURLCampaignByShortCode", ctx, arg)}
+}
+
+func (_c *MockStore_UpdateURLCampaignByShortCode_Call) Run(run func(ctx context.Context, arg db.UpdateURLCampaignByShortCodeParams)) *MockStore_UpdateURLCampaignByShortCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpdateURLCampaignByShortCodeParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_UpdateURLCampaignByShortCode_Call) Return(_a0 error) *MockStore_UpdateURLCampaignByShortCode_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_UpdateURLCampaignByShortCode_Call) RunAndReturn(run func(context.Context, db.UpdateURLCampaignByShortCodeParams) error) *MockStore_UpdateURLCampaignByShortCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateURLPasswordByShortCode provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpdateURLPasswordByShortCode(ctx context.Context, arg db.UpdateURLPasswordByShortCodeParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateURLPasswordByShortCode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateURLPasswordByShortCodeParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_UpdateURLPasswordByShortCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateURLPasswordByShortCode'
+type MockStore_UpdateURLPasswordByShortCode_Call struct {
+	*mock.Call
+}
+
+// UpdateURLPasswordByShortCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpdateURLPasswordByShortCodeParams
+func (_e *MockStore_Expecter) UpdateURLPasswordByShortCode(ctx interface{}, arg interface{}) *MockStore_UpdateURLPasswordByShortCode_Call {
+	return &MockStore_UpdateURLPasswordByShortCode_Call{Call: _e.mock.On("UpdateURLPasswordByShortCode", ctx, arg)}
+}
+
+func (_c *MockStore_UpdateURLPasswordByShortCode_Call) Run(run func(ctx context.Context, arg db.UpdateURLPasswordByShortCodeParams)) *MockStore_UpdateURLPasswordByShortCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpdateURLPasswordByShortCodeParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_UpdateURLPasswordByShortCode_Call) Return(_a0 error) *MockStore_UpdateURLPasswordByShortCode_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_UpdateURLPasswordByShortCode_Call) RunAndReturn(run func(context.Context, db.UpdateURLPasswordByShortCodeParams) error) *MockStore_UpdateURLPasswordByShortCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertTag provides a mock function with given fields: ctx, name
+func (_m *MockStore) UpsertTag(ctx context.Context, name string) (int64, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertTag")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_UpsertTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertTag'
+type MockStore_UpsertTag_Call struct {
+	*mock.Call
+}
+
+// UpsertTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockStore_Expecter) UpsertTag(ctx interface{}, name interface{}) *MockStore_UpsertTag_Call {
+	return &MockStore_UpsertTag_Call{Call: _e.mock.On("UpsertTag", ctx, name)}
+}
+
+func (_c *MockStore_UpsertTag_Call) Run(run func(ctx context.Context, name string)) *MockStore_UpsertTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_UpsertTag_Call) Return(_a0 int64, _a1 error) *MockStore_UpsertTag_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_UpsertTag_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *MockStore_UpsertTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertVisitorSketch provides a mock function with given fields: ctx, arg
+func (_m *MockStore) UpsertVisitorSketch(ctx context.Context, arg db.UpsertVisitorSketchParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertVisitorSketch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertVisitorSketchParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_UpsertVisitorSketch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertVisitorSketch'
+type MockStore_UpsertVisitorSketch_Call struct {
+	*mock.Call
+}
+
+// UpsertVisitorSketch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpsertVisitorSketchParams
+func (_e *MockStore_Expecter) UpsertVisitorSketch(ctx interface{}, arg interface{}) *MockStore_UpsertVisitorSketch_Call {
+	return &MockStore_UpsertVisitorSketch_Call{Call: _e.mock.On("UpsertVisitorSketch", ctx, arg)}
+}
+
+func (_c *MockStore_UpsertVisitorSketch_Call) Run(run func(ctx context.Context, arg db.UpsertVisitorSketchParams)) *MockStore_UpsertVisitorSketch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpsertVisitorSketchParams))
+	})
+	return _c
+}
+
+func (_c *MockStore_UpsertVisitorSketch_Call) Return(_a0 error) *MockStore_UpsertVisitorSketch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_UpsertVisitorSketch_Call) RunAndReturn(run func(context.Context, db.UpsertVisitorSketchParams) error) *MockStore_UpsertVisitorSketch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockStore creates a new instance of MockStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStore {
+	mock := &MockStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
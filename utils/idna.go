@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// asciiHost returns host with its internationalized labels mapped as UTS #46
+// does for lookups and encoded in Punycode, so bücher.de, BÜCHER.de and
+// ｂücher。de all become xn--bcher-kva.de. ASCII hosts, IP addresses among
+// them, are kept as they are. host must already be in lower case.
+func asciiHost(host string) (string, error) {
+	if isASCII(host) {
+		return host, nil
+	}
+
+	ascii, err := idna.Lookup.ToASCII(host)
+	if err != nil {
+		return "", ErrInvalidURL
+	}
+
+	return ascii, nil
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
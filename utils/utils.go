@@ -1,16 +1,89 @@
 package utils
 
 import (
+	"encoding/json"
 	"errors"
-	"math/rand"
+	"net/http"
+	"net/netip"
 	"net/url"
+	"slices"
+	"strings"
 	"time"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/bcrypt"
 )
 
 var (
-	ErrInvalidURL = errors.New("invalid URL")
+	ErrInvalidURL            = errors.New("invalid URL")
+	ErrInvalidRedirectStatus = errors.New("invalid redirect status")
+	ErrInvalidAlias          = errors.New("alias must be 3 to 32 characters long and contain only letters, numbers, '-' or '_'")
+	ErrReservedAlias         = errors.New("alias is reserved")
+	ErrInvalidLinkWindow     = errors.New("notBefore must be earlier than expiresAt")
+	ErrInvalidMaxClicks      = errors.New("maxClicks must be a positive number")
+	ErrInvalidLinkPassword   = errors.New("password must be at least 4 characters and at most 72 bytes long")
+	ErrInvalidTimezone       = errors.New("invalid timezone")
+	ErrInvalidDate           = errors.New("dates must be RFC 3339 timestamps or YYYY-MM-DD")
+	ErrInvalidTimeRange      = errors.New("from must be earlier than to")
+	ErrInvalidInterval       = errors.New("interval must be hour, day or week")
+	ErrTimeRangeTooLarge     = errors.New("time range has too many buckets for the interval")
+	ErrInvalidLimit          = errors.New("limit must be between 1 and 100")
+	ErrInvalidSort           = errors.New("sort must be createdAt, accessCount or updatedAt")
+	ErrInvalidOrder          = errors.New("order must be asc or desc")
+	ErrInvalidCursor         = errors.New("invalid cursor")
+	ErrInvalidBatchSize      = errors.New("batch must have between 1 and 1000 links")
+	ErrInvalidFormat         = errors.New("format must be csv or ndjson")
+	ErrInvalidImportHeader   = errors.New("CSV header must have a url column")
+	ErrInvalidTag            = errors.New("tags must be 1 to 32 characters long and contain only letters, numbers, '-' or '_'")
+	ErrTooManyTags           = errors.New("a link can have at most 20 tags")
+	ErrInvalidCampaignName   = errors.New("campaign name must be 1 to 100 characters long")
+	ErrInvalidCampaignRange  = errors.New("startsAt must be earlier than endsAt")
+	ErrInvalidCampaignID     = errors.New("campaign id must be a positive number")
+	ErrInvalidTitle          = errors.New("title must be at most 200 characters long")
+	ErrInvalidDescription    = errors.New("description must be at most 1000 characters long")
+	ErrInvalidNotes          = errors.New("notes must be at most 5000 characters long")
+	ErrInvalidPatch          = errors.New("patch must be a JSON object of link fields")
+	ErrInvalidIdempotencyKey = errors.New("Idempotency-Key must be 1 to 255 printable ASCII characters")
+)
+
+const (
+	MinAliasLength = 3
+	MaxAliasLength = 32
+
+	MaxTagLength = 32
+	MaxTags      = 20
+
+	MaxCampaignNameLength = 100
+
+	MaxTitleLength       = 200
+	MaxDescriptionLength = 1000
+	MaxNotesLength       = 5000
+
+	MaxIdempotencyKeyLength = 255
+
+	// MinPasswordLength is counted in characters.
+	MinPasswordLength = 4
+	// MaxPasswordLength is counted in bytes, the longest input bcrypt accepts.
+	MaxPasswordLength = 72
 )
 
+// reservedAliases are paths owned by the service itself; a short code with
+// one of these names would shadow or be shadowed by a route.
+var reservedAliases = map[string]struct{}{
+	"shorten":   {},
+	"metrics":   {},
+	"healthz":   {},
+	"readyz":    {},
+	"api":       {},
+	"admin":     {},
+	"static":    {},
+	"assets":    {},
+	"stats":     {},
+	"campaigns": {},
+	"tags":      {},
+	"export":    {},
+}
+
 func ValidateURL(link string) error {
 	parsedURL, err := url.ParseRequestURI(
 		link,
@@ -23,14 +96,396 @@ func ValidateURL(link string) error {
 	return nil
 }
 
-const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+// ValidateRedirectStatus checks that status is one of the redirect codes a
+// short link may answer with: 301, 302, 307 or 308.
+func ValidateRedirectStatus(status int) error {
+	switch status {
+	case http.StatusMovedPermanently, http.StatusFound,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return nil
+	}
+
+	return ErrInvalidRedirectStatus
+}
+
+// ValidateLinkWindow checks that a link becomes active before it expires.
+// Either end of the window may be left open.
+func ValidateLinkWindow(notBefore, expiresAt *time.Time) error {
+	if notBefore != nil && expiresAt != nil && !notBefore.Before(*expiresAt) {
+		return ErrInvalidLinkWindow
+	}
+
+	return nil
+}
+
+// ValidateAlias checks that a custom short code only uses URL safe
+// characters, has a sensible length and does not collide with a route.
+func ValidateAlias(alias string) error {
+	if len(alias) < MinAliasLength || len(alias) > MaxAliasLength {
+		return ErrInvalidAlias
+	}
 
-func RandomString(length int) string {
-	b := make([]byte, length)
-	for i := range b {
-		b[i] = charset[rand.Intn(len(charset))]
+	for _, r := range alias {
+		isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		isDigit := r >= '0' && r <= '9'
+		if !isLetter && !isDigit && r != '-' && r != '_' {
+			return ErrInvalidAlias
+		}
+	}
+
+	if IsReservedAlias(alias) {
+		return ErrReservedAlias
+	}
+
+	return nil
+}
+
+// IsReservedAlias reports whether code is a word owned by the service.
+func IsReservedAlias(code string) bool {
+	_, ok := reservedAliases[strings.ToLower(code)]
+	return ok
+}
+
+// NormalizeTags returns the tags of a link in lower case, sorted and without
+// duplicates, so "Spring" and "spring" are the same tag.
+func NormalizeTags(tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	seen := make(map[string]struct{}, len(tags))
+	normalized := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if len(tag) == 0 || len(tag) > MaxTagLength {
+			return nil, ErrInvalidTag
+		}
+
+		for _, r := range tag {
+			isLetter := r >= 'a' && r <= 'z'
+			isDigit := r >= '0' && r <= '9'
+			if !isLetter && !isDigit && r != '-' && r != '_' {
+				return nil, ErrInvalidTag
+			}
+		}
+
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > MaxTags {
+		return nil, ErrTooManyTags
+	}
+
+	slices.Sort(normalized)
+	return normalized, nil
+}
+
+// ValidateLinkMetadata checks the length, in characters, of the title,
+// description and notes of a link. All of them are optional.
+func ValidateLinkMetadata(title, description, notes string) error {
+	if utf8.RuneCountInString(title) > MaxTitleLength {
+		return ErrInvalidTitle
+	}
+
+	if utf8.RuneCountInString(description) > MaxDescriptionLength {
+		return ErrInvalidDescription
+	}
+
+	if utf8.RuneCountInString(notes) > MaxNotesLength {
+		return ErrInvalidNotes
+	}
+
+	return nil
+}
+
+// ValidateIdempotencyKey checks that a client chosen idempotency key is
+// printable ASCII, so it can be stored and compared as it was sent.
+func ValidateIdempotencyKey(key string) error {
+	if len(key) == 0 || len(key) > MaxIdempotencyKeyLength {
+		return ErrInvalidIdempotencyKey
+	}
+
+	for i := 0; i < len(key); i++ {
+		if key[i] < ' ' || key[i] > '~' {
+			return ErrInvalidIdempotencyKey
+		}
+	}
+
+	return nil
+}
+
+// ValidateCampaign checks that a campaign has a name and that its date
+// range, when it has one, starts before it ends.
+func ValidateCampaign(name string, startsAt, endsAt *time.Time) error {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 || len(name) > MaxCampaignNameLength {
+		return ErrInvalidCampaignName
+	}
+
+	if startsAt != nil && endsAt != nil && !startsAt.Before(*endsAt) {
+		return ErrInvalidCampaignRange
+	}
+
+	return nil
+}
+
+// AddQueryDefaults adds the parameters of defaults that link does not have
+// yet to its query, so the link's own values win. The rest of the link,
+// including its query and fragment, is kept as it is.
+func AddQueryDefaults(link string, defaults url.Values) string {
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+
+	query := parsed.Query()
+	missing := url.Values{}
+	for key, values := range defaults {
+		if len(values) == 0 || values[0] == "" || query.Has(key) {
+			continue
+		}
+		missing.Set(key, values[0])
+	}
+
+	if len(missing) == 0 {
+		return link
+	}
+
+	if parsed.RawQuery != "" {
+		parsed.RawQuery += "&"
+	}
+	parsed.RawQuery += missing.Encode()
+
+	return parsed.String()
+}
+
+// MergePatch applies a JSON Merge Patch (RFC 7396) to the JSON object doc:
+// the members of patch replace those of doc, null removes them and nested
+// objects are merged the same way.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	var target, changes map[string]any
+	if err := json.Unmarshal(patch, &changes); err != nil || changes == nil {
+		return nil, ErrInvalidPatch
+	}
+
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, err
 	}
-	return string(b)
+
+	return json.Marshal(mergeObject(target, changes))
+}
+
+func mergeObject(target, patch map[string]any) map[string]any {
+	if target == nil {
+		target = map[string]any{}
+	}
+
+	for key, value := range patch {
+		switch value := value.(type) {
+		case nil:
+			delete(target, key)
+		case map[string]any:
+			current, _ := target[key].(map[string]any)
+			target[key] = mergeObject(current, value)
+		default:
+			target[key] = value
+		}
+	}
+
+	return target
+}
+
+// ValidateLinkPassword checks that a link password can be hashed.
+func ValidateLinkPassword(password string) error {
+	if utf8.RuneCountInString(password) < MinPasswordLength || len(password) > MaxPasswordLength {
+		return ErrInvalidLinkPassword
+	}
+
+	return nil
+}
+
+// HashPassword returns a salted bcrypt hash of password.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+// CheckPassword reports whether password matches a hash made by HashPassword.
+func CheckPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// AnonymizeIP drops the host part of an address so a click can be traced
+// back to a network but not to a visitor: IPv4 keeps its first three octets
+// and IPv6 its first 48 bits. Invalid input gives an empty string.
+func AnonymizeIP(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ""
+	}
+
+	bits := 48
+	if addr.Unmap().Is4() {
+		addr = addr.Unmap()
+		bits = 24
+	}
+
+	prefix, err := addr.Prefix(bits)
+	if err != nil {
+		return ""
+	}
+
+	return prefix.Addr().String()
+}
+
+// defaultPorts are the ports a URL may leave out for its scheme.
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+}
+
+// NormalizeURL validates a URL and returns the form links store and are
+// compared by: the scheme and host in lower case, the host in punycode,
+// without the default port of the scheme, with the dot segments of the path
+// resolved and with "/" for an empty path. So HTTP://Example.com:80/a/../b
+// becomes http://example.com/b.
+func NormalizeURL(link string) (string, error) {
+	if err := ValidateURL(link); err != nil {
+		return "", err
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return "", ErrInvalidURL
+	}
+
+	host, err := asciiHost(strings.ToLower(parsed.Hostname()))
+	if err != nil {
+		return "", err
+	}
+
+	port := parsed.Port()
+	if port == defaultPorts[parsed.Scheme] {
+		port = ""
+	}
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	parsed.Host = host
+	if port != "" {
+		parsed.Host += ":" + port
+	}
+
+	// Resolving an absolute URL against itself only removes the dot
+	// segments of its path.
+	parsed = parsed.ResolveReference(parsed)
+	if parsed.Path == "" {
+		parsed.Path = "/"
+	}
+
+	return parsed.String(), nil
+}
+
+// trackingParams identify the click or the recipient a URL was copied from
+// rather than the page itself. UTM parameters are not among them: they are
+// usually set on purpose, as campaigns do.
+var trackingParams = map[string]struct{}{
+	"fbclid":    {},
+	"gclid":     {},
+	"dclid":     {},
+	"gbraid":    {},
+	"wbraid":    {},
+	"msclkid":   {},
+	"yclid":     {},
+	"twclid":    {},
+	"ttclid":    {},
+	"li_fat_id": {},
+	"igshid":    {},
+	"mc_eid":    {},
+	"_ga":       {},
+	"_gl":       {},
+	"_hsenc":    {},
+	"_hsmi":     {},
+	"mkt_tok":   {},
+}
+
+// StripTrackingParams removes the tracking parameters from the query of link.
+// The other parameters are kept as they are and in their order.
+func StripTrackingParams(link string) string {
+	parsed, err := url.Parse(link)
+	if err != nil || parsed.RawQuery == "" {
+		return link
+	}
+
+	var kept []string
+	for _, pair := range strings.Split(parsed.RawQuery, "&") {
+		key, _, _ := strings.Cut(pair, "=")
+		if key, err := url.QueryUnescape(key); err == nil {
+			if _, ok := trackingParams[key]; ok {
+				continue
+			}
+		}
+		kept = append(kept, pair)
+	}
+
+	parsed.RawQuery = strings.Join(kept, "&")
+	return parsed.String()
+}
+
+// Domain returns the host of a URL in lower case and without a leading
+// "www.", or an empty string when the value is missing or not a URL. It
+// gives the domain a visit came from and the domain a link points to.
+func Domain(link string) string {
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	return strings.TrimPrefix(host, "www.")
+}
+
+// ParseTimezone loads an IANA time zone such as "Europe/Madrid"; an empty
+// name means UTC.
+func ParseTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrInvalidTimezone
+	}
+
+	return loc, nil
+}
+
+// ParseDate reads an RFC 3339 timestamp or a plain YYYY-MM-DD date, which is
+// taken as midnight in loc. An empty value gives a nil time.
+func ParseDate(value string, loc *time.Location) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.ParseInLocation(time.DateOnly, value, loc)
+	if err != nil {
+		return nil, ErrInvalidDate
+	}
+
+	return &t, nil
 }
 
 func ParseISODate(dateStr string) (*time.Time, error) {